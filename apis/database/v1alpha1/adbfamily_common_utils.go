@@ -39,10 +39,15 @@
 package v1alpha1
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -73,6 +78,112 @@ func CreateMetaCondition(obj client.Object, err error, lifecycleState string, st
 type OCIConfigSpec struct {
 	ConfigMapName *string `json:"configMapName,omitempty"`
 	SecretName    *string `json:"secretName,omitempty"`
+	// APIKey uses the ConfigMap and the Secret. InstancePrincipal, ResourcePrincipal and WorkloadIdentity use the identity
+	// of the node, of the workload or of the service account of the operator. Defaults to APIKey if the ConfigMap and
+	// the Secret are set, InstancePrincipal otherwise
+	// +kubebuilder:validation:Enum=APIKey;InstancePrincipal;ResourcePrincipal;WorkloadIdentity
+	AuthMode string `json:"authMode,omitempty"`
+}
+
+/************************
+*	OCI tags
+************************/
+// TagPropagationSpec maps labels of the resource to tags of the OCI resource, so that the OCI resources can be
+// mapped back to their Kubernetes owners, for instance in cost reports
+type TagPropagationSpec struct {
+	// Labels copied as freeform tags. The value is the tag key, or the label name if empty
+	FreeformTags map[string]string `json:"freeformTags,omitempty"`
+	// Labels copied as defined tags. The value is the tag, as <namespace>.<key>
+	DefinedTags map[string]string `json:"definedTags,omitempty"`
+}
+
+/************************
+*	Drift policy
+************************/
+// DriftPolicyEnum controls what the operator does when the OCI resource is changed outside of the operator
+type DriftPolicyEnum string
+
+const (
+	// DriftPolicyMerge updates the spec with the changes made in OCI
+	DriftPolicyMerge DriftPolicyEnum = "Merge"
+	// DriftPolicyRevert applies the spec again to the OCI resource
+	DriftPolicyRevert DriftPolicyEnum = "Revert"
+	// DriftPolicyFlag leaves both unchanged and reports the changes in the Drifted condition
+	DriftPolicyFlag DriftPolicyEnum = "Flag"
+)
+
+// DriftedCondition is the condition type reporting changes made to the OCI resource outside of the operator
+const DriftedCondition string = "Drifted"
+
+// SetDriftedCondition sets the Drifted condition to true with the summary of the changes,
+// or to false if the summary is empty
+func SetDriftedCondition(conditions *[]metav1.Condition, generation int64, driftSummary []string) {
+	if len(driftSummary) == 0 {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               DriftedCondition,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             "InSync",
+			Message:            "The OCI resource matches the spec",
+		})
+		return
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               DriftedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "ChangedInOCI",
+		Message:            "The OCI resource has been changed outside of the operator: " + strings.Join(driftSummary, ", "),
+	})
+}
+
+// getDriftSummary returns the fields which are set in difSpec, as "<field>: <value in the spec> -> <value in OCI>".
+// difSpec should only contain the changed fields, as returned by removeUnchangedFields.
+func getDriftSummary(difSpec interface{}, ociSpec interface{}) ([]string, error) {
+	specValues, err := flattenFields(difSpec)
+	if err != nil {
+		return nil, err
+	}
+	ociValues, err := flattenFields(ociSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := []string{}
+	for field, val := range specValues {
+		summary = append(summary, fmt.Sprintf("%s: %v -> %v", field, val, ociValues[field]))
+	}
+	sort.Strings(summary)
+	return summary, nil
+}
+
+// flattenFields returns the JSON fields of a struct with their values, keyed by their dotted path
+func flattenFields(obj interface{}) (map[string]interface{}, error) {
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(out, &fields); err != nil {
+		return nil, err
+	}
+
+	flattened := map[string]interface{}{}
+	var walk func(prefix string, val interface{})
+	walk = func(prefix string, val interface{}) {
+		if m, ok := val.(map[string]interface{}); ok {
+			for key, child := range m {
+				if prefix != "" {
+					key = prefix + "." + key
+				}
+				walk(key, child)
+			}
+			return
+		}
+		flattened[prefix] = val
+	}
+	walk("", fields)
+	return flattened, nil
 }
 
 /************************
@@ -296,6 +407,22 @@ func NextADBStableState(state database.AutonomousDatabaseLifecycleStateEnum) dat
 	return state
 }
 
+// ValidADBWorkloadTransition returns true if the workload type of an Autonomous Database can be changed from one type
+// to the other: an Autonomous JSON Database can become Transaction Processing, and an APEX Service either of them.
+// The other changes require to create a new database.
+func ValidADBWorkloadTransition(from database.AutonomousDatabaseDbWorkloadEnum, to database.AutonomousDatabaseDbWorkloadEnum) bool {
+	if from == to {
+		return true
+	}
+	switch from {
+	case database.AutonomousDatabaseDbWorkloadAjd:
+		return to == database.AutonomousDatabaseDbWorkloadOltp
+	case database.AutonomousDatabaseDbWorkloadApex:
+		return to == database.AutonomousDatabaseDbWorkloadOltp || to == database.AutonomousDatabaseDbWorkloadAjd
+	}
+	return false
+}
+
 func IsBackupIntermediateState(state database.AutonomousDatabaseBackupLifecycleStateEnum) bool {
 	if state == database.AutonomousDatabaseBackupLifecycleStateCreating ||
 		state == database.AutonomousDatabaseBackupLifecycleStateDeleting {
@@ -68,6 +68,8 @@ type AutonomousContainerDatabaseSpec struct {
 	CompartmentOCID                 *string `json:"compartmentOCID,omitempty"`
 	DisplayName                     *string `json:"displayName,omitempty"`
 	AutonomousExadataVMClusterOCID  *string `json:"autonomousExadataVMClusterOCID,omitempty"`
+	// The Autonomous VM Cluster on Exadata Cloud@Customer, instead of the autonomousExadataVMClusterOCID on OCI
+	AutonomousVMClusterOCID *string `json:"autonomousVMClusterOCID,omitempty"`
 	// +kubebuilder:validation:Enum:="RELEASE_UPDATES";"RELEASE_UPDATE_REVISIONS"
 	PatchModel database.AutonomousContainerDatabasePatchModelEnum `json:"patchModel,omitempty"`
 	// +kubebuilder:validation:Enum:="SYNC";"RESTART";"TERMINATE"
@@ -85,6 +87,9 @@ type AutonomousContainerDatabaseStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 	LifecycleState database.AutonomousContainerDatabaseLifecycleStateEnum `json:"lifecycleState"`
 	TimeCreated    string                                                 `json:"timeCreated,omitempty"`
+
+	// The generation of the spec that has been processed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -174,6 +179,7 @@ func (acd *AutonomousContainerDatabase) UpdateFromOCIACD(ociObj database.Autonom
 	acd.Spec.CompartmentOCID = ociObj.CompartmentId
 	acd.Spec.DisplayName = ociObj.DisplayName
 	acd.Spec.AutonomousExadataVMClusterOCID = ociObj.CloudAutonomousVmClusterId
+	acd.Spec.AutonomousVMClusterOCID = ociObj.AutonomousVmClusterId
 	acd.Spec.PatchModel = ociObj.PatchModel
 
 	// special case: an emtpy map will be nil after unmarshalling while the OCI always returns an emty map.
@@ -63,10 +63,24 @@ var _ webhook.Validator = &AutonomousContainerDatabase{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *AutonomousContainerDatabase) ValidateCreate() error {
+	var allErrs field.ErrorList
+
 	autonomouscontainerdatabaselog.Info("validate create", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object creation.
-	return nil
+	// provisioning operation: the ACD is created in either an Exadata infrastructure on OCI or on Exadata Cloud@Customer
+	if r.Spec.AutonomousContainerDatabaseOCID == nil &&
+		(r.Spec.AutonomousExadataVMClusterOCID == nil) == (r.Spec.AutonomousVMClusterOCID == nil) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("autonomousExadataVMClusterOCID"),
+				"exactly one of autonomousExadataVMClusterOCID and autonomousVMClusterOCID is required to provision an Autonomous Container Database"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "database.oracle.com", Kind: "AutonomousContainerDatabase"},
+		r.Name, allErrs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -50,6 +50,31 @@ import (
 )
 
 var _ = Describe("test AutonomousContainerDatabase webhook", func() {
+	Describe("Test ValidateCreate of the AutonomousContainerDatabase validating webhook", func() {
+		It("Should require either the VM cluster on OCI or on Exadata Cloud@Customer", func() {
+			var errMsg string = "exactly one of autonomousExadataVMClusterOCID and autonomousVMClusterOCID is required to provision an Autonomous Container Database"
+
+			acd := &AutonomousContainerDatabase{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "database.oracle.com/v1alpha1",
+					Kind:       "AutonomousContainerDatabase",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testacd-create",
+					Namespace: "default",
+				},
+				Spec: AutonomousContainerDatabaseSpec{
+					CompartmentOCID:                common.String("fake-compartment-ocid"),
+					DisplayName:                    common.String("fake-displayName"),
+					AutonomousExadataVMClusterOCID: common.String("fake-vmcluster-ocid"),
+					AutonomousVMClusterOCID:        common.String("fake-exacc-vmcluster-ocid"),
+				},
+			}
+
+			validateInvalidTest(acd, false, errMsg)
+		})
+	})
+
 	Describe("Test ValidateUpdate of the AutonomousContainerDatabase validating webhook", func() {
 		var (
 			resourceName = "testacd"
@@ -41,9 +41,13 @@ package v1alpha1
 import (
 	"encoding/json"
 	"reflect"
+	"time"
 
 	"github.com/oracle/oci-go-sdk/v65/database"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -59,8 +63,39 @@ type AutonomousDatabaseSpec struct {
 	OCIConfig OCIConfigSpec             `json:"ociConfig,omitempty"`
 	// +kubebuilder:default:=false
 	HardLink *bool `json:"hardLink,omitempty"`
+	// Labels propagated as tags of the OCI Autonomous Database
+	TagPropagation *TagPropagationSpec `json:"tagPropagation,omitempty"`
+	// Manage: the spec is applied to the OCI Autonomous Database. ObserveOnly: the spec and the status are synced from OCI
+	// and the OCI Autonomous Database is never changed
+	// +kubebuilder:validation:Enum:="Manage";"ObserveOnly"
+	// +kubebuilder:default:=Manage
+	SyncPolicy SyncPolicyEnum `json:"syncPolicy,omitempty"`
+	// What to do when the OCI Autonomous Database is changed outside of the operator: Merge the changes into the spec,
+	// Revert them, or Flag them in the Drifted condition
+	// +kubebuilder:validation:Enum:="Merge";"Revert";"Flag"
+	// +kubebuilder:default:=Merge
+	DriftPolicy DriftPolicyEnum `json:"driftPolicy,omitempty"`
+	// Start and stop the Autonomous Database on a schedule, by setting details.lifecycleState at each transition
+	Schedule *AutonomousDatabaseSchedule `json:"schedule,omitempty"`
+}
+
+// AutonomousDatabaseSchedule defines the cron expressions of the starts and stops of the Autonomous Database
+type AutonomousDatabaseSchedule struct {
+	// Cron expression (minute hour day-of-month month day-of-week) of the starts of the Autonomous Database
+	Start string `json:"start"`
+	// Cron expression of the stops of the Autonomous Database
+	Stop string `json:"stop"`
+	// Time zone of the cron expressions, such as Europe/Paris. Defaults to UTC
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
+type SyncPolicyEnum string
+
+const (
+	SyncPolicyManage      SyncPolicyEnum = "Manage"
+	SyncPolicyObserveOnly SyncPolicyEnum = "ObserveOnly"
+)
+
 /************************
 *	ACD specs
 ************************/
@@ -160,6 +195,25 @@ type AutonomousDatabaseStatus struct {
 	LifecycleState       database.AutonomousDatabaseLifecycleStateEnum `json:"lifecycleState,omitempty"`
 	TimeCreated          string                                        `json:"timeCreated,omitempty"`
 	AllConnectionStrings []ConnectionStringProfile                     `json:"allConnectionStrings,omitempty"`
+
+	// The private endpoint and its IP address, when the network access type is PRIVATE
+	PrivateEndpoint   string `json:"privateEndpoint,omitempty"`
+	PrivateEndpointIP string `json:"privateEndpointIP,omitempty"`
+
+	// Running or Stopped according to .spec.schedule, the time of the next scheduled start or stop, and the time
+	// of the latest start or stop request sent for the schedule
+	ScheduledState          string `json:"scheduledState,omitempty"`
+	NextScheduledTransition string `json:"nextScheduledTransition,omitempty"`
+	LastScheduledRequest    string `json:"lastScheduledRequest,omitempty"`
+	// Time since which the Autonomous Database is STOPPED, and its OCPUs are not billed
+	StoppedSince string `json:"stoppedSince,omitempty"`
+
+	// Changes made outside of the operator, as "<field>: <value in the spec> -> <value in OCI>", when the driftPolicy is Flag
+	DriftSummary []string           `json:"driftSummary,omitempty"`
+	Conditions   []metaV1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// The generation of the spec that has been processed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 type TLSAuthenticationEnum string
@@ -256,6 +310,14 @@ func (adb *AutonomousDatabase) UpdateLastSuccessfulSpec() error {
 func (adb *AutonomousDatabase) UpdateStatusFromOCIADB(ociObj database.AutonomousDatabase) {
 	adb.Status.LifecycleState = ociObj.LifecycleState
 	adb.Status.TimeCreated = FormatSDKTime(ociObj.TimeCreated)
+	adb.Status.PrivateEndpoint = ""
+	if ociObj.PrivateEndpoint != nil {
+		adb.Status.PrivateEndpoint = *ociObj.PrivateEndpoint
+	}
+	adb.Status.PrivateEndpointIP = ""
+	if ociObj.PrivateEndpointIp != nil {
+		adb.Status.PrivateEndpointIP = *ociObj.PrivateEndpointIp
+	}
 
 	if *ociObj.IsDedicated {
 		conns := make([]ConnectionStringSpec, len(ociObj.ConnectionStrings.AllConnectionStrings))
@@ -381,6 +443,110 @@ func (adb *AutonomousDatabase) RemoveUnchangedDetails(prevSpec AutonomousDatabas
 	return changed, nil
 }
 
+// SetHealthConditions sets the Ready and Progressing conditions from the lifecycleState. The ADB is ready if it is
+// in the desired stable state and the latest generation of the spec has been processed.
+func (adb *AutonomousDatabase) SetHealthConditions() {
+	state := adb.Status.LifecycleState
+	status := dbcommons.StatusNotReady
+	switch {
+	case state == "" || IsADBIntermediateState(state):
+		status = dbcommons.StatusUpdating
+	case state == database.AutonomousDatabaseLifecycleStateAvailable:
+		status = dbcommons.StatusReady
+	case state == database.AutonomousDatabaseLifecycleStateStopped &&
+		adb.Spec.Details.LifecycleState == database.AutonomousDatabaseLifecycleStateStopped:
+		status = dbcommons.StatusReady
+	}
+
+	dbcommons.SetHealthConditions(&adb.Status.Conditions, adb.GetGeneration(), adb.Status.ObservedGeneration, status)
+}
+
+// NetworkAccessCondition is the condition type reporting the progress of the network access changes, which can
+// take several updates of the ADB, e.g. from PRIVATE to RESTRICTED
+const NetworkAccessCondition string = "NetworkAccessConfigured"
+
+// SetNetworkAccessCondition sets the NetworkAccessConfigured condition to false with the step in progress,
+// or to true if the reason is empty
+func (adb *AutonomousDatabase) SetNetworkAccessCondition(reason string, message string) {
+	condition := metaV1.Condition{
+		Type:               NetworkAccessCondition,
+		Status:             metaV1.ConditionFalse,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	}
+	if reason == "" {
+		condition.Status = metaV1.ConditionTrue
+		condition.Reason = "Configured"
+		condition.Message = "The network access type is " + string(adb.Spec.Details.NetworkAccess.AccessType)
+	}
+	meta.SetStatusCondition(&adb.Status.Conditions, condition)
+}
+
+// WorkloadCondition is the condition type reporting the progress of the changes of the workload type
+const WorkloadCondition string = "WorkloadConfigured"
+
+// SetWorkloadCondition sets the WorkloadConfigured condition to false with the step in progress,
+// or to true if the reason is empty
+func (adb *AutonomousDatabase) SetWorkloadCondition(reason string, message string) {
+	condition := metaV1.Condition{
+		Type:               WorkloadCondition,
+		Status:             metaV1.ConditionFalse,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	}
+	if reason == "" {
+		condition.Status = metaV1.ConditionTrue
+		condition.Reason = "Configured"
+		condition.Message = "The workload type is " + string(adb.Spec.Details.DbWorkload)
+	}
+	meta.SetStatusCondition(&adb.Status.Conditions, condition)
+}
+
+// ScheduleCondition is the condition type reporting whether the Autonomous Database is in the state of its schedule
+const ScheduleCondition string = "ScheduleApplied"
+
+// SetScheduleCondition sets the ScheduleApplied condition to false with the scheduled start or stop in progress,
+// or to true if the reason is empty
+func (adb *AutonomousDatabase) SetScheduleCondition(reason string, message string) {
+	condition := metaV1.Condition{
+		Type:               ScheduleCondition,
+		Status:             metaV1.ConditionFalse,
+		ObservedGeneration: adb.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	}
+	if reason == "" {
+		condition.Status = metaV1.ConditionTrue
+		condition.Reason = "Applied"
+		condition.Message = "The lifecycleState is " + string(adb.Status.LifecycleState) + " until " +
+			adb.Status.NextScheduledTransition
+	}
+	meta.SetStatusCondition(&adb.Status.Conditions, condition)
+}
+
+// SetStoppedSince records the time at which the Autonomous Database was found STOPPED, or clears it
+func (adb *AutonomousDatabase) SetStoppedSince() {
+	if adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateStopped {
+		adb.Status.StoppedSince = ""
+	} else if adb.Status.StoppedSince == "" {
+		adb.Status.StoppedSince = time.Now().UTC().Format(time.RFC3339)
+	}
+}
+
+// GetDriftSummary compares spec.details with the spec of the OCI Autonomous Database, and returns the fields
+// which have been changed in OCI. The fields that are not set in the spec are ignored.
+func (adb *AutonomousDatabase) GetDriftSummary(ociSpec AutonomousDatabaseSpec) ([]string, error) {
+	difADB := adb.DeepCopy()
+	changed, err := difADB.RemoveUnchangedDetails(ociSpec)
+	if err != nil || !changed {
+		return nil, err
+	}
+
+	return getDriftSummary(difADB.Spec.Details, ociSpec.Details)
+}
+
 // A helper function which is useful for debugging. The function prints out a structural JSON format.
 func (adb *AutonomousDatabase) String() (string, error) {
 	out, err := json.MarshalIndent(adb, "", "    ")
@@ -40,6 +40,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/database"
@@ -50,6 +51,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
 )
 
 // log is for logging in this package.
@@ -87,6 +90,11 @@ func (r *AutonomousDatabase) Default() {
 	} else { // Dedicated database
 		// AccessType can only be PRIVATE for a dedicated database
 		r.Spec.Details.NetworkAccess.AccessType = NetworkAccessTypePrivate
+
+		// A database created in an Autonomous Container Database is a dedicated database
+		if r.Spec.Details.IsDedicated == nil {
+			r.Spec.Details.IsDedicated = common.Bool(true)
+		}
 	}
 
 }
@@ -103,6 +111,14 @@ func (r *AutonomousDatabase) ValidateCreate() error {
 
 	autonomousdatabaselog.Info("validate create", "name", r.Name)
 
+	if r.Spec.SyncPolicy == SyncPolicyObserveOnly && r.Spec.Details.AutonomousDatabaseOCID == nil {
+		allErrs = append(allErrs,
+			field.Required(field.NewPath("spec").Child("details").Child("autonomousDatabaseOCID"),
+				"autonomousDatabaseOCID is required to adopt an Autonomous Database with the ObserveOnly syncPolicy"))
+	}
+	allErrs = validateSyncPolicy(r, allErrs)
+	allErrs = validateSchedule(r, allErrs)
+
 	if r.Spec.Details.AutonomousDatabaseOCID == nil { // provisioning operation
 		allErrs = validateCommon(r, allErrs)
 		allErrs = validateNetworkAccess(r, allErrs)
@@ -161,6 +177,14 @@ func (r *AutonomousDatabase) ValidateUpdate(old runtime.Object) error {
 				"autonomousDatabaseOCID cannot be modified"))
 	}
 
+	// only some workload types can be converted to another
+	if oldADB.Spec.Details.DbWorkload != "" && r.Spec.Details.DbWorkload != "" &&
+		!ValidADBWorkloadTransition(oldADB.Spec.Details.DbWorkload, r.Spec.Details.DbWorkload) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("details").Child("dbWorkload"),
+				fmt.Sprintf("dbWorkload cannot be changed from %s to %s", oldADB.Spec.Details.DbWorkload, r.Spec.Details.DbWorkload)))
+	}
+
 	// cannot change lifecycleState with other fields together (except the oci config)
 	var lifecycleChanged, otherFieldsChanged bool
 
@@ -183,6 +207,8 @@ func (r *AutonomousDatabase) ValidateUpdate(old runtime.Object) error {
 
 	allErrs = validateCommon(r, allErrs)
 	allErrs = validateNetworkAccess(r, allErrs)
+	allErrs = validateSyncPolicy(r, allErrs)
+	allErrs = validateSchedule(r, allErrs)
 
 	if len(allErrs) == 0 {
 		return nil
@@ -192,6 +218,34 @@ func (r *AutonomousDatabase) ValidateUpdate(old runtime.Object) error {
 		r.Name, allErrs)
 }
 
+func validateSyncPolicy(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	if adb.Spec.SyncPolicy == SyncPolicyObserveOnly && adb.Spec.HardLink != nil && *adb.Spec.HardLink {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("hardLink"),
+				"cannot terminate an Autonomous Database with the ObserveOnly syncPolicy"))
+	}
+
+	if adb.Spec.SyncPolicy == SyncPolicyObserveOnly && adb.Spec.Schedule != nil {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("schedule"),
+				"cannot start or stop an Autonomous Database with the ObserveOnly syncPolicy"))
+	}
+
+	return allErrs
+}
+
+func validateSchedule(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
+	if adb.Spec.Schedule != nil {
+		if _, _, err := dbcommons.GetScheduledState(adb.Spec.Schedule.Start, adb.Spec.Schedule.Stop,
+			adb.Spec.Schedule.TimeZone, time.Now()); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("schedule"), adb.Spec.Schedule, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
 func validateCommon(adb *AutonomousDatabase, allErrs field.ErrorList) field.ErrorList {
 	// password
 	if adb.Spec.Details.AdminPassword.K8sSecret.Name != nil && adb.Spec.Details.AdminPassword.OCISecret.OCID != nil {
@@ -153,6 +153,17 @@ var _ = Describe("test AutonomousDatabase webhook", func() {
 			validateInvalidTest(adb, false, errMsg)
 		})
 
+		It("Should not apply an invalid schedule", func() {
+			var errMsg string = "cron expression \"0 8 * *\" should have 5 fields"
+
+			adb.Spec.Schedule = &AutonomousDatabaseSchedule{
+				Start: "0 8 * *",
+				Stop:  "0 20 * * 1-5",
+			}
+
+			validateInvalidTest(adb, false, errMsg)
+		})
+
 		// Network validation
 		Context("Shared Autonomous Database", func() {
 			It("AccessControlList cannot be empty when the network access type is RESTRICTED", func() {
@@ -306,5 +317,16 @@ var _ = Describe("test AutonomousDatabase webhook", func() {
 
 			validateInvalidTest(adb, true, errMsg)
 		})
+
+		It("Cannot change dbWorkload from OLTP to AJD", func() {
+			var errMsg string = "dbWorkload cannot be changed from OLTP to AJD"
+
+			adb.Spec.Details.DbWorkload = database.AutonomousDatabaseDbWorkloadOltp
+			Expect(k8sClient.Update(context.TODO(), adb)).To(Succeed())
+
+			adb.Spec.Details.DbWorkload = database.AutonomousDatabaseDbWorkloadAjd
+
+			validateInvalidTest(adb, true, errMsg)
+		})
 	})
 })
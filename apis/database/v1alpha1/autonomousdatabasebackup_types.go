@@ -70,6 +70,9 @@ type AutonomousDatabaseBackupStatus struct {
 	CompartmentOCID        string                                              `json:"compartmentOCID"`
 	DBName                 string                                              `json:"dbName"`
 	DBDisplayName          string                                              `json:"dbDisplayName"`
+
+	// The generation of the spec that has been processed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -84,6 +84,9 @@ type AutonomousDatabaseRestoreStatus struct {
 	DbName          string                             `json:"dbName"`
 	WorkRequestOCID string                             `json:"workRequestOCID"`
 	Status          workrequests.WorkRequestStatusEnum `json:"status"`
+
+	// The generation of the spec that has been processed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -144,6 +144,9 @@ type CDBStatus struct {
 	Status bool `json:"status"`
 	// Message
 	Msg string `json:"msg,omitempty"`
+
+	// The generation of the spec that has been processed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,203 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// databaseClassReader reads the classes of the resources, set up with the webhooks
+var databaseClassReader client.Reader
+
+// getDatabaseClass returns an OracleDatabaseClass
+func getDatabaseClass(className string) (*OracleDatabaseClass, error) {
+	class := &OracleDatabaseClass{}
+	if err := databaseClassReader.Get(context.TODO(), types.NamespacedName{Name: className}, class); err != nil {
+		return nil, err
+	}
+	return class, nil
+}
+
+// validateDatabaseClass checks that the class of a resource exists and has defaults for its kind
+func validateDatabaseClass(path *field.Path, className string, kind string) *field.Error {
+	if className == "" || databaseClassReader == nil {
+		return nil
+	}
+	class, err := getDatabaseClass(className)
+	if apierrors.IsNotFound(err) {
+		return field.NotFound(path, className)
+	}
+	if err != nil {
+		return nil
+	}
+	if (kind == "SingleInstanceDatabase" && class.Spec.Database == nil) || (kind == "OracleRestDataService" && class.Spec.Ords == nil) {
+		return field.Invalid(path, className, "the class has no defaults for "+kind)
+	}
+	return nil
+}
+
+// applyClassDefaults merges the defaults of a class into a spec, and returns the merged spec as JSON. A field empty in
+// the spec, or still holding the value the class gave it, takes the value of the class. The values given are recorded
+// in the annotations of the resource, so that the fields set in the resource since are not changed by the class
+func applyClassDefaults(obj metav1.Object, spec interface{}, defaults interface{}, generation int64) ([]byte, error) {
+	specFields := map[string]interface{}{}
+	defaultFields := map[string]interface{}{}
+	appliedFields := map[string]interface{}{}
+	if err := remarshal(spec, &specFields); err != nil {
+		return nil, err
+	}
+	if err := remarshal(defaults, &defaultFields); err != nil {
+		return nil, err
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	if applied, ok := annotations[dbcommons.ClassDefaultsAnnotation]; ok {
+		// The values given earlier are only lost when the annotation is edited, the class then filling the empty fields
+		_ = json.Unmarshal([]byte(applied), &appliedFields)
+	}
+
+	mergeClassDefaults(specFields, defaultFields, appliedFields)
+
+	applied, err := json.Marshal(defaultFields)
+	if err != nil {
+		return nil, err
+	}
+	annotations[dbcommons.ClassDefaultsAnnotation] = string(applied)
+	annotations[dbcommons.ClassGenerationAnnotation] = strconv.FormatInt(generation, 10)
+	obj.SetAnnotations(annotations)
+	return json.Marshal(specFields)
+}
+
+// removeClassDefaults forgets the values given by the class of a resource no longer referencing it. They are kept
+// in the spec as values of the resource
+func removeClassDefaults(obj metav1.Object) {
+	annotations := obj.GetAnnotations()
+	delete(annotations, dbcommons.ClassDefaultsAnnotation)
+	delete(annotations, dbcommons.ClassGenerationAnnotation)
+	obj.SetAnnotations(annotations)
+}
+
+// mergeClassDefaults merges the JSON objects of the defaults of a class into the ones of a spec, given the defaults
+// applied before. Objects are merged field by field, other values are replaced as a whole
+func mergeClassDefaults(spec map[string]interface{}, defaults map[string]interface{}, applied map[string]interface{}) {
+	keys := map[string]bool{}
+	for key := range defaults {
+		keys[key] = true
+	}
+	for key := range applied {
+		keys[key] = true
+	}
+	for key := range keys {
+		value, inDefaults := defaults[key]
+		current := spec[key]
+		if valueFields, ok := value.(map[string]interface{}); ok {
+			currentFields, ok := current.(map[string]interface{})
+			if ok || isEmptyJSON(current) {
+				if currentFields == nil {
+					currentFields = map[string]interface{}{}
+				}
+				appliedFields, _ := applied[key].(map[string]interface{})
+				mergeClassDefaults(currentFields, valueFields, appliedFields)
+				spec[key] = currentFields
+				continue
+			}
+		}
+		previous, wasApplied := applied[key]
+		setInSpec := !isEmptyJSON(current)
+		if wasApplied {
+			// A false or 0 is omitted from the spec: a boolean or number the class gave that is no longer there was
+			// set in the resource
+			setInSpec = !reflect.DeepEqual(current, previous) && (setInSpec || isScalarJSON(previous))
+		}
+		if !setInSpec {
+			if inDefaults {
+				spec[key] = value
+			} else {
+				delete(spec, key)
+			}
+		}
+	}
+}
+
+// isEmptyJSON returns true for the JSON values of the fields that are not set. The booleans and numbers are told
+// apart from the unset fields by the values the class gave them
+func isEmptyJSON(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// isScalarJSON returns true for the JSON booleans and numbers
+func isScalarJSON(value interface{}) bool {
+	switch value.(type) {
+	case bool, float64:
+		return true
+	}
+	return false
+}
+
+// remarshal converts a value to the JSON representation of another type
+func remarshal(from interface{}, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
@@ -0,0 +1,196 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	// +kubebuilder:scaffold:imports
+)
+
+var _ = Describe("test the database classes", func() {
+	var (
+		class       *OracleDatabaseClass
+		classClient client.Client
+	)
+
+	BeforeEach(func() {
+		class = &OracleDatabaseClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "medium", Generation: 1},
+			Spec: OracleDatabaseClassSpec{Database: &OracleDatabaseClassDatabase{
+				Edition: "enterprise",
+				Image:   &OracleDatabaseClassImage{PullFrom: "enterprise:19.3.0"},
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+				},
+				Persistence: &OracleDatabaseClassPersistence{Size: "100Gi", AccessMode: "ReadWriteOnce"},
+				ArchiveLog:  true,
+			}},
+		}
+		classScheme := runtime.NewScheme()
+		Expect(AddToScheme(classScheme)).To(Succeed())
+		classClient = fake.NewClientBuilder().WithScheme(classScheme).WithObjects(class).Build()
+		databaseClassReader = classClient
+	})
+
+	AfterEach(func() {
+		databaseClassReader = nil
+	})
+
+	It("should fill the empty fields of a database", func() {
+		sidb := &SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: "sidb", Namespace: "default"},
+			Spec: SingleInstanceDatabaseSpec{
+				ClassName:   "medium",
+				Persistence: SingleInstanceDatabasePersistence{Size: "200Gi"},
+			},
+		}
+		sidb.applyDatabaseClass()
+		Expect(sidb.Spec.Edition).To(Equal("enterprise"))
+		Expect(sidb.Spec.Image.PullFrom).To(Equal("enterprise:19.3.0"))
+		Expect(sidb.Spec.Resources.Requests.Memory().String()).To(Equal("8Gi"))
+		Expect(sidb.Spec.Persistence.Size).To(Equal("200Gi"))
+		Expect(sidb.Spec.Persistence.AccessMode).To(Equal("ReadWriteOnce"))
+		Expect(sidb.Spec.ArchiveLog).To(BeTrue())
+		Expect(sidb.Annotations[dbcommons.ClassGenerationAnnotation]).To(Equal("1"))
+	})
+
+	It("should apply the changes of the class to the fields it gave", func() {
+		sidb := &SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: "sidb", Namespace: "default"},
+			Spec:       SingleInstanceDatabaseSpec{ClassName: "medium"},
+		}
+		sidb.applyDatabaseClass()
+		sidb.CreationTimestamp = metav1.Now()
+		sidb.Spec.Persistence.Size = "200Gi"
+
+		class.Generation = 2
+		class.Spec.Database.Edition = "standard"
+		class.Spec.Database.Image.PullFrom = "enterprise:21.3.0"
+		class.Spec.Database.Persistence.Size = "150Gi"
+		class.Spec.Database.ArchiveLog = false
+		Expect(classClient.Update(context.TODO(), class)).To(Succeed())
+
+		sidb.applyDatabaseClass()
+		Expect(sidb.Spec.Edition).To(Equal("enterprise"))
+		Expect(sidb.Spec.Image.PullFrom).To(Equal("enterprise:21.3.0"))
+		Expect(sidb.Spec.Persistence.Size).To(Equal("200Gi"))
+		Expect(sidb.Spec.ArchiveLog).To(BeFalse())
+		Expect(sidb.Annotations[dbcommons.ClassGenerationAnnotation]).To(Equal("2"))
+	})
+
+	It("should not change the volume of an existing database", func() {
+		sidb := &SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: "sidb", Namespace: "default"},
+			Spec:       SingleInstanceDatabaseSpec{ClassName: "medium"},
+		}
+		sidb.applyDatabaseClass()
+		sidb.CreationTimestamp = metav1.Now()
+
+		class.Generation = 2
+		class.Spec.Database.Persistence = &OracleDatabaseClassPersistence{Size: "150Gi", StorageClass: "fast", AccessMode: "ReadWriteMany"}
+		Expect(classClient.Update(context.TODO(), class)).To(Succeed())
+
+		sidb.applyDatabaseClass()
+		Expect(sidb.Spec.Persistence.Size).To(Equal("100Gi"))
+		Expect(sidb.Spec.Persistence.StorageClass).To(Equal(""))
+		Expect(sidb.Spec.Persistence.AccessMode).To(Equal("ReadWriteOnce"))
+	})
+
+	It("should keep the booleans set to false in the database", func() {
+		sidb := &SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: "sidb", Namespace: "default"},
+			Spec:       SingleInstanceDatabaseSpec{ClassName: "medium"},
+		}
+		sidb.applyDatabaseClass()
+		Expect(sidb.Spec.ArchiveLog).To(BeTrue())
+		sidb.CreationTimestamp = metav1.Now()
+		sidb.Spec.ArchiveLog = false
+
+		sidb.applyDatabaseClass()
+		Expect(sidb.Spec.ArchiveLog).To(BeFalse())
+
+		class.Generation = 2
+		class.Spec.Database.Image.PullFrom = "enterprise:21.3.0"
+		Expect(classClient.Update(context.TODO(), class)).To(Succeed())
+		sidb.applyDatabaseClass()
+		Expect(sidb.Spec.ArchiveLog).To(BeFalse())
+		Expect(sidb.Spec.Image.PullFrom).To(Equal("enterprise:21.3.0"))
+	})
+
+	It("should keep the values of the class when the class is removed", func() {
+		sidb := &SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: "sidb", Namespace: "default"},
+			Spec:       SingleInstanceDatabaseSpec{ClassName: "medium"},
+		}
+		sidb.applyDatabaseClass()
+		sidb.Spec.ClassName = ""
+		sidb.applyDatabaseClass()
+		Expect(sidb.Spec.Image.PullFrom).To(Equal("enterprise:19.3.0"))
+		Expect(sidb.Annotations[dbcommons.ClassDefaultsAnnotation]).To(Equal(""))
+	})
+
+	It("should reject the classes without defaults for the kind", func() {
+		Expect(validateDatabaseClass(nil, "medium", "SingleInstanceDatabase")).To(BeNil())
+		Expect(validateDatabaseClass(nil, "medium", "OracleRestDataService")).NotTo(BeNil())
+		Expect(validateDatabaseClass(nil, "large", "SingleInstanceDatabase")).NotTo(BeNil())
+	})
+
+	It("should merge the objects field by field", func() {
+		spec := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(`{"image":{"pullFrom":"custom"},"archiveLog":true}`), &spec)).To(Succeed())
+		defaults := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(`{"image":{"pullFrom":"class","pullSecrets":"secret"}}`), &defaults)).To(Succeed())
+		applied := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(`{"archiveLog":true}`), &applied)).To(Succeed())
+		mergeClassDefaults(spec, defaults, applied)
+		Expect(spec).To(Equal(map[string]interface{}{
+			"image": map[string]interface{}{"pullFrom": "custom", "pullSecrets": "secret"},
+		}))
+	})
+})
@@ -59,11 +59,29 @@ type DataguardBrokerSpec struct {
 	ProtectionMode       string                           `json:"protectionMode"`
 	NodeSelector         map[string]string                `json:"nodeSelector,omitempty"`
 	FastStartFailOver    DataguardBrokerFastStartFailOver `json:"fastStartFailOver,omitempty"`
+	// What the deletion of the DataguardBroker does in the databases. Delete removes the Data Guard configuration
+	// from the primary, Abandon leaves it untouched, for a primary that is corrupted or permanently lost
+	// +kubebuilder:validation:Enum=Delete;Abandon
+	// +kubebuilder:default:="Delete"
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
 }
 
 type DataguardBrokerFastStartFailOver struct {
 	Enable   bool                      `json:"enable,omitempty"`
 	Strategy []DataguardBrokerStrategy `json:"strategy,omitempty"`
+	// Seconds the observer and target standby wait before initiating a failover
+	// +kubebuilder:validation:Minimum=6
+	Threshold int `json:"threshold,omitempty"`
+	// Seconds the target standby may lag behind the primary for a failover to be allowed
+	// +kubebuilder:validation:Minimum=0
+	LagLimit int                     `json:"lagLimit,omitempty"`
+	Observer DataguardBrokerObserver `json:"observer,omitempty"`
+}
+
+// Observer pod managed by the operator to drive fast-start failover
+type DataguardBrokerObserver struct {
+	Enable       bool              `json:"enable,omitempty"`
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
 // FSFO strategy
@@ -84,6 +102,16 @@ type DataguardBrokerStatus struct {
 	ExternalConnectString string `json:"externalConnectString,omitempty"`
 	ClusterConnectString  string `json:"clusterConnectString,omitempty"`
 	Status                string `json:"status,omitempty"`
+	FastStartFailOver     string `json:"fastStartFailOver,omitempty"`
+	Observer              string `json:"observer,omitempty"`
+	ObserverStatus        string `json:"observerStatus,omitempty"`
+
+	// The generation of the spec that has been processed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 //+kubebuilder:object:root=true
@@ -94,6 +122,8 @@ type DataguardBrokerStatus struct {
 // +kubebuilder:printcolumn:JSONPath=".status.clusterConnectString",name="Cluster Connect Str",type="string",priority=1
 // +kubebuilder:printcolumn:JSONPath=".status.externalConnectString",name="Connect Str",type="string"
 // +kubebuilder:printcolumn:JSONPath=".spec.primaryDatabaseRef",name="Primary Database",type="string", priority=1
+// +kubebuilder:printcolumn:JSONPath=".status.fastStartFailOver",name="FSFO",type="string",priority=1
+// +kubebuilder:printcolumn:JSONPath=".status.observerStatus",name="Observer",type="string",priority=1
 // +kubebuilder:printcolumn:JSONPath=".status.status",name="Status",type="string"
 
 // DataguardBroker is the Schema for the dataguardbrokers API
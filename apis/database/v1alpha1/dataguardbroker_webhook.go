@@ -98,8 +98,25 @@ var _ webhook.Validator = &DataguardBroker{}
 func (r *DataguardBroker) ValidateCreate() error {
 	dataguardbrokerlog.Info("validate create", "name", r.Name)
 
-	// TODO(user): fill in your validation logic upon object creation.
-	return nil
+	var allErrs field.ErrorList
+
+	if r.Spec.FastStartFailOver.Observer.Enable && !r.Spec.FastStartFailOver.Enable {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("fastStartFailOver").Child("observer").Child("enable"), r.Spec.FastStartFailOver.Observer.Enable,
+				"observer requires fastStartFailOver to be enabled"))
+	}
+	if r.Spec.FastStartFailOver.Enable && r.Spec.ProtectionMode == "MaxPerformance" && r.Spec.FastStartFailOver.LagLimit == 0 {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("fastStartFailOver").Child("lagLimit"), r.Spec.FastStartFailOver.LagLimit,
+				"lagLimit must be greater than 0 for fast-start failover in MaxPerformance mode"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "database.oracle.com", Kind: "DataguardBroker"},
+		r.Name, allErrs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
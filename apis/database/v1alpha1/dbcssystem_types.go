@@ -54,9 +54,23 @@ import (
 type DbcsSystemSpec struct {
 	DbSystem     DbSystemDetails `json:"dbSystem,omitempty"`
 	Id           *string         `json:"id,omitempty"`
-	OCIConfigMap string          `json:"ociConfigMap"`
+	OCIConfigMap string          `json:"ociConfigMap,omitempty"`
 	OCISecret    string          `json:"ociSecret,omitempty"`
 	HardLink     bool            `json:"hardLink,omitempty"`
+
+	// APIKey uses ociConfigMap and ociSecret. InstancePrincipal, ResourcePrincipal and WorkloadIdentity use the identity
+	// of the node, of the workload or of the service account of the operator. Defaults to APIKey
+	// +kubebuilder:validation:Enum=APIKey;InstancePrincipal;ResourcePrincipal;WorkloadIdentity
+	OCIAuthMode string `json:"ociAuthMode,omitempty"`
+
+	// Labels propagated as tags of the OCI DB system
+	TagPropagation *TagPropagationSpec `json:"tagPropagation,omitempty"`
+
+	// What to do when the OCI DB system is changed outside of the operator: Merge the changes into the spec,
+	// Revert them, or Flag them in the Drifted condition
+	// +kubebuilder:validation:Enum:="Merge";"Revert";"Flag"
+	// +kubebuilder:default:=Merge
+	DriftPolicy DriftPolicyEnum `json:"driftPolicy,omitempty"`
 }
 
 // DbSystemDetails Spec
@@ -126,6 +140,13 @@ type DbcsSystemStatus struct {
 	DbInfo       []DbStatus       `json:"dbInfo,omitempty"`
 	Network      VmNetworkDetails `json:"network,omitempty"`
 	WorkRequests []DbWorkrequests `json:"workRequests,omitempty"`
+
+	// Changes made outside of the operator, as "<field>: <value in the spec> -> <value in OCI>", when the driftPolicy is Flag
+	DriftSummary []string           `json:"driftSummary,omitempty"`
+	Conditions   []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// The generation of the spec that has been processed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // DbcsSystemStatus defines the observed state of DbcsSystem
@@ -0,0 +1,268 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamespaceQuota limits the number of SingleInstanceDatabase and OracleRestDataService resources of a namespace,
+// the total size of their persistent volumes, and the replicas of each OracleRestDataService. A zero limit is no limit
+type NamespaceQuota struct {
+	SingleInstanceDatabases int
+	OracleRestDataServices  int
+	Storage                 resource.Quantity
+	OrdsReplicas            int
+}
+
+// Quota of the namespaces with no quota annotation, no quota when nil
+var namespaceQuota *NamespaceQuota
+
+// webhookReader reads the namespaces, the resources counted in their quota and the resources referencing a
+// database, set up with the webhooks
+var webhookReader client.Reader
+
+// ParseNamespaceQuota parses a quota such as singleinstancedatabases=5,oraclerestdataservices=5,storage=1Ti,ordsreplicas=4
+func ParseNamespaceQuota(quota string) (*NamespaceQuota, error) {
+	parsed := &NamespaceQuota{}
+	for _, limit := range strings.Split(quota, ",") {
+		if strings.TrimSpace(limit) == "" {
+			continue
+		}
+		name, value, found := strings.Cut(limit, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !found {
+			return nil, fmt.Errorf("invalid limit %q, use name=value", limit)
+		}
+		var err error
+		switch name {
+		case "singleinstancedatabases":
+			parsed.SingleInstanceDatabases, err = strconv.Atoi(value)
+		case "oraclerestdataservices":
+			parsed.OracleRestDataServices, err = strconv.Atoi(value)
+		case "storage":
+			parsed.Storage, err = resource.ParseQuantity(value)
+		case "ordsreplicas":
+			parsed.OrdsReplicas, err = strconv.Atoi(value)
+		default:
+			return nil, fmt.Errorf("unknown limit %q, expected singleinstancedatabases, oraclerestdataservices, storage or ordsreplicas", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit %q: %w", limit, err)
+		}
+	}
+	return parsed, nil
+}
+
+// SetNamespaceQuota sets the quota of the namespaces with no quota annotation, no quota when empty
+func SetNamespaceQuota(quota string) error {
+	if strings.TrimSpace(quota) == "" {
+		namespaceQuota = nil
+		return nil
+	}
+	parsed, err := ParseNamespaceQuota(quota)
+	if err != nil {
+		return err
+	}
+	namespaceQuota = parsed
+	return nil
+}
+
+// getNamespaceQuota returns the quota of the annotation of a namespace, or else the quota of the operator
+func getNamespaceQuota(namespace string) (*NamespaceQuota, error) {
+	ns := &corev1.Namespace{}
+	if err := webhookReader.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return namespaceQuota, nil
+		}
+		return nil, err
+	}
+	if value, ok := ns.Annotations[dbcommons.NamespaceQuotaAnnotation]; ok {
+		if quota, err := ParseNamespaceQuota(value); err == nil {
+			return quota, nil
+		}
+	}
+	return namespaceQuota, nil
+}
+
+// validateNamespaceQuota checks that a new resource of the given kind, with persistent volumes of the given size, fits
+// in the quota of its namespace
+func validateNamespaceQuota(kind string, namespace string, name string, size string) field.ErrorList {
+	return checkNamespaceQuota(kind, namespace, name, size, nil)
+}
+
+// validateNamespaceQuotaUpdate checks that the volumes of an updated resource fit in the quota of its namespace. The
+// resources existing before the quota was lowered can still be updated, as long as their volumes do not grow
+func validateNamespaceQuotaUpdate(kind string, namespace string, name string, size string, oldSize string) field.ErrorList {
+	return checkNamespaceQuota(kind, namespace, name, size, &oldSize)
+}
+
+// checkNamespaceQuota checks a resource against the quota of its namespace, oldSize being the size of its volumes
+// before an update, nil on creation
+func checkNamespaceQuota(kind string, namespace string, name string, size string, oldSize *string) field.ErrorList {
+	var allErrs field.ErrorList
+	if webhookReader == nil {
+		return allErrs
+	}
+	quota, err := getNamespaceQuota(namespace)
+	if err != nil {
+		return append(allErrs, field.InternalError(field.NewPath("metadata").Child("namespace"), err))
+	}
+	if quota == nil {
+		return allErrs
+	}
+
+	sidbs := &SingleInstanceDatabaseList{}
+	ordss := &OracleRestDataServiceList{}
+	if err := webhookReader.List(context.TODO(), sidbs, client.InNamespace(namespace)); err != nil {
+		return append(allErrs, field.InternalError(field.NewPath("metadata").Child("namespace"), err))
+	}
+	if err := webhookReader.List(context.TODO(), ordss, client.InNamespace(namespace)); err != nil {
+		return append(allErrs, field.InternalError(field.NewPath("metadata").Child("namespace"), err))
+	}
+
+	// Sizes of the volumes of the other resources, and the size of this one before the update
+	var sizes []string
+	var storedSize string
+	exists := oldSize != nil
+	if exists {
+		storedSize = *oldSize
+	}
+	count := 0
+	for _, sidb := range sidbs.Items {
+		if kind == "SingleInstanceDatabase" && sidb.Name == name {
+			continue
+		}
+		if kind == "SingleInstanceDatabase" {
+			count++
+		}
+		sizes = append(sizes, sidb.Spec.Persistence.Size)
+	}
+	for _, ords := range ordss.Items {
+		if kind == "OracleRestDataService" && ords.Name == name {
+			continue
+		}
+		if kind == "OracleRestDataService" {
+			count++
+		}
+		sizes = append(sizes, ords.Spec.Persistence.Size)
+	}
+
+	limit := quota.SingleInstanceDatabases
+	if kind == "OracleRestDataService" {
+		limit = quota.OracleRestDataServices
+	}
+	if !exists && limit > 0 && count >= limit {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("metadata").Child("namespace"),
+				fmt.Sprintf("namespace %s already has %d %s resources, the limit of its quota", namespace, count, kind)))
+	}
+
+	if quota.Storage.IsZero() || size == "" {
+		return allErrs
+	}
+	requested, err := resource.ParseQuantity(size)
+	if err != nil {
+		return allErrs
+	}
+	if stored, err := resource.ParseQuantity(storedSize); exists && err == nil && requested.Cmp(stored) <= 0 {
+		return allErrs
+	}
+	total := requested.DeepCopy()
+	for _, s := range sizes {
+		if q, err := resource.ParseQuantity(s); err == nil {
+			total.Add(q)
+		}
+	}
+	if total.Cmp(quota.Storage) > 0 {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("persistence").Child("size"),
+				fmt.Sprintf("the persistent volumes of namespace %s would total %s, over the storage quota %s of the namespace",
+					namespace, total.String(), quota.Storage.String())))
+	}
+	return allErrs
+}
+
+// validateOrdsReplicas checks that the replicas of a new OracleRestDataService, each opening its own pool of connections
+// to the database, fit in the quota of its namespace
+func validateOrdsReplicas(namespace string, replicas int) field.ErrorList {
+	return checkOrdsReplicas(namespace, replicas, nil)
+}
+
+// validateOrdsReplicasUpdate checks the replicas of an updated OracleRestDataService against the quota of its namespace.
+// An OracleRestDataService scaled before the quota was lowered can keep its replicas, but not add more
+func validateOrdsReplicasUpdate(namespace string, replicas int, oldReplicas int) field.ErrorList {
+	return checkOrdsReplicas(namespace, replicas, &oldReplicas)
+}
+
+// checkOrdsReplicas checks the replicas of an OracleRestDataService against the quota of its namespace, oldReplicas
+// being its replicas before an update, nil on creation
+func checkOrdsReplicas(namespace string, replicas int, oldReplicas *int) field.ErrorList {
+	var allErrs field.ErrorList
+	if webhookReader == nil {
+		return allErrs
+	}
+	quota, err := getNamespaceQuota(namespace)
+	if err != nil {
+		return append(allErrs, field.InternalError(field.NewPath("metadata").Child("namespace"), err))
+	}
+	if quota == nil || quota.OrdsReplicas == 0 || replicas <= quota.OrdsReplicas {
+		return allErrs
+	}
+	if oldReplicas != nil && replicas <= *oldReplicas {
+		return allErrs
+	}
+	allErrs = append(allErrs,
+		field.Forbidden(field.NewPath("spec").Child("replicas"),
+			fmt.Sprintf("%d replicas are over the limit of %d replicas per OracleRestDataService of the quota of namespace %s",
+				replicas, quota.OrdsReplicas, namespace)))
+	return allErrs
+}
@@ -0,0 +1,158 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	// +kubebuilder:scaffold:imports
+)
+
+// failingListReader is a client whose lists fail
+type failingListReader struct {
+	client.Client
+}
+
+func (r failingListReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return errors.New("list failed")
+}
+
+var _ = Describe("test the namespace quota", func() {
+	var (
+		namespace = "tenant"
+
+		quotaClient client.Client
+	)
+
+	BeforeEach(func() {
+		quotaScheme := runtime.NewScheme()
+		Expect(AddToScheme(quotaScheme)).To(Succeed())
+		Expect(corev1.AddToScheme(quotaScheme)).To(Succeed())
+		quotaClient = fake.NewClientBuilder().WithScheme(quotaScheme).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+			&SingleInstanceDatabase{
+				ObjectMeta: metav1.ObjectMeta{Name: "sidb-1", Namespace: namespace},
+				Spec:       SingleInstanceDatabaseSpec{Persistence: SingleInstanceDatabasePersistence{Size: "100Gi"}},
+			},
+		).Build()
+		webhookReader = quotaClient
+		Expect(SetNamespaceQuota("singleinstancedatabases=1,storage=150Gi")).To(Succeed())
+	})
+
+	AfterEach(func() {
+		webhookReader = nil
+		Expect(SetNamespaceQuota("")).To(Succeed())
+	})
+
+	It("Should reject a database over the limit of the namespace", func() {
+		errs := validateNamespaceQuota("SingleInstanceDatabase", namespace, "sidb-2", "")
+		Expect(errs.ToAggregate().Error()).To(ContainSubstring("already has 1 SingleInstanceDatabase resources"))
+	})
+
+	It("Should reject volumes over the storage quota of the namespace", func() {
+		errs := validateNamespaceQuota("OracleRestDataService", namespace, "ords-1", "100Gi")
+		Expect(errs.ToAggregate().Error()).To(ContainSubstring("would total 200Gi, over the storage quota 150Gi"))
+		Expect(validateNamespaceQuota("OracleRestDataService", namespace, "ords-1", "50Gi")).To(BeNil())
+	})
+
+	It("Should accept the updates of an existing database", func() {
+		Expect(validateNamespaceQuotaUpdate("SingleInstanceDatabase", namespace, "sidb-1", "100Gi", "100Gi")).To(BeNil())
+	})
+
+	It("Should reject the volumes of an existing database growing over the storage quota", func() {
+		errs := validateNamespaceQuotaUpdate("SingleInstanceDatabase", namespace, "sidb-1", "200Gi", "100Gi")
+		Expect(errs.ToAggregate().Error()).To(ContainSubstring("over the storage quota 150Gi"))
+	})
+
+	It("Should report the resources of the namespace that cannot be listed", func() {
+		webhookReader = failingListReader{quotaClient}
+		errs := validateNamespaceQuota("SingleInstanceDatabase", namespace, "sidb-2", "")
+		Expect(len(errs)).To(Equal(1))
+		Expect(errs[0].Type).To(Equal(field.ErrorTypeInternal))
+	})
+
+	It("Should use the quota of the annotation of the namespace", func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace,
+			Annotations: map[string]string{dbcommons.NamespaceQuotaAnnotation: "singleinstancedatabases=2"}}}
+		Expect(quotaClient.Update(context.TODO(), ns)).To(Succeed())
+		Expect(validateNamespaceQuota("SingleInstanceDatabase", namespace, "sidb-2", "")).To(BeNil())
+	})
+
+	It("Should reject the replicas of ORDS over the limit of the namespace", func() {
+		Expect(SetNamespaceQuota("ordsreplicas=3")).To(Succeed())
+		errs := validateOrdsReplicas(namespace, 4)
+		Expect(errs.ToAggregate().Error()).To(ContainSubstring("4 replicas are over the limit of 3 replicas"))
+		Expect(validateOrdsReplicas(namespace, 3)).To(BeNil())
+	})
+
+	It("Should accept the replicas of an ORDS scaled before the quota was lowered", func() {
+		Expect(SetNamespaceQuota("ordsreplicas=3")).To(Succeed())
+		Expect(validateOrdsReplicasUpdate(namespace, 5, 5)).To(BeNil())
+		Expect(validateOrdsReplicasUpdate(namespace, 4, 5)).To(BeNil())
+		Expect(validateOrdsReplicasUpdate(namespace, 6, 5)).ToNot(BeNil())
+	})
+
+	It("Should check the replicas of ORDS against the old replicas on update", func() {
+		Expect(SetNamespaceQuota("ordsreplicas=3")).To(Succeed())
+		old := &OracleRestDataService{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords-1", Namespace: namespace, CreationTimestamp: metav1.Now()},
+			Spec:       OracleRestDataServiceSpec{DatabaseRef: "sidb", Replicas: 5},
+		}
+		ords := old.DeepCopy()
+		Expect(ords.ValidateUpdate(old)).To(Succeed())
+		ords.Spec.Replicas = 6
+		err := ords.ValidateUpdate(old)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.replicas"))
+	})
+
+	It("Should reject an unknown limit", func() {
+		_, err := ParseNamespaceQuota("databases=1")
+		Expect(err).To(HaveOccurred())
+	})
+})
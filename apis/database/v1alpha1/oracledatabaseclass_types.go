@@ -0,0 +1,131 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OracleDatabaseClassSpec defines the shape of the SingleInstanceDatabase and OracleRestDataService resources
+// referencing the class with spec.className. The fields set in a resource win over the ones of its class
+type OracleDatabaseClassSpec struct {
+	// Defaults of the SingleInstanceDatabase resources of the class
+	Database *OracleDatabaseClassDatabase `json:"database,omitempty"`
+	// Defaults of the OracleRestDataService resources of the class
+	Ords *OracleDatabaseClassOrds `json:"ords,omitempty"`
+}
+
+// OracleDatabaseClassDatabase defines the defaults of the databases of a class, named like the fields of their spec
+type OracleDatabaseClassDatabase struct {
+	// Edition of the databases created with the class. Not changed on existing databases
+	// +kubebuilder:validation:Enum=standard;enterprise;express;free
+	Edition   string                       `json:"edition,omitempty"`
+	Image     *OracleDatabaseClassImage    `json:"image,omitempty"`
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Volume of the databases created with the class. Not changed on existing databases
+	Persistence *OracleDatabaseClassPersistence   `json:"persistence,omitempty"`
+	InitParams  *SingleInstanceDatabaseInitParams `json:"initParams,omitempty"`
+	// Backup policy of the databases: the archive log, force logging and flashback modes enabled when true
+	ArchiveLog   bool `json:"archiveLog,omitempty"`
+	ForceLogging bool `json:"forceLog,omitempty"`
+	FlashBack    bool `json:"flashBack,omitempty"`
+}
+
+// OracleDatabaseClassOrds defines the defaults of the ORDS of a class, named like the fields of their spec
+type OracleDatabaseClassOrds struct {
+	Image     *OracleDatabaseClassImage    `json:"image,omitempty"`
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Volume of the ORDS created with the class. Not changed on existing ORDS
+	Persistence *OracleDatabaseClassPersistence `json:"persistence,omitempty"`
+}
+
+// OracleDatabaseClassImage defines the image of the pods of a class
+type OracleDatabaseClassImage struct {
+	Version     string `json:"version,omitempty"`
+	PullFrom    string `json:"pullFrom,omitempty"`
+	PullSecrets string `json:"pullSecrets,omitempty"`
+}
+
+// OracleDatabaseClassPersistence defines the volume of the resources of a class
+type OracleDatabaseClassPersistence struct {
+	Size         string `json:"size,omitempty"`
+	StorageClass string `json:"storageClass,omitempty"`
+	// +kubebuilder:validation:Enum=ReadWriteOnce;ReadWriteMany
+	AccessMode string `json:"accessMode,omitempty"`
+}
+
+// OracleDatabaseClassStatus defines the observed state of OracleDatabaseClass
+type OracleDatabaseClassStatus struct {
+	// Number of SingleInstanceDatabase and OracleRestDataService resources of the class
+	Databases              int `json:"databases,omitempty"`
+	OracleRestDataServices int `json:"oracleRestDataServices,omitempty"`
+	// The generation of the spec given to the resources of the class
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:JSONPath=".spec.database.image.pullFrom",name="Database Image",type="string"
+// +kubebuilder:printcolumn:JSONPath=".spec.database.persistence.size",name="Database Size",type="string"
+// +kubebuilder:printcolumn:JSONPath=".status.databases",name="Databases",type="integer"
+// +kubebuilder:printcolumn:JSONPath=".status.oracleRestDataServices",name="ORDS",type="integer"
+
+// OracleDatabaseClass is the Schema for the oracledatabaseclasses API
+type OracleDatabaseClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OracleDatabaseClassSpec   `json:"spec,omitempty"`
+	Status OracleDatabaseClassStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OracleDatabaseClassList contains a list of OracleDatabaseClass
+type OracleDatabaseClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OracleDatabaseClass `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OracleDatabaseClass{}, &OracleDatabaseClassList{})
+}
@@ -39,6 +39,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -57,18 +58,243 @@ type OracleRestDataServiceSpec struct {
 	Image              OracleRestDataServiceImage               `json:"image,omitempty"`
 	OrdsPassword       OracleRestDataServicePassword            `json:"ordsPassword"`
 	ApexPassword       OracleRestDataServicePassword            `json:"apexPassword,omitempty"`
+	Apex               OracleRestDataServiceApex                `json:"apex,omitempty"`
+	Ords               *OracleRestDataServiceOrds               `json:"ords,omitempty"`
 	AdminPassword      OracleRestDataServicePassword            `json:"adminPassword"`
 	OrdsUser           string                                   `json:"ordsUser,omitempty"`
 	RestEnableSchemas  []OracleRestDataServiceRestEnableSchemas `json:"restEnableSchemas,omitempty"`
 	OracleService      string                                   `json:"oracleService,omitempty"`
 	ServiceAccountName string                                   `json:"serviceAccountName,omitempty"`
 	Persistence        OracleRestDataServicePersistence         `json:"persistence,omitempty"`
+	PodSecurityContext *OracleRestDataServicePodSecurityContext `json:"podSecurityContext,omitempty"`
+	Ingress            *OracleRestDataServiceIngress            `json:"ingress,omitempty"`
+
+	// DNS name published for the ORDS service and used in URLs instead of node or load balancer IPs
+	Hostname string `json:"hostname,omitempty"`
+
+	// Template of the url mapping of the schemas of restEnableSchemas without urlMapping, with the {pdb} and
+	// {schema} placeholders, such as {pdb}_{schema}. The name of the schema is used when not set
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_{}-]+$`
+	UrlMappingTemplate string `json:"urlMappingTemplate,omitempty"`
+
+	// Settings of the database for ORDS at scale, applied by the SingleInstanceDatabase controller while this
+	// OracleRestDataService exists. The highest settings of the ORDS of a database are applied
+	DatabaseTuning *OracleRestDataServiceDatabaseTuning `json:"databaseTuning,omitempty"`
+
+	// Options of the Service
+	// +kubebuilder:validation:Enum=None;ClientIP
+	SessionAffinity string `json:"sessionAffinity,omitempty"`
+	// +kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy    string   `json:"externalTrafficPolicy,omitempty"`
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// Node address published in the URLs of a NodePort service
+	NodePortAddress *OracleRestDataServiceNodePortAddress `json:"nodePortAddress,omitempty"`
+
+	// Keep the common users created for ORDS when it is deleted
+	KeepUsers bool `json:"keepUsers,omitempty"`
+
+	// Install ORDS in the CDB, serving all its PDBs, or only in the PDB of the database
+	// +kubebuilder:validation:Enum=CDB;PDB
+	// +kubebuilder:default:="CDB"
+	InstallScope string `json:"installScope,omitempty"`
+
+	// Name of an ORDS_METADATA backup taken before an image upgrade to restore into the database
+	// +kubebuilder:validation:Pattern=`^ords_metadata_[0-9]{14}$`
+	RestoreMetadataBackup string `json:"restoreMetadataBackup,omitempty"`
+
+	// How the pods are replaced when the image is upgraded
+	UpdateStrategy *OracleRestDataServiceUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// How the operator checks the health of ORDS. Exec runs curl in the pods. HTTP relies on a readiness probe
+	// of the pods, which service meshes such as Istio and Linkerd rewrite to go through their proxy
+	// +kubebuilder:validation:Enum=Exec;HTTP
+	// +kubebuilder:default:="Exec"
+	HealthCheck string `json:"healthCheck,omitempty"`
+
+	// Directory of the ORDS configuration on the volume, <SID>_ORDS by default, or <SID>_ORDS_<NAME> for the
+	// additional ORDS of a database. It cannot be changed once shown in status.configDir
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.-]+(/[A-Za-z0-9_.-]+)*$`
+	ConfigSubPath string `json:"configSubPath,omitempty"`
+
+	// Configuration directory of the ORDS pods. Shared pods run ORDS on the directory of the volume. PerPod pods
+	// run ORDS on a copy of it made at startup, so that the replicas and the pods of an upgrade do not write the same files
+	// +kubebuilder:validation:Enum=Shared;PerPod
+	// +kubebuilder:default:="Shared"
+	ConfigStrategy string `json:"configStrategy,omitempty"`
+
+	// Seconds after which the pods of an unreachable node are force deleted, their replacements being created as
+	// soon as the node is unreachable. 0 leaves the pods until they are evicted by Kubernetes
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default:=60
+	NodeFailureTimeout *int `json:"nodeFailureTimeout,omitempty"`
+
+	// Annotate the ORDS and database pods running the APEX installation as not safe to evict for the cluster autoscaler,
+	// until the installation completes. The operator itself never deletes or restarts them meanwhile
+	BlockEvictionDuringOperations bool `json:"blockEvictionDuringOperations,omitempty"`
+
+	// Context path of the ORDS server, in the URLs of the status and in the paths of the Ingress
+	// +kubebuilder:validation:Pattern=`^/[A-Za-z0-9_.-]+$`
+	// +kubebuilder:default:="/ords"
+	ContextPath string `json:"contextPath,omitempty"`
+
+	// What the operator does when the database of databaseRef is deleted. Wait keeps the ORDS until the database
+	// is created again, Suspend also stops the ORDS pods, and Delete deletes the OracleRestDataService
+	// +kubebuilder:validation:Enum=Wait;Suspend;Delete
+	// +kubebuilder:default:="Wait"
+	DatabaseMissingPolicy string `json:"databaseMissingPolicy,omitempty"`
+
+	// What the deletion of the OracleRestDataService does in the database. Delete uninstalls ORDS and drops the users
+	// it created, Abandon leaves the database untouched, for a database that is corrupted or permanently lost
+	// +kubebuilder:validation:Enum=Delete;Abandon
+	// +kubebuilder:default:="Delete"
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// Environment variables of the ORDS container and of the init container installing ORDS, such as NLS_LANG, TZ,
+	// TNS_ADMIN, JAVA_TOOL_OPTIONS or HTTPS_PROXY. The variables set by the operator cannot be overridden
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// OracleDatabaseClass giving the fields of the spec that are not set, such as the image, the resources and the
+	// persistence. They follow the changes of the class
+	ClassName string `json:"className,omitempty"`
+
+	// Resources of the ORDS container, applied to the pods created after a change
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// OCI services protecting the REST endpoints published by the load balancer of ORDS
+	Oci *OracleRestDataServiceOci `json:"oci,omitempty"`
+
+	// OAuth2 clients created in the REST enabled schemas along with their privileges. The credentials of each
+	// client are written to the Secret <name>-oauth-<client>
+	OAuthClients []OracleRestDataServiceOAuthClient `json:"oauthClients,omitempty"`
+
+	// Global settings of ORDS, properties rendered by the operator into the Secret <name>-settings, and merged by the
+	// init container into defaults.xml of the configuration directory. The pods are replaced when the properties change
+	Settings *OracleRestDataServiceSettings `json:"settings,omitempty"`
+
+	// Warm-up of the pool of the ORDS pods, before they are admitted to the service after a scale up or a restart
+	WarmUp *OracleRestDataServiceWarmUp `json:"warmUp,omitempty"`
+
+	// Hostnames each routed to the pool of a PDB, through the URL mapping of ORDS and a rule of the Ingress per host.
+	// Only for ORDS installed in the CDB, and requires the Ingress. The pods are replaced when the mapping changes
+	VirtualHosts []OracleRestDataServiceVirtualHost `json:"virtualHosts,omitempty"`
 
 	// +k8s:openapi-gen=true
 	// +kubebuilder:validation:Minimum=1
 	Replicas int `json:"replicas,omitempty"`
 }
 
+// OracleRestDataServiceUpdateStrategy defines how the pods are replaced when the image is upgraded
+type OracleRestDataServiceUpdateStrategy struct {
+	// Recreate replaces all the pods at once. Canary first brings up one pod with the new image, and replaces
+	// the other pods only if it passes the health and smoke checks, or else deletes it. The pods of another
+	// environment are replaced at once with Recreate, and else one at a time
+	// +kubebuilder:validation:Enum=Recreate;Canary
+	// +kubebuilder:default:="Recreate"
+	Type string `json:"type,omitempty"`
+	// Paths requested on the canary in addition to the metadata catalog, such as /ords/hr/employees/.
+	// They must answer with an HTTP status below 400
+	SmokeTestPaths []string `json:"smokeTestPaths,omitempty"`
+	// Seconds given to the canary to become ready before it is rolled back
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:default:=600
+	CanaryTimeoutSeconds int `json:"canaryTimeoutSeconds,omitempty"`
+}
+
+// OracleRestDataServiceVirtualHost defines a hostname serving a single PDB at the context path of ORDS
+type OracleRestDataServiceVirtualHost struct {
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`
+	Host string `json:"host"`
+	// +kubebuilder:validation:Pattern=`^[A-Za-z][A-Za-z0-9_]*$`
+	PdbName string `json:"pdbName"`
+	// Secret of the certificate of the host in the Ingress, the tlsSecret of the Ingress by default
+	TlsSecret string `json:"tlsSecret,omitempty"`
+}
+
+// OracleRestDataServiceSettings defines the ORDS settings rendered by the operator
+type OracleRestDataServiceSettings struct {
+	// ORDS properties, such as jdbc.MaxLimit or security.verifySSL, overriding the ones of the operator
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// OracleRestDataServiceWarmUp defines how the pool of an ORDS pod is warmed up before the pod is ready
+type OracleRestDataServiceWarmUp struct {
+	// Connections opened by the pool at startup, jdbc.InitialLimit, 5 by default. The pods are replaced when it changes
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	InitialPoolSize int `json:"initialPoolSize,omitempty"`
+	// Requests sent to each path on a new pod, as many at a time as the initial pool size. The pod is only admitted to
+	// the service once they all succeed
+	// +kubebuilder:validation:Minimum=0
+	Requests int `json:"requests,omitempty"`
+	// Paths of the warm-up requests, such as /ords/hr/employees/, the metadata catalog by default
+	Paths []string `json:"paths,omitempty"`
+}
+
+// OracleRestDataServiceOci defines the OCI services in front of the load balancer of ORDS
+type OracleRestDataServiceOci struct {
+	// OCID of the OCI Web Application Firewall policy applied to the load balancer, by the OCI cloud controller manager
+	WafPolicyId string `json:"wafPolicyId,omitempty"`
+	// OCI API Gateway the REST endpoints are registered with
+	ApiGateway *OracleRestDataServiceApiGateway `json:"apiGateway,omitempty"`
+	// Credentials of the calls to the OCI API Gateway, the instance principal of the node by default
+	OCIConfig OCIConfigSpec `json:"ociConfig,omitempty"`
+}
+
+// OracleRestDataServiceApiGateway defines the deployment of the REST endpoints on an OCI API Gateway
+type OracleRestDataServiceApiGateway struct {
+	GatewayId     string `json:"gatewayId"`
+	CompartmentId string `json:"compartmentId"`
+	// Path prefix of the deployment on the gateway, /<name> by default
+	// +kubebuilder:validation:Pattern=`^/[^/]`
+	PathPrefix string `json:"pathPrefix,omitempty"`
+}
+
+// OracleRestDataServiceOAuthClient defines an OAuth2 client of a REST enabled schema and the privileges it is granted
+type OracleRestDataServiceOAuthClient struct {
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+	// REST enabled schema owning the client, and its PDB, the PDB of the database by default
+	// +kubebuilder:validation:Pattern=`^[A-Za-z][A-Za-z0-9_#]*$`
+	Schema string `json:"schema"`
+	// +kubebuilder:validation:Pattern=`^[A-Za-z][A-Za-z0-9_]*$`
+	PdbName string `json:"pdbName,omitempty"`
+	// +kubebuilder:validation:Enum=client_credentials;authorization_code;implicit
+	// +kubebuilder:default:="client_credentials"
+	GrantType string `json:"grantType,omitempty"`
+	// Email address shown to the users authorizing the client
+	// +kubebuilder:validation:Pattern=`^[^@\s'"$]+@[^@\s'"$]+$`
+	SupportEmail string `json:"supportEmail"`
+	// Redirect URI of the authorization_code and implicit grants
+	// +kubebuilder:validation:Pattern=`^https?://[^\s'"$]+$`
+	RedirectUri string `json:"redirectUri,omitempty"`
+	// Origins allowed to call the REST endpoints with the tokens of the client, such as https://app.example.com
+	AllowedOrigins []string                              `json:"allowedOrigins,omitempty"`
+	Privileges     []OracleRestDataServiceOAuthPrivilege `json:"privileges,omitempty"`
+}
+
+// OracleRestDataServiceOAuthPrivilege defines a privilege protecting URL patterns of a schema, and the role of the same
+// name granted to the client
+type OracleRestDataServiceOAuthPrivilege struct {
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.-]+$`
+	Name string `json:"name"`
+	// Patterns of the URLs protected, relative to the url mapping of the schema, such as /employees/*
+	// +kubebuilder:validation:MinItems=1
+	Patterns []string `json:"patterns"`
+}
+
+// OracleRestDataServiceOAuthClientStatus defines an OAuth2 client created by the operator
+type OracleRestDataServiceOAuthClientStatus struct {
+	Name    string `json:"name"`
+	Schema  string `json:"schema"`
+	PdbName string `json:"pdbName"`
+	// Secret holding the client_id and client_secret of the client
+	SecretName string `json:"secretName"`
+	// Hash of the spec of the client the privileges were last applied from
+	SpecHash string `json:"specHash,omitempty"`
+}
+
 // OracleRestDataServicePersistence defines the storage releated params
 type OracleRestDataServicePersistence struct {
 	Size         string `json:"size,omitempty"`
@@ -77,6 +303,20 @@ type OracleRestDataServicePersistence struct {
 	// +kubebuilder:validation:Enum=ReadWriteOnce;ReadWriteMany
 	AccessMode string `json:"accessMode,omitempty"`
 	VolumeName string `json:"volumeName,omitempty"`
+
+	// Volume snapshot or claim the volume is populated from, such as
+	// {apiGroup: snapshot.storage.k8s.io, kind: VolumeSnapshot, name: <snapshot>}
+	DataSource *corev1.TypedLocalObjectReference `json:"dataSource,omitempty"`
+}
+
+// OracleRestDataServicePodSecurityContext overrides the OS user and groups the ORDS pods run as
+type OracleRestDataServicePodSecurityContext struct {
+	// +kubebuilder:validation:Minimum=1
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	FSGroup *int64 `json:"fsGroup,omitempty"`
 }
 
 // OracleRestDataServiceImage defines the Image source and pullSecrets for POD
@@ -84,6 +324,75 @@ type OracleRestDataServiceImage struct {
 	Version     string `json:"version,omitempty"`
 	PullFrom    string `json:"pullFrom"`
 	PullSecrets string `json:"pullSecrets,omitempty"`
+	// Directory of the ORDS configuration in the image, where the configuration volume is mounted
+	// +kubebuilder:validation:Pattern=`^/[^:]+$`
+	// +kubebuilder:default:="/opt/oracle/ords/config/ords"
+	ConfigDir string `json:"configDir,omitempty"`
+}
+
+// OracleRestDataServiceIngress defines the Ingress with a path based route per PDB
+type OracleRestDataServiceIngress struct {
+	ClassName   *string           `json:"className,omitempty"`
+	Host        string            `json:"host,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	TlsSecret   string            `json:"tlsSecret,omitempty"`
+}
+
+// OracleRestDataServiceNodePortAddress selects the node whose address is published for a NodePort service.
+// The node running a ready ORDS pod is preferred among the selected nodes
+type OracleRestDataServiceNodePortAddress struct {
+	// Address type to publish. If unset, ExternalIP is preferred over InternalIP
+	// +kubebuilder:validation:Enum=ExternalIP;InternalIP
+	Type string `json:"type,omitempty"`
+	// Labels of the nodes whose address can be published
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=de;es;fr;it;ja;ko;pt-br;zh-cn;zh-tw
+type ApexLanguage string
+
+// OracleRestDataServiceApex defines the APEX options
+type OracleRestDataServiceApex struct {
+	// Translations of the APEX builder loaded after the core install
+	Languages []ApexLanguage `json:"languages,omitempty"`
+	// Minutes the installation of APEX may run in the background before it is stopped. Defaults to 60
+	// +kubebuilder:validation:Minimum=10
+	InstallTimeout *int `json:"installTimeout,omitempty"`
+	// APEX distribution zip installed instead of the one of the image, such as apex_23.1.zip
+	Source *OracleRestDataServiceArtifactSource `json:"source,omitempty"`
+}
+
+// OracleRestDataServiceOrds defines the ORDS options
+type OracleRestDataServiceOrds struct {
+	// ORDS distribution zip whose ords.war is run instead of the one of the image, such as ords-23.1.0.zip
+	Source *OracleRestDataServiceArtifactSource `json:"source,omitempty"`
+}
+
+// OracleRestDataServiceArtifactSource defines where a distribution zip is fetched from by the init container of the
+// ORDS pods. Exactly one of persistentVolumeClaim, configMap and url is set
+type OracleRestDataServiceArtifactSource struct {
+	// Claim of the namespace holding the zip at path. It is mounted read only by all the ORDS pods
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	// ConfigMap holding the zip in binaryData under the key path. A ConfigMap is limited to 1 MiB
+	ConfigMap string `json:"configMap,omitempty"`
+	// Path of the zip in the claim, or key of the ConfigMap
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.-]+(/[A-Za-z0-9_.-]+)*$`
+	Path string `json:"path,omitempty"`
+	// HTTPS URL the zip is downloaded from, through the proxy of the operator if any
+	// +kubebuilder:validation:Pattern=`^https://[^'\s]+$`
+	URL string `json:"url,omitempty"`
+	// SHA-256 checksum of the zip, checked before it is extracted
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{64}$`
+	Sha256 string `json:"sha256,omitempty"`
+}
+
+// OracleRestDataServiceApexInstallation defines the progress of the installation of APEX run in the background
+type OracleRestDataServiceApexInstallation struct {
+	// Running, Cancelled or TimedOut. A cancelled or timed out installation is restarted by the reinstall-apex action
+	Phase string `json:"phase,omitempty"`
+	// ORDS pod running the installation, and its start in RFC 3339 format
+	Pod       string `json:"pod,omitempty"`
+	StartTime string `json:"startTime,omitempty"`
 }
 
 // OracleRestDataServicePassword defines the secret containing Password mapped to secretKey
@@ -94,6 +403,18 @@ type OracleRestDataServicePassword struct {
 	KeepSecret *bool  `json:"keepSecret,omitempty"`
 }
 
+// OracleRestDataServiceDatabaseTuning defines the database settings requested by an ORDS
+type OracleRestDataServiceDatabaseTuning struct {
+	// Minimum processes of the database. The database is restarted when processes changes
+	// +kubebuilder:validation:Minimum=0
+	Processes int `json:"processes,omitempty"`
+	// Shared servers, and TCP dispatchers added to the dispatchers of the database, for the ORDS connection pools
+	// +kubebuilder:validation:Minimum=0
+	SharedServers int `json:"sharedServers,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	Dispatchers int `json:"dispatchers,omitempty"`
+}
+
 // OracleRestDataServicePDBSchemas defines the PDB Schemas to be ORDS Enabled
 type OracleRestDataServiceRestEnableSchemas struct {
 	PdbName    string `json:"pdbName,omitempty"`
@@ -119,12 +440,59 @@ type OracleRestDataServiceStatus struct {
 	Replicas           int    `json:"replicas,omitempty"`
 
 	Image OracleRestDataServiceImage `json:"image,omitempty"`
+	// Node whose address is published in the URLs of a NodePort service
+	NodeName string `json:"nodeName,omitempty"`
+	// Directory of the ORDS configuration on the volume
+	ConfigDir string `json:"configDir,omitempty"`
+	// APEX languages loaded into the database
+	ApexLanguages []string `json:"apexLanguages,omitempty"`
+	// Common users created for ORDS, dropped when it is deleted
+	CreatedUsers []string `json:"createdUsers,omitempty"`
+	// Database API URL of each open PDB
+	DatabaseApiUrls map[string]string `json:"databaseApiUrls,omitempty"`
+	// Latest ORDS_METADATA backup and its location on the database volume
+	MetadataBackup         string `json:"metadataBackup,omitempty"`
+	MetadataBackupLocation string `json:"metadataBackupLocation,omitempty"`
+	MetadataRestored       string `json:"metadataRestored,omitempty"`
+	// Image of the canary pod of the latest canary rollout
+	CanaryImage string `json:"canaryImage,omitempty"`
+	// Installation of APEX in progress or stopped
+	ApexInstallation *OracleRestDataServiceApexInstallation `json:"apexInstallation,omitempty"`
+	// Latest export of the APEX workspaces and applications taken before an APEX upgrade, its directory on the
+	// database volume and the APEX version it was exported from
+	ApexExport         string `json:"apexExport,omitempty"`
+	ApexExportLocation string `json:"apexExportLocation,omitempty"`
+	ApexExportVersion  string `json:"apexExportVersion,omitempty"`
+	// Versions of ORDS in the ready pod, of APEX and of the database, and the edition of the database
+	OrdsVersion     string `json:"ordsVersion,omitempty"`
+	ApexVersion     string `json:"apexVersion,omitempty"`
+	DatabaseVersion string `json:"databaseVersion,omitempty"`
+	DatabaseEdition string `json:"databaseEdition,omitempty"`
+	// Deployment of the REST endpoints on the OCI API Gateway, the backend it routes to and its URL
+	ApiGatewayDeploymentId string `json:"apiGatewayDeploymentId,omitempty"`
+	ApiGatewayBackend      string `json:"apiGatewayBackend,omitempty"`
+	ApiGatewayUrl          string `json:"apiGatewayUrl,omitempty"`
+	// OAuth2 clients created in the schemas
+	OAuthClients []OracleRestDataServiceOAuthClientStatus `json:"oauthClients,omitempty"`
+	// Replicas whose pools of jdbc.MaxLimit connections, the default pool and one per PDB of the virtual hosts, fit in
+	// the processes parameter of the database, left by its background processes. Guidance for spec.replicas, which does
+	// not account for the other clients of the database
+	MaxReplicas int `json:"maxReplicas,omitempty"`
+
+	// The generation of the spec that has been processed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 // +kubebuilder:printcolumn:JSONPath=".status.status",name="Status",type="string"
 // +kubebuilder:printcolumn:JSONPath=".spec.databaseRef",name="Database",type="string"
+// +kubebuilder:printcolumn:JSONPath=".status.ordsVersion",name="ORDS Version",type="string",priority=1
+// +kubebuilder:printcolumn:JSONPath=".status.apexVersion",name="Apex Version",type="string",priority=1
 // +kubebuilder:printcolumn:JSONPath=".status.databaseApiUrl",name="Database API URL",type="string"
 // +kubebuilder:printcolumn:JSONPath=".status.databaseActionsUrl",name="Database Actions URL",type="string"
 // +kubebuilder:printcolumn:JSONPath=".status.apexUrl",name="Apex URL",type="string"
@@ -39,19 +39,39 @@
 package v1alpha1
 
 import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"regexp"
+	"strings"
+
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// Origins and URL patterns of the OAuth clients, quoted in the PL/SQL creating them
+var oauthOriginPattern = regexp.MustCompile(`^https?://[A-Za-z0-9.-]+(:[0-9]+)?$`)
+var oauthPatternPattern = regexp.MustCompile(`^/[A-Za-z0-9_.*/:-]*$`)
+
+// Paths of the warm-up requests, appended to the URL of the pods
+var warmUpPathPattern = regexp.MustCompile(`^/[A-Za-z0-9_./-]*$`)
+
+// Names of the ORDS_METADATA backups taken by the operator, passed to the shell importing them
+var metadataBackupPattern = regexp.MustCompile(`^ords_metadata_[0-9]{14}$`)
+
 // log is for logging in this package.
 var oraclerestdataservicelog = logf.Log.WithName("oraclerestdataservice-resource")
 
 func (r *OracleRestDataService) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	passwordSecretReader = mgr.GetAPIReader()
+	webhookReader = mgr.GetAPIReader()
+	databaseClassReader = mgr.GetAPIReader()
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -66,6 +86,8 @@ var _ webhook.Defaulter = &OracleRestDataService{}
 // Default implements webhook.Defaulter so a webhook will be registered for the type
 func (r *OracleRestDataService) Default() {
 	oraclerestdataservicelog.Info("default", "name", r.Name)
+	// The class gives its values to the fields left empty, before the defaults of the operator
+	r.applyDatabaseClass()
 	// OracleRestDataService Currently supports single replica
 	r.Spec.Replicas = 1
 	keepSecret := true
@@ -80,6 +102,40 @@ func (r *OracleRestDataService) Default() {
 	}
 }
 
+// Merge the ORDS defaults of the class of the ORDS into its spec
+func (r *OracleRestDataService) applyDatabaseClass() {
+	if r.Spec.ClassName == "" {
+		removeClassDefaults(r)
+		return
+	}
+	if databaseClassReader == nil {
+		return
+	}
+	class, err := getDatabaseClass(r.Spec.ClassName)
+	if err != nil || class.Spec.Ords == nil {
+		// Refused by the validating webhook
+		return
+	}
+	defaults := class.Spec.Ords.DeepCopy()
+	if !r.CreationTimestamp.IsZero() && defaults.Persistence != nil {
+		// The volume of an existing ORDS cannot be changed
+		defaults.Persistence.Size = r.Spec.Persistence.Size
+		defaults.Persistence.StorageClass = r.Spec.Persistence.StorageClass
+		defaults.Persistence.AccessMode = r.Spec.Persistence.AccessMode
+	}
+	data, err := applyClassDefaults(r, r.Spec, defaults, class.Generation)
+	if err != nil {
+		oraclerestdataservicelog.Error(err, "failed to apply the class", "name", r.Name, "class", r.Spec.ClassName)
+		return
+	}
+	spec := OracleRestDataServiceSpec{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		oraclerestdataservicelog.Error(err, "failed to apply the class", "name", r.Name, "class", r.Spec.ClassName)
+		return
+	}
+	r.Spec = spec
+}
+
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 //+kubebuilder:webhook:verbs=create;update,path=/validate-database-oracle-com-v1alpha1-oraclerestdataservice,mutating=false,failurePolicy=fail,sideEffects=None,groups=database.oracle.com,resources=oraclerestdataservices,versions=v1alpha1,name=voraclerestdataservice.kb.io,admissionReviewVersions={v1,v1beta1}
 
@@ -111,6 +167,102 @@ func (r *OracleRestDataService) ValidateCreate() error {
 					r.Spec.Persistence.AccessMode, "should be either \"ReadWriteOnce\" or \"ReadWriteMany\""))
 		}
 	}
+	allErrs = append(allErrs, validateVolumeDataSource(field.NewPath("spec").Child("persistence"),
+		r.Spec.Persistence.Size, r.Spec.Persistence.VolumeName, r.Spec.Persistence.DataSource)...)
+	if r.CreationTimestamp.IsZero() {
+		allErrs = append(allErrs, validateOrdsReplicas(r.Namespace, r.Spec.Replicas)...)
+		allErrs = append(allErrs, validateNamespaceQuota("OracleRestDataService", r.Namespace, r.Name, r.Spec.Persistence.Size)...)
+		if err := validateDatabaseClass(field.NewPath("spec").Child("className"), r.Spec.ClassName, "OracleRestDataService"); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	allErrs = append(allErrs, validateReadWriteManyStorageClass(field.NewPath("spec").Child("persistence"),
+		r.Spec.Persistence.AccessMode, r.Spec.Persistence.StorageClass)...)
+	// The OCI services front the load balancer
+	if r.Spec.Oci != nil && (r.Spec.Oci.WafPolicyId != "" || r.Spec.Oci.ApiGateway != nil) && !r.Spec.LoadBalancer {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("oci"), "requires loadBalancer: true"))
+	}
+	// The ORDS pods can be scheduled on any node, where a ReadWriteOnce volume attached to another node cannot be mounted
+	if r.Spec.Persistence.Size != "" && r.Spec.Persistence.AccessMode == "ReadWriteOnce" && r.Spec.Replicas > 1 {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("persistence").Child("accessMode"), r.Spec.Persistence.AccessMode,
+				"should be ReadWriteMany for more than 1 replica"))
+	}
+
+	// Hostname published through external-dns must be a valid DNS name
+	if r.Spec.Hostname != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(r.Spec.Hostname) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("hostname"), r.Spec.Hostname, msg))
+		}
+	}
+
+	// Source ranges only apply to LoadBalancer services
+	if len(r.Spec.LoadBalancerSourceRanges) != 0 && !r.Spec.LoadBalancer {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("loadBalancerSourceRanges"), "requires loadBalancer to be true"))
+	}
+	for _, cidr := range r.Spec.LoadBalancerSourceRanges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("loadBalancerSourceRanges"), cidr, "should be a CIDR such as 10.0.0.0/16"))
+		}
+	}
+
+	// Each schema needs its own url mapping in a PDB
+	if r.Spec.UrlMappingTemplate != "" {
+		if !strings.Contains(r.Spec.UrlMappingTemplate, "{schema}") {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("urlMappingTemplate"), r.Spec.UrlMappingTemplate,
+					"should contain the {schema} placeholder"))
+		} else if strings.ContainsAny(strings.NewReplacer("{pdb}", "", "{schema}", "").Replace(r.Spec.UrlMappingTemplate), "{}") {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("urlMappingTemplate"), r.Spec.UrlMappingTemplate,
+					"the only placeholders are {pdb} and {schema}"))
+		}
+	}
+
+	// Dispatchers hand the connections over to shared servers
+	if r.Spec.DatabaseTuning != nil && r.Spec.DatabaseTuning.Dispatchers > 0 && r.Spec.DatabaseTuning.SharedServers == 0 {
+		allErrs = append(allErrs,
+			field.Required(field.NewPath("spec").Child("databaseTuning").Child("sharedServers"), "required with dispatchers"))
+	}
+
+	// The environment of the pods can not override the variables set by the operator
+	for i, env := range r.Spec.Env {
+		switch env.Name {
+		case "ORACLE_HOST", "ORACLE_PORT", "ORACLE_SERVICE", "ORDS_USER", "ORDS_PWD", "ORACLE_PWD",
+			"ORDS_CONTEXT_PATH":
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec").Child("env").Index(i).Child("name"), env.Name+" is set by the operator"))
+		}
+	}
+
+	allErrs = append(allErrs, validateOAuthClients(r.Spec.OAuthClients)...)
+
+	allErrs = append(allErrs, validateWarmUp(r.Spec.WarmUp)...)
+
+	if r.Spec.RestoreMetadataBackup != "" && !metadataBackupPattern.MatchString(r.Spec.RestoreMetadataBackup) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec").Child("restoreMetadataBackup"), r.Spec.RestoreMetadataBackup,
+				"should be the name of a backup taken by the operator, see status.metadataBackup"))
+	}
+
+	allErrs = append(allErrs, validateVirtualHosts(&r.Spec)...)
+
+	// Distributions installed instead of the ones of the image
+	if r.Spec.Apex.Source != nil {
+		allErrs = append(allErrs, validateArtifactSource(field.NewPath("spec").Child("apex").Child("source"), r.Spec.Apex.Source)...)
+	}
+	if r.Spec.Ords != nil && r.Spec.Ords.Source != nil {
+		allErrs = append(allErrs, validateArtifactSource(field.NewPath("spec").Child("ords").Child("source"), r.Spec.Ords.Source)...)
+	}
+
+	// Password complexity, on creation only as ValidateUpdate also runs these validations
+	if r.ResourceVersion == "" {
+		allErrs = append(allErrs, r.validatePasswordSecrets(nil)...)
+	}
 
 	// Validating databaseRef and ORDS kind name not to be same
 	if r.Spec.DatabaseRef == r.Name {
@@ -128,6 +280,86 @@ func (r *OracleRestDataService) ValidateCreate() error {
 		r.Name, allErrs)
 }
 
+// validateOAuthClients checks the OAuth clients, each with a Secret of its own, and called from the allowed origins with
+// the redirect URI. Their origins and patterns are quoted in the PL/SQL creating them
+func validateOAuthClients(oauthClients []OracleRestDataServiceOAuthClient) field.ErrorList {
+	var allErrs field.ErrorList
+	clientNames := make(map[string]bool)
+	for i, oauthClient := range oauthClients {
+		clientPath := field.NewPath("spec").Child("oauthClients").Index(i)
+		if clientNames[oauthClient.Name] {
+			allErrs = append(allErrs, field.Duplicate(clientPath.Child("name"), oauthClient.Name))
+		}
+		clientNames[oauthClient.Name] = true
+		if oauthClient.GrantType != "" && oauthClient.GrantType != "client_credentials" && oauthClient.RedirectUri == "" {
+			allErrs = append(allErrs,
+				field.Required(clientPath.Child("redirectUri"), "required with the "+oauthClient.GrantType+" grant type"))
+		}
+		for j, origin := range oauthClient.AllowedOrigins {
+			if !oauthOriginPattern.MatchString(origin) {
+				allErrs = append(allErrs,
+					field.Invalid(clientPath.Child("allowedOrigins").Index(j), origin, "should be an origin such as https://app.example.com"))
+			}
+		}
+		for j, privilege := range oauthClient.Privileges {
+			for k, pattern := range privilege.Patterns {
+				if !oauthPatternPattern.MatchString(pattern) {
+					allErrs = append(allErrs,
+						field.Invalid(clientPath.Child("privileges").Index(j).Child("patterns").Index(k), pattern,
+							"should be a path such as /employees/*"))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateWarmUp checks the paths of the warm-up requests, sent with curl from the pods
+func validateWarmUp(warmUp *OracleRestDataServiceWarmUp) field.ErrorList {
+	var allErrs field.ErrorList
+	if warmUp == nil {
+		return allErrs
+	}
+	for i, path := range warmUp.Paths {
+		if !warmUpPathPattern.MatchString(path) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("warmUp").Child("paths").Index(i), path,
+					"should be a path such as /ords/hr/employees/"))
+		}
+	}
+	return allErrs
+}
+
+// validateVirtualHosts checks the virtual hosts, each routed by a rule of the Ingress to the pool of a PDB of the CDB
+func validateVirtualHosts(spec *OracleRestDataServiceSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(spec.VirtualHosts) == 0 {
+		return allErrs
+	}
+	if spec.InstallScope == "PDB" {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("virtualHosts"), "ORDS installed in a PDB only serves that PDB"))
+	}
+	if spec.Ingress == nil {
+		allErrs = append(allErrs,
+			field.Required(field.NewPath("spec").Child("ingress"), "required to route the virtual hosts"))
+	}
+	virtualHosts := map[string]bool{}
+	for i, virtualHost := range spec.VirtualHosts {
+		if virtualHosts[virtualHost.Host] {
+			allErrs = append(allErrs,
+				field.Duplicate(field.NewPath("spec").Child("virtualHosts").Index(i).Child("host"), virtualHost.Host))
+		}
+		virtualHosts[virtualHost.Host] = true
+		if spec.Ingress != nil && virtualHost.Host == spec.Ingress.Host {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("virtualHosts").Index(i).Child("host"), virtualHost.Host,
+					"is the host of the path based routes of the Ingress"))
+		}
+	}
+	return allErrs
+}
+
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *OracleRestDataService) ValidateUpdate(oldRuntimeObject runtime.Object) error {
 	oraclerestdataservicelog.Info("validate update", "name", r.Name)
@@ -145,16 +377,72 @@ func (r *OracleRestDataService) ValidateUpdate(oldRuntimeObject runtime.Object)
 	if !ok {
 		return nil
 	}
+	allErrs = append(allErrs, validateNamespaceQuotaUpdate("OracleRestDataService", r.Namespace, r.Name,
+		r.Spec.Persistence.Size, old.Spec.Persistence.Size)...)
+	allErrs = append(allErrs, validateOrdsReplicasUpdate(r.Namespace, r.Spec.Replicas, old.Spec.Replicas)...)
 
 	if old.Status.DatabaseRef != "" && old.Status.DatabaseRef != r.Spec.DatabaseRef {
 		allErrs = append(allErrs,
 			field.Forbidden(field.NewPath("spec").Child("databaseRef"), "cannot be changed"))
 	}
-	if old.Status.Image.PullFrom != "" && old.Status.Image != r.Spec.Image {
+	// ORDS metadata is backed up before an image upgrade, so image changes are only refused before ORDS is installed
+	if old.Status.Image.PullFrom != "" && !old.Status.OrdsInstalled && old.Status.Image != r.Spec.Image {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("image"), "cannot be changed while ORDS is being installed"))
+	}
+	if !reflect.DeepEqual(old.Spec.Persistence.DataSource, r.Spec.Persistence.DataSource) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("persistence").Child("dataSource"), "cannot be changed"))
+	}
+	if old.Status.ConfigDir != "" && r.Spec.ConfigSubPath != "" && r.Spec.ConfigSubPath != old.Status.ConfigDir {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("configSubPath"), "cannot be changed from "+old.Status.ConfigDir))
+	}
+	if old.Status.OrdsInstalled && old.Spec.InstallScope != r.Spec.InstallScope {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("installScope"), "cannot be changed after ORDS is installed"))
+	}
+	// ORDS installed in a PDB keeps its metadata in that PDB, which the service of the pool has to stay connected to
+	if old.Status.OrdsInstalled && r.Spec.InstallScope == "PDB" && !strings.EqualFold(old.Spec.OracleService, r.Spec.OracleService) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("oracleService"), "cannot be changed after ORDS is installed in a PDB"))
+	}
+	// The pool user is created by the installation of ORDS, while the service of the pool is set up by the pods
+	if old.Status.OrdsInstalled && !strings.EqualFold(old.Spec.OrdsUser, r.Spec.OrdsUser) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("ordsUser"), "cannot be changed after ORDS is installed"))
+	}
+	// ORDS configuration files are owned by the user ORDS was installed with
+	if old.Status.OrdsInstalled && !reflect.DeepEqual(old.Spec.PodSecurityContext, r.Spec.PodSecurityContext) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("podSecurityContext"), "cannot be changed after ORDS is installed"))
+	}
+
+	// The deployment on the API Gateway is deleted when apiGateway is removed, and created again when it is added back
+	if old.Status.ApiGatewayDeploymentId != "" && old.Spec.Oci != nil && old.Spec.Oci.ApiGateway != nil &&
+		r.Spec.Oci != nil && r.Spec.Oci.ApiGateway != nil && *old.Spec.Oci.ApiGateway != *r.Spec.Oci.ApiGateway {
 		allErrs = append(allErrs,
-			field.Forbidden(field.NewPath("spec").Child("image"), "cannot be changed"))
+			field.Forbidden(field.NewPath("spec").Child("oci").Child("apiGateway"), "cannot be changed once deployed, remove it first"))
+	}
+	// An OAuth client is created in its schema with its grant type, a renamed client is created again
+	for i, oauthClient := range r.Spec.OAuthClients {
+		for _, oldClient := range old.Spec.OAuthClients {
+			if oldClient.Name == oauthClient.Name && (oldClient.Schema != oauthClient.Schema ||
+				oldClient.PdbName != oauthClient.PdbName || oldClient.GrantType != oauthClient.GrantType) {
+				allErrs = append(allErrs,
+					field.Forbidden(field.NewPath("spec").Child("oauthClients").Index(i),
+						"schema, pdbName and grantType cannot be changed, rename the client to create it again"))
+			}
+		}
+	}
+	if old.Spec.ClassName != r.Spec.ClassName {
+		if err := validateDatabaseClass(field.NewPath("spec").Child("className"), r.Spec.ClassName, "OracleRestDataService"); err != nil {
+			allErrs = append(allErrs, err)
+		}
 	}
 
+	allErrs = append(allErrs, r.validatePasswordSecrets(old)...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -164,6 +452,68 @@ func (r *OracleRestDataService) ValidateUpdate(oldRuntimeObject runtime.Object)
 
 }
 
+// Check the complexity of the ORDS and APEX passwords when ORDS is created, or refers to other secrets. The admin
+// password is the one of the existing database
+func (r *OracleRestDataService) validatePasswordSecrets(old *OracleRestDataService) field.ErrorList {
+	var allErrs field.ErrorList
+
+	ordsUser := "ORDS_PUBLIC_USER"
+	if r.Spec.OrdsUser != "" {
+		ordsUser = r.Spec.OrdsUser
+	}
+	if old == nil || old.Spec.OrdsPassword.SecretName != r.Spec.OrdsPassword.SecretName ||
+		old.Spec.OrdsPassword.SecretKey != r.Spec.OrdsPassword.SecretKey {
+		if err := validatePasswordSecret(field.NewPath("spec").Child("ordsPassword").Child("secretName"), r.Namespace,
+			r.Spec.OrdsPassword.SecretName, r.Spec.OrdsPassword.SecretKey, ordsUser); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	if old == nil || old.Spec.ApexPassword.SecretName != r.Spec.ApexPassword.SecretName ||
+		old.Spec.ApexPassword.SecretKey != r.Spec.ApexPassword.SecretKey {
+		path := field.NewPath("spec").Child("apexPassword").Child("secretName")
+		if err := validatePasswordSecret(path, r.Namespace, r.Spec.ApexPassword.SecretName, r.Spec.ApexPassword.SecretKey,
+			"APEX_PUBLIC_USER", "ADMIN"); err != nil {
+			allErrs = append(allErrs, err)
+		} else if err := validateApexPasswordSecret(path, r.Namespace, r.Spec.ApexPassword.SecretName,
+			r.Spec.ApexPassword.SecretKey); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs
+}
+
+// Check that the zip of a distribution is fetched from exactly one source, at a path within the claim or ConfigMap
+func validateArtifactSource(path *field.Path, source *OracleRestDataServiceArtifactSource) field.ErrorList {
+	var allErrs field.ErrorList
+	sources := 0
+	for _, s := range []string{source.PersistentVolumeClaim, source.ConfigMap, source.URL} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		allErrs = append(allErrs,
+			field.Invalid(path, source, "specify exactly one of persistentVolumeClaim, configMap and url"))
+	}
+	if source.URL != "" && source.Path != "" {
+		allErrs = append(allErrs,
+			field.Forbidden(path.Child("path"), "cannot be used with url"))
+	}
+	if source.URL == "" && source.Path == "" {
+		allErrs = append(allErrs,
+			field.Required(path.Child("path"), "path of the zip in the persistentVolumeClaim or key of the configMap"))
+	}
+	for _, segment := range strings.Split(source.Path, "/") {
+		if segment == ".." {
+			allErrs = append(allErrs,
+				field.Invalid(path.Child("path"), source.Path, "should not contain .."))
+			break
+		}
+	}
+	return allErrs
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *OracleRestDataService) ValidateDelete() error {
 	oraclerestdataservicelog.Info("validate delete", "name", r.Name)
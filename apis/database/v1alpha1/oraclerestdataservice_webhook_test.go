@@ -0,0 +1,144 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	// +kubebuilder:scaffold:imports
+)
+
+var _ = Describe("test the ORDS webhook", func() {
+	var old *OracleRestDataService
+
+	BeforeEach(func() {
+		old = &OracleRestDataService{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: "default", CreationTimestamp: metav1.Now()},
+			Spec: OracleRestDataServiceSpec{
+				DatabaseRef: "sidb",
+				OAuthClients: []OracleRestDataServiceOAuthClient{{
+					Name:           "app",
+					Schema:         "HR",
+					SupportEmail:   "dba@example.com",
+					AllowedOrigins: []string{"https://app.example.com"},
+					Privileges: []OracleRestDataServiceOAuthPrivilege{{
+						Name:     "hr.employees",
+						Patterns: []string{"/employees/*"},
+					}},
+				}},
+			},
+		}
+	})
+
+	It("Should accept an update of valid OAuth clients", func() {
+		ords := old.DeepCopy()
+		ords.Spec.OAuthClients[0].AllowedOrigins = append(ords.Spec.OAuthClients[0].AllowedOrigins, "http://localhost:8080")
+		Expect(ords.ValidateUpdate(old)).To(Succeed())
+	})
+
+	It("Should reject the allowed origins quoting the PL/SQL on update", func() {
+		ords := old.DeepCopy()
+		ords.Spec.OAuthClients[0].AllowedOrigins = []string{"https://app.example.com');--"}
+		err := ords.ValidateUpdate(old)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.oauthClients[0].allowedOrigins[0]"))
+	})
+
+	It("Should reject the patterns quoting the PL/SQL on update", func() {
+		ords := old.DeepCopy()
+		ords.Spec.OAuthClients[0].Privileges[0].Patterns = []string{"/employees/*'"}
+		err := ords.ValidateUpdate(old)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.oauthClients[0].privileges[0].patterns[0]"))
+	})
+
+	It("Should reject the warm-up paths that are not plain paths on update", func() {
+		old.Spec.WarmUp = &OracleRestDataServiceWarmUp{Requests: 10, Paths: []string{"/ords/hr/employees/"}}
+		ords := old.DeepCopy()
+		Expect(ords.ValidateUpdate(old)).To(Succeed())
+		ords.Spec.WarmUp.Paths = []string{"/ords/hr/employees/ -o /tmp/out"}
+		err := ords.ValidateUpdate(old)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.warmUp.paths[0]"))
+	})
+
+	It("Should only restore the backups named by the operator", func() {
+		ords := old.DeepCopy()
+		ords.Spec.RestoreMetadataBackup = "ords_metadata_20230602190000"
+		Expect(ords.ValidateUpdate(old)).To(Succeed())
+		ords.Spec.RestoreMetadataBackup = "ords_metadata_x; rm -rf $ORACLE_BASE/oradata"
+		err := ords.ValidateUpdate(old)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.restoreMetadataBackup"))
+	})
+
+	It("Should check the virtual hosts on update", func() {
+		old.Spec.Ingress = &OracleRestDataServiceIngress{Host: "ords.example.com"}
+		old.Spec.VirtualHosts = []OracleRestDataServiceVirtualHost{{Host: "sales.example.com", PdbName: "SALESPDB"}}
+		ords := old.DeepCopy()
+		Expect(ords.ValidateUpdate(old)).To(Succeed())
+		ords.Spec.VirtualHosts = append(ords.Spec.VirtualHosts,
+			OracleRestDataServiceVirtualHost{Host: "ords.example.com", PdbName: "HRPDB"})
+		err := ords.ValidateUpdate(old)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.virtualHosts[1].host"))
+	})
+
+	It("Should reject a change of the service of ORDS installed in a PDB", func() {
+		old.Spec.InstallScope = "PDB"
+		old.Spec.OracleService = "PDB1"
+		old.Status.OrdsInstalled = true
+		ords := old.DeepCopy()
+		ords.Spec.OracleService = "PDB2"
+		err := ords.ValidateUpdate(old)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.oracleService"))
+
+		old.Spec.InstallScope = "CDB"
+		ords.Spec.InstallScope = "CDB"
+		Expect(ords.ValidateUpdate(old)).To(Succeed())
+	})
+
+	It("Should require the Ingress with virtual hosts", func() {
+		ords := old.DeepCopy()
+		ords.Spec.VirtualHosts = []OracleRestDataServiceVirtualHost{{Host: "sales.example.com", PdbName: "SALESPDB"}}
+		err := ords.ValidateUpdate(old)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.ingress"))
+	})
+})
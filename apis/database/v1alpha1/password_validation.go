@@ -0,0 +1,92 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	"context"
+
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// passwordSecretReader reads the password secrets referenced by the resources, set up with the webhooks.
+// It reads from the API server, so that the webhooks do not cache all the secrets of the cluster
+var passwordSecretReader client.Reader
+
+// getSecretPassword returns the password in a secret, or false if the secret cannot be read, for instance because
+// it is created after the resource. Such passwords are checked by the database when they are set
+func getSecretPassword(namespace string, secretName string, secretKey string) (string, bool) {
+	if passwordSecretReader == nil || secretName == "" {
+		return "", false
+	}
+	secret := &corev1.Secret{}
+	if err := passwordSecretReader.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: secretName}, secret); err != nil {
+		return "", false
+	}
+	password, ok := secret.Data[secretKey]
+	return string(password), ok
+}
+
+// validatePasswordSecret checks the complexity of the password in a secret, so that a weak password is rejected
+// on admission rather than failing deep inside the install scripts with ORA-28003
+func validatePasswordSecret(path *field.Path, namespace string, secretName string, secretKey string,
+	usernames ...string) *field.Error {
+	password, ok := getSecretPassword(namespace, secretName, secretKey)
+	if !ok {
+		return nil
+	}
+	if msg := dbcommons.PasswordComplexityError(password, usernames...); msg != "" {
+		return field.Invalid(path, secretName, "password in key "+secretKey+" of the secret "+msg)
+	}
+	return nil
+}
+
+// validateApexPasswordSecret checks the APEX password in a secret against the rules of the APEX admin password
+func validateApexPasswordSecret(path *field.Path, namespace string, secretName string, secretKey string) *field.Error {
+	password, ok := getSecretPassword(namespace, secretName, secretKey)
+	if ok && !dbcommons.ApexPasswordValidator(password) {
+		return field.Invalid(path, secretName, "password in key "+secretKey+
+			" of the secret must contain upper and lower case letters, a digit and a punctuation character")
+	}
+	return nil
+}
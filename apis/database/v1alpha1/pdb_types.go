@@ -108,6 +108,24 @@ type PDBSpec struct {
 	// The target state of the PDB
 	// +kubebuilder:validation:Enum=OPEN;CLOSE
 	PDBState string `json:"pdbState,omitempty"`
+
+	// Resource limits applied to the open PDB. Changes made in the database are reverted
+	ResourceLimits *PDBResourceLimits `json:"resourceLimits,omitempty"`
+}
+
+// PDBResourceLimits defines the CPU, memory and storage limits of a PDB. Limits not set are not managed
+type PDBResourceLimits struct {
+	// cpu_count of the PDB
+	// +kubebuilder:validation:Minimum=1
+	CPUCount int `json:"cpuCount,omitempty"`
+	// sga_target and pga_aggregate_limit of the PDB, such as 2G
+	// +kubebuilder:validation:Pattern=`^[0-9]+[KkMmGgTt]?$`
+	SgaTarget string `json:"sgaTarget,omitempty"`
+	// +kubebuilder:validation:Pattern=`^[0-9]+[KkMmGgTt]?$`
+	PgaAggregateLimit string `json:"pgaAggregateLimit,omitempty"`
+	// MAXSIZE of the storage of the PDB, such as 50G, or UNLIMITED
+	// +kubebuilder:validation:Pattern=`^([0-9]+[KkMmGgTt]?|UNLIMITED)$`
+	MaxSize string `json:"maxSize,omitempty"`
 }
 
 // PDBAdminName defines the secret containing Sys Admin User mapped to key 'adminName' for PDB
@@ -169,6 +187,12 @@ type PDBStatus struct {
 	Msg string `json:"msg,omitempty"`
 	// Last Completed Action
 	Action string `json:"action,omitempty"`
+
+	// The generation of the spec that has been processed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Resource limits applied to the PDB and found in sync at the last check
+	ResourceLimits *PDBResourceLimits `json:"resourceLimits,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -78,6 +78,20 @@ type ShardingDatabaseSpec struct {
 	NsConfigMap        string        `json:"nsConfigMap,omitempty"`
 	NsSecret           string        `json:"nsSecret,omitempty"`
 	IsDeleteOraPvc     bool          `json:"isDeleteOraPvc,omitempty"`
+
+	// A coordinated RMAN backup of the catalog and all the shards. Setting a new tag starts a new backup
+	Backup *ShardingBackupSpec `json:"backup,omitempty"`
+}
+
+// ShardingBackupSpec defines a backup of the catalog and the shards, consistent to a global restore point
+type ShardingBackupSpec struct {
+	// RMAN tag of the backups, also used as the name of the global restore point
+	// +kubebuilder:validation:Pattern=`^[A-Za-z][A-Za-z0-9_]{0,29}$`
+	Tag string `json:"tag"`
+	// full is an incremental level 0 backup, incremental a level 1 backup
+	// +kubebuilder:validation:Enum=full;incremental
+	// +kubebuilder:default:=full
+	Type string `json:"type,omitempty"`
 }
 
 // To understand Metav1.Condition, please refer the link https://pkg.go.dev/k8s.io/apimachinery/pkg/apis/meta/v1
@@ -95,6 +109,25 @@ type ShardingDatabaseStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	CrdStatus []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// The generation of the spec that has been processed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The last coordinated backup
+	Backup *ShardingBackupStatus `json:"backup,omitempty"`
+}
+
+// ShardingBackupStatus defines the progress of a coordinated backup
+type ShardingBackupStatus struct {
+	Tag string `json:"tag,omitempty"`
+	// The global restore point created through the GSM, to which all the databases can be recovered consistently
+	RestorePoint string `json:"restorePoint,omitempty"`
+	// RUNNING until the backups of all the databases are COMPLETED, or FAILED if any of them failed
+	State          string `json:"state,omitempty"`
+	StartTime      string `json:"startTime,omitempty"`
+	CompletionTime string `json:"completionTime,omitempty"`
+	// The state of the backup of each catalog and shard
+	Databases map[string]string `json:"databases,omitempty"`
 }
 
 type GsmStatus struct {
@@ -148,6 +181,8 @@ type ShardSpec struct {
 	Label            string                       `json:"label,omitempty"`
 	IsDelete         bool                         `json:"isDelete,omitempty"`
 	NodeSelector     map[string]string            `json:"nodeSelector,omitempty"`
+	Affinity         *corev1.Affinity             `json:"affinity,omitempty"`     // Optional scheduling constraints, e.g. to pin the pod to a fault domain
+	StorageClass     string                       `json:"storageClass,omitempty"` // Optional storage class overriding spec.storageClass
 	PvAnnotations    map[string]string            `json:"pvAnnotations,omitempty"`
 	PvMatchLabels    map[string]string            `json:"pvMatchLabels,omitempty"`
 	ImagePulllPolicy *corev1.PullPolicy           `json:"imagePullPolicy,omitempty"`
@@ -164,6 +199,8 @@ type CatalogSpec struct {
 	Label            string                       `json:"label,omitempty"`
 	IsDelete         bool                         `json:"isDelete,omitempty"`
 	NodeSelector     map[string]string            `json:"nodeSelector,omitempty"`
+	Affinity         *corev1.Affinity             `json:"affinity,omitempty"`     // Optional scheduling constraints, e.g. to pin the pod to a fault domain
+	StorageClass     string                       `json:"storageClass,omitempty"` // Optional storage class overriding spec.storageClass
 	PvAnnotations    map[string]string            `json:"pvAnnotations,omitempty"`
 	PvMatchLabels    map[string]string            `json:"pvMatchLabels,omitempty"`
 	ImagePulllPolicy *corev1.PullPolicy           `json:"imagePullPolicy,omitempty"`
@@ -182,6 +219,8 @@ type GsmSpec struct {
 	Label            string                       `json:"label,omitempty"` // Optional GSM Label
 	IsDelete         bool                         `json:"isDelete,omitempty"`
 	NodeSelector     map[string]string            `json:"nodeSelector,omitempty"`
+	Affinity         *corev1.Affinity             `json:"affinity,omitempty"`     // Optional scheduling constraints, e.g. to pin the pod to a fault domain
+	StorageClass     string                       `json:"storageClass,omitempty"` // Optional storage class overriding spec.storageClass
 	PvMatchLabels    map[string]string            `json:"pvMatchLabels,omitempty"`
 	ImagePulllPolicy *corev1.PullPolicy           `json:"imagePullPolicy,omitempty"`
 }
@@ -250,6 +289,14 @@ const (
 	ShardRemoveError      ShardLifecycleState = "SHARD_DELETE_ERROR_FROM_GSM"
 )
 
+type ShardingBackupState string
+
+const (
+	BackupRunningState   ShardingBackupState = "RUNNING"
+	BackupCompletedState ShardingBackupState = "COMPLETED"
+	BackupFailedState    ShardingBackupState = "FAILED"
+)
+
 type CrdReconcileState string
 
 const (
@@ -0,0 +1,121 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Kind of the consumers serving a database with ORDS
+const OracleRestDataServiceKind string = "OracleRestDataService"
+
+// Matches returns true if the consumer references the object, whose UID is only compared when the reference has one
+func (ref SingleInstanceDatabaseConsumerRef) Matches(kind string, obj metav1.Object) bool {
+	return ref.Kind == kind && ref.Name == obj.GetName() && ref.Namespace == obj.GetNamespace() &&
+		(ref.UID == "" || ref.UID == obj.GetUID())
+}
+
+// GetConsumers returns the consumers of a kind. The OracleRestDataService registered before the consumers are read
+// from ordsReferences and ordsReference, without their UID
+func (r *SingleInstanceDatabase) GetConsumers(kind string) []SingleInstanceDatabaseConsumerRef {
+	var consumers []SingleInstanceDatabaseConsumerRef
+	for _, consumer := range r.Status.Consumers {
+		if consumer.Kind == kind {
+			consumers = append(consumers, consumer)
+		}
+	}
+	if len(consumers) != 0 || kind != OracleRestDataServiceKind {
+		return consumers
+	}
+	names := r.Status.OrdsReferences
+	if len(names) == 0 && r.Status.OrdsReference != "" {
+		names = []string{r.Status.OrdsReference}
+	}
+	for _, name := range names {
+		consumers = append(consumers, SingleInstanceDatabaseConsumerRef{
+			Kind:      kind,
+			Name:      name,
+			Namespace: r.Namespace,
+			Owner:     name == r.Status.OrdsReference,
+		})
+	}
+	return consumers
+}
+
+// GetConsumerNames returns the names of the consumers of a kind
+func (r *SingleInstanceDatabase) GetConsumerNames(kind string) []string {
+	var names []string
+	for _, consumer := range r.GetConsumers(kind) {
+		names = append(names, consumer.Name)
+	}
+	return names
+}
+
+// GetOwnerConsumer returns the consumer owning what is shared by the consumers of a kind, nil if none
+func (r *SingleInstanceDatabase) GetOwnerConsumer(kind string) *SingleInstanceDatabaseConsumerRef {
+	for _, consumer := range r.GetConsumers(kind) {
+		if consumer.Owner {
+			return &consumer
+		}
+	}
+	return nil
+}
+
+// SetConsumers replaces the consumers of a kind. For OracleRestDataService, ordsReferences and ordsReference are set
+// from the consumers for the clients still reading them
+func (r *SingleInstanceDatabase) SetConsumers(kind string, consumers []SingleInstanceDatabaseConsumerRef) {
+	var others []SingleInstanceDatabaseConsumerRef
+	for _, consumer := range r.Status.Consumers {
+		if consumer.Kind != kind {
+			others = append(others, consumer)
+		}
+	}
+	r.Status.Consumers = append(others, consumers...)
+	if kind != OracleRestDataServiceKind {
+		return
+	}
+	r.Status.OrdsReferences = nil
+	r.Status.OrdsReference = ""
+	for _, consumer := range consumers {
+		r.Status.OrdsReferences = append(r.Status.OrdsReferences, consumer.Name)
+		if consumer.Owner {
+			r.Status.OrdsReference = consumer.Name
+		}
+	}
+}
@@ -0,0 +1,85 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	// +kubebuilder:scaffold:imports
+)
+
+var _ = Describe("test the consumers of a database", func() {
+	var sidb *SingleInstanceDatabase
+
+	BeforeEach(func() {
+		sidb = &SingleInstanceDatabase{ObjectMeta: metav1.ObjectMeta{Name: "sidb", Namespace: "default"}}
+	})
+
+	It("Should read the ORDS registered by name", func() {
+		sidb.Status.OrdsReference = "ords-a"
+		sidb.Status.OrdsReferences = []string{"ords-a", "ords-b"}
+		consumers := sidb.GetConsumers(OracleRestDataServiceKind)
+		Expect(consumers).To(Equal([]SingleInstanceDatabaseConsumerRef{
+			{Kind: OracleRestDataServiceKind, Name: "ords-a", Namespace: "default", Owner: true},
+			{Kind: OracleRestDataServiceKind, Name: "ords-b", Namespace: "default"},
+		}))
+		Expect(sidb.GetOwnerConsumer(OracleRestDataServiceKind).Name).To(Equal("ords-a"))
+	})
+
+	It("Should keep the names of the ORDS in sync with the consumers", func() {
+		sidb.SetConsumers(OracleRestDataServiceKind, []SingleInstanceDatabaseConsumerRef{
+			{Kind: OracleRestDataServiceKind, Name: "ords-b", Namespace: "default", UID: "uid-b"},
+			{Kind: OracleRestDataServiceKind, Name: "ords-a", Namespace: "default", UID: "uid-a", Owner: true},
+		})
+		Expect(sidb.Status.OrdsReferences).To(Equal([]string{"ords-b", "ords-a"}))
+		Expect(sidb.Status.OrdsReference).To(Equal("ords-a"))
+
+		sidb.SetConsumers(OracleRestDataServiceKind, nil)
+		Expect(sidb.Status.Consumers).To(BeNil())
+		Expect(sidb.Status.OrdsReferences).To(BeNil())
+		Expect(sidb.Status.OrdsReference).To(Equal(""))
+	})
+
+	It("Should not match an object recreated with the same name", func() {
+		consumer := SingleInstanceDatabaseConsumerRef{Kind: OracleRestDataServiceKind, Name: "ords-a", Namespace: "default", UID: "uid-a"}
+		Expect(consumer.Matches(OracleRestDataServiceKind, &metav1.ObjectMeta{Name: "ords-a", Namespace: "default", UID: "uid-a"})).To(BeTrue())
+		Expect(consumer.Matches(OracleRestDataServiceKind, &metav1.ObjectMeta{Name: "ords-a", Namespace: "default", UID: "uid-c"})).To(BeFalse())
+		consumer.UID = ""
+		Expect(consumer.Matches(OracleRestDataServiceKind, &metav1.ObjectMeta{Name: "ords-a", Namespace: "default", UID: "uid-c"})).To(BeTrue())
+	})
+})
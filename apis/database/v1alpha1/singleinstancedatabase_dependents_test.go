@@ -0,0 +1,94 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	// +kubebuilder:scaffold:imports
+)
+
+var _ = Describe("test the dependents of a database", func() {
+	var sidb *SingleInstanceDatabase
+
+	BeforeEach(func() {
+		dependentsScheme := runtime.NewScheme()
+		Expect(AddToScheme(dependentsScheme)).To(Succeed())
+		sidb = &SingleInstanceDatabase{ObjectMeta: metav1.ObjectMeta{Name: "sidb", Namespace: "default"}}
+		webhookReader = fake.NewClientBuilder().WithScheme(dependentsScheme).WithObjects(
+			sidb,
+			&OracleRestDataService{
+				ObjectMeta: metav1.ObjectMeta{Name: "ords-a", Namespace: "default"},
+				Spec:       OracleRestDataServiceSpec{DatabaseRef: "sidb"},
+			},
+			&OracleRestDataService{
+				ObjectMeta: metav1.ObjectMeta{Name: "ords-other", Namespace: "default"},
+				Spec:       OracleRestDataServiceSpec{DatabaseRef: "sidb-other"},
+			},
+			&SingleInstanceDatabase{
+				ObjectMeta: metav1.ObjectMeta{Name: "sidb-standby", Namespace: "default"},
+				Spec:       SingleInstanceDatabaseSpec{CreateAsStandby: true, PrimaryDatabaseRef: "sidb"},
+			},
+			&DataguardBroker{
+				ObjectMeta: metav1.ObjectMeta{Name: "broker", Namespace: "default"},
+				Spec:       DataguardBrokerSpec{PrimaryDatabaseRef: "sidb-other", StandbyDatabaseRefs: []string{"sidb"}},
+			},
+		).Build()
+	})
+
+	AfterEach(func() {
+		webhookReader = nil
+	})
+
+	It("Should list the resources referencing the database", func() {
+		dependents, err := sidb.getDependents()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dependents).To(Equal([]string{"OracleRestDataService/ords-a", "SingleInstanceDatabase/sidb-standby",
+			"DataguardBroker/broker"}))
+	})
+
+	It("Should reject the deletion of a database in use", func() {
+		err := sidb.ValidateDelete()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("delete the dependent resources first: OracleRestDataService/ords-a"))
+
+		unused := &SingleInstanceDatabase{ObjectMeta: metav1.ObjectMeta{Name: "sidb-unused", Namespace: "default"}}
+		Expect(unused.ValidateDelete()).To(Succeed())
+	})
+})
@@ -39,7 +39,9 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -71,6 +73,16 @@ type SingleInstanceDatabaseSpec struct {
 	TcpsCertRenewInterval string            `json:"tcpsCertRenewInterval,omitempty"`
 	DgBrokerConfigured    bool              `json:"dgBrokerConfigured,omitempty"`
 
+	// DNS name published for the database service and used in connect strings instead of node or load balancer IPs
+	Hostname string `json:"hostname,omitempty"`
+
+	// Options of the external Service
+	// +kubebuilder:validation:Enum=None;ClientIP
+	SessionAffinity string `json:"sessionAffinity,omitempty"`
+	// +kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy    string   `json:"externalTrafficPolicy,omitempty"`
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
 	CloneFrom            string `json:"cloneFrom,omitempty"`
 	PrimaryDatabaseRef   string `json:"primaryDatabaseRef,omitempty"`
 	CreateAsStandby      bool   `json:"createAsStandby,omitempty"`
@@ -80,11 +92,254 @@ type SingleInstanceDatabaseSpec struct {
 	// +k8s:openapi-gen=true
 	Replicas int `json:"replicas,omitempty"`
 
+	// Shutdown of the database run by the preStop hook of its pods
+	Shutdown SingleInstanceDatabaseShutdown `json:"shutdown,omitempty"`
+
+	// Start and stop the database, and its ORDS, on a schedule
+	Schedule *SingleInstanceDatabaseSchedule `json:"schedule,omitempty"`
+
+	// DBMS_CLOUD credential for the access of the PDB to OCI Object Storage
+	ObjectStorage *SingleInstanceDatabaseObjectStorage `json:"objectStorage,omitempty"`
+
+	// Streaming of the alert and listener logs, and events for the ORA- errors of the alert log
+	AlertLog *SingleInstanceDatabaseAlertLog `json:"alertLog,omitempty"`
+
+	// AWR or Statspack report generated for a time range into a ConfigMap
+	PerformanceReport *SingleInstanceDatabasePerformanceReport `json:"performanceReport,omitempty"`
+
+	// Report the sessions blocking other sessions, and optionally kill them
+	SessionManagement *SingleInstanceDatabaseSessionManagement `json:"sessionManagement,omitempty"`
+
+	// Data Guard lag, redo generation rate and apply rate published as metrics and in the status
+	ReplicationMetrics *SingleInstanceDatabaseReplicationMetrics `json:"replicationMetrics,omitempty"`
+
+	// CDB resource plan sharing the CPU between the PDBs
+	ResourceManagerPlan *SingleInstanceDatabaseResourceManagerPlan `json:"resourceManagerPlan,omitempty"`
+
 	NodeSelector  map[string]string                   `json:"nodeSelector,omitempty"`
 	AdminPassword SingleInstanceDatabaseAdminPassword `json:"adminPassword,omitempty"`
 	Image         SingleInstanceDatabaseImage         `json:"image"`
 	Persistence   SingleInstanceDatabasePersistence   `json:"persistence,omitempty"`
 	InitParams    SingleInstanceDatabaseInitParams    `json:"initParams,omitempty"`
+	TrueCache     *SingleInstanceDatabaseTrueCache    `json:"trueCache,omitempty"`
+
+	// Oracle Connection Manager instances proxying the connections to the database, to protect it from connection storms
+	ConnectionManager *SingleInstanceDatabaseConnectionManager `json:"connectionManager,omitempty"`
+
+	// Native network encryption and integrity of the connections to the database, enforced without TCPS certificates.
+	// The ORDS of the database connect with the same settings
+	NetworkEncryption *SingleInstanceDatabaseNetworkEncryption `json:"networkEncryption,omitempty"`
+
+	// Keep the manifests of the database and of its ORDS, with their status, in the <name>-state ConfigMap, to recreate
+	// them on a disaster recovery cluster where the storage is replicated
+	StateExport bool `json:"stateExport,omitempty"`
+
+	// Jobs launched on the lifecycle transitions of the database, to integrate steps such as DNS updates or CMDB calls
+	Hooks *SingleInstanceDatabaseHooks `json:"hooks,omitempty"`
+
+	// Annotate the pods running datapatch as not safe to evict for the cluster autoscaler, until datapatch completes.
+	// The operator itself never deletes or restarts them meanwhile
+	BlockEvictionDuringOperations bool `json:"blockEvictionDuringOperations,omitempty"`
+
+	// OracleDatabaseClass giving the fields of the spec that are not set, such as the image, the persistence and the
+	// init parameters. They follow the changes of the class
+	ClassName string `json:"className,omitempty"`
+
+	// Resources of the database container, applied to the pods created after a change
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// SingleInstanceDatabaseHooks defines the Jobs launched on the lifecycle transitions of the database
+type SingleInstanceDatabaseHooks struct {
+	// Once the database is ready for the first time
+	PostProvision *SingleInstanceDatabaseHook `json:"postProvision,omitempty"`
+	// When the database is deleted, before its pods. The deletion waits for the Job to complete or fail
+	PreDelete *SingleInstanceDatabaseHook `json:"preDelete,omitempty"`
+	// After an ORDS of the database backs up its metadata, or exports APEX, before an upgrade
+	PostBackup *SingleInstanceDatabaseHook `json:"postBackup,omitempty"`
+	// Once the database becomes the primary from a standby, through a switchover or a failover
+	PostFailover *SingleInstanceDatabaseHook `json:"postFailover,omitempty"`
+}
+
+// SingleInstanceDatabaseHook refers to the manifest of the Job launched by a hook. The operator names the Job, and sets
+// DATABASE_NAME, DATABASE_NAMESPACE, DATABASE_HOOK, DATABASE_ROLE and DATABASE_CONNECT_STRING in its containers
+type SingleInstanceDatabaseHook struct {
+	// ConfigMap holding the manifest of the Job
+	ConfigMap string `json:"configMap"`
+	// Key of the manifest in the ConfigMap
+	// +kubebuilder:default:="job.yaml"
+	Key string `json:"key,omitempty"`
+}
+
+// SingleInstanceDatabaseShutdown defines how the database is shut down when its pod stops
+type SingleInstanceDatabaseShutdown struct {
+	// Shutdown mode. If it does not complete within the grace period, the database is aborted
+	// +kubebuilder:validation:Enum=immediate;transactional;abort
+	// +kubebuilder:default:="immediate"
+	Mode string `json:"mode,omitempty"`
+	// Seconds given to the pod to shut down the database before it is killed. Defaults to 300
+	// +kubebuilder:validation:Minimum=30
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// Seconds the restart-database action waits for the user sessions to end before it shuts down the database.
+	// Defaults to 300
+	// +kubebuilder:validation:Minimum=0
+	DrainTimeoutSeconds *int64 `json:"drainTimeoutSeconds,omitempty"`
+}
+
+// SingleInstanceDatabaseRestart defines the progress of a restart of the database requested by the restart-database action
+type SingleInstanceDatabaseRestart struct {
+	// Draining while the user sessions end, then Restarting once the database is shut down and its pod deleted
+	Phase string `json:"phase,omitempty"`
+	// Pod of the database restarted, and the start of the restart in RFC 3339 format
+	Pod       string `json:"pod,omitempty"`
+	StartTime string `json:"startTime,omitempty"`
+	// Open mode of the database before the restart, checked once the database is restarted
+	OpenMode string `json:"openMode,omitempty"`
+}
+
+// SingleInstanceDatabaseSchedule defines when the database runs, for instance during office hours
+type SingleInstanceDatabaseSchedule struct {
+	// Cron expression (minute hour day-of-month month day-of-week) of the starts of the database
+	Start string `json:"start"`
+	// Cron expression of the stops of the database
+	Stop string `json:"stop"`
+	// Time zone of the cron expressions, such as Europe/Paris. Defaults to UTC
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// SingleInstanceDatabaseNetworkEncryption defines the native network encryption set in the sqlnet.ora of the database
+type SingleInstanceDatabaseNetworkEncryption struct {
+	// REQUIRED rejects the connections of the clients that do not encrypt, REQUESTED encrypts the connections of the
+	// clients that accept it
+	// +kubebuilder:validation:Enum=REQUIRED;REQUESTED
+	// +kubebuilder:default:="REQUIRED"
+	Level string `json:"level,omitempty"`
+	// Encryption algorithms by order of preference. Defaults to AES256
+	// +kubebuilder:validation:items:Enum=AES256;AES192;AES128
+	Algorithms []string `json:"algorithms,omitempty"`
+	// Integrity algorithms by order of preference. Defaults to SHA256
+	// +kubebuilder:validation:items:Enum=SHA512;SHA384;SHA256;SHA1
+	ChecksumAlgorithms []string `json:"checksumAlgorithms,omitempty"`
+}
+
+// SingleInstanceDatabaseObjectStorage defines the access of the PDB to OCI Object Storage through DBMS_CLOUD
+type SingleInstanceDatabaseObjectStorage struct {
+	// Secret with the user, tenancy, fingerprint and privatekey of an OCI API signing key
+	OciSecretName string `json:"ociSecretName"`
+	// Name of the DBMS_CLOUD credential created in the PDB
+	// +kubebuilder:validation:Pattern=`^[A-Za-z][A-Za-z0-9_]*$`
+	// +kubebuilder:default:="OCI_CRED"
+	CredentialName string `json:"credentialName,omitempty"`
+}
+
+// SingleInstanceDatabaseAlertLog defines how the database errors are surfaced in Kubernetes
+type SingleInstanceDatabaseAlertLog struct {
+	// Stream the alert and listener logs as JSON lines to the stdout of an alert-log sidecar container
+	Stream bool `json:"stream,omitempty"`
+	// Raise a Warning event on the database for each new ORA- error of the alert log
+	Events bool `json:"events,omitempty"`
+	// Seconds between two scans of the alert log for events
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:default:=60
+	PollInterval int `json:"pollInterval,omitempty"`
+}
+
+// SingleInstanceDatabasePerformanceReport defines the time range of a performance report of the database
+type SingleInstanceDatabasePerformanceReport struct {
+	// AWR requires the Diagnostics Pack. Statspack requires the PERFSTAT schema
+	// +kubebuilder:validation:Enum=awr;statspack
+	// +kubebuilder:default:="awr"
+	Type string `json:"type,omitempty"`
+	// Start and end of the report in RFC 3339 format. The report spans from the last snapshot taken
+	// before begin to the first snapshot taken after end
+	Begin string `json:"begin"`
+	End   string `json:"end"`
+}
+
+// SingleInstanceDatabaseSessionManagement defines how the sessions blocking other sessions are handled
+type SingleInstanceDatabaseSessionManagement struct {
+	// Kill the user sessions that have blocked other sessions for this many seconds. Blockers are only reported if not set
+	// +kubebuilder:validation:Minimum=10
+	KillBlockersAfterSeconds int `json:"killBlockersAfterSeconds,omitempty"`
+	// Users whose sessions are never killed, in addition to SYS and SYSTEM
+	ProtectedUsers []string `json:"protectedUsers,omitempty"`
+	// Seconds between two scans of the blocking sessions
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:default:=60
+	PollInterval int `json:"pollInterval,omitempty"`
+}
+
+// SingleInstanceDatabaseReplicationMetrics defines how often the replication of the database is measured
+type SingleInstanceDatabaseReplicationMetrics struct {
+	// Seconds between two measures of the lag and of the rates
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:default:=60
+	PollInterval int `json:"pollInterval,omitempty"`
+}
+
+// SingleInstanceDatabaseResourceManagerPlan defines a CDB resource plan created through DBMS_RESOURCE_MANAGER
+type SingleInstanceDatabaseResourceManagerPlan struct {
+	// Name of the plan, set as the resource_manager_plan of the database
+	// +kubebuilder:validation:Pattern=`^[A-Za-z][A-Za-z0-9_]*$`
+	// +kubebuilder:validation:MaxLength=30
+	Name string `json:"name"`
+	// Keep the plan active during the maintenance windows of the scheduler
+	Force bool `json:"force,omitempty"`
+	// +kubebuilder:validation:MinItems=1
+	Directives []SingleInstanceDatabaseResourceManagerDirective `json:"directives"`
+}
+
+// SingleInstanceDatabaseResourceManagerDirective defines the CPU allocated to a PDB by a CDB resource plan
+type SingleInstanceDatabaseResourceManagerDirective struct {
+	// +kubebuilder:validation:Pattern=`^[A-Za-z][A-Za-z0-9_]*$`
+	PdbName string `json:"pdbName"`
+	// Share of the CPU of the PDB relative to the other PDBs when the CPU is contended
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	Shares int `json:"shares,omitempty"`
+	// Maximum percentage of the CPU used by the PDB
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default:=100
+	UtilizationLimit int `json:"utilizationLimit,omitempty"`
+}
+
+// SingleInstanceDatabaseTrueCache defines the True Cache instances deployed in front of the primary database
+type SingleInstanceDatabaseTrueCache struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	Replicas     int                                      `json:"replicas,omitempty"`
+	Services     []SingleInstanceDatabaseTrueCacheService `json:"services,omitempty"`
+	NodeSelector map[string]string                        `json:"nodeSelector,omitempty"`
+}
+
+// SingleInstanceDatabaseTrueCacheService maps a primary PDB service to the True Cache service offloading its reads
+type SingleInstanceDatabaseTrueCacheService struct {
+	PrimaryService string `json:"primaryService"`
+	CacheService   string `json:"cacheService"`
+}
+
+// SingleInstanceDatabaseConnectionManager defines the Oracle Connection Manager (CMAN) instances in front of the database
+type SingleInstanceDatabaseConnectionManager struct {
+	// Oracle Connection Manager image, such as container-registry.oracle.com/database/cman
+	Image       string `json:"image"`
+	PullSecrets string `json:"pullSecrets,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	Replicas int32 `json:"replicas,omitempty"`
+	// Maximum number of connections proxied by each instance
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=256
+	MaxConnections int `json:"maxConnections,omitempty"`
+	// Maximum number of new connections accepted per second by each instance. Not limited if not set
+	// +kubebuilder:validation:Minimum=1
+	ConnectionRateLimit int `json:"connectionRateLimit,omitempty"`
+	// Port of the instances and of their service
+	// +kubebuilder:default:=1521
+	ListenerPort int32 `json:"listenerPort,omitempty"`
+	// Expose the instances through a LoadBalancer service instead of a ClusterIP service
+	LoadBalancer bool              `json:"loadBalancer,omitempty"`
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
 // SingleInstanceDatabasePersistence defines the storage size and class for PVC
@@ -95,6 +350,10 @@ type SingleInstanceDatabasePersistence struct {
 	AccessMode            string `json:"accessMode,omitempty"`
 	VolumeName            string `json:"volumeName,omitempty"`
 	VolumeClaimAnnotation string `json:"volumeClaimAnnotation,omitempty"`
+
+	// Volume snapshot or claim the volume is populated from, such as
+	// {apiGroup: snapshot.storage.k8s.io, kind: VolumeSnapshot, name: <snapshot>}
+	DataSource *corev1.TypedLocalObjectReference `json:"dataSource,omitempty"`
 }
 
 // SingleInstanceDatabaseInitParams defines the Init Parameters
@@ -111,6 +370,11 @@ type SingleInstanceDatabaseImage struct {
 	PullFrom    string `json:"pullFrom"`
 	PullSecrets string `json:"pullSecrets,omitempty"`
 	PrebuiltDB  bool   `json:"prebuiltDB,omitempty"`
+	// Architectures of the nodes pullFrom runs on. The pods are scheduled on nodes of these architectures
+	// +kubebuilder:validation:items:Enum=amd64;arm64
+	Architectures []string `json:"architectures,omitempty"`
+	// Images replacing pullFrom on the nodes of an architecture, such as the arm64 build of the release
+	ArchitectureImages map[string]string `json:"architectureImages,omitempty"`
 }
 
 // SingleInsatnceAdminPassword defines the secret containing Admin Password mapped to secretKey for Database
@@ -119,6 +383,64 @@ type SingleInstanceDatabaseAdminPassword struct {
 	// +kubebuilder:default:="oracle_pwd"
 	SecretKey  string `json:"secretKey,omitempty"`
 	KeepSecret *bool  `json:"keepSecret,omitempty"`
+	// Store the admin password in an auto-login wallet in the database pod, through which the operator validates the
+	// admin password and its SQL Jobs connect. The secret is still read to set up ORDS, APEX and standby databases
+	ExternalPasswordStore bool `json:"externalPasswordStore,omitempty"`
+}
+
+// SingleInstanceDatabasePerformanceReportStatus defines the outcome of the last performance report
+type SingleInstanceDatabasePerformanceReportStatus struct {
+	Type          string `json:"type,omitempty"`
+	Begin         string `json:"begin,omitempty"`
+	End           string `json:"end,omitempty"`
+	BeginSnapshot int    `json:"beginSnapshot,omitempty"`
+	EndSnapshot   int    `json:"endSnapshot,omitempty"`
+	// ConfigMap and key holding the report. Reports larger than a ConfigMap are gzipped into its binaryData
+	ConfigMap   string `json:"configMap,omitempty"`
+	Key         string `json:"key,omitempty"`
+	GeneratedAt string `json:"generatedAt,omitempty"`
+	// True if the report still exceeded a ConfigMap once gzipped, and was truncated
+	Truncated bool `json:"truncated,omitempty"`
+	// Error of the report generation, if it failed
+	Error string `json:"error,omitempty"`
+}
+
+// SingleInstanceDatabaseBlockingSession defines a session blocking other sessions
+type SingleInstanceDatabaseBlockingSession struct {
+	// Session as "sid,serial#"
+	Session  string `json:"session"`
+	Username string `json:"username,omitempty"`
+	// Number of sessions waiting on this session, and the longest of their waits
+	BlockedSessions int `json:"blockedSessions,omitempty"`
+	BlockedSeconds  int `json:"blockedSeconds,omitempty"`
+	// True if the session was killed at this scan
+	Killed bool `json:"killed,omitempty"`
+}
+
+// SingleInstanceDatabaseReplication defines the Data Guard lag and the redo rates of the database. The lags and the
+// apply rate are measured on a standby, the redo generation rate on a primary, and unknown values are omitted
+type SingleInstanceDatabaseReplication struct {
+	// Time between the last redo received by the standby and the last redo generated by the primary
+	TransportLagSeconds *int64 `json:"transportLagSeconds,omitempty"`
+	// Time between the last redo applied by the standby and the last redo generated by the primary
+	ApplyLagSeconds *int64 `json:"applyLagSeconds,omitempty"`
+	// Redo applied per second by the managed recovery of the standby
+	ApplyRateBytesPerSecond *int64 `json:"applyRateBytesPerSecond,omitempty"`
+	// Redo generated per second by the primary over the last minute
+	RedoGenerationBytesPerSecond *int64 `json:"redoGenerationBytesPerSecond,omitempty"`
+	// RFC 3339 time of the measure
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// SingleInstanceDatabaseConsumerRef references a resource depending on the database. A resource recreated with the
+// same name has another UID, and is not the consumer referenced
+type SingleInstanceDatabaseConsumerRef struct {
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace,omitempty"`
+	UID       types.UID `json:"uid,omitempty"`
+	// The consumer owning what is shared by the consumers of its kind, such as the ORDS repository
+	Owner bool `json:"owner,omitempty"`
 }
 
 // SingleInstanceDatabaseStatus defines the observed state of SingleInstanceDatabase
@@ -162,6 +484,40 @@ type SingleInstanceDatabaseStatus struct {
 	ClientWalletLoc       string `json:"clientWalletLoc,omitempty"`
 	PrimaryDatabase       string `json:"primaryDatabase,omitempty"`
 	DgBrokerConfigured    bool   `json:"dgBrokerConfigured,omitempty"`
+	// ResourceVersion of the admin password secret stored in the external password store
+	PasswordStoreVersion string `json:"passwordStoreVersion,omitempty"`
+	// DBMS_CLOUD credential created in the PDB and the ResourceVersion of the OCI secret it was created from
+	ObjectStorageCredential    string `json:"objectStorageCredential,omitempty"`
+	ObjectStorageSecretVersion string `json:"objectStorageSecretVersion,omitempty"`
+	// Running or Stopped according to .spec.schedule, and the time of the next scheduled start or stop
+	ScheduledState          string `json:"scheduledState,omitempty"`
+	NextScheduledTransition string `json:"nextScheduledTransition,omitempty"`
+	// Restart of the database in progress
+	Restart *SingleInstanceDatabaseRestart `json:"restart,omitempty"`
+	// All the OracleRestDataService resources serving this database. ordsReference is the one owning the ORDS repository.
+	// Deprecated: read consumers instead, ordsReference and ordsReferences are kept in sync with the consumers of kind
+	// OracleRestDataService
+	OrdsReferences []string `json:"ordsReferences,omitempty"`
+	// Resources depending on this database, such as the OracleRestDataService serving it
+	Consumers []SingleInstanceDatabaseConsumerRef `json:"consumers,omitempty"`
+	// Database settings applied for the databaseTuning of the ORDS of ordsReferences
+	OrdsTuning OracleRestDataServiceDatabaseTuning `json:"ordsTuning,omitempty"`
+
+	// Number of lines of the alert log already scanned for errors
+	AlertLogOffset int `json:"alertLogOffset,omitempty"`
+
+	// Last performance report generated, and the ConfigMap holding it
+	PerformanceReport *SingleInstanceDatabasePerformanceReportStatus `json:"performanceReport,omitempty"`
+
+	// Sessions blocking other sessions at the last scan of .spec.sessionManagement
+	BlockingSessions []SingleInstanceDatabaseBlockingSession `json:"blockingSessions,omitempty"`
+
+	// Replication measured at the last poll of .spec.replicationMetrics
+	Replication *SingleInstanceDatabaseReplication `json:"replication,omitempty"`
+
+	// Resource plan created from .spec.resourceManagerPlan, and the top plan active in the database
+	ResourceManagerPlan       *SingleInstanceDatabaseResourceManagerPlan `json:"resourceManagerPlan,omitempty"`
+	ActiveResourceManagerPlan string                                     `json:"activeResourceManagerPlan,omitempty"`
 
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -171,6 +527,40 @@ type SingleInstanceDatabaseStatus struct {
 
 	InitParams  SingleInstanceDatabaseInitParams  `json:"initParams,omitempty"`
 	Persistence SingleInstanceDatabasePersistence `json:"persistence"`
+
+	TrueCache *SingleInstanceDatabaseTrueCacheStatus `json:"trueCache,omitempty"`
+
+	ConnectionManager *SingleInstanceDatabaseConnectionManagerStatus `json:"connectionManager,omitempty"`
+
+	// Native network encryption level and algorithms set in the sqlnet.ora of the database
+	NetworkEncryption string `json:"networkEncryption,omitempty"`
+
+	// Image the pods ran before the last image change, the database can be rolled back to
+	PreviousImage string `json:"previousImage,omitempty"`
+
+	// Latest Job launched by each hook, and the role of the database when the hooks were last checked
+	HookJobs map[string]string `json:"hookJobs,omitempty"`
+	HookRole string            `json:"hookRole,omitempty"`
+
+	// The generation of the spec that has been processed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// SingleInstanceDatabaseTrueCacheStatus defines the observed state of the True Cache instances
+type SingleInstanceDatabaseTrueCacheStatus struct {
+	Status         string   `json:"status,omitempty"`
+	Replicas       int      `json:"replicas,omitempty"`
+	ReadyReplicas  int      `json:"readyReplicas,omitempty"`
+	ConnectStrings []string `json:"connectStrings,omitempty"`
+}
+
+// SingleInstanceDatabaseConnectionManagerStatus defines the observed state of the Oracle Connection Manager instances
+type SingleInstanceDatabaseConnectionManagerStatus struct {
+	Status        string `json:"status,omitempty"`
+	Replicas      int32  `json:"replicas,omitempty"`
+	ReadyReplicas int32  `json:"readyReplicas,omitempty"`
+	// Connect descriptor routing the connections to the PDB through the instances
+	ConnectString string `json:"connectString,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -39,25 +39,39 @@
 package v1alpha1
 
 import (
+	"context"
+	"encoding/json"
+	"net"
+	"reflect"
+	"regexp"
 	"strings"
 	"time"	
 	"strconv"
 
 	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// Names of the DBMS_CLOUD credentials, quoted in the PL/SQL creating them
+var credentialNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,127}$`)
+
 // log is for logging in this package.
 var singleinstancedatabaselog = logf.Log.WithName("singleinstancedatabase-resource")
 
 func (r *SingleInstanceDatabase) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	passwordSecretReader = mgr.GetAPIReader()
+	webhookReader = mgr.GetAPIReader()
+	databaseClassReader = mgr.GetAPIReader()
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -73,6 +87,9 @@ var _ webhook.Defaulter = &SingleInstanceDatabase{}
 func (r *SingleInstanceDatabase) Default() {
 	singleinstancedatabaselog.Info("default", "name", r.Name)
 
+	// The class gives its values to the fields left empty, before the defaults of the operator
+	r.applyDatabaseClass()
+
 	if r.Spec.LoadBalancer {
 		// Annotations required for a flexible load balancer on oci 
 		if r.Spec.ServiceAnnotations == nil {
@@ -97,6 +114,14 @@ func (r *SingleInstanceDatabase) Default() {
 		r.Spec.AdminPassword.KeepSecret = &keepSecret
 	}
 
+	// In test mode, default to the lightweight Free edition image, or to its mirror
+	if dbcommons.IsTestMode() && r.Spec.Image.PullFrom == "" && r.Spec.CloneFrom == "" && r.Spec.PrimaryDatabaseRef == "" {
+		r.Spec.Image.PullFrom, _ = dbcommons.ResolveExternalLocation(dbcommons.TestModeFreeImage)
+		if r.Spec.Edition == "" {
+			r.Spec.Edition = "free"
+		}
+	}
+
 	if r.Spec.Edition == "" {
 		if r.Spec.CloneFrom == "" && !r.Spec.Image.PrebuiltDB {
 			r.Spec.Edition = "enterprise"
@@ -105,21 +130,21 @@ func (r *SingleInstanceDatabase) Default() {
 
 	if r.Spec.Sid == "" {
 		if r.Spec.Edition == "express" {
-			r.Spec.Sid = "XE"
+			r.Spec.Sid = dbcommons.ExpressEditionSID
 		} else if r.Spec.Edition == "free" {
-			r.Spec.Sid = "FREE"
+			r.Spec.Sid = dbcommons.FreeEditionSID
 		} else {
-			r.Spec.Sid = "ORCLCDB"
+			r.Spec.Sid = dbcommons.DefaultSID
 		}
 	}
 
 	if r.Spec.Pdbname == "" {
 		if r.Spec.Edition == "express" {
-			r.Spec.Pdbname = "XEPDB1"
+			r.Spec.Pdbname = dbcommons.ExpressEditionPDB
 		} else if r.Spec.Edition == "free" {
-			r.Spec.Pdbname = "FREEPDB1"
+			r.Spec.Pdbname = dbcommons.FreeEditionPDB
 		} else {
-			r.Spec.Pdbname = "ORCLPDB1"
+			r.Spec.Pdbname = dbcommons.DefaultPDB
 		}
 	}
 
@@ -137,6 +162,43 @@ func (r *SingleInstanceDatabase) Default() {
 	}
 }
 
+// Merge the database defaults of the class of the database into its spec
+func (r *SingleInstanceDatabase) applyDatabaseClass() {
+	if r.Spec.ClassName == "" {
+		removeClassDefaults(r)
+		return
+	}
+	if databaseClassReader == nil {
+		return
+	}
+	class, err := getDatabaseClass(r.Spec.ClassName)
+	if err != nil || class.Spec.Database == nil {
+		// Refused by the validating webhook
+		return
+	}
+	defaults := class.Spec.Database.DeepCopy()
+	if !r.CreationTimestamp.IsZero() {
+		// The edition and the volume of an existing database cannot be changed
+		defaults.Edition = r.Spec.Edition
+		if defaults.Persistence != nil {
+			defaults.Persistence.Size = r.Spec.Persistence.Size
+			defaults.Persistence.StorageClass = r.Spec.Persistence.StorageClass
+			defaults.Persistence.AccessMode = r.Spec.Persistence.AccessMode
+		}
+	}
+	data, err := applyClassDefaults(r, r.Spec, defaults, class.Generation)
+	if err != nil {
+		singleinstancedatabaselog.Error(err, "failed to apply the class", "name", r.Name, "class", r.Spec.ClassName)
+		return
+	}
+	spec := SingleInstanceDatabaseSpec{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		singleinstancedatabaselog.Error(err, "failed to apply the class", "name", r.Name, "class", r.Spec.ClassName)
+		return
+	}
+	r.Spec = spec
+}
+
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 //+kubebuilder:webhook:verbs=create;update;delete,path=/validate-database-oracle-com-v1alpha1-singleinstancedatabase,mutating=false,failurePolicy=fail,sideEffects=None,groups=database.oracle.com,resources=singleinstancedatabases,versions=v1alpha1,name=vsingleinstancedatabase.kb.io,admissionReviewVersions={v1,v1beta1}
 
@@ -167,6 +229,24 @@ func (r *SingleInstanceDatabase) ValidateCreate() error {
 					r.Spec.Persistence.AccessMode, "should be either \"ReadWriteOnce\" or \"ReadWriteMany\""))
 		}
 	}
+	allErrs = append(allErrs, validateVolumeDataSource(field.NewPath("spec").Child("persistence"),
+		r.Spec.Persistence.Size, r.Spec.Persistence.VolumeName, r.Spec.Persistence.DataSource)...)
+	if r.CreationTimestamp.IsZero() {
+		allErrs = append(allErrs, validateNamespaceQuota("SingleInstanceDatabase", r.Namespace, r.Name, r.Spec.Persistence.Size)...)
+		if err := validateDatabaseClass(field.NewPath("spec").Child("className"), r.Spec.ClassName, "SingleInstanceDatabase"); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	if r.Spec.Persistence.DataSource != nil && (r.Spec.CloneFrom != "" || r.Spec.CreateAsStandby) {
+		// The datafiles on the populated volume are opened as they are, there is nothing to clone or duplicate
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("persistence").Child("dataSource"),
+				"cannot be used with cloneFrom or createAsStandby"))
+	}
+
+	// With ReadWriteOnce, the replicas are scheduled on the node of the volume by the controller
+	allErrs = append(allErrs, validateReadWriteManyStorageClass(field.NewPath("spec").Child("persistence"),
+		r.Spec.Persistence.AccessMode, r.Spec.Persistence.StorageClass)...)
 
 	// Replica validation
 	if r.Spec.Replicas > 1 {
@@ -194,25 +274,44 @@ func (r *SingleInstanceDatabase) ValidateCreate() error {
 				field.Invalid(field.NewPath("spec").Child("createAsStandby"), r.Spec.CreateAsStandby,
 					"Physical Standby Database creation is not supported for " + r.Spec.Edition + " edition"))
 		}
-		if r.Spec.Edition == "express" && strings.ToUpper(r.Spec.Sid) != "XE" {
+		if r.Spec.Edition == "express" && strings.ToUpper(r.Spec.Sid) != dbcommons.ExpressEditionSID {
 			allErrs = append(allErrs,
 				field.Invalid(field.NewPath("spec").Child("sid"), r.Spec.Sid,
-					"Express edition SID must only be XE"))
+					"Express edition SID must only be "+dbcommons.ExpressEditionSID))
 		}
-		if r.Spec.Edition == "free" && strings.ToUpper(r.Spec.Sid) != "FREE" {
+		if r.Spec.Edition == "free" && strings.ToUpper(r.Spec.Sid) != dbcommons.FreeEditionSID {
 			allErrs = append(allErrs,
 				field.Invalid(field.NewPath("spec").Child("sid"), r.Spec.Sid,
-					"Free edition SID must only be FREE"))
+					"Free edition SID must only be "+dbcommons.FreeEditionSID))
 		}
-		if r.Spec.Edition == "express" && strings.ToUpper(r.Spec.Pdbname) != "XEPDB1" {
+		if r.Spec.Edition == "express" && strings.ToUpper(r.Spec.Pdbname) != dbcommons.ExpressEditionPDB {
 			allErrs = append(allErrs,
 				field.Invalid(field.NewPath("spec").Child("pdbName"), r.Spec.Pdbname,
-					"Express edition PDB must be XEPDB1"))
+					"Express edition PDB must be "+dbcommons.ExpressEditionPDB))
 		}
-		if r.Spec.Edition == "free" && strings.ToUpper(r.Spec.Pdbname) != "FREEPDB1" {
+		if r.Spec.Edition == "free" && strings.ToUpper(r.Spec.Pdbname) != dbcommons.FreeEditionPDB {
 			allErrs = append(allErrs,
 				field.Invalid(field.NewPath("spec").Child("pdbName"), r.Spec.Pdbname,
-					"Free edition PDB must be FREEPDB1"))
+					"Free edition PDB must be "+dbcommons.FreeEditionPDB))
+		}
+		if r.Spec.Edition == "free" && r.Spec.Image.Version != "" {
+			// Version label is of the form 23.2.0.0; only validate when the major version is parseable
+			majorVersion, err := strconv.Atoi(strings.Split(r.Spec.Image.Version, ".")[0])
+			if err == nil && majorVersion < dbcommons.FreeEditionMinMajorVersion {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec").Child("image").Child("version"), r.Spec.Image.Version,
+						"Free edition is only available from version 23 onwards"))
+			}
+		}
+		if r.Spec.Edition == "express" && r.Spec.AdminPassword.ExternalPasswordStore {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("adminPassword").Child("externalPasswordStore"), r.Spec.AdminPassword.ExternalPasswordStore,
+					"External password store is not supported for express edition"))
+		}
+		if r.Spec.Edition == "free" && r.Spec.Image.PrebuiltDB {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("image").Child("prebuiltDB"), r.Spec.Image.PrebuiltDB,
+					"Prebuilt databases are not supported for free edition"))
 		}
 		if r.Spec.InitParams.CpuCount != 0 {
 			allErrs = append(allErrs,
@@ -235,18 +334,77 @@ func (r *SingleInstanceDatabase) ValidateCreate() error {
 					r.Spec.Edition + " edition does not support changing init parameter pgaAggregateTarget."))
 		}
 	} else {
-		if r.Spec.Sid == "XE" {
+		if r.Spec.Sid == dbcommons.ExpressEditionSID {
 			allErrs = append(allErrs,
 				field.Invalid(field.NewPath("spec").Child("sid"), r.Spec.Sid,
 					"XE is reserved as the SID for Express edition of the database"))
 		}
-		if r.Spec.Sid == "FREE" {
+		if r.Spec.Sid == dbcommons.FreeEditionSID {
 			allErrs = append(allErrs,
 				field.Invalid(field.NewPath("spec").Child("sid"), r.Spec.Sid,
 					"FREE is reserved as the SID for FREE edition of the database"))
 		}
 	}
 
+	// Architecture validation
+	for arch := range r.Spec.Image.ArchitectureImages {
+		if arch != "amd64" && arch != "arm64" {
+			allErrs = append(allErrs,
+				field.NotSupported(field.NewPath("spec").Child("image").Child("architectureImages"), arch, []string{"amd64", "arm64"}))
+		}
+	}
+	if arch, ok := r.Spec.NodeSelector["kubernetes.io/arch"]; ok && len(r.Spec.Image.Architectures) != 0 {
+		supported := r.Spec.Image.ArchitectureImages[arch] != ""
+		for _, imageArch := range r.Spec.Image.Architectures {
+			supported = supported || imageArch == arch
+		}
+		if !supported {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("nodeSelector"), arch,
+					"no image for this architecture in image.architectures or image.architectureImages"))
+		}
+	}
+
+	// True Cache validation
+	if r.Spec.TrueCache != nil {
+		if r.Spec.Edition == "express" {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("trueCache"), r.Spec.Edition,
+					"True Cache is not supported for express edition"))
+		}
+		if r.Spec.Image.Version != "" {
+			majorVersion, err := strconv.Atoi(strings.Split(r.Spec.Image.Version, ".")[0])
+			if err == nil && majorVersion < 23 {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec").Child("trueCache"), r.Spec.Image.Version,
+						"True Cache is only available from version 23 onwards"))
+			}
+		}
+		if r.Spec.CreateAsStandby {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("trueCache"), r.Spec.CreateAsStandby,
+					"True Cache can only be deployed in front of a primary database"))
+		}
+		if r.Spec.AdminPassword.KeepSecret != nil && !*r.Spec.AdminPassword.KeepSecret {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("adminPassword").Child("keepSecret"), *r.Spec.AdminPassword.KeepSecret,
+					"adminPassword secret must be kept for True Cache instances to register with the primary"))
+		}
+		cacheServices := make(map[string]bool)
+		for i, svc := range r.Spec.TrueCache.Services {
+			if svc.PrimaryService == "" || svc.CacheService == "" {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec").Child("trueCache").Child("services").Index(i), svc,
+						"both primaryService and cacheService must be specified"))
+			}
+			if cacheServices[strings.ToUpper(svc.CacheService)] {
+				allErrs = append(allErrs,
+					field.Duplicate(field.NewPath("spec").Child("trueCache").Child("services").Index(i).Child("cacheService"), svc.CacheService))
+			}
+			cacheServices[strings.ToUpper(svc.CacheService)] = true
+		}
+	}
+
 	if r.Spec.CloneFrom != "" {
 		if r.Spec.Image.PrebuiltDB {
 			allErrs = append(allErrs,
@@ -312,6 +470,84 @@ func (r *SingleInstanceDatabase) ValidateCreate() error {
 				"listenerPort and tcpsListenerPort can not be equal."))
 	}
 
+	// Hostname published through external-dns must be a valid DNS name
+	if r.Spec.Hostname != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(r.Spec.Hostname) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("hostname"), r.Spec.Hostname, msg))
+		}
+	}
+
+	// Schedule validation
+	if r.Spec.Schedule != nil {
+		if _, _, err := dbcommons.GetScheduledState(r.Spec.Schedule.Start, r.Spec.Schedule.Stop,
+			r.Spec.Schedule.TimeZone, time.Now()); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("schedule"), r.Spec.Schedule, err.Error()))
+		}
+	}
+
+	// Performance report time range
+	if r.Spec.PerformanceReport != nil {
+		begin, beginErr := time.Parse(time.RFC3339, r.Spec.PerformanceReport.Begin)
+		if beginErr != nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("performanceReport").Child("begin"), r.Spec.PerformanceReport.Begin,
+					"should be an RFC 3339 time, such as 2023-06-05T08:00:00Z"))
+		}
+		end, endErr := time.Parse(time.RFC3339, r.Spec.PerformanceReport.End)
+		if endErr != nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("performanceReport").Child("end"), r.Spec.PerformanceReport.End,
+					"should be an RFC 3339 time, such as 2023-06-05T09:00:00Z"))
+		}
+		if beginErr == nil && endErr == nil && !end.After(begin) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("performanceReport").Child("end"), r.Spec.PerformanceReport.End,
+					"should be after begin"))
+		}
+	}
+
+	// Resource plan directives, one per PDB
+	if r.Spec.ResourceManagerPlan != nil {
+		pdbNames := make(map[string]bool)
+		for i, directive := range r.Spec.ResourceManagerPlan.Directives {
+			pdbName := strings.ToUpper(directive.PdbName)
+			if pdbNames[pdbName] {
+				allErrs = append(allErrs,
+					field.Duplicate(field.NewPath("spec").Child("resourceManagerPlan").Child("directives").Index(i).Child("pdbName"),
+						directive.PdbName))
+			}
+			pdbNames[pdbName] = true
+		}
+	}
+
+	// Object Storage credential
+	if r.Spec.ObjectStorage != nil {
+		if r.Spec.ObjectStorage.CredentialName != "" && !credentialNamePattern.MatchString(r.Spec.ObjectStorage.CredentialName) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("objectStorage").Child("credentialName"), r.Spec.ObjectStorage.CredentialName,
+					"should be a SQL identifier such as OCI_CRED"))
+		}
+	}
+
+	// Source ranges only apply to LoadBalancer services
+	if len(r.Spec.LoadBalancerSourceRanges) != 0 && !r.Spec.LoadBalancer {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("loadBalancerSourceRanges"), "requires loadBalancer to be true"))
+	}
+	for _, cidr := range r.Spec.LoadBalancerSourceRanges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec").Child("loadBalancerSourceRanges"), cidr, "should be a CIDR such as 10.0.0.0/16"))
+		}
+	}
+
+	// Password complexity, on creation only as ValidateUpdate also runs these validations
+	if r.ResourceVersion == "" {
+		allErrs = append(allErrs, r.validatePasswordSecrets(nil)...)
+	}
+
 	// Certificate Renew Duration Validation
 	if r.Spec.EnableTCPS && r.Spec.TcpsCertRenewInterval != "" {
 		duration, err := time.ParseDuration(r.Spec.TcpsCertRenewInterval)
@@ -361,6 +597,8 @@ func (r *SingleInstanceDatabase) ValidateUpdate(oldRuntimeObject runtime.Object)
 	if !ok {
 		return nil
 	}
+	allErrs = append(allErrs, validateNamespaceQuotaUpdate("SingleInstanceDatabase", r.Namespace, r.Name,
+		r.Spec.Persistence.Size, old.Spec.Persistence.Size)...)
 
 	if (old.Status.Role != dbcommons.ValueUnavailable && old.Status.Role != "PRIMARY") {
 		// Restriciting Patching of secondary databases archiveLog, forceLog, flashBack
@@ -430,7 +668,17 @@ func (r *SingleInstanceDatabase) ValidateUpdate(oldRuntimeObject runtime.Object)
 		allErrs = append(allErrs,
 			field.Forbidden(field.NewPath("spec").Child("cloneFrom"), "cannot be changed"))
 	}
-	if old.Status.OrdsReference != "" && r.Status.Persistence != r.Spec.Persistence {
+	allErrs = append(allErrs, r.validatePasswordSecrets(old)...)
+	if old.Spec.ClassName != r.Spec.ClassName {
+		if err := validateDatabaseClass(field.NewPath("spec").Child("className"), r.Spec.ClassName, "SingleInstanceDatabase"); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	if !reflect.DeepEqual(old.Spec.Persistence.DataSource, r.Spec.Persistence.DataSource) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("persistence").Child("dataSource"), "cannot be changed"))
+	}
+	if old.GetOwnerConsumer(OracleRestDataServiceKind) != nil && !reflect.DeepEqual(r.Status.Persistence, r.Spec.Persistence) {
 		allErrs = append(allErrs,
 			field.Forbidden(field.NewPath("spec").Child("persistence"), "uninstall ORDS to change Persistence"))
 	}
@@ -443,13 +691,35 @@ func (r *SingleInstanceDatabase) ValidateUpdate(oldRuntimeObject runtime.Object)
 
 }
 
+// Check the complexity of the admin password when the database is created, or refers to another secret
+func (r *SingleInstanceDatabase) validatePasswordSecrets(old *SingleInstanceDatabase) field.ErrorList {
+	var allErrs field.ErrorList
+	// The password of a prebuilt database is already set
+	if r.Spec.Image.PrebuiltDB {
+		return allErrs
+	}
+	if old == nil || old.Spec.AdminPassword.SecretName != r.Spec.AdminPassword.SecretName ||
+		old.Spec.AdminPassword.SecretKey != r.Spec.AdminPassword.SecretKey {
+		if err := validatePasswordSecret(field.NewPath("spec").Child("adminPassword").Child("secretName"), r.Namespace,
+			r.Spec.AdminPassword.SecretName, r.Spec.AdminPassword.SecretKey, "SYS", "SYSTEM", "PDBADMIN"); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *SingleInstanceDatabase) ValidateDelete() error {
 	singleinstancedatabaselog.Info("validate delete", "name", r.Name)
 	var allErrs field.ErrorList
-	if r.Status.OrdsReference != "" {
+	dependents, err := r.getDependents()
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if len(dependents) != 0 {
 		allErrs = append(allErrs,
-			field.Forbidden(field.NewPath("status").Child("ordsReference"), "delete "+r.Status.OrdsReference+" to cleanup this SIDB"))
+			field.Forbidden(field.NewPath("metadata").Child("name"),
+				"database is in use; delete the dependent resources first: "+strings.Join(dependents, ", ")))
 	}
 	if len(allErrs) == 0 {
 		return nil
@@ -458,3 +728,111 @@ func (r *SingleInstanceDatabase) ValidateDelete() error {
 		schema.GroupKind{Group: "database.oracle.com", Kind: "SingleInstanceDatabase"},
 		r.Name, allErrs)
 }
+
+// getDependents returns the resources which must be deleted before this database, as "<Kind>/<name>": the
+// OracleRestDataServices, the standby databases and the DataguardBrokers referencing it
+func (r *SingleInstanceDatabase) getDependents() ([]string, error) {
+	var dependents []string
+	if webhookReader == nil {
+		return dependents, nil
+	}
+
+	ordss := &OracleRestDataServiceList{}
+	if err := webhookReader.List(context.TODO(), ordss, client.InNamespace(r.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, ords := range ordss.Items {
+		if ords.Spec.DatabaseRef == r.Name {
+			dependents = append(dependents, OracleRestDataServiceKind+"/"+ords.Name)
+		}
+	}
+
+	sidbs := &SingleInstanceDatabaseList{}
+	if err := webhookReader.List(context.TODO(), sidbs, client.InNamespace(r.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, sidb := range sidbs.Items {
+		if sidb.Spec.CreateAsStandby && sidb.Spec.PrimaryDatabaseRef == r.Name {
+			dependents = append(dependents, "SingleInstanceDatabase/"+sidb.Name)
+		}
+	}
+
+	brokers := &DataguardBrokerList{}
+	if err := webhookReader.List(context.TODO(), brokers, client.InNamespace(r.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, broker := range brokers.Items {
+		if broker.Spec.PrimaryDatabaseRef == r.Name {
+			dependents = append(dependents, "DataguardBroker/"+broker.Name)
+			continue
+		}
+		for _, standby := range broker.Spec.StandbyDatabaseRefs {
+			if standby == r.Name {
+				dependents = append(dependents, "DataguardBroker/"+broker.Name)
+				break
+			}
+		}
+	}
+	return dependents, nil
+}
+
+// Storage classes provisioning ReadWriteMany volumes, any storage class when empty
+var readWriteManyStorageClasses []string
+
+// SetReadWriteManyStorageClasses sets the storage classes accepted with the ReadWriteMany access mode
+func SetReadWriteManyStorageClasses(storageClasses []string) {
+	readWriteManyStorageClasses = nil
+	for _, sc := range storageClasses {
+		if sc = strings.TrimSpace(sc); sc != "" {
+			readWriteManyStorageClasses = append(readWriteManyStorageClasses, sc)
+		}
+	}
+}
+
+// Check that the storage class of a ReadWriteMany volume provisions such volumes
+func validateReadWriteManyStorageClass(path *field.Path, accessMode string, storageClass string) field.ErrorList {
+	var allErrs field.ErrorList
+	if accessMode != "ReadWriteMany" || storageClass == "" || len(readWriteManyStorageClasses) == 0 {
+		return allErrs
+	}
+	for _, sc := range readWriteManyStorageClasses {
+		if sc == storageClass {
+			return allErrs
+		}
+	}
+	allErrs = append(allErrs,
+		field.Invalid(path.Child("storageClass"), storageClass,
+			"does not support ReadWriteMany, use one of "+strings.Join(readWriteManyStorageClasses, ", ")))
+	return allErrs
+}
+
+// Check the source a volume is populated from, which is either a volume snapshot or a claim
+func validateVolumeDataSource(path *field.Path, size string, volumeName string,
+	dataSource *corev1.TypedLocalObjectReference) field.ErrorList {
+	var allErrs field.ErrorList
+	if dataSource == nil {
+		return allErrs
+	}
+	if size == "" {
+		allErrs = append(allErrs,
+			field.Invalid(path.Child("size"), size, "specify the size of the volume populated from dataSource"))
+	}
+	if volumeName != "" {
+		allErrs = append(allErrs,
+			field.Forbidden(path.Child("dataSource"), "cannot be used with volumeName"))
+	}
+	apiGroup := ""
+	if dataSource.APIGroup != nil {
+		apiGroup = *dataSource.APIGroup
+	}
+	if !(apiGroup == "snapshot.storage.k8s.io" && dataSource.Kind == "VolumeSnapshot") &&
+		!(apiGroup == "" && dataSource.Kind == "PersistentVolumeClaim") {
+		allErrs = append(allErrs,
+			field.NotSupported(path.Child("dataSource").Child("kind"), dataSource.Kind,
+				[]string{"VolumeSnapshot (snapshot.storage.k8s.io)", "PersistentVolumeClaim"}))
+	}
+	if dataSource.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("dataSource").Child("name"), ""))
+	}
+	return allErrs
+}
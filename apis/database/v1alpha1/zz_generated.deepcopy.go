@@ -148,6 +148,11 @@ func (in *AutonomousContainerDatabaseSpec) DeepCopyInto(out *AutonomousContainer
 		*out = new(string)
 		**out = **in
 	}
+	if in.AutonomousVMClusterOCID != nil {
+		in, out := &in.AutonomousVMClusterOCID, &out.AutonomousVMClusterOCID
+		*out = new(string)
+		**out = **in
+	}
 	if in.FreeformTags != nil {
 		in, out := &in.FreeformTags, &out.FreeformTags
 		*out = make(map[string]string, len(*in))
@@ -511,6 +516,21 @@ func (in *AutonomousDatabaseRestoreStatus) DeepCopy() *AutonomousDatabaseRestore
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutonomousDatabaseSchedule) DeepCopyInto(out *AutonomousDatabaseSchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutonomousDatabaseSchedule.
+func (in *AutonomousDatabaseSchedule) DeepCopy() *AutonomousDatabaseSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(AutonomousDatabaseSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutonomousDatabaseSpec) DeepCopyInto(out *AutonomousDatabaseSpec) {
 	*out = *in
@@ -521,6 +541,16 @@ func (in *AutonomousDatabaseSpec) DeepCopyInto(out *AutonomousDatabaseSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.TagPropagation != nil {
+		in, out := &in.TagPropagation, &out.TagPropagation
+		*out = new(TagPropagationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(AutonomousDatabaseSchedule)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutonomousDatabaseSpec.
@@ -543,6 +573,18 @@ func (in *AutonomousDatabaseStatus) DeepCopyInto(out *AutonomousDatabaseStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DriftSummary != nil {
+		in, out := &in.DriftSummary, &out.DriftSummary
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutonomousDatabaseStatus.
@@ -809,6 +851,11 @@ func (in *CatalogSpec) DeepCopyInto(out *CatalogSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PvAnnotations != nil {
 		in, out := &in.PvAnnotations, &out.PvAnnotations
 		*out = make(map[string]string, len(*in))
@@ -881,7 +928,7 @@ func (in *DataguardBroker) DeepCopyInto(out *DataguardBroker) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataguardBroker.
@@ -910,6 +957,7 @@ func (in *DataguardBrokerFastStartFailOver) DeepCopyInto(out *DataguardBrokerFas
 		*out = make([]DataguardBrokerStrategy, len(*in))
 		copy(*out, *in)
 	}
+	in.Observer.DeepCopyInto(&out.Observer)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataguardBrokerFastStartFailOver.
@@ -954,6 +1002,28 @@ func (in *DataguardBrokerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataguardBrokerObserver) DeepCopyInto(out *DataguardBrokerObserver) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataguardBrokerObserver.
+func (in *DataguardBrokerObserver) DeepCopy() *DataguardBrokerObserver {
+	if in == nil {
+		return nil
+	}
+	out := new(DataguardBrokerObserver)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataguardBrokerSpec) DeepCopyInto(out *DataguardBrokerSpec) {
 	*out = *in
@@ -962,6 +1032,13 @@ func (in *DataguardBrokerSpec) DeepCopyInto(out *DataguardBrokerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -985,6 +1062,13 @@ func (in *DataguardBrokerSpec) DeepCopy() *DataguardBrokerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataguardBrokerStatus) DeepCopyInto(out *DataguardBrokerStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataguardBrokerStatus.
@@ -1163,6 +1247,11 @@ func (in *DbcsSystemSpec) DeepCopyInto(out *DbcsSystemSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TagPropagation != nil {
+		in, out := &in.TagPropagation, &out.TagPropagation
+		*out = new(TagPropagationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DbcsSystemSpec.
@@ -1218,6 +1307,18 @@ func (in *DbcsSystemStatus) DeepCopyInto(out *DbcsSystemStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DriftSummary != nil {
+		in, out := &in.DriftSummary, &out.DriftSummary
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DbcsSystemStatus.
@@ -1265,6 +1366,11 @@ func (in *GsmSpec) DeepCopyInto(out *GsmSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PvMatchLabels != nil {
 		in, out := &in.PvMatchLabels, &out.PvMatchLabels
 		*out = make(map[string]string, len(*in))
@@ -1413,6 +1519,22 @@ func (in *K8sSecretSpec) DeepCopy() *K8sSecretSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceQuota) DeepCopyInto(out *NamespaceQuota) {
+	*out = *in
+	out.Storage = in.Storage.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceQuota.
+func (in *NamespaceQuota) DeepCopy() *NamespaceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkAccessSpec) DeepCopyInto(out *NetworkAccessSpec) {
 	*out = *in
@@ -1546,7 +1668,7 @@ func (in *ORDSPassword) DeepCopy() *ORDSPassword {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OracleRestDataService) DeepCopyInto(out *OracleRestDataService) {
+func (in *OracleDatabaseClass) DeepCopyInto(out *OracleDatabaseClass) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1554,18 +1676,18 @@ func (in *OracleRestDataService) DeepCopyInto(out *OracleRestDataService) {
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataService.
-func (in *OracleRestDataService) DeepCopy() *OracleRestDataService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleDatabaseClass.
+func (in *OracleDatabaseClass) DeepCopy() *OracleDatabaseClass {
 	if in == nil {
 		return nil
 	}
-	out := new(OracleRestDataService)
+	out := new(OracleDatabaseClass)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OracleRestDataService) DeepCopyObject() runtime.Object {
+func (in *OracleDatabaseClass) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1573,46 +1695,81 @@ func (in *OracleRestDataService) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OracleRestDataServiceImage) DeepCopyInto(out *OracleRestDataServiceImage) {
+func (in *OracleDatabaseClassDatabase) DeepCopyInto(out *OracleDatabaseClassDatabase) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(OracleDatabaseClassImage)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(OracleDatabaseClassPersistence)
+		**out = **in
+	}
+	if in.InitParams != nil {
+		in, out := &in.InitParams, &out.InitParams
+		*out = new(SingleInstanceDatabaseInitParams)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleDatabaseClassDatabase.
+func (in *OracleDatabaseClassDatabase) DeepCopy() *OracleDatabaseClassDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleDatabaseClassDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleDatabaseClassImage) DeepCopyInto(out *OracleDatabaseClassImage) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceImage.
-func (in *OracleRestDataServiceImage) DeepCopy() *OracleRestDataServiceImage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleDatabaseClassImage.
+func (in *OracleDatabaseClassImage) DeepCopy() *OracleDatabaseClassImage {
 	if in == nil {
 		return nil
 	}
-	out := new(OracleRestDataServiceImage)
+	out := new(OracleDatabaseClassImage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OracleRestDataServiceList) DeepCopyInto(out *OracleRestDataServiceList) {
+func (in *OracleDatabaseClassList) DeepCopyInto(out *OracleDatabaseClassList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OracleRestDataService, len(*in))
+		*out = make([]OracleDatabaseClass, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceList.
-func (in *OracleRestDataServiceList) DeepCopy() *OracleRestDataServiceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleDatabaseClassList.
+func (in *OracleDatabaseClassList) DeepCopy() *OracleDatabaseClassList {
 	if in == nil {
 		return nil
 	}
-	out := new(OracleRestDataServiceList)
+	out := new(OracleDatabaseClassList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OracleRestDataServiceList) DeepCopyObject() runtime.Object {
+func (in *OracleDatabaseClassList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1620,131 +1777,111 @@ func (in *OracleRestDataServiceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OracleRestDataServicePassword) DeepCopyInto(out *OracleRestDataServicePassword) {
+func (in *OracleDatabaseClassOrds) DeepCopyInto(out *OracleDatabaseClassOrds) {
 	*out = *in
-	if in.KeepSecret != nil {
-		in, out := &in.KeepSecret, &out.KeepSecret
-		*out = new(bool)
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(OracleDatabaseClassImage)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServicePassword.
-func (in *OracleRestDataServicePassword) DeepCopy() *OracleRestDataServicePassword {
-	if in == nil {
-		return nil
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(OracleDatabaseClassPersistence)
+		**out = **in
 	}
-	out := new(OracleRestDataServicePassword)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OracleRestDataServicePersistence) DeepCopyInto(out *OracleRestDataServicePersistence) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServicePersistence.
-func (in *OracleRestDataServicePersistence) DeepCopy() *OracleRestDataServicePersistence {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleDatabaseClassOrds.
+func (in *OracleDatabaseClassOrds) DeepCopy() *OracleDatabaseClassOrds {
 	if in == nil {
 		return nil
 	}
-	out := new(OracleRestDataServicePersistence)
+	out := new(OracleDatabaseClassOrds)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OracleRestDataServiceRestEnableSchemas) DeepCopyInto(out *OracleRestDataServiceRestEnableSchemas) {
+func (in *OracleDatabaseClassPersistence) DeepCopyInto(out *OracleDatabaseClassPersistence) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceRestEnableSchemas.
-func (in *OracleRestDataServiceRestEnableSchemas) DeepCopy() *OracleRestDataServiceRestEnableSchemas {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleDatabaseClassPersistence.
+func (in *OracleDatabaseClassPersistence) DeepCopy() *OracleDatabaseClassPersistence {
 	if in == nil {
 		return nil
 	}
-	out := new(OracleRestDataServiceRestEnableSchemas)
+	out := new(OracleDatabaseClassPersistence)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OracleRestDataServiceSpec) DeepCopyInto(out *OracleRestDataServiceSpec) {
+func (in *OracleDatabaseClassSpec) DeepCopyInto(out *OracleDatabaseClassSpec) {
 	*out = *in
-	if in.ServiceAnnotations != nil {
-		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(OracleDatabaseClassDatabase)
+		(*in).DeepCopyInto(*out)
 	}
-	out.Image = in.Image
-	in.OrdsPassword.DeepCopyInto(&out.OrdsPassword)
-	in.ApexPassword.DeepCopyInto(&out.ApexPassword)
-	in.AdminPassword.DeepCopyInto(&out.AdminPassword)
-	if in.RestEnableSchemas != nil {
-		in, out := &in.RestEnableSchemas, &out.RestEnableSchemas
-		*out = make([]OracleRestDataServiceRestEnableSchemas, len(*in))
-		copy(*out, *in)
+	if in.Ords != nil {
+		in, out := &in.Ords, &out.Ords
+		*out = new(OracleDatabaseClassOrds)
+		(*in).DeepCopyInto(*out)
 	}
-	out.Persistence = in.Persistence
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceSpec.
-func (in *OracleRestDataServiceSpec) DeepCopy() *OracleRestDataServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleDatabaseClassSpec.
+func (in *OracleDatabaseClassSpec) DeepCopy() *OracleDatabaseClassSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OracleRestDataServiceSpec)
+	out := new(OracleDatabaseClassSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OracleRestDataServiceStatus) DeepCopyInto(out *OracleRestDataServiceStatus) {
+func (in *OracleDatabaseClassStatus) DeepCopyInto(out *OracleDatabaseClassStatus) {
 	*out = *in
-	out.Image = in.Image
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceStatus.
-func (in *OracleRestDataServiceStatus) DeepCopy() *OracleRestDataServiceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleDatabaseClassStatus.
+func (in *OracleDatabaseClassStatus) DeepCopy() *OracleDatabaseClassStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OracleRestDataServiceStatus)
+	out := new(OracleDatabaseClassStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDB) DeepCopyInto(out *PDB) {
+func (in *OracleRestDataService) DeepCopyInto(out *OracleRestDataService) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDB.
-func (in *PDB) DeepCopy() *PDB {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataService.
+func (in *OracleRestDataService) DeepCopy() *OracleRestDataService {
 	if in == nil {
 		return nil
 	}
-	out := new(PDB)
+	out := new(OracleRestDataService)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PDB) DeepCopyObject() runtime.Object {
+func (in *OracleRestDataService) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1752,353 +1889,1440 @@ func (in *PDB) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDBAdminName) DeepCopyInto(out *PDBAdminName) {
+func (in *OracleRestDataServiceApex) DeepCopyInto(out *OracleRestDataServiceApex) {
 	*out = *in
-	out.Secret = in.Secret
+	if in.Languages != nil {
+		in, out := &in.Languages, &out.Languages
+		*out = make([]ApexLanguage, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstallTimeout != nil {
+		in, out := &in.InstallTimeout, &out.InstallTimeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(OracleRestDataServiceArtifactSource)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBAdminName.
-func (in *PDBAdminName) DeepCopy() *PDBAdminName {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceApex.
+func (in *OracleRestDataServiceApex) DeepCopy() *OracleRestDataServiceApex {
 	if in == nil {
 		return nil
 	}
-	out := new(PDBAdminName)
+	out := new(OracleRestDataServiceApex)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDBAdminPassword) DeepCopyInto(out *PDBAdminPassword) {
+func (in *OracleRestDataServiceApexInstallation) DeepCopyInto(out *OracleRestDataServiceApexInstallation) {
 	*out = *in
-	out.Secret = in.Secret
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBAdminPassword.
-func (in *PDBAdminPassword) DeepCopy() *PDBAdminPassword {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceApexInstallation.
+func (in *OracleRestDataServiceApexInstallation) DeepCopy() *OracleRestDataServiceApexInstallation {
 	if in == nil {
 		return nil
 	}
-	out := new(PDBAdminPassword)
+	out := new(OracleRestDataServiceApexInstallation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDBList) DeepCopyInto(out *PDBList) {
+func (in *OracleRestDataServiceApiGateway) DeepCopyInto(out *OracleRestDataServiceApiGateway) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PDB, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBList.
-func (in *PDBList) DeepCopy() *PDBList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceApiGateway.
+func (in *OracleRestDataServiceApiGateway) DeepCopy() *OracleRestDataServiceApiGateway {
 	if in == nil {
 		return nil
 	}
-	out := new(PDBList)
+	out := new(OracleRestDataServiceApiGateway)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PDBList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceArtifactSource) DeepCopyInto(out *OracleRestDataServiceArtifactSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceArtifactSource.
+func (in *OracleRestDataServiceArtifactSource) DeepCopy() *OracleRestDataServiceArtifactSource {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(OracleRestDataServiceArtifactSource)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDBSecret) DeepCopyInto(out *PDBSecret) {
+func (in *OracleRestDataServiceDatabaseTuning) DeepCopyInto(out *OracleRestDataServiceDatabaseTuning) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBSecret.
-func (in *PDBSecret) DeepCopy() *PDBSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceDatabaseTuning.
+func (in *OracleRestDataServiceDatabaseTuning) DeepCopy() *OracleRestDataServiceDatabaseTuning {
 	if in == nil {
 		return nil
 	}
-	out := new(PDBSecret)
+	out := new(OracleRestDataServiceDatabaseTuning)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDBSpec) DeepCopyInto(out *PDBSpec) {
+func (in *OracleRestDataServiceImage) DeepCopyInto(out *OracleRestDataServiceImage) {
 	*out = *in
-	out.PDBTlsKey = in.PDBTlsKey
-	out.PDBTlsCrt = in.PDBTlsCrt
-	out.PDBTlsCat = in.PDBTlsCat
-	out.AdminName = in.AdminName
-	out.AdminPwd = in.AdminPwd
-	if in.ReuseTempFile != nil {
-		in, out := &in.ReuseTempFile, &out.ReuseTempFile
-		*out = new(bool)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceImage.
+func (in *OracleRestDataServiceImage) DeepCopy() *OracleRestDataServiceImage {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceIngress) DeepCopyInto(out *OracleRestDataServiceIngress) {
+	*out = *in
+	if in.ClassName != nil {
+		in, out := &in.ClassName, &out.ClassName
+		*out = new(string)
 		**out = **in
 	}
-	if in.UnlimitedStorage != nil {
-		in, out := &in.UnlimitedStorage, &out.UnlimitedStorage
-		*out = new(bool)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceIngress.
+func (in *OracleRestDataServiceIngress) DeepCopy() *OracleRestDataServiceIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceList) DeepCopyInto(out *OracleRestDataServiceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OracleRestDataService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceList.
+func (in *OracleRestDataServiceList) DeepCopy() *OracleRestDataServiceList {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OracleRestDataServiceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceNodePortAddress) DeepCopyInto(out *OracleRestDataServiceNodePortAddress) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceNodePortAddress.
+func (in *OracleRestDataServiceNodePortAddress) DeepCopy() *OracleRestDataServiceNodePortAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceNodePortAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceOAuthClient) DeepCopyInto(out *OracleRestDataServiceOAuthClient) {
+	*out = *in
+	if in.AllowedOrigins != nil {
+		in, out := &in.AllowedOrigins, &out.AllowedOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]OracleRestDataServiceOAuthPrivilege, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceOAuthClient.
+func (in *OracleRestDataServiceOAuthClient) DeepCopy() *OracleRestDataServiceOAuthClient {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceOAuthClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceOAuthClientStatus) DeepCopyInto(out *OracleRestDataServiceOAuthClientStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceOAuthClientStatus.
+func (in *OracleRestDataServiceOAuthClientStatus) DeepCopy() *OracleRestDataServiceOAuthClientStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceOAuthClientStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceOAuthPrivilege) DeepCopyInto(out *OracleRestDataServiceOAuthPrivilege) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceOAuthPrivilege.
+func (in *OracleRestDataServiceOAuthPrivilege) DeepCopy() *OracleRestDataServiceOAuthPrivilege {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceOAuthPrivilege)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceOci) DeepCopyInto(out *OracleRestDataServiceOci) {
+	*out = *in
+	if in.ApiGateway != nil {
+		in, out := &in.ApiGateway, &out.ApiGateway
+		*out = new(OracleRestDataServiceApiGateway)
 		**out = **in
 	}
-	if in.AsClone != nil {
-		in, out := &in.AsClone, &out.AsClone
-		*out = new(bool)
+	in.OCIConfig.DeepCopyInto(&out.OCIConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceOci.
+func (in *OracleRestDataServiceOci) DeepCopy() *OracleRestDataServiceOci {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceOci)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceOrds) DeepCopyInto(out *OracleRestDataServiceOrds) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(OracleRestDataServiceArtifactSource)
 		**out = **in
 	}
-	if in.TDEImport != nil {
-		in, out := &in.TDEImport, &out.TDEImport
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceOrds.
+func (in *OracleRestDataServiceOrds) DeepCopy() *OracleRestDataServiceOrds {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceOrds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServicePassword) DeepCopyInto(out *OracleRestDataServicePassword) {
+	*out = *in
+	if in.KeepSecret != nil {
+		in, out := &in.KeepSecret, &out.KeepSecret
 		*out = new(bool)
 		**out = **in
 	}
-	if in.TDEExport != nil {
-		in, out := &in.TDEExport, &out.TDEExport
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServicePassword.
+func (in *OracleRestDataServicePassword) DeepCopy() *OracleRestDataServicePassword {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServicePassword)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServicePersistence) DeepCopyInto(out *OracleRestDataServicePersistence) {
+	*out = *in
+	if in.DataSource != nil {
+		in, out := &in.DataSource, &out.DataSource
+		*out = new(corev1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServicePersistence.
+func (in *OracleRestDataServicePersistence) DeepCopy() *OracleRestDataServicePersistence {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServicePersistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServicePodSecurityContext) DeepCopyInto(out *OracleRestDataServicePodSecurityContext) {
+	*out = *in
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServicePodSecurityContext.
+func (in *OracleRestDataServicePodSecurityContext) DeepCopy() *OracleRestDataServicePodSecurityContext {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServicePodSecurityContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceRestEnableSchemas) DeepCopyInto(out *OracleRestDataServiceRestEnableSchemas) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceRestEnableSchemas.
+func (in *OracleRestDataServiceRestEnableSchemas) DeepCopy() *OracleRestDataServiceRestEnableSchemas {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceRestEnableSchemas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceSettings) DeepCopyInto(out *OracleRestDataServiceSettings) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceSettings.
+func (in *OracleRestDataServiceSettings) DeepCopy() *OracleRestDataServiceSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceSpec) DeepCopyInto(out *OracleRestDataServiceSpec) {
+	*out = *in
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.Image = in.Image
+	in.OrdsPassword.DeepCopyInto(&out.OrdsPassword)
+	in.ApexPassword.DeepCopyInto(&out.ApexPassword)
+	in.Apex.DeepCopyInto(&out.Apex)
+	if in.Ords != nil {
+		in, out := &in.Ords, &out.Ords
+		*out = new(OracleRestDataServiceOrds)
+		(*in).DeepCopyInto(*out)
+	}
+	in.AdminPassword.DeepCopyInto(&out.AdminPassword)
+	if in.RestEnableSchemas != nil {
+		in, out := &in.RestEnableSchemas, &out.RestEnableSchemas
+		*out = make([]OracleRestDataServiceRestEnableSchemas, len(*in))
+		copy(*out, *in)
+	}
+	in.Persistence.DeepCopyInto(&out.Persistence)
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(OracleRestDataServicePodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(OracleRestDataServiceIngress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DatabaseTuning != nil {
+		in, out := &in.DatabaseTuning, &out.DatabaseTuning
+		*out = new(OracleRestDataServiceDatabaseTuning)
+		**out = **in
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodePortAddress != nil {
+		in, out := &in.NodePortAddress, &out.NodePortAddress
+		*out = new(OracleRestDataServiceNodePortAddress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(OracleRestDataServiceUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeFailureTimeout != nil {
+		in, out := &in.NodeFailureTimeout, &out.NodeFailureTimeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Oci != nil {
+		in, out := &in.Oci, &out.Oci
+		*out = new(OracleRestDataServiceOci)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuthClients != nil {
+		in, out := &in.OAuthClients, &out.OAuthClients
+		*out = make([]OracleRestDataServiceOAuthClient, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(OracleRestDataServiceSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WarmUp != nil {
+		in, out := &in.WarmUp, &out.WarmUp
+		*out = new(OracleRestDataServiceWarmUp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VirtualHosts != nil {
+		in, out := &in.VirtualHosts, &out.VirtualHosts
+		*out = make([]OracleRestDataServiceVirtualHost, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceSpec.
+func (in *OracleRestDataServiceSpec) DeepCopy() *OracleRestDataServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceStatus) DeepCopyInto(out *OracleRestDataServiceStatus) {
+	*out = *in
+	out.Image = in.Image
+	if in.ApexLanguages != nil {
+		in, out := &in.ApexLanguages, &out.ApexLanguages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CreatedUsers != nil {
+		in, out := &in.CreatedUsers, &out.CreatedUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DatabaseApiUrls != nil {
+		in, out := &in.DatabaseApiUrls, &out.DatabaseApiUrls
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ApexInstallation != nil {
+		in, out := &in.ApexInstallation, &out.ApexInstallation
+		*out = new(OracleRestDataServiceApexInstallation)
+		**out = **in
+	}
+	if in.OAuthClients != nil {
+		in, out := &in.OAuthClients, &out.OAuthClients
+		*out = make([]OracleRestDataServiceOAuthClientStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceStatus.
+func (in *OracleRestDataServiceStatus) DeepCopy() *OracleRestDataServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceUpdateStrategy) DeepCopyInto(out *OracleRestDataServiceUpdateStrategy) {
+	*out = *in
+	if in.SmokeTestPaths != nil {
+		in, out := &in.SmokeTestPaths, &out.SmokeTestPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceUpdateStrategy.
+func (in *OracleRestDataServiceUpdateStrategy) DeepCopy() *OracleRestDataServiceUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceVirtualHost) DeepCopyInto(out *OracleRestDataServiceVirtualHost) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceVirtualHost.
+func (in *OracleRestDataServiceVirtualHost) DeepCopy() *OracleRestDataServiceVirtualHost {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceVirtualHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OracleRestDataServiceWarmUp) DeepCopyInto(out *OracleRestDataServiceWarmUp) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OracleRestDataServiceWarmUp.
+func (in *OracleRestDataServiceWarmUp) DeepCopy() *OracleRestDataServiceWarmUp {
+	if in == nil {
+		return nil
+	}
+	out := new(OracleRestDataServiceWarmUp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDB) DeepCopyInto(out *PDB) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDB.
+func (in *PDB) DeepCopy() *PDB {
+	if in == nil {
+		return nil
+	}
+	out := new(PDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PDB) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDBAdminName) DeepCopyInto(out *PDBAdminName) {
+	*out = *in
+	out.Secret = in.Secret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBAdminName.
+func (in *PDBAdminName) DeepCopy() *PDBAdminName {
+	if in == nil {
+		return nil
+	}
+	out := new(PDBAdminName)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDBAdminPassword) DeepCopyInto(out *PDBAdminPassword) {
+	*out = *in
+	out.Secret = in.Secret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBAdminPassword.
+func (in *PDBAdminPassword) DeepCopy() *PDBAdminPassword {
+	if in == nil {
+		return nil
+	}
+	out := new(PDBAdminPassword)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDBList) DeepCopyInto(out *PDBList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PDB, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBList.
+func (in *PDBList) DeepCopy() *PDBList {
+	if in == nil {
+		return nil
+	}
+	out := new(PDBList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PDBList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDBResourceLimits) DeepCopyInto(out *PDBResourceLimits) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBResourceLimits.
+func (in *PDBResourceLimits) DeepCopy() *PDBResourceLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(PDBResourceLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDBSecret) DeepCopyInto(out *PDBSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBSecret.
+func (in *PDBSecret) DeepCopy() *PDBSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(PDBSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDBSpec) DeepCopyInto(out *PDBSpec) {
+	*out = *in
+	out.PDBTlsKey = in.PDBTlsKey
+	out.PDBTlsCrt = in.PDBTlsCrt
+	out.PDBTlsCat = in.PDBTlsCat
+	out.AdminName = in.AdminName
+	out.AdminPwd = in.AdminPwd
+	if in.ReuseTempFile != nil {
+		in, out := &in.ReuseTempFile, &out.ReuseTempFile
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UnlimitedStorage != nil {
+		in, out := &in.UnlimitedStorage, &out.UnlimitedStorage
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AsClone != nil {
+		in, out := &in.AsClone, &out.AsClone
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TDEImport != nil {
+		in, out := &in.TDEImport, &out.TDEImport
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TDEExport != nil {
+		in, out := &in.TDEExport, &out.TDEExport
+		*out = new(bool)
+		**out = **in
+	}
+	out.TDEPassword = in.TDEPassword
+	out.TDESecret = in.TDESecret
+	if in.GetScript != nil {
+		in, out := &in.GetScript, &out.GetScript
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ResourceLimits != nil {
+		in, out := &in.ResourceLimits, &out.ResourceLimits
+		*out = new(PDBResourceLimits)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBSpec.
+func (in *PDBSpec) DeepCopy() *PDBSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PDBSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDBStatus) DeepCopyInto(out *PDBStatus) {
+	*out = *in
+	if in.ResourceLimits != nil {
+		in, out := &in.ResourceLimits, &out.ResourceLimits
+		*out = new(PDBResourceLimits)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBStatus.
+func (in *PDBStatus) DeepCopy() *PDBStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PDBStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDBTLSCAT) DeepCopyInto(out *PDBTLSCAT) {
+	*out = *in
+	out.Secret = in.Secret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBTLSCAT.
+func (in *PDBTLSCAT) DeepCopy() *PDBTLSCAT {
+	if in == nil {
+		return nil
+	}
+	out := new(PDBTLSCAT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDBTLSCRT) DeepCopyInto(out *PDBTLSCRT) {
+	*out = *in
+	out.Secret = in.Secret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBTLSCRT.
+func (in *PDBTLSCRT) DeepCopy() *PDBTLSCRT {
+	if in == nil {
+		return nil
+	}
+	out := new(PDBTLSCRT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDBTLSKEY) DeepCopyInto(out *PDBTLSKEY) {
+	*out = *in
+	out.Secret = in.Secret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBTLSKEY.
+func (in *PDBTLSKEY) DeepCopy() *PDBTLSKEY {
+	if in == nil {
+		return nil
+	}
+	out := new(PDBTLSKEY)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PITSpec) DeepCopyInto(out *PITSpec) {
+	*out = *in
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PITSpec.
+func (in *PITSpec) DeepCopy() *PITSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PITSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordSpec) DeepCopyInto(out *PasswordSpec) {
+	*out = *in
+	in.K8sSecret.DeepCopyInto(&out.K8sSecret)
+	in.OCISecret.DeepCopyInto(&out.OCISecret)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordSpec.
+func (in *PasswordSpec) DeepCopy() *PasswordSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortMapping) DeepCopyInto(out *PortMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortMapping.
+func (in *PortMapping) DeepCopy() *PortMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(PortMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
+	*out = *in
+	if in.SubnetOCID != nil {
+		in, out := &in.SubnetOCID, &out.SubnetOCID
+		*out = new(string)
+		**out = **in
+	}
+	if in.NsgOCIDs != nil {
+		in, out := &in.NsgOCIDs, &out.NsgOCIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostnamePrefix != nil {
+		in, out := &in.HostnamePrefix, &out.HostnamePrefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpointSpec.
+func (in *PrivateEndpointSpec) DeepCopy() *PrivateEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardSpec) DeepCopyInto(out *ShardSpec) {
+	*out = *in
+	if in.EnvVars != nil {
+		in, out := &in.EnvVars, &out.EnvVars
+		*out = make([]EnvironmentVariable, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PvAnnotations != nil {
+		in, out := &in.PvAnnotations, &out.PvAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PvMatchLabels != nil {
+		in, out := &in.PvMatchLabels, &out.PvMatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImagePulllPolicy != nil {
+		in, out := &in.ImagePulllPolicy, &out.ImagePulllPolicy
+		*out = new(corev1.PullPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardSpec.
+func (in *ShardSpec) DeepCopy() *ShardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingBackupSpec) DeepCopyInto(out *ShardingBackupSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingBackupSpec.
+func (in *ShardingBackupSpec) DeepCopy() *ShardingBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingBackupStatus) DeepCopyInto(out *ShardingBackupStatus) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingBackupStatus.
+func (in *ShardingBackupStatus) DeepCopy() *ShardingBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingDatabase) DeepCopyInto(out *ShardingDatabase) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingDatabase.
+func (in *ShardingDatabase) DeepCopy() *ShardingDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShardingDatabase) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingDatabaseList) DeepCopyInto(out *ShardingDatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ShardingDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingDatabaseList.
+func (in *ShardingDatabaseList) DeepCopy() *ShardingDatabaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingDatabaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShardingDatabaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingDatabaseSpec) DeepCopyInto(out *ShardingDatabaseSpec) {
+	*out = *in
+	if in.Shard != nil {
+		in, out := &in.Shard, &out.Shard
+		*out = make([]ShardSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = make([]CatalogSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Gsm != nil {
+		in, out := &in.Gsm, &out.Gsm
+		*out = make([]GsmSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PortMappings != nil {
+		in, out := &in.PortMappings, &out.PortMappings
+		*out = make([]PortMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(ShardingBackupSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingDatabaseSpec.
+func (in *ShardingDatabaseSpec) DeepCopy() *ShardingDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShardingDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShardingDatabaseStatus) DeepCopyInto(out *ShardingDatabaseStatus) {
+	*out = *in
+	if in.Shard != nil {
+		in, out := &in.Shard, &out.Shard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Gsm.DeepCopyInto(&out.Gsm)
+	if in.CrdStatus != nil {
+		in, out := &in.CrdStatus, &out.CrdStatus
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	out.TDEPassword = in.TDEPassword
-	out.TDESecret = in.TDESecret
-	if in.GetScript != nil {
-		in, out := &in.GetScript, &out.GetScript
-		*out = new(bool)
-		**out = **in
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(ShardingBackupStatus)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBSpec.
-func (in *PDBSpec) DeepCopy() *PDBSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingDatabaseStatus.
+func (in *ShardingDatabaseStatus) DeepCopy() *ShardingDatabaseStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PDBSpec)
+	out := new(ShardingDatabaseStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDBStatus) DeepCopyInto(out *PDBStatus) {
+func (in *SingleInstanceDatabase) DeepCopyInto(out *SingleInstanceDatabase) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBStatus.
-func (in *PDBStatus) DeepCopy() *PDBStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabase.
+func (in *SingleInstanceDatabase) DeepCopy() *SingleInstanceDatabase {
 	if in == nil {
 		return nil
 	}
-	out := new(PDBStatus)
+	out := new(SingleInstanceDatabase)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SingleInstanceDatabase) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDBTLSCAT) DeepCopyInto(out *PDBTLSCAT) {
+func (in *SingleInstanceDatabaseAdminPassword) DeepCopyInto(out *SingleInstanceDatabaseAdminPassword) {
 	*out = *in
-	out.Secret = in.Secret
+	if in.KeepSecret != nil {
+		in, out := &in.KeepSecret, &out.KeepSecret
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBTLSCAT.
-func (in *PDBTLSCAT) DeepCopy() *PDBTLSCAT {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseAdminPassword.
+func (in *SingleInstanceDatabaseAdminPassword) DeepCopy() *SingleInstanceDatabaseAdminPassword {
 	if in == nil {
 		return nil
 	}
-	out := new(PDBTLSCAT)
+	out := new(SingleInstanceDatabaseAdminPassword)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDBTLSCRT) DeepCopyInto(out *PDBTLSCRT) {
+func (in *SingleInstanceDatabaseAlertLog) DeepCopyInto(out *SingleInstanceDatabaseAlertLog) {
 	*out = *in
-	out.Secret = in.Secret
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBTLSCRT.
-func (in *PDBTLSCRT) DeepCopy() *PDBTLSCRT {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseAlertLog.
+func (in *SingleInstanceDatabaseAlertLog) DeepCopy() *SingleInstanceDatabaseAlertLog {
 	if in == nil {
 		return nil
 	}
-	out := new(PDBTLSCRT)
+	out := new(SingleInstanceDatabaseAlertLog)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PDBTLSKEY) DeepCopyInto(out *PDBTLSKEY) {
+func (in *SingleInstanceDatabaseBlockingSession) DeepCopyInto(out *SingleInstanceDatabaseBlockingSession) {
 	*out = *in
-	out.Secret = in.Secret
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDBTLSKEY.
-func (in *PDBTLSKEY) DeepCopy() *PDBTLSKEY {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseBlockingSession.
+func (in *SingleInstanceDatabaseBlockingSession) DeepCopy() *SingleInstanceDatabaseBlockingSession {
 	if in == nil {
 		return nil
 	}
-	out := new(PDBTLSKEY)
+	out := new(SingleInstanceDatabaseBlockingSession)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PITSpec) DeepCopyInto(out *PITSpec) {
+func (in *SingleInstanceDatabaseConnectionManager) DeepCopyInto(out *SingleInstanceDatabaseConnectionManager) {
 	*out = *in
-	if in.Timestamp != nil {
-		in, out := &in.Timestamp, &out.Timestamp
-		*out = new(string)
-		**out = **in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PITSpec.
-func (in *PITSpec) DeepCopy() *PITSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseConnectionManager.
+func (in *SingleInstanceDatabaseConnectionManager) DeepCopy() *SingleInstanceDatabaseConnectionManager {
 	if in == nil {
 		return nil
 	}
-	out := new(PITSpec)
+	out := new(SingleInstanceDatabaseConnectionManager)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordSpec) DeepCopyInto(out *PasswordSpec) {
+func (in *SingleInstanceDatabaseConnectionManagerStatus) DeepCopyInto(out *SingleInstanceDatabaseConnectionManagerStatus) {
 	*out = *in
-	in.K8sSecret.DeepCopyInto(&out.K8sSecret)
-	in.OCISecret.DeepCopyInto(&out.OCISecret)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordSpec.
-func (in *PasswordSpec) DeepCopy() *PasswordSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseConnectionManagerStatus.
+func (in *SingleInstanceDatabaseConnectionManagerStatus) DeepCopy() *SingleInstanceDatabaseConnectionManagerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordSpec)
+	out := new(SingleInstanceDatabaseConnectionManagerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortMapping) DeepCopyInto(out *PortMapping) {
+func (in *SingleInstanceDatabaseConsumerRef) DeepCopyInto(out *SingleInstanceDatabaseConsumerRef) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortMapping.
-func (in *PortMapping) DeepCopy() *PortMapping {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseConsumerRef.
+func (in *SingleInstanceDatabaseConsumerRef) DeepCopy() *SingleInstanceDatabaseConsumerRef {
 	if in == nil {
 		return nil
 	}
-	out := new(PortMapping)
+	out := new(SingleInstanceDatabaseConsumerRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
+func (in *SingleInstanceDatabaseHook) DeepCopyInto(out *SingleInstanceDatabaseHook) {
 	*out = *in
-	if in.SubnetOCID != nil {
-		in, out := &in.SubnetOCID, &out.SubnetOCID
-		*out = new(string)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseHook.
+func (in *SingleInstanceDatabaseHook) DeepCopy() *SingleInstanceDatabaseHook {
+	if in == nil {
+		return nil
+	}
+	out := new(SingleInstanceDatabaseHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingleInstanceDatabaseHooks) DeepCopyInto(out *SingleInstanceDatabaseHooks) {
+	*out = *in
+	if in.PostProvision != nil {
+		in, out := &in.PostProvision, &out.PostProvision
+		*out = new(SingleInstanceDatabaseHook)
 		**out = **in
 	}
-	if in.NsgOCIDs != nil {
-		in, out := &in.NsgOCIDs, &out.NsgOCIDs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.PreDelete != nil {
+		in, out := &in.PreDelete, &out.PreDelete
+		*out = new(SingleInstanceDatabaseHook)
+		**out = **in
 	}
-	if in.HostnamePrefix != nil {
-		in, out := &in.HostnamePrefix, &out.HostnamePrefix
-		*out = new(string)
+	if in.PostBackup != nil {
+		in, out := &in.PostBackup, &out.PostBackup
+		*out = new(SingleInstanceDatabaseHook)
+		**out = **in
+	}
+	if in.PostFailover != nil {
+		in, out := &in.PostFailover, &out.PostFailover
+		*out = new(SingleInstanceDatabaseHook)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateEndpointSpec.
-func (in *PrivateEndpointSpec) DeepCopy() *PrivateEndpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseHooks.
+func (in *SingleInstanceDatabaseHooks) DeepCopy() *SingleInstanceDatabaseHooks {
 	if in == nil {
 		return nil
 	}
-	out := new(PrivateEndpointSpec)
+	out := new(SingleInstanceDatabaseHooks)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ShardSpec) DeepCopyInto(out *ShardSpec) {
+func (in *SingleInstanceDatabaseImage) DeepCopyInto(out *SingleInstanceDatabaseImage) {
 	*out = *in
-	if in.EnvVars != nil {
-		in, out := &in.EnvVars, &out.EnvVars
-		*out = make([]EnvironmentVariable, len(*in))
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.PvAnnotations != nil {
-		in, out := &in.PvAnnotations, &out.PvAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.PvMatchLabels != nil {
-		in, out := &in.PvMatchLabels, &out.PvMatchLabels
+	if in.ArchitectureImages != nil {
+		in, out := &in.ArchitectureImages, &out.ArchitectureImages
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.ImagePulllPolicy != nil {
-		in, out := &in.ImagePulllPolicy, &out.ImagePulllPolicy
-		*out = new(corev1.PullPolicy)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseImage.
+func (in *SingleInstanceDatabaseImage) DeepCopy() *SingleInstanceDatabaseImage {
+	if in == nil {
+		return nil
 	}
+	out := new(SingleInstanceDatabaseImage)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardSpec.
-func (in *ShardSpec) DeepCopy() *ShardSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingleInstanceDatabaseInitParams) DeepCopyInto(out *SingleInstanceDatabaseInitParams) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseInitParams.
+func (in *SingleInstanceDatabaseInitParams) DeepCopy() *SingleInstanceDatabaseInitParams {
 	if in == nil {
 		return nil
 	}
-	out := new(ShardSpec)
+	out := new(SingleInstanceDatabaseInitParams)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ShardingDatabase) DeepCopyInto(out *ShardingDatabase) {
+func (in *SingleInstanceDatabaseList) DeepCopyInto(out *SingleInstanceDatabaseList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SingleInstanceDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingDatabase.
-func (in *ShardingDatabase) DeepCopy() *ShardingDatabase {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseList.
+func (in *SingleInstanceDatabaseList) DeepCopy() *SingleInstanceDatabaseList {
 	if in == nil {
 		return nil
 	}
-	out := new(ShardingDatabase)
+	out := new(SingleInstanceDatabaseList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ShardingDatabase) DeepCopyObject() runtime.Object {
+func (in *SingleInstanceDatabaseList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2106,235 +3330,251 @@ func (in *ShardingDatabase) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ShardingDatabaseList) DeepCopyInto(out *ShardingDatabaseList) {
+func (in *SingleInstanceDatabaseNetworkEncryption) DeepCopyInto(out *SingleInstanceDatabaseNetworkEncryption) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ShardingDatabase, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Algorithms != nil {
+		in, out := &in.Algorithms, &out.Algorithms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChecksumAlgorithms != nil {
+		in, out := &in.ChecksumAlgorithms, &out.ChecksumAlgorithms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseNetworkEncryption.
+func (in *SingleInstanceDatabaseNetworkEncryption) DeepCopy() *SingleInstanceDatabaseNetworkEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(SingleInstanceDatabaseNetworkEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingleInstanceDatabaseObjectStorage) DeepCopyInto(out *SingleInstanceDatabaseObjectStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseObjectStorage.
+func (in *SingleInstanceDatabaseObjectStorage) DeepCopy() *SingleInstanceDatabaseObjectStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(SingleInstanceDatabaseObjectStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingleInstanceDatabasePerformanceReport) DeepCopyInto(out *SingleInstanceDatabasePerformanceReport) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabasePerformanceReport.
+func (in *SingleInstanceDatabasePerformanceReport) DeepCopy() *SingleInstanceDatabasePerformanceReport {
+	if in == nil {
+		return nil
+	}
+	out := new(SingleInstanceDatabasePerformanceReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingleInstanceDatabasePerformanceReportStatus) DeepCopyInto(out *SingleInstanceDatabasePerformanceReportStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabasePerformanceReportStatus.
+func (in *SingleInstanceDatabasePerformanceReportStatus) DeepCopy() *SingleInstanceDatabasePerformanceReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SingleInstanceDatabasePerformanceReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingleInstanceDatabasePersistence) DeepCopyInto(out *SingleInstanceDatabasePersistence) {
+	*out = *in
+	if in.DataSource != nil {
+		in, out := &in.DataSource, &out.DataSource
+		*out = new(corev1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingDatabaseList.
-func (in *ShardingDatabaseList) DeepCopy() *ShardingDatabaseList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabasePersistence.
+func (in *SingleInstanceDatabasePersistence) DeepCopy() *SingleInstanceDatabasePersistence {
 	if in == nil {
 		return nil
 	}
-	out := new(ShardingDatabaseList)
+	out := new(SingleInstanceDatabasePersistence)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ShardingDatabaseList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ShardingDatabaseSpec) DeepCopyInto(out *ShardingDatabaseSpec) {
+func (in *SingleInstanceDatabaseReplication) DeepCopyInto(out *SingleInstanceDatabaseReplication) {
 	*out = *in
-	if in.Shard != nil {
-		in, out := &in.Shard, &out.Shard
-		*out = make([]ShardSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.TransportLagSeconds != nil {
+		in, out := &in.TransportLagSeconds, &out.TransportLagSeconds
+		*out = new(int64)
+		**out = **in
 	}
-	if in.Catalog != nil {
-		in, out := &in.Catalog, &out.Catalog
-		*out = make([]CatalogSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ApplyLagSeconds != nil {
+		in, out := &in.ApplyLagSeconds, &out.ApplyLagSeconds
+		*out = new(int64)
+		**out = **in
 	}
-	if in.Gsm != nil {
-		in, out := &in.Gsm, &out.Gsm
-		*out = make([]GsmSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ApplyRateBytesPerSecond != nil {
+		in, out := &in.ApplyRateBytesPerSecond, &out.ApplyRateBytesPerSecond
+		*out = new(int64)
+		**out = **in
 	}
-	if in.PortMappings != nil {
-		in, out := &in.PortMappings, &out.PortMappings
-		*out = make([]PortMapping, len(*in))
-		copy(*out, *in)
+	if in.RedoGenerationBytesPerSecond != nil {
+		in, out := &in.RedoGenerationBytesPerSecond, &out.RedoGenerationBytesPerSecond
+		*out = new(int64)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingDatabaseSpec.
-func (in *ShardingDatabaseSpec) DeepCopy() *ShardingDatabaseSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseReplication.
+func (in *SingleInstanceDatabaseReplication) DeepCopy() *SingleInstanceDatabaseReplication {
 	if in == nil {
 		return nil
 	}
-	out := new(ShardingDatabaseSpec)
+	out := new(SingleInstanceDatabaseReplication)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ShardingDatabaseStatus) DeepCopyInto(out *ShardingDatabaseStatus) {
+func (in *SingleInstanceDatabaseReplicationMetrics) DeepCopyInto(out *SingleInstanceDatabaseReplicationMetrics) {
 	*out = *in
-	if in.Shard != nil {
-		in, out := &in.Shard, &out.Shard
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Catalog != nil {
-		in, out := &in.Catalog, &out.Catalog
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Gsm.DeepCopyInto(&out.Gsm)
-	if in.CrdStatus != nil {
-		in, out := &in.CrdStatus, &out.CrdStatus
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingDatabaseStatus.
-func (in *ShardingDatabaseStatus) DeepCopy() *ShardingDatabaseStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseReplicationMetrics.
+func (in *SingleInstanceDatabaseReplicationMetrics) DeepCopy() *SingleInstanceDatabaseReplicationMetrics {
 	if in == nil {
 		return nil
 	}
-	out := new(ShardingDatabaseStatus)
+	out := new(SingleInstanceDatabaseReplicationMetrics)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SingleInstanceDatabase) DeepCopyInto(out *SingleInstanceDatabase) {
+func (in *SingleInstanceDatabaseResourceManagerDirective) DeepCopyInto(out *SingleInstanceDatabaseResourceManagerDirective) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabase.
-func (in *SingleInstanceDatabase) DeepCopy() *SingleInstanceDatabase {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseResourceManagerDirective.
+func (in *SingleInstanceDatabaseResourceManagerDirective) DeepCopy() *SingleInstanceDatabaseResourceManagerDirective {
 	if in == nil {
 		return nil
 	}
-	out := new(SingleInstanceDatabase)
+	out := new(SingleInstanceDatabaseResourceManagerDirective)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SingleInstanceDatabase) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SingleInstanceDatabaseAdminPassword) DeepCopyInto(out *SingleInstanceDatabaseAdminPassword) {
+func (in *SingleInstanceDatabaseResourceManagerPlan) DeepCopyInto(out *SingleInstanceDatabaseResourceManagerPlan) {
 	*out = *in
-	if in.KeepSecret != nil {
-		in, out := &in.KeepSecret, &out.KeepSecret
-		*out = new(bool)
-		**out = **in
+	if in.Directives != nil {
+		in, out := &in.Directives, &out.Directives
+		*out = make([]SingleInstanceDatabaseResourceManagerDirective, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseAdminPassword.
-func (in *SingleInstanceDatabaseAdminPassword) DeepCopy() *SingleInstanceDatabaseAdminPassword {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseResourceManagerPlan.
+func (in *SingleInstanceDatabaseResourceManagerPlan) DeepCopy() *SingleInstanceDatabaseResourceManagerPlan {
 	if in == nil {
 		return nil
 	}
-	out := new(SingleInstanceDatabaseAdminPassword)
+	out := new(SingleInstanceDatabaseResourceManagerPlan)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SingleInstanceDatabaseImage) DeepCopyInto(out *SingleInstanceDatabaseImage) {
+func (in *SingleInstanceDatabaseRestart) DeepCopyInto(out *SingleInstanceDatabaseRestart) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseImage.
-func (in *SingleInstanceDatabaseImage) DeepCopy() *SingleInstanceDatabaseImage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseRestart.
+func (in *SingleInstanceDatabaseRestart) DeepCopy() *SingleInstanceDatabaseRestart {
 	if in == nil {
 		return nil
 	}
-	out := new(SingleInstanceDatabaseImage)
+	out := new(SingleInstanceDatabaseRestart)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SingleInstanceDatabaseInitParams) DeepCopyInto(out *SingleInstanceDatabaseInitParams) {
+func (in *SingleInstanceDatabaseSchedule) DeepCopyInto(out *SingleInstanceDatabaseSchedule) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseInitParams.
-func (in *SingleInstanceDatabaseInitParams) DeepCopy() *SingleInstanceDatabaseInitParams {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseSchedule.
+func (in *SingleInstanceDatabaseSchedule) DeepCopy() *SingleInstanceDatabaseSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(SingleInstanceDatabaseInitParams)
+	out := new(SingleInstanceDatabaseSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SingleInstanceDatabaseList) DeepCopyInto(out *SingleInstanceDatabaseList) {
+func (in *SingleInstanceDatabaseSessionManagement) DeepCopyInto(out *SingleInstanceDatabaseSessionManagement) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]SingleInstanceDatabase, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ProtectedUsers != nil {
+		in, out := &in.ProtectedUsers, &out.ProtectedUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseList.
-func (in *SingleInstanceDatabaseList) DeepCopy() *SingleInstanceDatabaseList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseSessionManagement.
+func (in *SingleInstanceDatabaseSessionManagement) DeepCopy() *SingleInstanceDatabaseSessionManagement {
 	if in == nil {
 		return nil
 	}
-	out := new(SingleInstanceDatabaseList)
+	out := new(SingleInstanceDatabaseSessionManagement)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SingleInstanceDatabaseList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SingleInstanceDatabasePersistence) DeepCopyInto(out *SingleInstanceDatabasePersistence) {
+func (in *SingleInstanceDatabaseShutdown) DeepCopyInto(out *SingleInstanceDatabaseShutdown) {
 	*out = *in
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DrainTimeoutSeconds != nil {
+		in, out := &in.DrainTimeoutSeconds, &out.DrainTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabasePersistence.
-func (in *SingleInstanceDatabasePersistence) DeepCopy() *SingleInstanceDatabasePersistence {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseShutdown.
+func (in *SingleInstanceDatabaseShutdown) DeepCopy() *SingleInstanceDatabaseShutdown {
 	if in == nil {
 		return nil
 	}
-	out := new(SingleInstanceDatabasePersistence)
+	out := new(SingleInstanceDatabaseShutdown)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2349,6 +3589,47 @@ func (in *SingleInstanceDatabaseSpec) DeepCopyInto(out *SingleInstanceDatabaseSp
 			(*out)[key] = val
 		}
 	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(SingleInstanceDatabaseSchedule)
+		**out = **in
+	}
+	if in.ObjectStorage != nil {
+		in, out := &in.ObjectStorage, &out.ObjectStorage
+		*out = new(SingleInstanceDatabaseObjectStorage)
+		**out = **in
+	}
+	if in.AlertLog != nil {
+		in, out := &in.AlertLog, &out.AlertLog
+		*out = new(SingleInstanceDatabaseAlertLog)
+		**out = **in
+	}
+	if in.PerformanceReport != nil {
+		in, out := &in.PerformanceReport, &out.PerformanceReport
+		*out = new(SingleInstanceDatabasePerformanceReport)
+		**out = **in
+	}
+	if in.SessionManagement != nil {
+		in, out := &in.SessionManagement, &out.SessionManagement
+		*out = new(SingleInstanceDatabaseSessionManagement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicationMetrics != nil {
+		in, out := &in.ReplicationMetrics, &out.ReplicationMetrics
+		*out = new(SingleInstanceDatabaseReplicationMetrics)
+		**out = **in
+	}
+	if in.ResourceManagerPlan != nil {
+		in, out := &in.ResourceManagerPlan, &out.ResourceManagerPlan
+		*out = new(SingleInstanceDatabaseResourceManagerPlan)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -2357,9 +3638,34 @@ func (in *SingleInstanceDatabaseSpec) DeepCopyInto(out *SingleInstanceDatabaseSp
 		}
 	}
 	in.AdminPassword.DeepCopyInto(&out.AdminPassword)
-	out.Image = in.Image
-	out.Persistence = in.Persistence
+	in.Image.DeepCopyInto(&out.Image)
+	in.Persistence.DeepCopyInto(&out.Persistence)
 	out.InitParams = in.InitParams
+	if in.TrueCache != nil {
+		in, out := &in.TrueCache, &out.TrueCache
+		*out = new(SingleInstanceDatabaseTrueCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionManager != nil {
+		in, out := &in.ConnectionManager, &out.ConnectionManager
+		*out = new(SingleInstanceDatabaseConnectionManager)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkEncryption != nil {
+		in, out := &in.NetworkEncryption, &out.NetworkEncryption
+		*out = new(SingleInstanceDatabaseNetworkEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(SingleInstanceDatabaseHooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseSpec.
@@ -2387,6 +3693,42 @@ func (in *SingleInstanceDatabaseStatus) DeepCopyInto(out *SingleInstanceDatabase
 			(*out)[key] = val
 		}
 	}
+	if in.Restart != nil {
+		in, out := &in.Restart, &out.Restart
+		*out = new(SingleInstanceDatabaseRestart)
+		**out = **in
+	}
+	if in.OrdsReferences != nil {
+		in, out := &in.OrdsReferences, &out.OrdsReferences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]SingleInstanceDatabaseConsumerRef, len(*in))
+		copy(*out, *in)
+	}
+	out.OrdsTuning = in.OrdsTuning
+	if in.PerformanceReport != nil {
+		in, out := &in.PerformanceReport, &out.PerformanceReport
+		*out = new(SingleInstanceDatabasePerformanceReportStatus)
+		**out = **in
+	}
+	if in.BlockingSessions != nil {
+		in, out := &in.BlockingSessions, &out.BlockingSessions
+		*out = make([]SingleInstanceDatabaseBlockingSession, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(SingleInstanceDatabaseReplication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceManagerPlan != nil {
+		in, out := &in.ResourceManagerPlan, &out.ResourceManagerPlan
+		*out = new(SingleInstanceDatabaseResourceManagerPlan)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -2395,7 +3737,24 @@ func (in *SingleInstanceDatabaseStatus) DeepCopyInto(out *SingleInstanceDatabase
 		}
 	}
 	out.InitParams = in.InitParams
-	out.Persistence = in.Persistence
+	in.Persistence.DeepCopyInto(&out.Persistence)
+	if in.TrueCache != nil {
+		in, out := &in.TrueCache, &out.TrueCache
+		*out = new(SingleInstanceDatabaseTrueCacheStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionManager != nil {
+		in, out := &in.ConnectionManager, &out.ConnectionManager
+		*out = new(SingleInstanceDatabaseConnectionManagerStatus)
+		**out = **in
+	}
+	if in.HookJobs != nil {
+		in, out := &in.HookJobs, &out.HookJobs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseStatus.
@@ -2408,6 +3767,68 @@ func (in *SingleInstanceDatabaseStatus) DeepCopy() *SingleInstanceDatabaseStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingleInstanceDatabaseTrueCache) DeepCopyInto(out *SingleInstanceDatabaseTrueCache) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]SingleInstanceDatabaseTrueCacheService, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseTrueCache.
+func (in *SingleInstanceDatabaseTrueCache) DeepCopy() *SingleInstanceDatabaseTrueCache {
+	if in == nil {
+		return nil
+	}
+	out := new(SingleInstanceDatabaseTrueCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingleInstanceDatabaseTrueCacheService) DeepCopyInto(out *SingleInstanceDatabaseTrueCacheService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseTrueCacheService.
+func (in *SingleInstanceDatabaseTrueCacheService) DeepCopy() *SingleInstanceDatabaseTrueCacheService {
+	if in == nil {
+		return nil
+	}
+	out := new(SingleInstanceDatabaseTrueCacheService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SingleInstanceDatabaseTrueCacheStatus) DeepCopyInto(out *SingleInstanceDatabaseTrueCacheStatus) {
+	*out = *in
+	if in.ConnectStrings != nil {
+		in, out := &in.ConnectStrings, &out.ConnectStrings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingleInstanceDatabaseTrueCacheStatus.
+func (in *SingleInstanceDatabaseTrueCacheStatus) DeepCopy() *SingleInstanceDatabaseTrueCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SingleInstanceDatabaseTrueCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceSpec) DeepCopyInto(out *SourceSpec) {
 	*out = *in
@@ -2457,6 +3878,35 @@ func (in *TDESecret) DeepCopy() *TDESecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TagPropagationSpec) DeepCopyInto(out *TagPropagationSpec) {
+	*out = *in
+	if in.FreeformTags != nil {
+		in, out := &in.FreeformTags, &out.FreeformTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DefinedTags != nil {
+		in, out := &in.DefinedTags, &out.DefinedTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagPropagationSpec.
+func (in *TagPropagationSpec) DeepCopy() *TagPropagationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TagPropagationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetSpec) DeepCopyInto(out *TargetSpec) {
 	*out = *in
@@ -0,0 +1,423 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+// kubectl-oracle is a kubectl plugin that wraps the common day-2 flows of the
+// resources managed by the Oracle Database Operator.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	databasev1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+const usage = `kubectl oracle - common operations on Oracle Database Operator resources
+
+Usage:
+  kubectl oracle connect-string (sidb|adb) NAME   Print the connect strings of a database
+  kubectl oracle sql NAME                         Open a SQL*Plus session to a SingleInstanceDatabase
+  kubectl oracle alert-log NAME [-f] [--tail N]   Print the alert log of a SingleInstanceDatabase
+  kubectl oracle sessions NAME                    Print the blocking sessions of a SingleInstanceDatabase
+  kubectl oracle ords-urls NAME                   Print the URLs of an OracleRestDataService
+  kubectl oracle backup NAME [--display-name D]   Back up an AutonomousDatabase
+  kubectl oracle restore NAME (--backup B | --timestamp T)
+                                                  Restore an AutonomousDatabase
+
+Flags:
+  -n, --namespace   Namespace of the resource (defaults to the current context)
+`
+
+var scheme = runtime.NewScheme()
+
+// stdout receives the output of the commands
+var stdout io.Writer = os.Stdout
+
+// newClient creates the client of the cluster of the current kubeconfig context
+var newClient = func() (client.Client, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(databasev1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command given by the arguments of the plugin
+func run(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return errors.New("no command given")
+	}
+
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "connect-string":
+		return connectString(args)
+	case "sql":
+		return sql(args)
+	case "alert-log":
+		return alertLog(args)
+	case "sessions":
+		return sessions(args)
+	case "ords-urls":
+		return ordsURLs(args)
+	case "backup":
+		return backup(args)
+	case "restore":
+		return restore(args)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+// command holds the flags and the client shared by all the commands.
+type command struct {
+	flags     *flag.FlagSet
+	namespace string
+	args      []string
+	client    client.Client
+}
+
+func newCommand(name string) *command {
+	c := &command{flags: flag.NewFlagSet(name, flag.ContinueOnError)}
+	c.flags.StringVar(&c.namespace, "n", "", "namespace of the resource")
+	c.flags.StringVar(&c.namespace, "namespace", "", "namespace of the resource")
+	return c
+}
+
+// parse parses the flags, which may be interleaved with the positional
+// arguments, and creates the client. nargs is the number of expected
+// positional arguments.
+func (c *command) parse(args []string, nargs int) error {
+	for {
+		if err := c.flags.Parse(args); err != nil {
+			return err
+		}
+		if c.flags.NArg() == 0 {
+			break
+		}
+		c.args = append(c.args, c.flags.Arg(0))
+		args = c.flags.Args()[1:]
+	}
+	if len(c.args) != nargs {
+		return fmt.Errorf("%s expects %d argument(s), got %d", c.flags.Name(), nargs, len(c.args))
+	}
+
+	if c.namespace == "" {
+		loader := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+		ns, _, err := loader.Namespace()
+		if err != nil {
+			return err
+		}
+		c.namespace = ns
+	}
+
+	var err error
+	c.client, err = newClient()
+	return err
+}
+
+func (c *command) get(name string, obj client.Object) error {
+	return c.client.Get(context.Background(), client.ObjectKey{Namespace: c.namespace, Name: name}, obj)
+}
+
+// readyPod returns the ready pod of a SingleInstanceDatabase. The pods are
+// found by the same "app" label that the controller sets on them.
+func (c *command) readyPod(sidb *databasev1alpha1.SingleInstanceDatabase) (*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := c.client.List(context.Background(), podList,
+		client.InNamespace(c.namespace), client.MatchingLabels{"app": sidb.Name}); err != nil {
+		return nil, err
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+				return pod, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no ready pod found for database %s (status: %s)", sidb.Name, sidb.Status.Status)
+}
+
+// kubectlExec runs a command in the database container through kubectl so
+// that terminal handling and authentication behave as in kubectl itself.
+func (c *command) kubectlExec(pod *corev1.Pod, container string, tty bool, cmd ...string) error {
+	args := []string{"exec", "-n", c.namespace, pod.Name, "-c", container}
+	if tty {
+		args = append(args, "-it")
+	}
+	args = append(append(args, "--"), cmd...)
+
+	kubectl := exec.Command("kubectl", args...)
+	kubectl.Stdin = os.Stdin
+	kubectl.Stdout = os.Stdout
+	kubectl.Stderr = os.Stderr
+	return kubectl.Run()
+}
+
+func connectString(args []string) error {
+	c := newCommand("connect-string")
+	if err := c.parse(args, 2); err != nil {
+		return err
+	}
+
+	switch kind, name := c.args[0], c.args[1]; kind {
+	case "sidb", "singleinstancedatabase":
+		sidb := &databasev1alpha1.SingleInstanceDatabase{}
+		if err := c.get(name, sidb); err != nil {
+			return err
+		}
+		printField("CDB", sidb.Status.ConnectString)
+		printField("PDB", sidb.Status.PdbConnectString)
+		printField("CDB (TCPS)", sidb.Status.TcpsConnectString)
+		printField("PDB (TCPS)", sidb.Status.TcpsPdbConnectString)
+		printField("Cluster", sidb.Status.ClusterConnectString)
+	case "adb", "autonomousdatabase":
+		adb := &databasev1alpha1.AutonomousDatabase{}
+		if err := c.get(name, adb); err != nil {
+			return err
+		}
+		for _, profile := range adb.Status.AllConnectionStrings {
+			for _, conn := range profile.ConnectionStrings {
+				printField(fmt.Sprintf("%s (%s)", conn.TNSName, profile.TLSAuthentication), conn.ConnectionString)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported kind %q, must be one of sidb, adb", kind)
+	}
+	return nil
+}
+
+func sql(args []string) error {
+	c := newCommand("sql")
+	if err := c.parse(args, 1); err != nil {
+		return err
+	}
+
+	sidb := &databasev1alpha1.SingleInstanceDatabase{}
+	if err := c.get(c.args[0], sidb); err != nil {
+		return err
+	}
+	pod, err := c.readyPod(sidb)
+	if err != nil {
+		return err
+	}
+	return c.kubectlExec(pod, sidb.Name, true, "sqlplus", "/ as sysdba")
+}
+
+func alertLog(args []string) error {
+	c := newCommand("alert-log")
+	follow := c.flags.Bool("f", false, "follow the alert log")
+	lines := c.flags.Int("tail", 100, "number of lines to print")
+	if err := c.parse(args, 1); err != nil {
+		return err
+	}
+
+	sidb := &databasev1alpha1.SingleInstanceDatabase{}
+	if err := c.get(c.args[0], sidb); err != nil {
+		return err
+	}
+	pod, err := c.readyPod(sidb)
+	if err != nil {
+		return err
+	}
+
+	// The diag directory is named after the db_unique_name, which differs
+	// between a primary and its standbys, hence the wildcard
+	sid := strings.ToUpper(sidb.Spec.Sid)
+	tail := "tail -n " + strconv.Itoa(*lines)
+	if *follow {
+		tail += " -F"
+	}
+	script := fmt.Sprintf("%s ${ORACLE_BASE}/diag/rdbms/*/%s/trace/alert_%s.log", tail, sid, sid)
+	return c.kubectlExec(pod, sidb.Name, false, "/bin/bash", "-c", script)
+}
+
+func sessions(args []string) error {
+	c := newCommand("sessions")
+	if err := c.parse(args, 1); err != nil {
+		return err
+	}
+
+	sidb := &databasev1alpha1.SingleInstanceDatabase{}
+	if err := c.get(c.args[0], sidb); err != nil {
+		return err
+	}
+	if sidb.Spec.SessionManagement == nil {
+		return fmt.Errorf("the blocking sessions of %s are not scanned, set .spec.sessionManagement", sidb.Name)
+	}
+	fmt.Fprintf(stdout, "%-16s %-20s %-8s %-10s %s\n", "SESSION", "USERNAME", "BLOCKED", "SECONDS", "KILLED")
+	for _, session := range sidb.Status.BlockingSessions {
+		fmt.Fprintf(stdout, "%-16s %-20s %-8d %-10d %t\n", session.Session, session.Username, session.BlockedSessions,
+			session.BlockedSeconds, session.Killed)
+	}
+	return nil
+}
+
+func ordsURLs(args []string) error {
+	c := newCommand("ords-urls")
+	if err := c.parse(args, 1); err != nil {
+		return err
+	}
+
+	ords := &databasev1alpha1.OracleRestDataService{}
+	if err := c.get(c.args[0], ords); err != nil {
+		return err
+	}
+	printField("Database API", ords.Status.DatabaseApiUrl)
+	printField("Database Actions", ords.Status.DatabaseActionsUrl)
+	printField("APEX", ords.Status.ApxeUrl)
+	return nil
+}
+
+func backup(args []string) error {
+	c := newCommand("backup")
+	displayName := c.flags.String("display-name", "", "display name of the backup in OCI")
+	if err := c.parse(args, 1); err != nil {
+		return err
+	}
+
+	adbName := c.args[0]
+	adb := &databasev1alpha1.AutonomousDatabase{}
+	if err := c.get(adbName, adb); err != nil {
+		return err
+	}
+
+	adbBackup := &databasev1alpha1.AutonomousDatabaseBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: adbName + "-backup-",
+			Namespace:    c.namespace,
+		},
+		Spec: databasev1alpha1.AutonomousDatabaseBackupSpec{
+			Target: databasev1alpha1.TargetSpec{
+				K8sADB: databasev1alpha1.K8sADBSpec{Name: &adbName},
+			},
+			OCIConfig: adb.Spec.OCIConfig,
+		},
+	}
+	if *displayName != "" {
+		adbBackup.Spec.DisplayName = displayName
+	}
+	if err := c.client.Create(context.Background(), adbBackup); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "autonomousdatabasebackup.database.oracle.com/%s created\n", adbBackup.Name)
+	return nil
+}
+
+func restore(args []string) error {
+	c := newCommand("restore")
+	backupName := c.flags.String("backup", "", "name of the AutonomousDatabaseBackup to restore from")
+	timestamp := c.flags.String("timestamp", "", "point in time to restore to, in the format YYYY-MM-DD HH:MM:SS GMT")
+	if err := c.parse(args, 1); err != nil {
+		return err
+	}
+	if (*backupName == "") == (*timestamp == "") {
+		return errors.New("exactly one of --backup or --timestamp must be specified")
+	}
+
+	adbName := c.args[0]
+	adb := &databasev1alpha1.AutonomousDatabase{}
+	if err := c.get(adbName, adb); err != nil {
+		return err
+	}
+
+	adbRestore := &databasev1alpha1.AutonomousDatabaseRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: adbName + "-restore-",
+			Namespace:    c.namespace,
+		},
+		Spec: databasev1alpha1.AutonomousDatabaseRestoreSpec{
+			Target: databasev1alpha1.TargetSpec{
+				K8sADB: databasev1alpha1.K8sADBSpec{Name: &adbName},
+			},
+			OCIConfig: adb.Spec.OCIConfig,
+		},
+	}
+	if *backupName != "" {
+		adbRestore.Spec.Source.K8sADBBackup.Name = backupName
+	} else {
+		adbRestore.Spec.Source.PointInTime.Timestamp = timestamp
+	}
+	if err := c.client.Create(context.Background(), adbRestore); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "autonomousdatabaserestore.database.oracle.com/%s created\n", adbRestore.Name)
+	return nil
+}
+
+func printField(name string, value string) {
+	if value != "" {
+		fmt.Fprintf(stdout, "%-20s %s\n", name+":", value)
+	}
+}
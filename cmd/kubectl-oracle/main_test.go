@@ -0,0 +1,181 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	databasev1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("kubectl-oracle", func() {
+	const namespace = "db"
+
+	var kubeClient client.Client
+	var out *bytes.Buffer
+
+	BeforeEach(func() {
+		configMap := "oci-cred"
+		kubeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&databasev1alpha1.SingleInstanceDatabase{
+				ObjectMeta: metav1.ObjectMeta{Name: "sidb", Namespace: namespace},
+				Spec: databasev1alpha1.SingleInstanceDatabaseSpec{
+					SessionManagement: &databasev1alpha1.SingleInstanceDatabaseSessionManagement{},
+				},
+				Status: databasev1alpha1.SingleInstanceDatabaseStatus{
+					ConnectString:    "10.0.0.1:1521/ORCLCDB",
+					PdbConnectString: "10.0.0.1:1521/ORCLPDB1",
+					BlockingSessions: []databasev1alpha1.SingleInstanceDatabaseBlockingSession{
+						{Session: "12,345", Username: "HR", BlockedSessions: 3, BlockedSeconds: 120, Killed: true},
+					},
+				},
+			},
+			&databasev1alpha1.AutonomousDatabase{
+				ObjectMeta: metav1.ObjectMeta{Name: "adb", Namespace: namespace},
+				Spec: databasev1alpha1.AutonomousDatabaseSpec{
+					OCIConfig: databasev1alpha1.OCIConfigSpec{ConfigMapName: &configMap},
+				},
+				Status: databasev1alpha1.AutonomousDatabaseStatus{
+					AllConnectionStrings: []databasev1alpha1.ConnectionStringProfile{{
+						TLSAuthentication: "Mutual TLS",
+						ConnectionStrings: []databasev1alpha1.ConnectionStringSpec{
+							{TNSName: "adb_high", ConnectionString: "(description=(address=(host=adb.example.com)))"},
+						},
+					}},
+				},
+			},
+			&databasev1alpha1.OracleRestDataService{
+				ObjectMeta: metav1.ObjectMeta{Name: "ords", Namespace: namespace},
+				Status: databasev1alpha1.OracleRestDataServiceStatus{
+					DatabaseApiUrl:     "https://10.0.0.2:8443/ords/orclpdb1/_/db-api/stable/",
+					DatabaseActionsUrl: "https://10.0.0.2:8443/ords/sql-developer",
+				},
+			},
+		).Build()
+		newClient = func() (client.Client, error) { return kubeClient, nil }
+		out = &bytes.Buffer{}
+		stdout = out
+	})
+
+	AfterEach(func() {
+		stdout = os.Stdout
+	})
+
+	It("Should print the connect strings of the databases", func() {
+		Expect(run([]string{"connect-string", "sidb", "sidb", "-n", namespace})).To(Succeed())
+		Expect(out.String()).To(Equal("CDB:                 10.0.0.1:1521/ORCLCDB\nPDB:                 10.0.0.1:1521/ORCLPDB1\n"))
+
+		out.Reset()
+		Expect(run([]string{"connect-string", "-n", namespace, "adb", "adb"})).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("adb_high (Mutual TLS):"))
+
+		Expect(run([]string{"connect-string", "pdb", "pdb", "-n", namespace})).To(MatchError(ContainSubstring("unsupported kind")))
+	})
+
+	It("Should print the URLs of ORDS", func() {
+		Expect(run([]string{"ords-urls", "ords", "--namespace", namespace})).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("Database API:        https://10.0.0.2:8443/ords/orclpdb1/_/db-api/stable/\n"))
+		Expect(out.String()).NotTo(ContainSubstring("APEX"))
+	})
+
+	It("Should print the blocking sessions", func() {
+		Expect(run([]string{"sessions", "sidb", "-n", namespace})).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("12,345           HR                   3        120        true"))
+	})
+
+	It("Should create a backup of an AutonomousDatabase with its OCI config", func() {
+		Expect(run([]string{"backup", "adb", "-n", namespace, "--display-name", "nightly"})).To(Succeed())
+		Expect(out.String()).To(HavePrefix("autonomousdatabasebackup.database.oracle.com/adb-backup-"))
+
+		backups := &databasev1alpha1.AutonomousDatabaseBackupList{}
+		Expect(kubeClient.List(context.Background(), backups, client.InNamespace(namespace))).To(Succeed())
+		Expect(backups.Items).To(HaveLen(1))
+		Expect(*backups.Items[0].Spec.Target.K8sADB.Name).To(Equal("adb"))
+		Expect(*backups.Items[0].Spec.DisplayName).To(Equal("nightly"))
+		Expect(*backups.Items[0].Spec.OCIConfig.ConfigMapName).To(Equal("oci-cred"))
+	})
+
+	It("Should restore an AutonomousDatabase from exactly one source", func() {
+		Expect(run([]string{"restore", "adb", "-n", namespace})).To(MatchError(ContainSubstring("exactly one of")))
+		Expect(run([]string{"restore", "adb", "-n", namespace, "--backup", "b", "--timestamp", "t"})).To(
+			MatchError(ContainSubstring("exactly one of")))
+
+		Expect(run([]string{"restore", "adb", "-n", namespace, "--timestamp", "2023-10-12 09:30:00 GMT"})).To(Succeed())
+		restores := &databasev1alpha1.AutonomousDatabaseRestoreList{}
+		Expect(kubeClient.List(context.Background(), restores, client.InNamespace(namespace))).To(Succeed())
+		Expect(restores.Items).To(HaveLen(1))
+		Expect(*restores.Items[0].Spec.Source.PointInTime.Timestamp).To(Equal("2023-10-12 09:30:00 GMT"))
+		Expect(restores.Items[0].Spec.Source.K8sADBBackup.Name).To(BeNil())
+	})
+
+	It("Should find the ready pod of a database", func() {
+		sidb := &databasev1alpha1.SingleInstanceDatabase{ObjectMeta: metav1.ObjectMeta{Name: "sidb", Namespace: namespace}}
+		c := newCommand("sql")
+		Expect(c.parse([]string{"sidb", "-n", namespace}, 1)).To(Succeed())
+		_, err := c.readyPod(sidb)
+		Expect(err).To(MatchError(ContainSubstring("no ready pod found")))
+
+		for _, name := range []string{"sidb-pending", "sidb-ready"} {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": "sidb"}}}
+			Expect(kubeClient.Create(context.Background(), pod)).To(Succeed())
+			pod.Status.Phase = corev1.PodRunning
+			if name == "sidb-ready" {
+				pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			}
+			Expect(kubeClient.Status().Update(context.Background(), pod)).To(Succeed())
+		}
+		pod, err := c.readyPod(sidb)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pod.Name).To(Equal("sidb-ready"))
+	})
+
+	It("Should reject the wrong number of arguments and the unknown commands", func() {
+		Expect(run([]string{"ords-urls", "ords", "extra", "-n", namespace})).To(MatchError(ContainSubstring("expects 1 argument(s), got 2")))
+		Expect(run([]string{"drop"})).To(MatchError(ContainSubstring("unknown command")))
+		Expect(run([]string{"sessions", "missing", "-n", namespace})).To(HaveOccurred())
+	})
+})
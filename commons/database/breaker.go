@@ -0,0 +1,151 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	utilexec "k8s.io/client-go/util/exec"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Consecutive failures of the SQL commands of a database pod after which its circuit opens
+const CircuitFailureThreshold int = 3
+
+// Time for which the SQL commands of a database pod fail immediately once its circuit is open
+const CircuitCoolDown time.Duration = 2 * time.Minute
+
+// Returned instead of executing a SQL command while the circuit of the database pod is open
+var ErrDatabaseUnreachable = errors.New("the database is unreachable, SQL commands are suspended")
+
+// Errors of SQL*Plus when the database does not accept connections
+var unreachableErrors = []string{"ORA-01034", "ORA-01089", "ORA-03113", "ORA-03114", "ORA-12528", "ORA-12537"}
+
+// Circuits of the database pods, by pod
+var circuits = struct {
+	sync.Mutex
+	pods map[types.NamespacedName]*circuit
+}{pods: make(map[types.NamespacedName]*circuit)}
+
+type circuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+// Replaced in the tests
+var now = time.Now
+
+var databaseReachable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "oracle_database_operator_database_reachable",
+	Help: "Whether the last SQL commands executed by the operator in a database pod reached the database (1) or not (0)",
+}, []string{"namespace", "pod"})
+
+func init() {
+	metrics.Registry.MustRegister(databaseReachable)
+}
+
+// Whether a command runs SQL*Plus
+func isSQLCommand(command []string) bool {
+	for _, arg := range command {
+		if strings.Contains(arg, "sqlplus") {
+			return true
+		}
+	}
+	return false
+}
+
+// Records the result of a SQL command executed in a pod. The circuit of the pod opens after consecutive
+// failures to reach the database, and a single failure after the cool-down opens it again
+func recordSQLResult(namespace string, podName string, out string, err error) {
+	failed := false
+	var exitErr utilexec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		// The exec did not complete, as opposed to SQL*Plus exiting with an error
+		failed = true
+	}
+	for _, oraError := range unreachableErrors {
+		if strings.Contains(out, oraError) {
+			failed = true
+		}
+	}
+
+	circuits.Lock()
+	defer circuits.Unlock()
+	key := types.NamespacedName{Namespace: namespace, Name: podName}
+	c, ok := circuits.pods[key]
+	if !ok {
+		c = &circuit{}
+		circuits.pods[key] = c
+	}
+	if !failed {
+		c.failures = 0
+		c.openUntil = time.Time{}
+		databaseReachable.WithLabelValues(namespace, podName).Set(1)
+		return
+	}
+	c.failures++
+	if c.failures >= CircuitFailureThreshold {
+		c.openUntil = now().Add(CircuitCoolDown)
+		databaseReachable.WithLabelValues(namespace, podName).Set(0)
+	}
+}
+
+// Time until which the SQL commands of a pod are suspended, zero when its circuit is closed
+func DatabaseUnreachableUntil(namespace string, podName string) time.Time {
+	circuits.Lock()
+	defer circuits.Unlock()
+	c, ok := circuits.pods[types.NamespacedName{Namespace: namespace, Name: podName}]
+	if !ok || !now().Before(c.openUntil) {
+		return time.Time{}
+	}
+	return c.openUntil
+}
+
+// Forgets the circuit and the metric of a deleted pod
+func ForgetDatabasePod(namespace string, podName string) {
+	circuits.Lock()
+	defer circuits.Unlock()
+	delete(circuits.pods, types.NamespacedName{Namespace: namespace, Name: podName})
+	databaseReachable.DeleteLabelValues(namespace, podName)
+}
@@ -0,0 +1,95 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+var _ = Describe("Circuit breaker", func() {
+	var current time.Time
+
+	BeforeEach(func() {
+		current = time.Date(2023, 6, 2, 19, 0, 0, 0, time.UTC)
+		now = func() time.Time { return current }
+		DeferCleanup(func() {
+			now = time.Now
+			ForgetDatabasePod("default", "sidb-abcde")
+		})
+	})
+
+	It("Should open after consecutive failures to reach the database", func() {
+		for i := 1; i < CircuitFailureThreshold; i++ {
+			recordSQLResult("default", "sidb-abcde", "ORA-01034: ORACLE not available", nil)
+			Expect(DatabaseUnreachableUntil("default", "sidb-abcde").IsZero()).To(BeTrue())
+		}
+		recordSQLResult("default", "sidb-abcde", "", errors.New("i/o timeout"))
+		Expect(DatabaseUnreachableUntil("default", "sidb-abcde")).To(Equal(current.Add(CircuitCoolDown)))
+	})
+
+	It("Should ignore SQL errors and exit codes", func() {
+		for i := 0; i < CircuitFailureThreshold; i++ {
+			recordSQLResult("default", "sidb-abcde", "ORA-00942: table or view does not exist",
+				utilexec.CodeExitError{Err: errors.New("command terminated with exit code 1"), Code: 1})
+		}
+		Expect(DatabaseUnreachableUntil("default", "sidb-abcde").IsZero()).To(BeTrue())
+	})
+
+	It("Should close after the cool-down and a success", func() {
+		for i := 0; i < CircuitFailureThreshold; i++ {
+			recordSQLResult("default", "sidb-abcde", "ORA-01034: ORACLE not available", nil)
+		}
+		current = current.Add(CircuitCoolDown)
+		Expect(DatabaseUnreachableUntil("default", "sidb-abcde").IsZero()).To(BeTrue())
+
+		// A single failure after the cool-down opens the circuit again
+		recordSQLResult("default", "sidb-abcde", "ORA-01034: ORACLE not available", nil)
+		Expect(DatabaseUnreachableUntil("default", "sidb-abcde").IsZero()).To(BeFalse())
+
+		current = current.Add(CircuitCoolDown)
+		recordSQLResult("default", "sidb-abcde", "OPEN", nil)
+		recordSQLResult("default", "sidb-abcde", "ORA-01034: ORACLE not available", nil)
+		Expect(DatabaseUnreachableUntil("default", "sidb-abcde").IsZero()).To(BeTrue())
+	})
+})
@@ -38,6 +38,8 @@
 
 package commons
 
+import "fmt"
+
 const CONTAINER_LISTENER_PORT int32 = 1521
 
 const CONTAINER_TCPS_PORT int32 = 2484
@@ -48,15 +50,145 @@ const ORACLE_GUID int64 = 54321
 
 const DBA_GUID int64 = 54322
 
+// Default SID and PDB names baked into the Express and Free edition images
+const ExpressEditionSID string = "XE"
+
+const ExpressEditionPDB string = "XEPDB1"
+
+const FreeEditionSID string = "FREE"
+
+const FreeEditionPDB string = "FREEPDB1"
+
+const DefaultSID string = "ORCLCDB"
+
+const DefaultPDB string = "ORCLPDB1"
+
+// Annotation of the pods with the image of the spec, when they run the image of the architecture of their node
+const ImageAnnotation string = "database.oracle.com/image"
+
+// Annotation of the ORDS pods with the hash of the environment of the spec they were created with
+const EnvHashAnnotation string = "database.oracle.com/env-hash"
+
+// Annotation of the ORDS pods with the version of ORDS they run, read once per pod
+const OrdsVersionAnnotation string = "database.oracle.com/ords-version"
+
+// Annotation of the pods running a critical operation, with the name of the lease of the operation
+const CriticalOperationAnnotation string = "database.oracle.com/critical-operation"
+
+// Annotation read by the cluster autoscaler, set to false to keep it from evicting a pod
+const SafeToEvictAnnotation string = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
+// Critical operations, keeping their pods from being deleted or restarted until they end
+const CriticalOperationDatapatch string = "datapatch"
+const CriticalOperationApexInstall string = "apex-install"
+
+// Annotation of a resource setting the log level of its reconciles, debug to log the commands run in its pods
+const LogLevelAnnotation string = "database.oracle.com/log-level"
+
+const LogLevelDebug string = "debug"
+
+// Annotation of a namespace overriding the quota of the operator for the databases and ORDS of the namespace
+const NamespaceQuotaAnnotation string = "database.oracle.com/quota"
+
+// Annotations of a resource of an OracleDatabaseClass: the values the class gave to the spec, as JSON, and the
+// generation of the class they come from
+const ClassDefaultsAnnotation string = "database.oracle.com/class-defaults"
+
+const ClassGenerationAnnotation string = "database.oracle.com/class-generation"
+
+// Annotation of a consumer of a database, such as an OracleRestDataService, with the UID of the database it registered
+// with. A database recreated with the same name is not the one of the annotation
+const DatabaseUIDAnnotation string = "database.oracle.com/database-uid"
+
+// Annotation requesting a one-off action from the controller, removed once the action is started
+const ActionAnnotation string = "database.oracle.com/action"
+
+// Actions of the action annotation on an OracleRestDataService
+const ActionRestartOrds string = "restart-ords"
+
+const ActionReinstallApex string = "reinstall-apex"
+
+const ActionRefreshUrls string = "refresh-urls"
+
+const ActionCancelApexInstall string = "cancel-apex-install"
+
+// Actions of the action annotation on a standby SingleInstanceDatabase of a Data Guard configuration
+const ActionConvertToSnapshotStandby string = "convert-to-snapshot-standby"
+
+const ActionConvertToPhysicalStandby string = "convert-to-physical-standby"
+
+// Action of the action annotation on a SingleInstanceDatabase restarting the database once its user sessions end
+const ActionRestartDatabase string = "restart-database"
+
+// Condition of a SingleInstanceDatabase with the outcome of the last action of its action annotation
+const ActionCompletedCondition string = "ActionCompleted"
+
+const ActionSucceededReason string = "ActionSucceeded"
+
+const ActionFailedReason string = "ActionFailed"
+
+// Phases of a restart of the database
+const RestartPhaseDraining string = "Draining"
+
+const RestartPhaseRestarting string = "Restarting"
+
+// Seconds a restart waits for the user sessions to end by default
+const DefaultRestartDrainTimeout int64 = 300
+
+// Counts the sessions of the users of the database. The sessions of the ORDS pools and of APEX, including the schemas
+// ORDS connects to as a proxy, reconnect on their own and the ORDS are restarted after the restart of the database
+var CountUserSessionsSQL = LoadSQL("count_user_sessions", "")
+
+// Converts the standby database of the pod through the broker, connected to the primary database
+const ConvertStandbyCMD string = "dgmgrl sys@${PRIMARY_SID} \"CONVERT DATABASE ${ORACLE_SID} TO %s STANDBY\" < admin.pwd"
+
+// Index of the pods in the cache of the manager by their "app" label
+const PodAppIndex string = "metadata.labels.app"
+
+// Name suffix for the True Cache pods and service of a SingleInstanceDatabase
+const TrueCacheSuffix string = "-truecache"
+
+// Name suffix for the Oracle Connection Manager deployment, config map and service of a SingleInstanceDatabase
+const ConnectionManagerSuffix string = "-cman"
+
+// Directory of the cman.ora of the Oracle Connection Manager instances, set as their TNS_ADMIN
+const ConnectionManagerConfigDir string = "/opt/oracle/cman/admin"
+
+// cman.ora of the Oracle Connection Manager instances. Clients connect with SOURCE_ROUTE=YES through the
+// instances to the database service, the only destination accepted by the rules
+const ConnectionManagerConfig string = `cman =
+  (configuration =
+    (address = (protocol = tcp)(host = 0.0.0.0)(port = ##CMAN_PORT##)##CMAN_RATE_LIMIT##)
+    (parameter_list =
+      (max_connections = ##MAX_CONNECTIONS##)
+      (idle_timeout = 0)
+      (valid_node_checking_registration = off)
+      (log_level = user)
+    )
+    (rule_list =
+      (rule = (src = *)(dst = ##DATABASE_HOST##)(srv = *)(act = accept))
+      (rule = (src = *)(dst = 127.0.0.1)(srv = cmon)(act = accept))
+    )
+  )
+`
+
+const ConnectionManagerConnectString string = "(DESCRIPTION=(SOURCE_ROUTE=YES)" +
+	"(ADDRESS=(PROTOCOL=TCP)(HOST=##CMAN_HOST##)(PORT=##CMAN_PORT##))" +
+	"(ADDRESS=(PROTOCOL=TCP)(HOST=##DATABASE_HOST##)(PORT=1521))" +
+	"(CONNECT_DATA=(SERVICE_NAME=##PDB_NAME##)))"
+
+// Oracle Database Free images are only published from 23ai onwards
+const FreeEditionMinMajorVersion int = 23
+
 const SQLPlusCLI string = "sqlplus -s / as sysdba"
 
 const NoCloneRef string = "Unavailable"
 
-const GetVersionSQL string = "SELECT VERSION_FULL FROM V\\$INSTANCE;"
+var GetVersionSQL = LoadSQL("get_version", "")
 
-const CheckModesSQL string = "SELECT 'log_mode:' || log_mode AS log_mode ,'flashback_on:' || flashback_on AS flashback_on ,'force_logging:' || force_logging AS force_logging FROM v\\$database;"
+var CheckModesSQL = LoadSQL("check_modes", "")
 
-const ListPdbSQL string = "SELECT NAME FROM V\\$PDBS;"
+var ListPdbSQL = LoadSQL("list_pdb", "")
 
 const CreateChkFileCMD string = "touch \"${ORACLE_BASE}/oradata/.${ORACLE_SID}.nochk\" && sync"
 
@@ -64,27 +196,15 @@ const RemoveChkFileCMD string = "rm -f \"${ORACLE_BASE}/oradata/.${ORACLE_SID}.n
 
 const CreateDBRecoveryDestCMD string = "mkdir -p ${ORACLE_BASE}/oradata/fast_recovery_area"
 
-const SetDBRecoveryDestSQL string = "SHOW PARAMETER db_recovery_file_dest;" +
-	"\nALTER SYSTEM SET db_recovery_file_dest_size=50G scope=both sid='*';" +
-	"\nALTER SYSTEM SET db_recovery_file_dest='${ORACLE_BASE}/oradata/fast_recovery_area' scope=both sid='*';" +
-	"\nSHOW PARAMETER db_recovery_file_dest;"
+var SetDBRecoveryDestSQL = LoadSQL("set_db_recovery_dest", "")
 
-const ForceLoggingTrueSQL string = "SELECT force_logging FROM v\\$database;" +
-	"\nALTER DATABASE FORCE LOGGING;" +
-	"\nALTER SYSTEM SWITCH LOGFILE;" +
-	"\nSELECT force_logging FROM v\\$database;"
+var ForceLoggingTrueSQL = LoadSQL("force_logging_true", "")
 
-const ForceLoggingFalseSQL string = "SELECT force_logging FROM v\\$database;" +
-	"\nALTER DATABASE NO FORCE LOGGING;" +
-	"\nSELECT force_logging FROM v\\$database;"
+var ForceLoggingFalseSQL = LoadSQL("force_logging_false", "")
 
-const FlashBackTrueSQL string = "SELECT flashback_on FROM v\\$database;" +
-	"\nALTER DATABASE FLASHBACK ON;" +
-	"\nSELECT flashback_on FROM v\\$database;"
+var FlashBackTrueSQL = LoadSQL("flash_back_true", "")
 
-const FlashBackFalseSQL string = "SELECT flashback_on FROM v\\$database;" +
-	"\nALTER DATABASE FLASHBACK OFF;" +
-	"\nSELECT flashback_on FROM v\\$database;"
+var FlashBackFalseSQL = LoadSQL("flash_back_false", "")
 
 const ArchiveLogTrueCMD string = CreateChkFileCMD + " && " +
 	"echo -e  \"SHUTDOWN IMMEDIATE; \n STARTUP MOUNT; \n ALTER DATABASE ARCHIVELOG; \n SELECT log_mode FROM v\\$database; \n ALTER DATABASE OPEN;" +
@@ -94,15 +214,7 @@ const ArchiveLogFalseCMD string = CreateChkFileCMD + " && " +
 	"echo -e  \"SHUTDOWN IMMEDIATE; \n STARTUP MOUNT; \n ALTER DATABASE NOARCHIVELOG; \n SELECT log_mode FROM v\\$database; \n ALTER DATABASE OPEN;" +
 	" \n ALTER PLUGGABLE DATABASE ALL OPEN; \n ALTER SYSTEM REGISTER;\" | %s && " + RemoveChkFileCMD
 
-const StandbyDatabasePrerequisitesSQL string = "ALTER SYSTEM SET db_create_file_dest='/opt/oracle/oradata/';" +
-	"\nALTER SYSTEM SET db_create_online_log_dest_1='/opt/oracle/oradata/';" +
-	"\nALTER SYSTEM SWITCH LOGFILE;" +
-	"\nALTER DATABASE ADD STANDBY LOGFILE THREAD 1 SIZE 200M;" +
-	"\nALTER DATABASE ADD STANDBY LOGFILE THREAD 1 SIZE 200M;" +
-	"\nALTER DATABASE ADD STANDBY LOGFILE THREAD 1 SIZE 200M;" +
-	"\nALTER DATABASE ADD STANDBY LOGFILE THREAD 1 SIZE 200M;" +
-	"\nALTER SYSTEM SET STANDBY_FILE_MANAGEMENT=AUTO;" +
-	"\nALTER SYSTEM SET dg_broker_start=TRUE;"
+var StandbyDatabasePrerequisitesSQL = LoadSQL("standby_database_prerequisites", "")
 
 const GetDBOpenMode string = "select open_mode from v\\$database;"
 
@@ -221,20 +333,42 @@ const DataguardBrokerGetDatabaseCMD string = "SELECT DATABASE || ':' || DATAGUAR
 
 const EnableFSFOCMD string = "ENABLE FAST_START FAILOVER;"
 
+const DisableFSFOCMD string = "DISABLE FAST_START FAILOVER;"
+
+const ShowFSFOCMD string = "SHOW FAST_START FAILOVER;"
+
+const SetFSFOThresholdCMD string = "EDIT CONFIGURATION SET PROPERTY FastStartFailoverThreshold=%d;"
+
+const SetFSFOLagLimitCMD string = "EDIT CONFIGURATION SET PROPERTY FastStartFailoverLagLimit=%d;"
+
+// Runs the observer in the foreground against the primary, reading the SYS password from the mounted secret
+const StartObserverCMD string = "dgmgrl -silent sys@%s \"START OBSERVER %s\" < /run/secrets/oracle_pwd"
+
 const RemoveDataguardConfiguration string = "DISABLE FAST_START FAILOVER;" +
 	"\nEDIT CONFIGURATION SET PROTECTION MODE AS MAXPERFORMANCE;" +
 	"\nREMOVE CONFIGURATION;"
 
 const GetDatabaseRoleCMD string = "SELECT DATABASE_ROLE FROM V\\$DATABASE; "
 
-const RunDatapatchCMD string = " ( while true; do  sleep 60; echo \"Installing patches...\" ; done ) & if ! $ORACLE_HOME/OPatch/datapatch -skip_upgrade_check;" +
+const RunDatapatchCMD string = " ( while true; do  sleep 60; echo \"Installing patches...\" ; done ) & if ! $ORACLE_HOME/OPatch/datapatch -skip_upgrade_check -verbose;" +
 	" then echo \"Datapatch execution has failed.\" ; else echo \"DONE: Datapatch execution.\" ; fi ; kill -9 $!;"
 
-const GetSqlpatchDescriptionSQL string = "select TARGET_VERSION || ' (' || ACTION || ' of ' || PATCH_ID || ')' as patchinfo from dba_registry_sqlpatch order by action_time desc;"
+var GetSqlpatchDescriptionSQL = LoadSQL("get_sqlpatch_description", "")
+
+// sqlnet.ora of the database on its persistent volume, and the markers of the native network encryption settings
+// written to it by the operator
+const DatabaseSqlnetOra string = "/opt/oracle/oradata/dbconfig/${ORACLE_SID^^}/sqlnet.ora"
+
+const NetworkEncryptionBegin string = "# BEGIN OPERATOR NETWORK ENCRYPTION"
+
+const NetworkEncryptionEnd string = "# END OPERATOR NETWORK ENCRYPTION"
+
+// Components of the registries of the CDB and its open PDBs left in another state than valid by datapatch
+var GetInvalidComponentsSQL = LoadSQL("get_invalid_components", "")
 
-const GetSqlpatchStatusSQL string = "select status from dba_registry_sqlpatch order by action_time desc;"
+var GetSqlpatchStatusSQL = LoadSQL("get_sqlpatch_status", "")
 
-const GetSqlpatchVersionSQL string = "select SOURCE_VERSION || ':' || TARGET_VERSION as versions from dba_registry_sqlpatch order by action_time desc;"
+var GetSqlpatchVersionSQL = LoadSQL("get_sqlpatch_version", "")
 
 const GetCheckpointFileCMD string = "find ${ORACLE_BASE}/oradata -maxdepth 1 -name .${ORACLE_SID}${CHECKPOINT_FILE_EXTN}"
 
@@ -244,34 +378,31 @@ const GetStandardEditionFileCMD string = "if [ -f ${ORACLE_BASE}/oradata/dbconfi
 
 const CreateSIDlinkCMD string = "cd ${ORACLE_BASE}/oradata && test ! -e $ORACLE_SID && ln -s $(basename $PRIMARY_DB_CONN_STR)/$ORACLE_SID"
 
-const GetPdbsSQL string = "select name from v\\$pdbs where name not like 'PDB\\$SEED' and open_mode like 'READ WRITE';"
+var GetPdbsSQL = LoadSQL("get_pdbs", "")
 
 const OpenPDBSeed = "alter pluggable database pdb\\$seed close;" +
 	"\nalter pluggable database pdb\\$seed open read only;"
 
-const SetAdminUsersSQL string = "CREATE USER C##DBAPI_CDB_ADMIN IDENTIFIED BY \\\"%[1]s\\\" ACCOUNT UNLOCK CONTAINER=ALL;" +
-	"\nalter user C##DBAPI_CDB_ADMIN identified by \\\"%[1]s\\\" account unlock;" +
-	"\nGRANT DBA TO C##DBAPI_CDB_ADMIN CONTAINER = ALL;" +
-	"\nGRANT PDB_DBA  TO C##DBAPI_CDB_ADMIN CONTAINER = ALL;" +
-	"\nCREATE USER C##_DBAPI_PDB_ADMIN IDENTIFIED BY \\\"%[1]s\\\" CONTAINER=ALL ACCOUNT UNLOCK;" +
-	"\nalter user C##_DBAPI_PDB_ADMIN identified by \\\"%[1]s\\\" account unlock;" +
-	"\nGRANT DBA TO C##_DBAPI_PDB_ADMIN CONTAINER = ALL;" +
-	"\nalter pluggable database pdb\\$seed close;" +
-	"\nalter pluggable database pdb\\$seed open read write force;"
-
-const GetUserORDSSchemaStatusSQL string = "alter session set container=%[2]s;" +
-	"\nselect 'STATUS:'||status as status from ords_metadata.ords_schemas where upper(parsing_schema) = upper('%[1]s');"
-
-const CreateORDSSchemaSQL = "\nALTER SESSION SET CONTAINER=%[3]s;" +
-	"\nCREATE USER %[1]s IDENTIFIED BY \\\"%[2]s\\\";" +
-	"\nGRANT CONNECT, RESOURCE, DBA, PDB_DBA TO %[1]s;"
-
-const EnableORDSSchemaSQL string = "\nALTER SESSION SET CONTAINER=%[4]s;" +
-	"\nGRANT INHERIT PRIVILEGES ON USER SYS TO ORDS_METADATA;" +
-	"\nexec ORDS.enable_schema(p_enabled => %[2]s ,p_schema => '%[1]s',p_url_mapping_type => 'BASE_PATH',p_url_mapping_pattern => '%[3]s',p_auto_rest_auth => FALSE);"
-
-	// SetupORDSCMD is run only for the FIRST TIME, ORDS is installed. Once ORDS is installed, we delete the pod that ran SetupORDSCMD and create new ones.
-	// Newly created pod doesn't run this SetupORDSCMD.
+var GetUserORDSSchemaStatusSQL = LoadSQL("get_user_ords_schema_status", "")
+
+var CreateORDSSchemaSQL = LoadSQL("create_ords_schema", "")
+
+var EnableORDSSchemaSQL = LoadSQL("enable_ords_schema", "")
+
+// PL/SQL defining the privileges of an OAuth client, creating the client or updating the existing one, and printing
+// its credentials
+var DefineOAuthClientSQL = LoadSQL("define_oauth_client", "")
+
+// Creates the role of a privilege, protects its URL patterns, filled in l_patterns, and grants the role to the client
+var DefineOAuthPrivilegeSQL = LoadSQL("define_oauth_privilege", "")
+
+var GrantOAuthClientRoleSQL = LoadSQL("grant_oauth_client_role", "")
+
+// The privileges of a deleted client are left, as they may protect the endpoints for other clients
+var DeleteOAuthClientSQL = LoadSQL("delete_oauth_client", "")
+
+// SetupORDSCMD is run only for the FIRST TIME, ORDS is installed. Once ORDS is installed, we delete the pod that ran SetupORDSCMD and create new ones.
+// Newly created pod doesn't run this SetupORDSCMD.
 const SetupORDSCMD string = "$JAVA_HOME/bin/java -jar $ORDS_HOME/ords.war set-property database.api.enabled true" +
 	"\n$JAVA_HOME/bin/java -jar $ORDS_HOME/ords.war set-property jdbc.auth.enabled true" +
 	"\n$JAVA_HOME/bin/java -jar $ORDS_HOME/ords.war set-property database.api.management.services.disabled false" +
@@ -314,7 +445,7 @@ const SetupORDSCMD string = "$JAVA_HOME/bin/java -jar $ORDS_HOME/ords.war set-pr
 	"\nsed -i 's,jetty.port=8888,jetty.secure.port=8443\\nssl.cert=\\nssl.cert.key=\\nssl.host=%[3]s,g' /opt/oracle/ords/config/ords/standalone/standalone.properties " +
 	"\nsed -i 's,standalone.static.path=/opt/oracle/ords/doc_root/i,standalone.static.path=/opt/oracle/ords/config/apex/images,g' /opt/oracle/ords/config/ords/standalone/standalone.properties"
 
-const InitORDSCMD string = "if [ -f $ORDS_HOME/config/ords/defaults.xml ]; then exit ;fi;" +
+const initORDSSetupCMD string = "if [ -f $ORDS_HOME/config/ords/defaults.xml ]; then exit ;fi;" +
 	"\nexport APEXI=$ORDS_HOME/config/apex/images" +
 	"\n$ORDS_HOME/runOrds.sh --setuponly" +
 	"\n$JAVA_HOME/bin/java -jar $ORDS_HOME/ords.war set-property database.api.enabled true" +
@@ -331,32 +462,52 @@ const InitORDSCMD string = "if [ -f $ORDS_HOME/config/ords/defaults.xml ]; then
 	"\n$JAVA_HOME/bin/java -jar $ORDS_HOME/ords.war set-property security.verifySSL false" +
 	"\n$JAVA_HOME/bin/java -jar $ORDS_HOME/ords.war set-property jdbc.maxRows 1000" +
 	"\nmkdir -p $ORDS_HOME/config/ords/conf" +
-	"\numask 177" +
-	"\necho db.cdb.adminUser=C##DBAPI_CDB_ADMIN AS SYSDBA > cdbAdmin.properties" +
+	"\numask 177"
+
+// Admin users of the PDB lifecycle management Database API, only available when ORDS is installed at CDB level
+const initORDSAdminUsersCMD string = "\necho db.cdb.adminUser=C##DBAPI_CDB_ADMIN AS SYSDBA > cdbAdmin.properties" +
 	"\necho db.cdb.adminUser.password=\"${ORACLE_PWD}\" >> cdbAdmin.properties" +
 	"\n$JAVA_HOME/bin/java -jar $ORDS_HOME/ords.war set-properties --conf apex_pu cdbAdmin.properties" +
 	"\nrm -f cdbAdmin.properties" +
 	"\necho db.adminUser=C##_DBAPI_PDB_ADMIN > pdbAdmin.properties" +
 	"\necho db.adminUser.password=\"${ORACLE_PWD}\">> pdbAdmin.properties" +
 	"\n$JAVA_HOME/bin/java -jar $ORDS_HOME/ords.war set-properties --conf apex_pu pdbAdmin.properties" +
-	"\nrm -f pdbAdmin.properties" +
-	"\necho -e \"${ORDS_PWD}\n${ORDS_PWD}\" > sqladmin.passwd" +
+	"\nrm -f pdbAdmin.properties"
+
+const initORDSSqlAdminCMD string = "\necho -e \"${ORDS_PWD}\n${ORDS_PWD}\" > sqladmin.passwd" +
 	"\n$JAVA_HOME/bin/java -jar $ORDS_HOME/ords.war user ${ORDS_USER} \"SQL Administrator , System Administrator , SQL Developer , oracle.dbtools.autorest.any.schema \" < sqladmin.passwd" +
 	"\nrm -f sqladmin.passwd" +
 	"\numask 022"
 
-const GetSessionInfoSQL string = "select s.sid || ',' || s.serial# as Info FROM v\\$session s, v\\$process p " +
-	"WHERE (s.username = 'ORDS_PUBLIC_USER' or " +
-	"s.username = 'APEX_PUBLIC_USER' or " +
-	"s.username = 'APEX_REST_PUBLIC_USER' or " +
-	"s.username = 'APEX_LISTENER' or " +
-	"s.username = 'C##_DBAPI_CDB_ADMIN' or " +
-	"s.username = 'C##_DBAPI_PDB_ADMIN' ) AND p.addr(+) = s.paddr;"
+const InitORDSCMD string = initORDSSetupCMD + initORDSAdminUsersCMD + initORDSSqlAdminCMD
+
+// Installs ORDS_METADATA in a single PDB, without common users
+const InitORDSPdbCMD string = initORDSSetupCMD + initORDSSqlAdminCMD
+
+const OrdsInstallScopeCDB string = "CDB"
+const OrdsInstallScopePDB string = "PDB"
+
+var GetSessionInfoSQL = LoadSQL("get_session_info", "")
 
-const KillSessionSQL string = "alter system kill session '%[1]s';"
+var GetOpenPdbsSQL = LoadSQL("get_open_pdbs", "")
 
-const DropAdminUsersSQL string = "drop user C##DBAPI_CDB_ADMIN cascade;" +
-	"\ndrop user C##_DBAPI_PDB_ADMIN cascade;"
+// Prints the open mode of a PDB as "OPEN_MODE:READ WRITE"
+var GetPdbOpenModeSQL = LoadSQL("get_pdb_open_mode", "")
+
+var KillSessionSQL = LoadSQL("kill_session", "")
+
+// Common users created for the Database API of ORDS installed at CDB level
+const OrdsCdbAdminUser string = "C##DBAPI_CDB_ADMIN"
+const OrdsPdbAdminUser string = "C##_DBAPI_PDB_ADMIN"
+
+var GetOrdsCommonUsersSQL = fmt.Sprintf(LoadSQL("get_ords_common_users", ""), OrdsCdbAdminUser, OrdsPdbAdminUser)
+
+// DBMS_CLOUD credential of the OCI API signing key, replaced if it exists
+var CreateCloudCredentialSQL = LoadSQL("create_cloud_credential", "")
+
+var DropCloudCredentialSQL = LoadSQL("drop_cloud_credential", "")
+
+var DropAdminUsersSQL = LoadSQL("drop_admin_users", "")
 
 const UninstallORDSCMD string = "\numask 177" +
 	"\necho -e \"1\n${ORACLE_HOST}\n${ORACLE_PORT}\n1\n${ORACLE_SERVICE}\nsys\n%[1]s\n%[1]s\n1\" > ords.cred" +
@@ -364,11 +515,114 @@ const UninstallORDSCMD string = "\numask 177" +
 	"\nrm -f ords.cred" +
 	"\numask 022" +
 	"\nrm -f /opt/oracle/ords/config/ords/defaults.xml" +
+	"\nrm -f /opt/oracle/ords/config/ords/defaults.xml.orig" +
 	"\nrm -f /opt/oracle/ords/config/ords/credentials" +
 	"\nrm -rf /opt/oracle/ords/config/ords/conf" +
 	"\nrm -rf /opt/oracle/ords/config/ords/standalone" +
 	"\nrm -rf /opt/oracle/ords/config/ords/apex"
 
+const UninstallJobSuffix string = "-uninstall"
+
+// ConfigMap of an ORDS keeping the end of its installation logs
+const InstallLogsSuffix string = "-install-logs"
+
+// Secret of the ORDS properties rendered from spec.settings, the settings file of the configuration directory they
+// are merged into, and the directory the Secret is mounted on in the init container
+const OrdsSettingsSecretSuffix string = "-settings"
+
+const OrdsSettingsFile string = "defaults.xml"
+
+const OrdsSettingsMountDir string = "/run/secrets/ords-settings"
+
+// Starts from the settings file of the volume, kept in defaults.xml.orig while properties are merged into it, or puts
+// it back once there are no more properties to merge
+const ResetOrdsSettingsCMD string = "f=/opt/oracle/ords/config/ords/defaults.xml; s=" + OrdsSettingsMountDir + "/" +
+	OrdsSettingsFile + "; if [ -f $s ] && [ -f $f ]; then if [ -f $f.orig ]; then cp $f.orig $f.tmp && mv $f.tmp $f;" +
+	" else cp $f $f.orig; fi; elif [ -f $f.orig ]; then mv $f.orig $f; fi"
+
+// Merges the properties of spec.settings into the settings file, replacing the entries of the same keys
+const MergeOrdsSettingsCMD string = "f=/opt/oracle/ords/config/ords/defaults.xml; s=" + OrdsSettingsMountDir + "/" +
+	OrdsSettingsFile + "; if [ -f $s ] && [ -f $f ]; then awk '" +
+	`function key(l) { if (match(l, /<entry key="[^"]*"/)) return substr(l, RSTART + 12, RLENGTH - 13); return "" }` +
+	` NR == FNR { if (key($0) != "") { keys[key($0)] = 1; entries = entries $0 "\n" } next }` +
+	` /<\/properties>/ { printf "%s", entries; print; next }` +
+	` !(key($0) in keys) { print }` +
+	"' $s $f > $f.tmp && mv $f.tmp $f; fi"
+
+// Directory of the installation logs in the ORDS configuration volume
+const OrdsInstallLogDir string = "/opt/oracle/ords/config/ords/install-logs"
+
+// Number of the installation logs of each kind kept in OrdsInstallLogDir, and of the lines kept in the ConfigMap
+const InstallLogRotation int = 5
+
+const InstallLogTailLines int = 200
+
+// Runs a command with its output saved in a new log file of OrdsInstallLogDir, removes the oldest log files of the
+// same name, and prints the Oracle errors and the end of the log. The exit code is the one of the command.
+const SaveInstallLogCMD string = "mkdir -p " + OrdsInstallLogDir + "; log=" + OrdsInstallLogDir + "/%[1]s-$(date +%%Y%%m%%d%%H%%M%%S).log;" +
+	" ( %[2]s ) > $log 2>&1; rc=$?;" +
+	" ls -1t " + OrdsInstallLogDir + "/%[1]s-*.log | tail -n +%[3]d | xargs -r rm -f;" +
+	" echo \"Log saved in $log\"; grep -E 'ORA-[0-9]+|SP2-[0-9]+' $log | head -n 20; echo ...; tail -n %[4]d $log; exit $rc"
+
+// Runs the base64 encoded command %[2]s detached from the exec, in its own process group, with its pid, output and
+// exit code saved in the %[1]s.pid, %[1]s.out and %[1]s.exit files of OrdsInstallLogDir
+const StartBackgroundCMD string = "mkdir -p " + OrdsInstallLogDir + "; cd " + OrdsInstallLogDir + " && rm -f %[1]s.exit %[1]s.out;" +
+	" setsid nohup bash -c \"( $(echo %[2]s | base64 -d) ); echo \\$? > " + OrdsInstallLogDir + "/%[1]s.exit\"" +
+	" > %[1]s.out 2>&1 < /dev/null & echo $! > %[1]s.pid; echo STARTED:$!"
+
+// Reports EXIT:<code> followed by the output of a finished background command, RUNNING, or LOST if its process is
+// gone without an exit code, such as after a restart of the container
+const PollBackgroundCMD string = "cd " + OrdsInstallLogDir + " 2>/dev/null;" +
+	" if [ -f %[1]s.exit ]; then echo EXIT:$(cat %[1]s.exit); cat %[1]s.out;" +
+	" elif [ -f %[1]s.pid ] && kill -0 $(cat %[1]s.pid) 2>/dev/null; then echo RUNNING; else echo LOST; fi"
+
+// Kills the process group of a background command
+const StopBackgroundCMD string = "cd " + OrdsInstallLogDir + " 2>/dev/null;" +
+	" if [ -f %[1]s.pid ]; then kill -TERM -- -$(cat %[1]s.pid) 2>/dev/null; rm -f %[1]s.pid; fi; echo STOPPED"
+
+// Phases of an APEX installation run in the background
+const ApexInstallRunning string = "Running"
+
+const ApexInstallCancelled string = "Cancelled"
+
+const ApexInstallTimedOut string = "TimedOut"
+
+// Fails the uninstall job if the uninstall script reports an error
+const UninstallORDSJobCMD string = "(%[1]s\n) 2>&1 | tee /tmp/uninstall.log; ! grep -qi error /tmp/uninstall.log"
+
+// Location of the ORDS_METADATA backups, within the ORDS config directory on the database volume
+const ORDSMetadataBackupDir string = "/opt/oracle/oradata/${ORACLE_SID^^}_ORDS/backup"
+
+// The PDB, %[1]s, is left to the commands running the SQL
+var ORDSMetadataBackupDirectorySQL = fmt.Sprintf(LoadSQL("ords_metadata_backup_directory", ""), "%[1]s", ORDSMetadataBackupDir)
+
+var ExportORDSMetadataCMD string = "mkdir -p " + ORDSMetadataBackupDir + " && " +
+	"echo -e \"" + ORDSMetadataBackupDirectorySQL + "\" | " + SQLPlusCLI + " && " +
+	"expdp '\"sys/%[2]s@localhost:1521/%[1]s as sysdba\"' schemas=ORDS_METADATA directory=ORDS_BACKUP_DIR " +
+	"dumpfile=%[3]s.dmp logfile=%[3]s_exp.log reuse_dumpfiles=y"
+
+// Schema the backup is first imported into, so that a dump that fails to import leaves ORDS_METADATA in place
+const ORDSMetadataStagingSchema string = "ORDS_METADATA_STAGE"
+
+// Printed by ImportORDSMetadataCMD once the backup has replaced ORDS_METADATA
+const ORDSMetadataRestoredMsg string = "ORDS_METADATA restored"
+
+// Imports the backup into the staging schema, and only when that completes without errors replaces ORDS_METADATA with it
+var ImportORDSMetadataCMD string = "if [ ! -f " + ORDSMetadataBackupDir + "/%[3]s.dmp ]; then echo \"ERROR: backup %[3]s not found\"; exit 1; fi; " +
+	"echo -e \"" + ORDSMetadataBackupDirectorySQL + "\nDROP USER " + ORDSMetadataStagingSchema + " CASCADE;\" | " + SQLPlusCLI + "; " +
+	"impdp '\"sys/%[2]s@localhost:1521/%[1]s as sysdba\"' schemas=ORDS_METADATA remap_schema=ORDS_METADATA:" + ORDSMetadataStagingSchema + " " +
+	"directory=ORDS_BACKUP_DIR dumpfile=%[3]s.dmp logfile=%[3]s_stage.log > /tmp/%[3]s_stage.out 2>&1; cat /tmp/%[3]s_stage.out; " +
+	"if ! grep -q 'successfully completed' /tmp/%[3]s_stage.out; then echo \"ERROR: backup %[3]s does not import cleanly, ORDS_METADATA is kept\"; exit 1; fi; " +
+	"echo -e \"" + ORDSMetadataBackupDirectorySQL + "\nDROP USER " + ORDSMetadataStagingSchema + " CASCADE;\nDROP USER ORDS_METADATA CASCADE;\" | " + SQLPlusCLI + " && " +
+	"impdp '\"sys/%[2]s@localhost:1521/%[1]s as sysdba\"' schemas=ORDS_METADATA directory=ORDS_BACKUP_DIR " +
+	"dumpfile=%[3]s.dmp logfile=%[3]s_imp.log > /tmp/%[3]s_imp.out 2>&1; cat /tmp/%[3]s_imp.out; " +
+	"grep -q 'successfully completed' /tmp/%[3]s_imp.out && echo \"" + ORDSMetadataRestoredMsg + "\""
+
+// Annotation read by external-dns to publish a DNS record for a service
+const ExternalDNSHostnameAnnotation string = "external-dns.alpha.kubernetes.io/hostname"
+
+const GetORDSHomeOwnerCMD string = "stat -c %u ${ORDS_HOME}"
+
 const GetORDSStatus string = "curl -sSkv -k -X GET https://localhost:8443/ords/_/db-api/stable/metadata-catalog/"
 
 const ValidateAdminPassword string = "conn sys/\\\"%s\\\"@${ORACLE_SID} as sysdba\nshow user"
@@ -389,6 +643,214 @@ const ReconcileBlocked string = "ReconcileBlocked"
 
 const ReconcileBlockedReason string = "LastReconcileCycleBlocked"
 
+const ReadyCondition string = "Ready"
+
+const ProgressingCondition string = "Progressing"
+
+const ReadyReason string = "Healthy"
+
+const NotReadyReason string = "NotHealthy"
+
+const ProgressingReason string = "InProgress"
+
+const SpecNotObservedReason string = "SpecNotObserved"
+
+// Condition reporting the phase of the canary rollout of an image upgrade
+const RolloutCondition string = "Rollout"
+
+const CanaryDeployingReason string = "CanaryDeploying"
+
+const CanaryVerifyingReason string = "CanaryVerifying"
+
+const RolledBackReason string = "RolledBack"
+
+const RolloutCompleteReason string = "Completed"
+
+// Prints the HTTP status of an ORDS path requested on the pod
+const GetORDSPathStatus string = "curl -sSk -o /dev/null -w '%%{http_code}' https://localhost:8443%s"
+
+// Returns the body of the response to a path of the ORDS pod
+const GetORDSPathBody string = "curl -sSk https://localhost:8443%s"
+
+// Readiness gate of the ORDS pods, set once the pool of the pod has validated its connection to the database
+const OrdsPoolReadyCondition string = "database.oracle.com/ords-pool-ready"
+
+// Directory of the ORDS configuration in the ORDS image, replaced in the commands with the directory of the spec
+const OrdsConfigDir string = "/opt/oracle/ords/config/ords"
+
+// Context path of ORDS in the paths and commands below, replaced with the context path of the spec
+const OrdsDefaultContextPath string = "/ords"
+
+// Sets the context path of the ORDS standalone server from ORDS_CONTEXT_PATH, once the server is configured
+const SetOrdsContextPathCMD string = "f=/opt/oracle/ords/config/ords/standalone/standalone.properties;" +
+	" if [ -f $f ]; then sed -i '/^standalone.context.path=/d' $f && echo \"standalone.context.path=${ORDS_CONTEXT_PATH:-" +
+	OrdsDefaultContextPath + "}\" >> $f; fi"
+
+// Sets the initial size of the ORDS pool, jdbc.InitialLimit, from ORDS_JDBC_INITIAL_LIMIT when set
+const SetOrdsJdbcInitialLimitCMD string = "f=/opt/oracle/ords/config/ords/defaults.xml;" +
+	" if [ -f $f ] && [ -n \"${ORDS_JDBC_INITIAL_LIMIT}\" ]; then sed -i '/<entry key=\"jdbc.InitialLimit\">/d' $f &&" +
+	" sed -i \"s|</properties>|<entry key=\\\"jdbc.InitialLimit\\\">${ORDS_JDBC_INITIAL_LIMIT}</entry>\\n</properties>|\" $f; fi"
+
+// Sets the database service of the ORDS pool, db.servicename, from ORACLE_SERVICE, as the service may change after the
+// setup of the configuration
+const SetOrdsServiceNameCMD string = "f=/opt/oracle/ords/config/ords/defaults.xml;" +
+	" if [ -f $f ] && [ -n \"${ORACLE_SERVICE}\" ]; then sed -i '/<entry key=\"db.servicename\">/d' $f &&" +
+	" sed -i \"s|</properties>|<entry key=\\\"db.servicename\\\">${ORACLE_SERVICE}</entry>\\n</properties>|\" $f; fi"
+
+// Prefix of the ORDS pools serving the PDBs of the virtual hosts
+const OrdsVirtualHostPoolPrefix string = "vhost_"
+
+// Writes url-mapping.xml from ORDS_URL_MAPPING, and creates the pools of ORDS_VIRTUAL_HOST_POOLS, "pool=PDB" separated
+// by spaces, as copies of the default pool connecting to the service of the PDB. The mapping and the pools left by
+// previous virtual hosts are removed first
+const SetOrdsVirtualHostsCMD string = "d=/opt/oracle/ords/config/ords;" +
+	" if grep -qs 'Oracle Database Operator' $d/url-mapping.xml; then rm -f $d/url-mapping.xml; fi;" +
+	" rm -f $d/conf/" + OrdsVirtualHostPoolPrefix + "*.xml;" +
+	" if [ -n \"${ORDS_URL_MAPPING}\" ]; then printf '%s' \"${ORDS_URL_MAPPING}\" > $d/url-mapping.xml &&" +
+	" for p in ${ORDS_VIRTUAL_HOST_POOLS}; do for f in $d/conf/apex.xml $d/conf/apex_*.xml; do if [ -f $f ]; then" +
+	" sed -e '/<entry key=\"db.servicename\">/d' -e \"s|</properties>|<entry key=\\\"db.servicename\\\">${p#*=}</entry>\\n</properties>|\"" +
+	" $f > $d/conf/${p%%=*}${f#$d/conf/apex}; fi; done; done; fi"
+
+// Sends %[1]d warm-up requests to each path of a pod, %[2]d at a time, and prints the number of successful ones. The
+// paths are the arguments of the script
+const WarmUpORDSCMD string = "for p in \"$@\"; do for i in $(seq %[1]d); do printf '%%s\\n' \"$p\"; done; done |" +
+	" xargs -d '\\n' -P %[2]d -I{} curl -sSk -o /dev/null -w '%%{http_code}\\n' https://localhost:8443{} | grep -c '^[23]'"
+
+// ORDS path answering only when the pool connects to the database
+const OrdsPoolValidationPath string = "/ords/_/db-api/stable/metadata-catalog/"
+
+// Name and application protocol of the HTTPS port of the ORDS pods and service, as expected by service meshes
+const OrdsPortName string = "https"
+
+// Name, application protocol and number of the port of the MongoDB API of ORDS
+const OrdsMongoPortName string = "mongo"
+
+const OrdsMongoPort int32 = 27017
+
+const OrdsHealthCheckHTTP string = "HTTP"
+
+// Strategies of the ORDS configuration directory. Shared pods use the directory on the volume, PerPod pods copy it
+// at startup to a directory of their own
+const OrdsConfigStrategyShared string = "Shared"
+
+const OrdsConfigStrategyPerPod string = "PerPod"
+
+// Directory of the shared ORDS configuration in the ORDS container of PerPod pods
+const OrdsSharedConfigDir string = "/opt/oracle/ords/config/ords-shared"
+
+// Directory of the configuration of the pod in the init container of PerPod pods, and the copy of the shared configuration
+// made to it once set up
+const OrdsPodConfigDir string = "/opt/oracle/ords/config/ords-pod"
+
+const CopyOrdsConfigToPodCMD string = "cp -a " + OrdsConfigDir + "/. " + OrdsPodConfigDir + "/"
+
+// Copies the configuration changed in the directory of a pod back to the shared ORDS configuration
+const PublishOrdsConfigCMD string = "cp -a " + OrdsConfigDir + "/. " + OrdsSharedConfigDir + "/"
+
+// Directory of the init container of the ORDS pods where the zips of spec.apex.source and spec.ords.source are extracted,
+// and where their volumes are mounted
+const OrdsArtifactsDir string = "/opt/oracle/artifacts"
+
+const OrdsArtifactSourcesDir string = "/mnt/artifact-sources"
+
+// APEX distribution and ORDS binary of the ORDS image, over which the extracted distributions are mounted
+const OrdsApexDir string = "/opt/oracle/ords/config/apex"
+
+const OrdsWarFile string = "/opt/oracle/ords/ords.war"
+
+const DownloadArtifactCMD string = "curl -fsSL --connect-timeout 30 --retry 3 -o %[2]s '%[1]s'"
+
+const CheckArtifactCMD string = "echo '%[1]s  %[2]s' | sha256sum -c -"
+
+const ExtractArtifactCMD string = "rm -rf %[2]s && mkdir -p %[2]s && unzip -qo %[1]s -d %[2]s"
+
+const PoolValidatedReason string = "PoolValidated"
+
+const PoolNotValidatedReason string = "PoolNotValidated"
+
+const PoolWarmingUpReason string = "PoolWarmingUp"
+
+// Condition of an OracleRestDataService whose pool runs queries on the database, checked apart from the HTTP health of ORDS
+const DatabaseConnectivityCondition string = "DatabaseConnectivity"
+
+const QuerySucceededReason string = "QuerySucceeded"
+
+const QueryFailedReason string = "QueryFailed"
+
+// Condition of a resource reconciled in offline mode, telling which steps reaching out to the internet were skipped
+const OfflineCondition string = "Offline"
+
+const ExternalStepsSkippedReason string = "ExternalStepsSkipped"
+
+const ExternalStepsMirroredReason string = "ExternalStepsMirrored"
+
+// Conditions of the optional features of an OracleRestDataService, retried apart from the rest of the reconcile
+const SchemasEnabledCondition string = "SchemasEnabled"
+
+const ApexConfiguredCondition string = "ApexConfigured"
+
+const ApexLanguagesInstalledCondition string = "ApexLanguagesInstalled"
+
+const OAuthClientsConfiguredCondition string = "OAuthClientsConfigured"
+
+const FeatureReconciledReason string = "Reconciled"
+
+const FeatureRetryingReason string = "Retrying"
+
+// Condition of an OracleRestDataService registered with an OCI API Gateway
+const ApiGatewayRegisteredCondition string = "ApiGatewayRegistered"
+
+// Annotation of a load balancer service applying a WAF policy, read by the OCI cloud controller manager
+const WafPolicyAnnotation string = "oci.oraclecloud.com/waf-policy-ocid"
+
+// Condition of an OracleRestDataService whose database reference is not found
+const DatabaseMissingCondition string = "DatabaseMissing"
+
+const DatabaseNotFoundReason string = "DatabaseNotFound"
+
+const DatabaseFoundReason string = "DatabaseFound"
+
+// Condition of an OracleRestDataService whose installation waits for the PDB of the database to open read write
+const WaitingForPDBCondition string = "WaitingForPDB"
+
+const PdbNotOpenReason string = "PdbNotOpen"
+
+const PdbOpenTimedOutReason string = "PdbOpenTimedOut"
+
+const PdbOpenReason string = "PdbOpen"
+
+// Seconds the installation of ORDS waits for the PDB to open before the ORDS is in error. It keeps waiting afterwards
+const OrdsPdbOpenTimeout int64 = 900
+
+// Condition of a SingleInstanceDatabase whose SQL commands are suspended by the circuit breaker of its pod
+const DatabaseUnreachableCondition string = "DatabaseUnreachable"
+
+const SQLSuspendedReason string = "SQLSuspended"
+
+const DatabaseReachableReason string = "DatabaseReachable"
+
+// Condition of a SingleInstanceDatabase whose registry components are verified after datapatch
+const DatapatchVerifiedCondition string = "DatapatchVerified"
+
+const DatapatchPendingReason string = "DatapatchPending"
+
+const ComponentsValidReason string = "ComponentsValid"
+
+const ComponentsInvalidReason string = "ComponentsInvalid"
+
+// Condition of a SingleInstanceDatabase that can be rolled back to the image it was patched from
+const RollbackAvailableCondition string = "RollbackAvailable"
+
+const PreviousImageReason string = "PreviousImage"
+
+// Policies of an OracleRestDataService whose database reference is not found
+const DatabaseMissingSuspend string = "Suspend"
+
+const DatabaseMissingDelete string = "Delete"
+
+// Deletion policy of an OracleRestDataService or a DataguardBroker skipping the cleanup in the database
+const DeletionPolicyAbandon string = "Abandon"
+
 const StatusPending string = "Pending"
 
 const StatusCreating string = "Creating"
@@ -403,6 +865,12 @@ const StatusReady string = "Healthy"
 
 const StatusError string = "Error"
 
+const StatusStopped string = "Stopped"
+
+const ScheduledStateRunning string = "Running"
+
+const ScheduledStateStopped string = "Stopped"
+
 const ValueUnavailable string = "Unavailable"
 
 const NoExternalIp string = "Node ExternalIP unavailable"
@@ -416,6 +884,30 @@ const WalletDeleteCMD string = "rm -rf ${WALLET_DIR}"
 const WalletEntriesCMD string = "umask 177\ncat > wallet.passwd <<EOF\n${WALLET_PWD}\nEOF\n mkstore -wrl ${WALLET_DIR} -createEntry oracle.dbsecurity.sysPassword %[1]s -createEntry oracle.dbsecurity.systemPassword %[1]s " +
 	"-createEntry oracle.dbsecurity.pdbAdminPassword %[1]s -createEntry oracle.dbsecurity.dbsnmpPassword %[1]s < wallet.passwd\nrm -f wallet.passwd\numask 022;"
 
+// Secure external password store holding the sys credential, persisted with the database configuration
+const PasswordStoreDir string = "${ORACLE_BASE}/oradata/dbconfig/${ORACLE_SID^^}/.seps"
+
+const PasswordStoreAlias string = "SYSDBA_LOCAL"
+
+const CreatePasswordStoreCMD string = "mkdir -p " + PasswordStoreDir + " && if [ ! -f " + PasswordStoreDir + "/cwallet.sso ]; then mkstore -wrl " + PasswordStoreDir + " -createALO; fi" +
+	" && echo -e \"WALLET_LOCATION=(SOURCE=(METHOD=FILE)(METHOD_DATA=(DIRECTORY=" + PasswordStoreDir + ")))\nSQLNET.WALLET_OVERRIDE=TRUE\" > " + PasswordStoreDir + "/sqlnet.ora" +
+	" && echo \"" + PasswordStoreAlias + "=(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=localhost)(PORT=1521))(CONNECT_DATA=(SERVICE_NAME=${ORACLE_SID})))\" > " + PasswordStoreDir + "/tnsnames.ora"
+
+const SetPasswordStoreCredentialCMD string = "umask 177\ncat > seps.passwd <<EOF\n%[1]s\n%[1]s\nEOF\n" +
+	"if mkstore -wrl " + PasswordStoreDir + " -listCredential | grep -qi " + PasswordStoreAlias + "; then OP=-modifyCredential; else OP=-createCredential; fi\n" +
+	"mkstore -wrl " + PasswordStoreDir + " $OP " + PasswordStoreAlias + " sys < seps.passwd\nrm -f seps.passwd\numask 022;"
+
+const DeletePasswordStoreCMD string = "rm -rf " + PasswordStoreDir
+
+const PasswordStoreSQLClient string = "TNS_ADMIN=" + PasswordStoreDir + " sqlplus -s /@" + PasswordStoreAlias + " as sysdba"
+
+// SQL*Plus client of the commands run in Jobs, connecting as sysdba to the database pod through the password store.
+// The Job copies the configuration of the store, with the IP of the pod as the host of its alias
+const JobSQLClientSetupCMD string = "mkdir -p /tmp/seps && sed 's/(HOST=localhost)/(HOST=%[1]s)/' " + PasswordStoreDir + "/tnsnames.ora > /tmp/seps/tnsnames.ora" +
+	" && cp " + PasswordStoreDir + "/sqlnet.ora /tmp/seps/ && "
+
+const JobSQLClient string = "TNS_ADMIN=/tmp/seps sqlplus -s /@" + PasswordStoreAlias + " as sysdba"
+
 const InitWalletCMD string = "if [ ! -f $ORACLE_BASE/oradata/.${ORACLE_SID}${CHECKPOINT_FILE_EXTN} ] || [ ! -f ${ORACLE_BASE}/oradata/dbconfig/$ORACLE_SID/.docker_%s ];" +
 	" then while [ ! -f ${WALLET_DIR}/ewallet.p12 ] || pgrep -f $WALLET_CLI > /dev/null; do sleep 0.5; done; fi "
 
@@ -424,10 +916,21 @@ const InitPrebuiltDbCMD string = "if [ ! -d /mnt/oradata/${ORACLE_SID} -a -d $OR
 
 const AlterSgaPgaCpuCMD string = "echo -e  \"alter system set sga_target=%dM scope=both; \n alter system set pga_aggregate_target=%dM scope=both; \n alter system set cpu_count=%d; \" | %s "
 
-const AlterProcessesCMD string = "echo -e  \"alter system set processes=%d scope=spfile; \" | %s && " + CreateChkFileCMD + " && " +
+const AlterProcessesCMD string = "echo -e  \"alter system set processes=%d scope=spfile; \" | %s && " + restartDatabaseCMD
+
+// Resets processes to its default, restarting the database like AlterProcessesCMD
+const ResetProcessesCMD string = "echo -e  \"alter system reset processes scope=spfile; \" | %s && " + restartDatabaseCMD
+
+const restartDatabaseCMD string = CreateChkFileCMD + " && " +
 	"echo -e  \"SHUTDOWN IMMEDIATE; \n STARTUP MOUNT; \n ALTER DATABASE OPEN; \n ALTER PLUGGABLE DATABASE ALL OPEN; \n ALTER SYSTEM REGISTER;\" | %s && " +
 	RemoveChkFileCMD
 
+// Shared servers for ORDS, and the ORDS dispatchers at index 1 of dispatchers, after the XDB dispatcher of the database
+var SetOrdsSharedServersSQL = LoadSQL("set_ords_shared_servers", "")
+
+// Reverts SetOrdsSharedServersSQL, shared_servers returning to its default of 1 with the XDB dispatcher
+var ResetOrdsSharedServersSQL = LoadSQL("reset_ords_shared_servers", "")
+
 const GetInitParamsSQL string = "echo -e  \"select name,display_value from v\\$parameter  where name in  ('sga_target','pga_aggregate_target','cpu_count','processes') order by name asc;\" | %s"
 
 const UnzipApexOnSIDBPod string = "if [ -f /opt/oracle/oradata/apex-latest.zip ]; then unzip -o /opt/oracle/oradata/apex-latest.zip -d /opt/oracle/oradata/${ORACLE_SID^^}; else echo \"apex-latest.zip not found\"; fi;"
@@ -448,6 +951,42 @@ const InstallApexInContainer string = "cd ${ORDS_HOME}/config/apex/ && echo -e \
 const IsApexInstalled string = "echo -e \"select 'APEXVERSION:'||version as version FROM DBA_REGISTRY WHERE COMP_ID='APEX';\"" +
 	" | sqlplus -s sys/%[1]s@${ORACLE_HOST}:${ORACLE_PORT}/%[2]s as sysdba;"
 
+// Prints the version of the ORDS of the pod, Oracle REST Data Services <version>
+const GetOrdsVersionCMD string = "$JAVA_HOME/bin/java -jar $ORDS_HOME/ords.war version 2>&1"
+
+var GetApexVersionSQL = LoadSQL("get_apex_version", "")
+
+// Exports the APEX workspaces and applications of a PDB, one file each, to a directory next to the ORDS_METADATA backups
+const ExportApexCMD string = "mkdir -p " + ORDSMetadataBackupDir + "/%[2]s && echo -e \"ALTER SESSION SET CONTAINER=%[1]s;" +
+	"\nCREATE OR REPLACE DIRECTORY APEX_EXPORT_DIR AS '" + ORDSMetadataBackupDir + "/%[2]s';" +
+	"\nSET SERVEROUTPUT ON" +
+	"\nDECLARE" +
+	"\n  PROCEDURE save(p_files apex_t_export_files) IS" +
+	"\n  BEGIN" +
+	"\n    FOR i IN 1 .. p_files.count LOOP" +
+	"\n      DBMS_XSLPROCESSOR.CLOB2FILE(p_files(i).contents, 'APEX_EXPORT_DIR', p_files(i).name);" +
+	"\n      DBMS_OUTPUT.PUT_LINE('APEXEXPORT:' || p_files(i).name);" +
+	"\n    END LOOP;" +
+	"\n  END;" +
+	"\nBEGIN" +
+	"\n  FOR w IN (SELECT workspace_id FROM apex_workspaces WHERE workspace NOT IN (" + apexInternalWorkspaces + ")) LOOP" +
+	"\n    save(apex_export.get_workspace(p_workspace_id => w.workspace_id));" +
+	"\n  END LOOP;" +
+	"\n  FOR a IN (SELECT application_id FROM apex_applications WHERE workspace NOT IN (" + apexInternalWorkspaces + ")) LOOP" +
+	"\n    save(apex_export.get_application(p_application_id => a.application_id));" +
+	"\n  END LOOP;" +
+	"\nEND;" +
+	"\n/\" | " + SQLPlusCLI
+
+const apexInternalWorkspaces string = "'INTERNAL', 'COM.ORACLE.APEX.REPOSITORY', 'COM.ORACLE.CUST.REPOSITORY'"
+
+const InstallApexLanguageInContainer string = "export NLS_LANG=American_America.AL32UTF8 && cd ${ORDS_HOME}/config/apex/builder/%[1]s && " +
+	"echo -e \"whenever sqlerror exit sql.sqlcode;\ncolumn apex_schema new_value apex_schema\n" +
+	"select schema apex_schema from dba_registry where comp_id='APEX';\n" +
+	"alter session set current_schema=&apex_schema;\n" +
+	"@load_%[1]s.sql\n" +
+	"\" | sqlplus -s sys/%[2]s@${ORACLE_HOST}:${ORACLE_PORT}/%[3]s as sysdba;"
+
 const UninstallApex string = "cd ${ORDS_HOME}/config/apex/ && echo -e \"@apxremov.sql\n\" | sqlplus -s sys/%[1]s@${ORACLE_HOST}:${ORACLE_PORT}/%[2]s as sysdba;"
 
 const ConfigureApexRest string = "if [ -f ${ORDS_HOME}/config/apex/apex_rest_config.sql ]; then  cd ${ORDS_HOME}/config/apex && " +
@@ -541,3 +1080,148 @@ const TcpsPort string = "\"name\": \"listener-tcps\", \"protocol\": \"TCP\", \"p
 
 // Payload section for TCPS node port
 const TcpsNodePort string = "\"name\": \"listener-tcps\", \"protocol\": \"TCP\", \"port\": 2484, \"nodePort\": %d"
+
+// Database shutdown run by the preStop hook of the database pods
+const DefaultShutdownGracePeriod int64 = 300
+
+const ShutdownAbortCMD string = "/bin/echo -en 'shutdown abort;\n' | env ORACLE_SID=${ORACLE_SID^^} sqlplus -S / as sysdba"
+
+// Shuts down the database in the given mode, aborting it if the shutdown does not complete within the timeout
+const ShutdownDatabaseCMD string = "/bin/echo -en 'shutdown %[1]s;\n' | env ORACLE_SID=${ORACLE_SID^^} timeout %[2]d sqlplus -S / as sysdba || " +
+	ShutdownAbortCMD
+
+// Sidecar streaming the alert and listener logs of the database as JSON lines
+const AlertLogContainerName string = "alert-log"
+
+// The sidecar reads the logs in the file system of the database container, through the root of its runOracle.sh
+// process in the process namespace shared by the pod
+const AlertLogStreamCMD string = `until root=$(grep -l '[r]unOracle\.sh' /proc/[0-9]*/cmdline 2>/dev/null | head -1); [ -n "$root" ]; do sleep 10; done
+root=${root%/cmdline}/root
+stream() {
+  until ls $root$2 >/dev/null 2>&1; do sleep 10; done
+  tail -n +1 -F $(ls $root$2 | head -1) | awk -v src=$1 '
+    /^[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T/ { ts = $1; next }
+    {
+      lvl = ($0 ~ /(ORA|TNS)-[0-9]+/) ? "error" : "info"
+      gsub(/\\/, "\\\\\\\\"); gsub(/"/, "\\\""); gsub(/\t/, " ")
+      printf "{\"time\":\"%s\",\"source\":\"%s\",\"level\":\"%s\",\"message\":\"%s\"}\n", ts, src, lvl, $0
+      fflush()
+    }'
+}
+stream alert "$ORACLE_BASE/diag/rdbms/*/${ORACLE_SID}/trace/alert_${ORACLE_SID}.log" &
+stream listener "$ORACLE_BASE/diag/tnslsnr/*/listener/trace/listener.log" &
+wait`
+
+// Prints the ORA- errors of the alert log after the given line, followed by the number of lines of the alert log
+const AlertLogErrorsCMD string = "f=$(ls $ORACLE_BASE/diag/rdbms/*/${ORACLE_SID^^}/trace/alert_${ORACLE_SID^^}.log 2>/dev/null | head -1); " +
+	"if [ -n \"$f\" ]; then awk -v off=%d 'NR > off && /ORA-[0-9]+/ { print } END { print \"LINES:\" NR }' \"$f\"; fi"
+
+// Maximum number of events raised for the errors found in one scan of the alert log
+const AlertLogMaxEvents int = 20
+
+// Performance reports of the database. The snapshots are selected on the time of the database host
+var GetAwrSnapshotsSQL = LoadSQL("get_awr_snapshots", "")
+
+var GetStatspackSnapshotsSQL = LoadSQL("get_statspack_snapshots", "")
+
+const PerformanceReportFile string = "/tmp/performance_report"
+
+// Runs awrrpt or spreport non-interactively and prints the report, or the errors of the script
+const PerformanceReportCMD string = "echo -e \"%[1]sdefine report_type='%[2]s'\\ndefine num_days=%[3]d\\ndefine begin_snap=%[4]d\\ndefine end_snap=%[5]d\\n" +
+	"define report_name=" + PerformanceReportFile + "\\n@?/rdbms/admin/%[6]s\" | " + SQLPlusCLI + " > " + PerformanceReportFile + ".log; " +
+	"if [ -s " + PerformanceReportFile + " ]; then cat " + PerformanceReportFile + "; rm -f " + PerformanceReportFile + "*; " +
+	"else grep -E 'ORA-|SP2-' " + PerformanceReportFile + ".log >&2; rm -f " + PerformanceReportFile + "*; fi"
+
+// ConfigMaps hold at most 1 MiB, reports above this size are gzipped, and truncated if they still do not fit
+const PerformanceReportMaxSize int = 1000 * 1024
+
+// Appended to the reports truncated to fit in their ConfigMap
+const PerformanceReportTruncatedMsg string = "\n... report truncated to fit in its ConfigMap\n"
+
+// Snapshots are taken hourly by default, a report waits this many hours for the snapshot following its end
+const PerformanceReportSnapshotWaitHours int = 2
+
+// Lists the user sessions blocking other sessions as "sid,serial#,username,blocked sessions,longest wait in seconds"
+var GetBlockingSessionsSQL = LoadSQL("get_blocking_sessions", "")
+
+// Lists the Data Guard lags as "transport_lag,+DD HH:MI:SS" and "apply_lag,+DD HH:MI:SS", and the apply and redo
+// generation rates as "apply_rate,bytes per second" and "redo_generation_rate,bytes per second"
+var GetReplicationStatsSQL = LoadSQL("get_replication_stats", "")
+
+// Lists the processes parameter of the database as "processes,value" and its background processes as
+// "background_processes,count"
+var GetProcessLimitsSQL = LoadSQL("get_process_limits", "")
+
+// Connections of the pool of an ORDS pod, jdbc.MaxLimit being 20 in the ORDS setup
+const OrdsJdbcMaxLimit int = 20
+
+// Users whose sessions are never killed by .spec.sessionManagement
+var SessionManagementProtectedUsers = []string{"SYS", "SYSTEM"}
+
+// Maximum number of sessions killed per scan of the blocking sessions
+const SessionManagementMaxKills int = 5
+
+// CDB resource plans. An active plan can not be deleted, so the plan is deactivated before it is replaced
+var DeactivateResourcePlanSQL = LoadSQL("deactivate_resource_plan", "")
+
+var DeleteCdbPlanSQL = LoadSQL("delete_cdb_plan", "")
+
+var CreateCdbPlanSQL = LoadSQL("create_cdb_plan", "")
+
+var CreateCdbPlanDirectiveSQL = LoadSQL("create_cdb_plan_directive", "")
+
+var ActivateResourcePlanSQL = LoadSQL("activate_resource_plan", "")
+
+var GetActiveResourcePlanSQL = LoadSQL("get_active_resource_plan", "")
+
+// Runs the SQL passed as $1 with SQLcl in the ORDS pod of a CDB, connected to the CDB as its administrator user without
+// SYSDBA. The credentials are read from the secrets of the pod and piped to SQLcl, so this runs with nologCommand
+const CDBAdminSQLCMD string = "cd /opt/oracle/ords/secrets && url=${DBTNSURL:-//$ORACLE_HOST:$ORACLE_PORT/$ORACLE_SERVICE} && " +
+	"printf 'whenever sqlerror exit failure\\nset feedback off\\nconnect %s/\"%s\"@%s\\n%s\\nexit\\n' " +
+	"\"$(cat $CDBADMIN_USER_KEY)\" \"$(cat $CDBADMIN_PWD_KEY)\" \"$url\" \"$1\" | /opt/oracle/sqlcl/bin/sql -S /nolog"
+
+// Resource limits of a PDB, as name=value. These are passed to CDBAdminSQLCMD as an argument, so they are not shell escaped
+var GetPDBResourceLimitsSQL = LoadSQL("get_pdb_resource_limits", "")
+
+var SetPDBParameterSQL = LoadSQL("set_pdb_parameter", "")
+
+var SetPDBMaxSizeSQL = LoadSQL("set_pdb_max_size", "")
+
+// Oracle rounds memory parameters up to the SGA granule, so smaller differences are not a drift
+const PDBMemoryGranule int64 = 64 * 1024 * 1024
+
+// ConfigMap holding the exported state of a database and of its ORDS, suffixed to the name of the database
+const StateConfigMapSuffix string = "-state"
+
+// Keys of the state ConfigMap: the manifests of the database and of its ORDS, and the secrets they refer to
+const StateDatabaseKey string = "singleinstancedatabase.yaml"
+
+const StateOrdsKey string = "oraclerestdataservices.yaml"
+
+const StateSecretsKey string = "secrets"
+
+// Annotation of the exported manifests holding the status of the resource, imported when the resource is created
+// with an empty status
+const ImportedStatusAnnotation string = "database.oracle.com/imported-status"
+
+const StateImportedReason string = "State Imported"
+
+// Hooks of a database, the keys of status.hookJobs
+const HookPostProvision string = "postProvision"
+
+const HookPreDelete string = "preDelete"
+
+const HookPostBackup string = "postBackup"
+
+const HookPostFailover string = "postFailover"
+
+// Default key of the Job manifest in the ConfigMap of a hook
+const HookJobKey string = "job.yaml"
+
+// Labels of the Jobs launched by the hooks, with the name of the hook and of the database
+const HookLabel string = "database.oracle.com/hook"
+
+const HookDatabaseLabel string = "database.oracle.com/database"
+
+// Service account the Jobs of the hooks run as, created without any role in the namespace of the database
+const HookServiceAccount string = "oracle-database-hooks"
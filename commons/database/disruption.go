@@ -0,0 +1,141 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"context"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Duration for which the lease of a critical operation stays valid without being renewed
+const CriticalOperationLeaseDuration int32 = 900
+
+// Name of the Lease object held while the resource name runs operation
+func CriticalOperationLeaseName(name string, operation string) string {
+	return name + "-" + operation
+}
+
+// Starts or renews a critical operation of holder: grabs the lease leaseName and annotates the pods involved with it, and
+// as not safe to evict for the cluster autoscaler if blockEviction is set. The operator does not delete or restart the
+// annotated pods until EndCriticalOperation or the expiry of the lease.
+// Returns false if the lease is held by another holder.
+func BeginCriticalOperation(ctx context.Context, c client.Client, namespace string, leaseName string, holder string,
+	blockEviction bool, pods ...corev1.Pod) (bool, error) {
+	acquired, err := acquireLease(ctx, c, namespace, leaseName, holder, CriticalOperationLeaseDuration)
+	if err != nil || !acquired {
+		return false, err
+	}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Name == "" || pod.Annotations[CriticalOperationAnnotation] == leaseName &&
+			(!blockEviction || pod.Annotations[SafeToEvictAnnotation] == "false") {
+			continue
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[CriticalOperationAnnotation] = leaseName
+		if blockEviction {
+			pod.Annotations[SafeToEvictAnnotation] = "false"
+		}
+		if err := c.Patch(ctx, pod, patch); err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// Ends the critical operation of holder: removes the annotations of the operation from the pods and releases its lease
+func EndCriticalOperation(ctx context.Context, c client.Client, namespace string, leaseName string, holder string,
+	pods ...corev1.Pod) error {
+	for _, p := range pods {
+		if p.Name == "" {
+			continue
+		}
+		pod := &corev1.Pod{}
+		if err := c.Get(ctx, types.NamespacedName{Name: p.Name, Namespace: namespace}, pod); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if pod.Annotations[CriticalOperationAnnotation] != leaseName {
+			continue
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		delete(pod.Annotations, CriticalOperationAnnotation)
+		// The operator does not set it on its pods otherwise
+		if pod.Annotations[SafeToEvictAnnotation] == "false" {
+			delete(pod.Annotations, SafeToEvictAnnotation)
+		}
+		if err := c.Patch(ctx, pod, patch); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return releaseLease(ctx, c, namespace, leaseName, holder)
+}
+
+// Returns the name of the lease of the critical operation running on one of the pods, or "" if none runs on them.
+// An annotation whose lease is released or expired does not protect the pod anymore.
+func CriticalOperation(ctx context.Context, c client.Reader, pods ...corev1.Pod) (string, error) {
+	for _, pod := range pods {
+		leaseName := pod.Annotations[CriticalOperationAnnotation]
+		if pod.Name == "" || leaseName == "" {
+			continue
+		}
+		lease := &coordinationv1.Lease{}
+		err := c.Get(ctx, types.NamespacedName{Name: leaseName, Namespace: pod.Namespace}, lease)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", err
+		}
+		if !isLeaseExpired(lease) {
+			return leaseName, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,109 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Critical operations", func() {
+	const namespace = "default"
+	ctx := context.TODO()
+	leaseName := CriticalOperationLeaseName("sidb-sample", CriticalOperationDatapatch)
+	holder := DatabaseLockHolder("SingleInstanceDatabase", namespace, "sidb-sample")
+
+	var c client.Client
+	var pod corev1.Pod
+
+	BeforeEach(func() {
+		pod = corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "sidb-sample-abcde", Namespace: namespace}}
+		c = fake.NewClientBuilder().WithObjects(pod.DeepCopy()).Build()
+	})
+
+	getPod := func() corev1.Pod {
+		current := corev1.Pod{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: pod.Name, Namespace: namespace}, &current)).To(Succeed())
+		return current
+	}
+
+	It("Should protect the pods until the operation ends", func() {
+		acquired, err := BeginCriticalOperation(ctx, c, namespace, leaseName, holder, true, pod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acquired).To(BeTrue())
+		Expect(getPod().Annotations).To(HaveKeyWithValue(CriticalOperationAnnotation, leaseName))
+		Expect(getPod().Annotations).To(HaveKeyWithValue(SafeToEvictAnnotation, "false"))
+		Expect(CriticalOperation(ctx, c, getPod())).To(Equal(leaseName))
+
+		Expect(EndCriticalOperation(ctx, c, namespace, leaseName, holder, pod)).To(Succeed())
+		Expect(getPod().Annotations).NotTo(HaveKey(CriticalOperationAnnotation))
+		Expect(getPod().Annotations).NotTo(HaveKey(SafeToEvictAnnotation))
+		Expect(CriticalOperation(ctx, c, getPod())).To(BeEmpty())
+	})
+
+	It("Should leave the eviction to the cluster autoscaler unless requested", func() {
+		_, err := BeginCriticalOperation(ctx, c, namespace, leaseName, holder, false, pod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(getPod().Annotations).To(HaveKey(CriticalOperationAnnotation))
+		Expect(getPod().Annotations).NotTo(HaveKey(SafeToEvictAnnotation))
+	})
+
+	It("Should not protect the pods with a released lease", func() {
+		_, err := BeginCriticalOperation(ctx, c, namespace, leaseName, holder, false, pod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(releaseLease(ctx, c, namespace, leaseName, holder)).To(Succeed())
+		Expect(CriticalOperation(ctx, c, getPod())).To(BeEmpty())
+	})
+
+	It("Should not let another holder take the lease", func() {
+		_, err := BeginCriticalOperation(ctx, c, namespace, leaseName, holder, false, pod)
+		Expect(err).NotTo(HaveOccurred())
+		acquired, err := BeginCriticalOperation(ctx, c, namespace, leaseName, "OracleRestDataService/default/ords-sample", false, pod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acquired).To(BeFalse())
+	})
+})
@@ -0,0 +1,57 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+// Package commons provides the building blocks shared by the controllers that manage
+// containerized Oracle databases (SingleInstanceDatabase, OracleRestDataService, DataguardBroker).
+// It is imported as dbcommons and can be reused by external tooling.
+//
+// The package is organised as follows:
+//
+//   - constants.go holds the raw SQL statements, shell commands and status values.
+//   - sql.go exposes typed functions that render the parameterized SQL templates and
+//     wrap a statement into a SQL*Plus invocation, together with ExecSQL to run it in a pod.
+//   - parse.go parses the SQL*Plus output of the status queries into Go values.
+//   - utils.go contains pod discovery, command execution (ExecCommand) and
+//     higher level database status queries built on the above.
+//   - executor.go defines the CommandExecutor interface behind ExecCommand, and a
+//     FakeCommandExecutor to run the controllers in tests without an Oracle image.
+//   - lock.go serializes administrative SQL executed against a single database.
+//
+// All exported identifiers are considered stable. New SQL should be added as a constant
+// along with a typed function rendering it, instead of formatting templates at the call site.
+package commons
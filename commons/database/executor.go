@@ -0,0 +1,161 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CommandExecutor runs a command in a container of a pod and returns its output.
+// It is the single entry point used by ExecCommand, so that the exec/SQL layer can be
+// swapped out, e.g. with a FakeCommandExecutor in integration tests.
+type CommandExecutor interface {
+	ExecCommand(r client.Reader, config *rest.Config, podName string, namespace string, containerName string,
+		ctx context.Context, req ctrl.Request, command ...string) (string, error)
+}
+
+// PodCommandExecutor is the default CommandExecutor, exec-ing into the pods
+type PodCommandExecutor struct{}
+
+var commandExecutor = struct {
+	sync.RWMutex
+	executor CommandExecutor
+}{executor: PodCommandExecutor{}}
+
+// Returns the CommandExecutor used by ExecCommand
+func GetCommandExecutor() CommandExecutor {
+	commandExecutor.RLock()
+	defer commandExecutor.RUnlock()
+	return commandExecutor.executor
+}
+
+// Sets the CommandExecutor used by ExecCommand and returns the previous one
+func SetCommandExecutor(executor CommandExecutor) CommandExecutor {
+	commandExecutor.Lock()
+	defer commandExecutor.Unlock()
+	previous := commandExecutor.executor
+	commandExecutor.executor = executor
+	return previous
+}
+
+// FakeCommandExecutor is a CommandExecutor that never reaches a pod.
+// It records every executed command and answers with the output of the first
+// registered response whose pattern is contained in the command.
+type FakeCommandExecutor struct {
+	mu        sync.Mutex
+	responses []fakeCommandResponse
+	commands  []FakeCommand
+}
+
+// FakeCommand is a command recorded by FakeCommandExecutor
+type FakeCommand struct {
+	PodName   string
+	Namespace string
+	Command   string
+}
+
+type fakeCommandResponse struct {
+	pattern string
+	output  string
+	err     error
+}
+
+// Returns a FakeCommandExecutor answering every command with an empty output
+func NewFakeCommandExecutor() *FakeCommandExecutor {
+	return &FakeCommandExecutor{}
+}
+
+// Registers output as the response of the commands containing pattern
+func (f *FakeCommandExecutor) On(pattern string, output string) *FakeCommandExecutor {
+	return f.OnError(pattern, output, nil)
+}
+
+// Registers output and err as the response of the commands containing pattern
+func (f *FakeCommandExecutor) OnError(pattern string, output string, err error) *FakeCommandExecutor {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses = append(f.responses, fakeCommandResponse{pattern: pattern, output: output, err: err})
+	return f
+}
+
+// Clears the registered responses and the recorded commands
+func (f *FakeCommandExecutor) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses = nil
+	f.commands = nil
+}
+
+// Returns the commands executed so far
+func (f *FakeCommandExecutor) Commands() []FakeCommand {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeCommand{}, f.commands...)
+}
+
+// Returns true if a command containing pattern was executed
+func (f *FakeCommandExecutor) Executed(pattern string) bool {
+	for _, command := range f.Commands() {
+		if strings.Contains(command.Command, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// Records the command and returns the matching registered response
+func (f *FakeCommandExecutor) ExecCommand(r client.Reader, config *rest.Config, podName string, namespace string, containerName string,
+	ctx context.Context, req ctrl.Request, command ...string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cmd := strings.Join(command, " ")
+	f.commands = append(f.commands, FakeCommand{PodName: podName, Namespace: namespace, Command: cmd})
+	for _, response := range f.responses {
+		if strings.Contains(cmd, response.pattern) {
+			return response.output, response.err
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,91 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var _ = Describe("FakeCommandExecutor", func() {
+	var fake *FakeCommandExecutor
+	var previous CommandExecutor
+
+	BeforeEach(func() {
+		fake = NewFakeCommandExecutor()
+		previous = SetCommandExecutor(fake)
+	})
+
+	AfterEach(func() {
+		SetCommandExecutor(previous)
+	})
+
+	It("Should answer ExecCommand with the first matching response", func() {
+		fake.On("V\\$INSTANCE", "\nVERSION_FULL\n------------\n19.3.0.0.0\n").
+			OnError("curl", "", errors.New("connection refused"))
+
+		out, err := ExecCommand(nil, nil, "pod", "default", "", context.TODO(), ctrl.Request{}, false,
+			"bash", "-c", SQLPlusCommand(GetVersionSQL))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ParseColumnValue(out)).To(Equal("19.3.0.0.0"))
+
+		_, err = ExecCommand(nil, nil, "pod", "default", "", context.TODO(), ctrl.Request{}, false,
+			"bash", "-c", GetORDSStatus)
+		Expect(err).To(MatchError("connection refused"))
+
+		out, err = ExecCommand(nil, nil, "pod", "default", "", context.TODO(), ctrl.Request{}, false,
+			"bash", "-c", GetSidPdbEditionCMD)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(BeEmpty())
+	})
+
+	It("Should record the executed commands", func() {
+		ExecCommand(nil, nil, "pod", "default", "", context.TODO(), ctrl.Request{}, true, "bash", "-c", DropAdminUsersSQL)
+		Expect(fake.Executed("drop user C##DBAPI_CDB_ADMIN")).To(BeTrue())
+		Expect(fake.Commands()).To(HaveLen(1))
+		Expect(fake.Commands()[0].PodName).To(Equal("pod"))
+
+		fake.Reset()
+		Expect(fake.Commands()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,278 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	utilexec "k8s.io/client-go/util/exec"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Time a command run by the JobCommandExecutor has to complete
+const CommandJobTimeout time.Duration = 10 * time.Minute
+
+// Time the Job of a command is kept once finished, should the operator restart before deleting it
+const CommandJobTTL int32 = 300
+
+// Label of the Jobs of the JobCommandExecutor, set to the name of the pod of the command
+const CommandJobLabel string = "database.oracle.com/command-pod"
+
+// Returned by the JobCommandExecutor for the commands that act on the processes of a pod, which a Job cannot reach
+var ErrPodExecRequired = errors.New("the command needs to run in the pod, and the operator is not permitted to exec into pods")
+
+// Commands acting on the processes of a database pod: datapatch and the TCPS setup connect locally to the instance
+// and restart its listener
+var podExecOnlyCommands = []string{"datapatch", "$CONFIG_TCPS_FILE", "lsnrctl"}
+
+var podExec = struct {
+	sync.RWMutex
+	permitted bool
+}{permitted: true}
+
+// Returns false if the operator is not permitted to exec into pods, and runs the commands in Jobs
+func PodExecPermitted() bool {
+	podExec.RLock()
+	defer podExec.RUnlock()
+	return podExec.permitted
+}
+
+// Sets whether the operator execs into pods, and the CommandExecutor used by ExecCommand accordingly
+func SetPodExecPermitted(permitted bool) {
+	podExec.Lock()
+	podExec.permitted = permitted
+	podExec.Unlock()
+	if permitted {
+		SetCommandExecutor(PodCommandExecutor{})
+	} else {
+		SetCommandExecutor(JobCommandExecutor{})
+	}
+}
+
+// Checks with a SelfSubjectAccessReview whether the operator may create pods/exec in all namespaces
+func DetectPodExec(ctx context.Context, config *rest.Config) (bool, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return false, err
+	}
+	review, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:        "create",
+				Resource:    "pods",
+				Subresource: "exec",
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// JobCommandExecutor is the CommandExecutor of operators not permitted to exec into pods. It runs the command in a
+// Job cloning the container of the pod, with its volumes and on its node, and returns the logs of the Job.
+// The commands reach the pod through its IP instead of localhost, and the SQL*Plus commands connect to the database
+// as sysdba through the secure external password store of the database, which needs to be enabled
+type JobCommandExecutor struct{}
+
+// Runs the command in a Job cloning the container of podName, and returns its logs
+func (JobCommandExecutor) ExecCommand(r client.Reader, config *rest.Config, podName string, namespace string, containerName string,
+	ctx context.Context, req ctrl.Request, command ...string) (string, error) {
+
+	log := ctrllog.FromContext(ctx).WithValues("ExecCommand", req.NamespacedName)
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, types.NamespacedName{Name: podName, Namespace: namespace}, pod); err != nil {
+		return "", fmt.Errorf("could not find pod to execute command: %v", err)
+	}
+	if containerName == "" {
+		containerName = mainContainer(*pod)
+	}
+	var container *corev1.Container
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == containerName {
+			container = &pod.Spec.Containers[i]
+		}
+	}
+	if container == nil {
+		return "", fmt.Errorf("container %s not found in pod %s", containerName, podName)
+	}
+	command, err := jobCommand(command, pod.Status.PodIP)
+	if err != nil {
+		return "", err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return "", err
+	}
+	job := commandJob(pod, container, command)
+	job, err = clientset.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to create the job of the command: %v", err)
+	}
+	defer func() {
+		policy := metav1.DeletePropagationBackground
+		if err := clientset.BatchV1().Jobs(namespace).Delete(context.Background(), job.Name,
+			metav1.DeleteOptions{PropagationPolicy: &policy}); err != nil {
+			log.Info("Failed to delete the job of the command", "job", job.Name, "error", err.Error())
+		}
+	}()
+
+	// Wait for the container of the Job to terminate
+	var terminated *corev1.ContainerStateTerminated
+	var jobPod corev1.Pod
+	err = wait.PollImmediate(2*time.Second, CommandJobTimeout, func() (bool, error) {
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: "job-name=" + job.Name})
+		if err != nil || len(pods.Items) == 0 {
+			return false, nil
+		}
+		jobPod = pods.Items[0]
+		for _, status := range jobPod.Status.ContainerStatuses {
+			if status.State.Terminated != nil {
+				terminated = status.State.Terminated
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("the job %s of the command did not complete: %v", job.Name, err)
+	}
+	logs, err := clientset.CoreV1().Pods(namespace).GetLogs(jobPod.Name, &corev1.PodLogOptions{}).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the logs of the job %s: %v", job.Name, err)
+	}
+	if terminated.ExitCode != 0 {
+		return string(logs), utilexec.CodeExitError{
+			Err:  fmt.Errorf("command terminated with exit code %d", terminated.ExitCode),
+			Code: int(terminated.ExitCode),
+		}
+	}
+	return string(logs), nil
+}
+
+// Adapts a command for a Job: the pod is reached through its IP, and SQL*Plus connects as sysdba through the password
+// store. The commands acting on the processes of the pod are rejected
+func jobCommand(command []string, podIP string) ([]string, error) {
+	if len(command) < 3 || command[len(command)-2] != "-c" {
+		return command, nil
+	}
+	script := command[len(command)-1]
+	for _, pattern := range podExecOnlyCommands {
+		if strings.Contains(script, pattern) {
+			return nil, ErrPodExecRequired
+		}
+	}
+	if podIP == "" {
+		return nil, errors.New("the pod has no IP yet")
+	}
+	script = strings.ReplaceAll(script, "localhost", podIP)
+	if strings.Contains(script, "sqlplus") {
+		script = strings.NewReplacer(SQLPlusCLI, JobSQLClient, PasswordStoreSQLClient, JobSQLClient).Replace(script)
+		script = fmt.Sprintf(JobSQLClientSetupCMD, podIP) + script
+	}
+	return append(append([]string{}, command[:len(command)-1]...), script), nil
+}
+
+// Job running a command in a clone of a container of a pod, on the node of the pod
+func commandJob(pod *corev1.Pod, container *corev1.Container, command []string) *batchv1.Job {
+	backoffLimit := int32(0)
+	ttl := CommandJobTTL
+	name := pod.Name
+	if len(name) > 50 {
+		name = name[:50]
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-cmd-" + GenerateRandomString(5),
+			Namespace: pod.Namespace,
+			Labels:    map[string]string{CommandJobLabel: pod.Name},
+			// Deleted with the pod, should the operator not delete it
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Name:       pod.Name,
+				UID:        pod.UID,
+			}},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{CommandJobLabel: pod.Name},
+				},
+				Spec: corev1.PodSpec{
+					NodeName:           pod.Spec.NodeName,
+					Volumes:            pod.Spec.Volumes,
+					SecurityContext:    pod.Spec.SecurityContext,
+					ServiceAccountName: pod.Spec.ServiceAccountName,
+					ImagePullSecrets:   pod.Spec.ImagePullSecrets,
+					Tolerations:        pod.Spec.Tolerations,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:            "command",
+						Image:           container.Image,
+						Command:         command,
+						Env:             container.Env,
+						EnvFrom:         container.EnvFrom,
+						VolumeMounts:    container.VolumeMounts,
+						SecurityContext: container.SecurityContext,
+					}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,66 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Job command executor", func() {
+	It("Should reach the pod through its IP", func() {
+		command, err := jobCommand([]string{"bash", "-c", "curl -sSk https://localhost:8443/ords/"}, "10.0.0.7")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(command).To(Equal([]string{"bash", "-c", "curl -sSk https://10.0.0.7:8443/ords/"}))
+	})
+
+	It("Should connect SQL*Plus through the password store", func() {
+		command, err := jobCommand([]string{"bash", "-c", "echo -e \"select 1 from dual;\" | " + SQLPlusCLI}, "10.0.0.7")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(command[2]).To(HavePrefix("mkdir -p /tmp/seps && sed 's/(HOST=localhost)/(HOST=10.0.0.7)/' "))
+		Expect(command[2]).To(HaveSuffix("echo -e \"select 1 from dual;\" | " + JobSQLClient))
+	})
+
+	It("Should reject the commands acting on the processes of the pod", func() {
+		_, err := jobCommand([]string{"bash", "-c", RunDatapatchCMD}, "10.0.0.7")
+		Expect(err).To(MatchError(ErrPodExecRequired))
+		_, err = jobCommand([]string{"bash", "-c", EnableTcpsCMD}, "10.0.0.7")
+		Expect(err).To(MatchError(ErrPodExecRequired))
+	})
+})
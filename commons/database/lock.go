@@ -0,0 +1,186 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Duration for which a database admin lock lease stays valid without being renewed
+const DatabaseLockLeaseDuration int32 = 300
+
+// In-memory registry of database admin locks held by reconcilers of this operator process
+var dbLocks = struct {
+	sync.Mutex
+	holders map[string]string
+}{holders: make(map[string]string)}
+
+func databaseLockKey(namespace string, dbName string) string {
+	return namespace + "/" + dbName
+}
+
+// Name of the Lease object backing the admin lock of a database
+func DatabaseLockName(dbName string) string {
+	return dbName + "-admin-lock"
+}
+
+// Returns the lock holder identity for a given custom resource kind and name
+func DatabaseLockHolder(kind string, namespace string, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// Acquires the administrative SQL lock on the database dbName in namespace for holder.
+// The lock is re-entrant for the same holder. It is held in memory and backed by a
+// coordination.k8s.io Lease so that operator processes that do not share memory are also serialized.
+// Returns false if the lock is currently held by someone else.
+func LockDatabase(ctx context.Context, c client.Client, namespace string, dbName string, holder string) (bool, error) {
+	key := databaseLockKey(namespace, dbName)
+
+	dbLocks.Lock()
+	if current, ok := dbLocks.holders[key]; ok && current != holder {
+		dbLocks.Unlock()
+		return false, nil
+	}
+	dbLocks.holders[key] = holder
+	dbLocks.Unlock()
+
+	acquired, err := acquireLease(ctx, c, namespace, DatabaseLockName(dbName), holder, DatabaseLockLeaseDuration)
+	if err != nil || !acquired {
+		releaseInMemoryLock(key, holder)
+		return false, err
+	}
+	return true, nil
+}
+
+// Releases the administrative SQL lock on the database dbName in namespace if it is held by holder
+func UnlockDatabase(ctx context.Context, c client.Client, namespace string, dbName string, holder string) error {
+	releaseInMemoryLock(databaseLockKey(namespace, dbName), holder)
+	return releaseLease(ctx, c, namespace, DatabaseLockName(dbName), holder)
+}
+
+func releaseInMemoryLock(key string, holder string) {
+	dbLocks.Lock()
+	defer dbLocks.Unlock()
+	if dbLocks.holders[key] == holder {
+		delete(dbLocks.holders, key)
+	}
+}
+
+// Deletes the lease name in namespace if it is held by holder
+func releaseLease(ctx context.Context, c client.Client, namespace string, name string, holder string) error {
+	lease := &coordinationv1.Lease{}
+	err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, lease)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != holder {
+		return nil
+	}
+	err = c.Delete(ctx, lease)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// Creates or renews the lease name in namespace for holder, unless another holder has it and it has not expired
+func acquireLease(ctx context.Context, c client.Client, namespace string, name string, holder string, leaseDuration int32) (bool, error) {
+	now := metav1.NewMicroTime(time.Now())
+
+	lease := &coordinationv1.Lease{}
+	err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, lease)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &holder,
+				LeaseDurationSeconds: &leaseDuration,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		err = c.Create(ctx, lease)
+		if apierrors.IsAlreadyExists(err) {
+			// Lost the race to another holder
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != holder && !isLeaseExpired(lease) {
+		return false, nil
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != holder {
+		lease.Spec.HolderIdentity = &holder
+		lease.Spec.AcquireTime = &now
+	}
+	lease.Spec.LeaseDurationSeconds = &leaseDuration
+	lease.Spec.RenewTime = &now
+	err = c.Update(ctx, lease)
+	if apierrors.IsConflict(err) {
+		// Lease modified concurrently, retry on next reconcile
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func isLeaseExpired(lease *coordinationv1.Lease) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return time.Now().After(expiry)
+}
@@ -0,0 +1,142 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Database admin lock", func() {
+	const namespace = "default"
+	ctx := context.TODO()
+	leaseName := DatabaseLockName("sidb-sample")
+	holder := DatabaseLockHolder("SingleInstanceDatabase", namespace, "sidb-sample")
+	other := DatabaseLockHolder("OracleRestDataService", namespace, "ords-sample")
+
+	var c client.Client
+
+	BeforeEach(func() {
+		c = fake.NewClientBuilder().Build()
+	})
+
+	getLease := func() *coordinationv1.Lease {
+		lease := &coordinationv1.Lease{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: leaseName, Namespace: namespace}, lease)).To(Succeed())
+		return lease
+	}
+
+	It("Should create the lease for its first holder", func() {
+		acquired, err := acquireLease(ctx, c, namespace, leaseName, holder, 60)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acquired).To(BeTrue())
+		Expect(*getLease().Spec.HolderIdentity).To(Equal(holder))
+		Expect(*getLease().Spec.LeaseDurationSeconds).To(Equal(int32(60)))
+	})
+
+	It("Should renew the lease of its holder and refuse it to another holder", func() {
+		_, err := acquireLease(ctx, c, namespace, leaseName, holder, 60)
+		Expect(err).NotTo(HaveOccurred())
+		acquireTime := getLease().Spec.AcquireTime
+
+		acquired, err := acquireLease(ctx, c, namespace, leaseName, holder, 60)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acquired).To(BeTrue())
+		Expect(getLease().Spec.AcquireTime.Equal(acquireTime)).To(BeTrue())
+
+		acquired, err = acquireLease(ctx, c, namespace, leaseName, other, 60)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acquired).To(BeFalse())
+		Expect(*getLease().Spec.HolderIdentity).To(Equal(holder))
+	})
+
+	It("Should hand an expired lease over to another holder", func() {
+		_, err := acquireLease(ctx, c, namespace, leaseName, holder, 60)
+		Expect(err).NotTo(HaveOccurred())
+		lease := getLease()
+		expired := metav1.NewMicroTime(time.Now().Add(-2 * time.Minute))
+		lease.Spec.RenewTime = &expired
+		Expect(c.Update(ctx, lease)).To(Succeed())
+
+		acquired, err := acquireLease(ctx, c, namespace, leaseName, other, 60)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acquired).To(BeTrue())
+		Expect(*getLease().Spec.HolderIdentity).To(Equal(other))
+	})
+
+	It("Should only release the lease of its holder", func() {
+		_, err := acquireLease(ctx, c, namespace, leaseName, holder, 60)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(releaseLease(ctx, c, namespace, leaseName, other)).To(Succeed())
+		Expect(*getLease().Spec.HolderIdentity).To(Equal(holder))
+
+		Expect(releaseLease(ctx, c, namespace, leaseName, holder)).To(Succeed())
+		err = c.Get(ctx, types.NamespacedName{Name: leaseName, Namespace: namespace}, &coordinationv1.Lease{})
+		Expect(err).To(HaveOccurred())
+
+		// Releasing a lease that no longer exists is not an error
+		Expect(releaseLease(ctx, c, namespace, leaseName, holder)).To(Succeed())
+	})
+
+	It("Should serialize the holders of the database lock", func() {
+		locked, err := LockDatabase(ctx, c, namespace, "sidb-sample", holder)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(locked).To(BeTrue())
+
+		locked, err = LockDatabase(ctx, c, namespace, "sidb-sample", other)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(locked).To(BeFalse())
+
+		Expect(UnlockDatabase(ctx, c, namespace, "sidb-sample", holder)).To(Succeed())
+		locked, err = LockDatabase(ctx, c, namespace, "sidb-sample", other)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(locked).To(BeTrue())
+		Expect(UnlockDatabase(ctx, c, namespace, "sidb-sample", other)).To(Succeed())
+	})
+})
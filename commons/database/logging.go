@@ -0,0 +1,82 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Verbosity of the commands run in the pods and of their output, above the debug level of the development logger of the
+// operator so that they are only logged for the annotated resources, or with --zap-log-level=2
+const CommandLogVerbosity int = 2
+
+var debugLogger = struct {
+	sync.RWMutex
+	logger *logr.Logger
+}{}
+
+// SetDebugLogger sets the logger of the reconciles of the resources annotated with database.oracle.com/log-level:
+// debug. It logs at the CommandLogVerbosity whatever the level of the operator
+func SetDebugLogger(logger logr.Logger) {
+	debugLogger.Lock()
+	defer debugLogger.Unlock()
+	debugLogger.logger = &logger
+}
+
+// WithLogLevel returns ctx with the debug logger when obj is annotated with database.oracle.com/log-level: debug,
+// so that the commands run in its pods are logged with their output, except those holding secrets.
+// Other values, or no debug logger, leave the logger of ctx unchanged
+func WithLogLevel(ctx context.Context, obj client.Object) context.Context {
+	if !strings.EqualFold(obj.GetAnnotations()[LogLevelAnnotation], LogLevelDebug) {
+		return ctx
+	}
+	debugLogger.RLock()
+	defer debugLogger.RUnlock()
+	if debugLogger.logger == nil {
+		return ctx
+	}
+	return ctrllog.IntoContext(ctx, debugLogger.logger.WithValues("namespace", obj.GetNamespace(), "name", obj.GetName(),
+		"logLevel", LogLevelDebug))
+}
@@ -0,0 +1,133 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// URL probed by DetectOffline. The registry of the Oracle images is the first external location a cluster needs
+const OfflineProbeURL string = "https://container-registry.oracle.com"
+
+// Timeout of the probe of DetectOffline
+const OfflineProbeTimeout time.Duration = 10 * time.Second
+
+// In offline mode, the steps reaching out to the internet with no mirror configured are skipped instead of hanging
+// on the network, and the resources report them in their Offline condition. The mirrors redirect the URLs and images
+// starting with a prefix to an internal location, whether or not the operator runs in offline mode
+var offline struct {
+	sync.RWMutex
+	enabled bool
+	mirrors map[string]string
+}
+
+// Sets whether the operator runs in offline mode
+func SetOfflineMode(enabled bool) {
+	offline.Lock()
+	offline.enabled = enabled
+	offline.Unlock()
+}
+
+// Returns true if the operator runs in offline mode
+func IsOfflineMode() bool {
+	offline.RLock()
+	defer offline.RUnlock()
+	return offline.enabled
+}
+
+// Sets the mirrors from a comma separated list of prefix=replacement, such as
+// container-registry.oracle.com=registry.internal/oracle,https://download.oracle.com=https://mirror.internal/oracle
+func SetMirrors(mirrors string) error {
+	parsed := make(map[string]string)
+	for _, mirror := range strings.Split(mirrors, ",") {
+		if strings.TrimSpace(mirror) == "" {
+			continue
+		}
+		prefix, replacement, found := strings.Cut(mirror, "=")
+		prefix, replacement = strings.TrimSpace(prefix), strings.TrimSpace(replacement)
+		if !found || prefix == "" || replacement == "" {
+			return fmt.Errorf("invalid mirror %q, use prefix=replacement", mirror)
+		}
+		parsed[prefix] = replacement
+	}
+	offline.Lock()
+	offline.mirrors = parsed
+	offline.Unlock()
+	return nil
+}
+
+// Returns the location with the longest matching prefix of the mirrors replaced, and whether it is reachable:
+// in offline mode, a location with no mirror is not
+func ResolveExternalLocation(location string) (string, bool) {
+	offline.RLock()
+	defer offline.RUnlock()
+	prefixes := make([]string, 0, len(offline.mirrors))
+	for prefix := range offline.mirrors {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(location, prefix) {
+			return offline.mirrors[prefix] + strings.TrimPrefix(location, prefix), true
+		}
+	}
+	return location, !offline.enabled
+}
+
+// Returns true if OfflineProbeURL cannot be reached, through the proxy of the operator if any
+func DetectOffline(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, OfflineProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, OfflineProbeURL, nil)
+	if err != nil {
+		return true
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return true
+	}
+	resp.Body.Close()
+	return false
+}
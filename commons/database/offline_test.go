@@ -0,0 +1,79 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Offline mode", func() {
+	AfterEach(func() {
+		SetOfflineMode(false)
+		Expect(SetMirrors("")).To(Succeed())
+	})
+
+	It("Should redirect the locations to the longest matching mirror", func() {
+		Expect(SetMirrors("https://download.oracle.com=https://mirror.internal,https://download.oracle.com/otn=https://otn.internal")).To(Succeed())
+		location, reachable := ResolveExternalLocation("https://download.oracle.com/otn/apex.zip")
+		Expect(location).To(Equal("https://otn.internal/apex.zip"))
+		Expect(reachable).To(BeTrue())
+		location, _ = ResolveExternalLocation("https://download.oracle.com/ords.zip")
+		Expect(location).To(Equal("https://mirror.internal/ords.zip"))
+	})
+
+	It("Should only report the locations with no mirror unreachable in offline mode", func() {
+		Expect(SetMirrors("container-registry.oracle.com=registry.internal/oracle")).To(Succeed())
+		_, reachable := ResolveExternalLocation("https://download.oracle.com/apex.zip")
+		Expect(reachable).To(BeTrue())
+
+		SetOfflineMode(true)
+		location, reachable := ResolveExternalLocation("https://download.oracle.com/apex.zip")
+		Expect(location).To(Equal("https://download.oracle.com/apex.zip"))
+		Expect(reachable).To(BeFalse())
+		location, reachable = ResolveExternalLocation(TestModeFreeImage)
+		Expect(location).To(Equal("registry.internal/oracle/database/free:latest"))
+		Expect(reachable).To(BeTrue())
+	})
+
+	It("Should reject a mirror with no replacement", func() {
+		Expect(SetMirrors("container-registry.oracle.com")).NotTo(Succeed())
+		Expect(SetMirrors("container-registry.oracle.com=")).NotTo(Succeed())
+	})
+})
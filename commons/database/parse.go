@@ -0,0 +1,334 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Returns true if the SQL*Plus output out has no rows, or reports an ORA- error instead of the rows
+func IsQueryOutputEmpty(out string) bool {
+	return strings.Contains(out, "no rows selected") || strings.Contains(out, "ORA-")
+}
+
+// Returns all the values of a single column query from its SQL*Plus output.
+// Spaces within values are replaced by "_"
+func ParseColumnValues(out string) ([]string, error) {
+	if IsQueryOutputEmpty(out) {
+		return []string{}, errors.New("query returned no rows")
+	}
+	fields := strings.Fields(strings.Replace(out, " ", "_", -1))
+	// first 2 values will be column name and a seperator(--------------)
+	if len(fields) < 3 {
+		return []string{}, errors.New("unexpected query output")
+	}
+	return fields[2:], nil
+}
+
+// Returns the first value of a single column query from its SQL*Plus output
+func ParseColumnValue(out string) (string, error) {
+	values, err := ParseColumnValues(out)
+	if err != nil {
+		return "", err
+	}
+	return values[0], nil
+}
+
+// Returns flashBackStatus, archiveLogStatus, forceLoggingStatus from the output of CheckModesSQL
+func ParseDBModes(out string) (bool, bool, bool) {
+	flashBackStatus := strings.Contains(out, "flashback_on:YES")
+	archiveLogStatus := strings.Contains(out, "log_mode:ARCHIVELOG")
+	forceLoggingStatus := strings.Contains(out, "force_logging:YES")
+	return flashBackStatus, archiveLogStatus, forceLoggingStatus
+}
+
+// Returns sid, pdbName, edition from the output of GetSidPdbEditionCMD
+func ParseSidPdbEdition(out string) (string, string, string, error) {
+	splitstr := strings.Split(strings.TrimSpace(out), ",")
+	if len(splitstr) != 3 {
+		return "", "", "", errors.New("unexpected output " + out)
+	}
+	return splitstr[0], splitstr[1], splitstr[2], nil
+}
+
+// Returns the version of ORDS from the output of GetOrdsVersionCMD, or "" if it is not found
+func ParseOrdsVersion(out string) string {
+	if match := regexp.MustCompile(`Oracle REST Data Services\s+(\d+(\.\w+)+)`).FindStringSubmatch(out); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// Returns the version of APEX from the output of GetApexVersionSQL or IsApexInstalled, or "" if APEX is not installed
+func ParseApexVersion(out string) string {
+	i := strings.LastIndex(out, "APEXVERSION:")
+	if i < 0 {
+		return ""
+	}
+	return strings.TrimSpace(strings.SplitN(out[i+len("APEXVERSION:"):], "\n", 2)[0])
+}
+
+// Returns the source and target versions from a row of GetSqlpatchVersionSQL output
+func ParseSqlpatchVersions(line string) (string, string, error) {
+	splitstr := strings.Split(line, ":")
+	if len(splitstr) != 2 {
+		return "", "", errors.New("unexpected sqlpatch versions " + line)
+	}
+	return splitstr[0], splitstr[1], nil
+}
+
+// Returns whether fast-start failover is enabled, its threshold and lag limit in seconds, and the
+// observer host from the output of ShowFSFOCMD. Values not present in out are returned as 0 and ""
+func ParseFSFOStatus(out string) (bool, int, int, string) {
+	enabled := false
+	if match := regexp.MustCompile(`(?i)Fast-Start Failover:\s+(\w+)`).FindStringSubmatch(out); match != nil {
+		enabled = strings.EqualFold(match[1], "Enabled")
+	}
+	threshold, lagLimit := 0, 0
+	if match := regexp.MustCompile(`Threshold:\s+(\d+) seconds`).FindStringSubmatch(out); match != nil {
+		threshold, _ = strconv.Atoi(match[1])
+	}
+	if match := regexp.MustCompile(`Lag Limit:\s+(\d+) seconds`).FindStringSubmatch(out); match != nil {
+		lagLimit, _ = strconv.Atoi(match[1])
+	}
+	observer := ""
+	if match := regexp.MustCompile(`Observer:\s+(\S+)`).FindStringSubmatch(out); match != nil && match[1] != "(none)" {
+		observer = match[1]
+	}
+	return enabled, threshold, lagLimit, observer
+}
+
+// Returns the errors and the number of lines of the alert log from the output of AlertLogErrorsCMD.
+// The number of lines is -1 when the database has not created its alert log yet
+func ParseAlertLogErrors(out string) ([]string, int, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	last := lines[len(lines)-1]
+	if !strings.HasPrefix(last, "LINES:") {
+		return nil, -1, nil
+	}
+	count, err := strconv.Atoi(strings.TrimPrefix(last, "LINES:"))
+	if err != nil {
+		return nil, -1, errors.New("unexpected alert log line count " + last)
+	}
+	return lines[:len(lines)-1], count, nil
+}
+
+// Returns the components left invalid by datapatch, as con_id:comp_id:status, from the output of GetInvalidComponentsSQL
+func ParseInvalidComponents(out string) ([]string, error) {
+	if strings.Contains(out, "ORA-") {
+		return nil, errors.New("failed to query the registry components: " + strings.TrimSpace(out))
+	}
+	if strings.Contains(out, "no rows selected") {
+		return []string{}, nil
+	}
+	return ParseColumnValues(out)
+}
+
+// Returns the begin and end snapshots from the output of GetPerformanceReportSnapshots. A snapshot not found is returned
+// as 0, and waiting is true while the end snapshot may still be taken
+func ParsePerformanceReportSnapshots(out string) (begin int, end int, waiting bool, err error) {
+	value, err := ParseColumnValue(out)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	splitstr := strings.Split(value, ":")
+	if len(splitstr) != 2 {
+		return 0, 0, false, errors.New("unexpected snapshots " + value)
+	}
+	snapshots := []int{0, 0}
+	for i := range splitstr {
+		if splitstr[i] == "none" || (i == 1 && splitstr[i] == "wait") {
+			continue
+		}
+		if snapshots[i], err = strconv.Atoi(splitstr[i]); err != nil {
+			return 0, 0, false, errors.New("unexpected snapshots " + value)
+		}
+	}
+	return snapshots[0], snapshots[1], splitstr[1] == "wait", nil
+}
+
+// A session blocking other sessions, as listed by GetBlockingSessionsSQL
+type BlockingSession struct {
+	Session         string
+	Username        string
+	BlockedSessions int
+	BlockedSeconds  int
+}
+
+// Returns the blocking sessions from the output of GetBlockingSessionsSQL
+func ParseBlockingSessions(out string) ([]BlockingSession, error) {
+	if strings.Contains(out, "no rows selected") {
+		return nil, nil
+	}
+	rows, err := ParseColumnValues(out)
+	if err != nil {
+		return nil, err
+	}
+	var sessions []BlockingSession
+	for _, row := range rows {
+		fields := strings.Split(row, ",")
+		if len(fields) != 5 {
+			return nil, errors.New("unexpected blocking session " + row)
+		}
+		blockedSessions, err1 := strconv.Atoi(fields[3])
+		blockedSeconds, err2 := strconv.Atoi(fields[4])
+		if err1 != nil || err2 != nil {
+			return nil, errors.New("unexpected blocking session " + row)
+		}
+		sessions = append(sessions, BlockingSession{
+			Session:         fields[0] + "," + fields[1],
+			Username:        fields[2],
+			BlockedSessions: blockedSessions,
+			BlockedSeconds:  blockedSeconds,
+		})
+	}
+	return sessions, nil
+}
+
+// Data Guard lags and redo rates, as listed by GetReplicationStatsSQL. Nil values are not known to the database
+type ReplicationStats struct {
+	TransportLagSeconds          *int64
+	ApplyLagSeconds              *int64
+	ApplyRateBytesPerSecond      *int64
+	RedoGenerationBytesPerSecond *int64
+}
+
+// Returns the processes parameter and the number of background processes from the output of GetProcessLimitsSQL
+func ParseProcessLimits(out string) (processes int, background int, err error) {
+	rows, err := ParseColumnValues(out)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, row := range rows {
+		name, value, found := strings.Cut(row, ",")
+		parsed, err := strconv.Atoi(value)
+		if !found || err != nil {
+			return 0, 0, errors.New("unexpected process limit " + row)
+		}
+		switch name {
+		case "processes":
+			processes = parsed
+		case "background_processes":
+			background = parsed
+		}
+	}
+	if processes == 0 {
+		return 0, 0, errors.New("processes parameter not found in " + out)
+	}
+	return processes, background, nil
+}
+
+// Returns the lags and the rates from the output of GetReplicationStatsSQL
+func ParseReplicationStats(out string) (ReplicationStats, error) {
+	stats := ReplicationStats{}
+	if strings.Contains(out, "no rows selected") {
+		return stats, nil
+	}
+	rows, err := ParseColumnValues(out)
+	if err != nil {
+		return stats, err
+	}
+	for _, row := range rows {
+		name, value, found := strings.Cut(row, ",")
+		if !found {
+			return stats, errors.New("unexpected replication statistic " + row)
+		}
+		if value == "" {
+			continue
+		}
+		var parsed int64
+		var err error
+		if strings.HasSuffix(name, "_lag") {
+			parsed, err = parseIntervalSeconds(value)
+		} else {
+			parsed, err = strconv.ParseInt(value, 10, 64)
+		}
+		if err != nil {
+			return stats, errors.New("unexpected replication statistic " + row)
+		}
+		switch name {
+		case "transport_lag":
+			stats.TransportLagSeconds = &parsed
+		case "apply_lag":
+			stats.ApplyLagSeconds = &parsed
+		case "apply_rate":
+			stats.ApplyRateBytesPerSecond = &parsed
+		case "redo_generation_rate":
+			stats.RedoGenerationBytesPerSecond = &parsed
+		}
+	}
+	return stats, nil
+}
+
+// Day to second interval such as +00 00:01:05, whose space may be replaced by "_"
+var intervalPattern = regexp.MustCompile(`^\+?(\d+)[ _](\d+):(\d+):(\d+)`)
+
+// Returns the seconds of a day to second interval
+func parseIntervalSeconds(interval string) (int64, error) {
+	match := intervalPattern.FindStringSubmatch(interval)
+	if match == nil {
+		return 0, errors.New("unexpected interval " + interval)
+	}
+	seconds := int64(0)
+	for i, factor := range []int64{86400, 3600, 60, 1} {
+		value, _ := strconv.ParseInt(match[i+1], 10, 64)
+		seconds += value * factor
+	}
+	return seconds, nil
+}
+
+// Returns the number of bytes of a size such as 512M or 2G, as accepted by the Oracle size parameters
+func ParseSizeBytes(size string) (int64, error) {
+	size = strings.ToUpper(strings.TrimSpace(size))
+	multiplier := int64(1)
+	if size != "" {
+		if i := strings.IndexByte("KMGT", size[len(size)-1]); i >= 0 {
+			multiplier = int64(1) << (10 * (i + 1))
+			size = size[:len(size)-1]
+		}
+	}
+	value, err := strconv.ParseInt(size, 10, 64)
+	if err != nil || value < 0 {
+		return 0, errors.New("unexpected size " + size)
+	}
+	return value * multiplier, nil
+}
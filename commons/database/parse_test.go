@@ -0,0 +1,306 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parsing SQL*Plus output", func() {
+	Describe("ParseColumnValue", func() {
+		It("Should return the first value after the column header", func() {
+			out := "\nDATABASE_ROLE\n----------------\nPHYSICAL STANDBY\n"
+			value, err := ParseColumnValue(out)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal("PHYSICAL_STANDBY"))
+		})
+
+		It("Should fail when no rows are selected", func() {
+			_, err := ParseColumnValue("\nno rows selected\n")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should fail on ORA- errors", func() {
+			_, err := ParseColumnValue("ORA-01034: ORACLE not available")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should fail on truncated output", func() {
+			_, err := ParseColumnValue("\nVERSION_FULL\n")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseColumnValues", func() {
+		It("Should return all the rows", func() {
+			out := "\nDATABASE\n--------------------\nORCLCDB:PRIMARY\nORCLS1:PHYSICAL_STANDBY\n"
+			values, err := ParseColumnValues(out)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(values).To(Equal([]string{"ORCLCDB:PRIMARY", "ORCLS1:PHYSICAL_STANDBY"}))
+		})
+	})
+
+	Describe("ParseDBModes", func() {
+		It("Should parse enabled modes", func() {
+			flashBack, archiveLog, forceLogging := ParseDBModes("log_mode:ARCHIVELOG flashback_on:YES force_logging:YES")
+			Expect(flashBack).To(BeTrue())
+			Expect(archiveLog).To(BeTrue())
+			Expect(forceLogging).To(BeTrue())
+		})
+
+		It("Should parse disabled modes", func() {
+			flashBack, archiveLog, forceLogging := ParseDBModes("log_mode:NOARCHIVELOG flashback_on:NO force_logging:NO")
+			Expect(flashBack).To(BeFalse())
+			Expect(archiveLog).To(BeFalse())
+			Expect(forceLogging).To(BeFalse())
+		})
+	})
+
+	Describe("ParseSidPdbEdition", func() {
+		It("Should split sid, pdb and edition", func() {
+			sid, pdb, edition, err := ParseSidPdbEdition("ORCLCDB,ORCLPDB1,enterprise\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect([]string{sid, pdb, edition}).To(Equal([]string{"ORCLCDB", "ORCLPDB1", "enterprise"}))
+		})
+
+		It("Should fail on unexpected output", func() {
+			_, _, _, err := ParseSidPdbEdition("ORCLCDB")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseOrdsVersion", func() {
+		It("Should read the version of ORDS", func() {
+			Expect(ParseOrdsVersion("Oracle REST Data Services 21.4.2.r0621806\n")).To(Equal("21.4.2.r0621806"))
+		})
+		It("Should return an empty version for another output", func() {
+			Expect(ParseOrdsVersion("Error: Unable to access jarfile /opt/oracle/ords/ords.war")).To(BeEmpty())
+		})
+	})
+
+	Describe("ParseApexVersion", func() {
+		It("Should read the version of APEX", func() {
+			Expect(ParseApexVersion("\nVERSION\n--------------------\nAPEXVERSION:22.2.0\n")).To(Equal("22.2.0"))
+		})
+		It("Should return an empty version when APEX is not installed", func() {
+			Expect(ParseApexVersion("\nno rows selected\n")).To(BeEmpty())
+		})
+	})
+
+	Describe("ParseSqlpatchVersions", func() {
+		It("Should split source and target versions", func() {
+			source, target, err := ParseSqlpatchVersions("19.3.0.0.0:19.19.0.0.0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(source).To(Equal("19.3.0.0.0"))
+			Expect(target).To(Equal("19.19.0.0.0"))
+		})
+	})
+
+	Describe("ParseFSFOStatus", func() {
+		It("Should parse an enabled configuration with an observer", func() {
+			out := "\nFast-Start Failover: Enabled in Potential Data Loss Mode\n\n" +
+				"  Protection Mode:    MaxPerformance\n" +
+				"  Lag Limit:          30 seconds\n\n" +
+				"  Threshold:          45 seconds\n" +
+				"  Active Target:      ORCLS1\n" +
+				"  Observer:           dgbroker-sample-observer\n"
+			enabled, threshold, lagLimit, observer := ParseFSFOStatus(out)
+			Expect(enabled).To(BeTrue())
+			Expect(threshold).To(Equal(45))
+			Expect(lagLimit).To(Equal(30))
+			Expect(observer).To(Equal("dgbroker-sample-observer"))
+		})
+		It("Should parse a disabled configuration", func() {
+			enabled, threshold, lagLimit, observer := ParseFSFOStatus("\nFast-Start Failover:  Disabled\n\n  Observer:           (none)\n")
+			Expect(enabled).To(BeFalse())
+			Expect(threshold).To(Equal(0))
+			Expect(lagLimit).To(Equal(0))
+			Expect(observer).To(BeEmpty())
+		})
+	})
+
+	Describe("ParseAlertLogErrors", func() {
+		It("Should return the errors and the line count", func() {
+			errs, count, err := ParseAlertLogErrors("ORA-00600: internal error code\nORA-01555: snapshot too old\nLINES:1042\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(errs).To(Equal([]string{"ORA-00600: internal error code", "ORA-01555: snapshot too old"}))
+			Expect(count).To(Equal(1042))
+		})
+
+		It("Should return no errors for a clean alert log", func() {
+			errs, count, err := ParseAlertLogErrors("LINES:12\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(errs).To(BeEmpty())
+			Expect(count).To(Equal(12))
+		})
+
+		It("Should return -1 when there is no alert log yet", func() {
+			_, count, err := ParseAlertLogErrors("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(-1))
+		})
+	})
+
+	Describe("ParseInvalidComponents", func() {
+		It("Should return the invalid components of each container", func() {
+			out := "\nCOMPONENTS\n--------------------\n1:CATPROC:INVALID\n3:APEX:UPGRADED\n"
+			components, err := ParseInvalidComponents(out)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(components).To(Equal([]string{"1:CATPROC:INVALID", "3:APEX:UPGRADED"}))
+		})
+
+		It("Should return no components when all are valid", func() {
+			components, err := ParseInvalidComponents("\nno rows selected\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(components).To(BeEmpty())
+		})
+
+		It("Should fail on ORA- errors", func() {
+			_, err := ParseInvalidComponents("ORA-00942: table or view does not exist")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParsePerformanceReportSnapshots", func() {
+		It("Should return the begin and end snapshots", func() {
+			begin, end, waiting, err := ParsePerformanceReportSnapshots("\nSNAPSHOTS\n--------------------\n1041:1043\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(begin).To(Equal(1041))
+			Expect(end).To(Equal(1043))
+			Expect(waiting).To(BeFalse())
+		})
+
+		It("Should return 0 for a snapshot not taken", func() {
+			begin, end, waiting, err := ParsePerformanceReportSnapshots("\nSNAPSHOTS\n--------------------\n1041:none\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(begin).To(Equal(1041))
+			Expect(end).To(Equal(0))
+			Expect(waiting).To(BeFalse())
+		})
+
+		It("Should wait for an end snapshot not taken yet", func() {
+			begin, end, waiting, err := ParsePerformanceReportSnapshots("\nSNAPSHOTS\n--------------------\n1041:wait\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(begin).To(Equal(1041))
+			Expect(end).To(Equal(0))
+			Expect(waiting).To(BeTrue())
+			_, _, _, err = ParsePerformanceReportSnapshots("\nSNAPSHOTS\n--------------------\nwait:1043\n")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should fail on ORA- errors", func() {
+			_, _, _, err := ParsePerformanceReportSnapshots("ORA-00942: table or view does not exist")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseBlockingSessions", func() {
+		It("Should return the blocking sessions", func() {
+			out := "\nBLOCKERS\n--------------------\n123,4567,SCOTT,3,315\n45,891,HR,1,12\n"
+			sessions, err := ParseBlockingSessions(out)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sessions).To(Equal([]BlockingSession{
+				{Session: "123,4567", Username: "SCOTT", BlockedSessions: 3, BlockedSeconds: 315},
+				{Session: "45,891", Username: "HR", BlockedSessions: 1, BlockedSeconds: 12},
+			}))
+		})
+
+		It("Should return no sessions when nothing is blocked", func() {
+			sessions, err := ParseBlockingSessions("\nno rows selected\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sessions).To(BeEmpty())
+		})
+	})
+
+	Describe("ParseReplicationStats", func() {
+		It("Should return the lags and the apply rate of a standby", func() {
+			out := "\nSTATS\n--------------------\ntransport_lag,+00 00:00:02\napply_lag,+00 01:02:05\n" +
+				"apply_rate,524288\nredo_generation_rate,0\n"
+			stats, err := ParseReplicationStats(out)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*stats.TransportLagSeconds).To(Equal(int64(2)))
+			Expect(*stats.ApplyLagSeconds).To(Equal(int64(3725)))
+			Expect(*stats.ApplyRateBytesPerSecond).To(Equal(int64(524288)))
+			Expect(*stats.RedoGenerationBytesPerSecond).To(Equal(int64(0)))
+		})
+
+		It("Should omit the values unknown to the database", func() {
+			out := "\nSTATS\n--------------------\ntransport_lag,\napply_lag,\nredo_generation_rate,1048576\n"
+			stats, err := ParseReplicationStats(out)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats.TransportLagSeconds).To(BeNil())
+			Expect(stats.ApplyLagSeconds).To(BeNil())
+			Expect(stats.ApplyRateBytesPerSecond).To(BeNil())
+			Expect(*stats.RedoGenerationBytesPerSecond).To(Equal(int64(1048576)))
+		})
+
+		It("Should fail on an unexpected lag", func() {
+			_, err := ParseReplicationStats("\nSTATS\n--------------------\napply_lag,unknown\n")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseProcessLimits", func() {
+		It("Should return the processes parameter and the background processes", func() {
+			processes, background, err := ParseProcessLimits("\nLIMITS\n--------------------\nprocesses,320\nbackground_processes,58\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(processes).To(Equal(320))
+			Expect(background).To(Equal(58))
+		})
+
+		It("Should fail without the processes parameter", func() {
+			_, _, err := ParseProcessLimits("\nLIMITS\n--------------------\nbackground_processes,58\n")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseSizeBytes", func() {
+		It("Should return the bytes of a size", func() {
+			Expect(ParseSizeBytes("1024")).To(Equal(int64(1024)))
+			Expect(ParseSizeBytes("512m")).To(Equal(int64(512 * 1024 * 1024)))
+			Expect(ParseSizeBytes("2G")).To(Equal(int64(2 * 1024 * 1024 * 1024)))
+		})
+
+		It("Should fail on an unexpected size", func() {
+			_, err := ParseSizeBytes("UNLIMITED")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,95 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	transportLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "oracle_database_operator_dataguard_transport_lag_seconds",
+		Help: "Time between the last redo received by a standby database and the last redo generated by its primary",
+	}, []string{"namespace", "database"})
+	applyLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "oracle_database_operator_dataguard_apply_lag_seconds",
+		Help: "Time between the last redo applied by a standby database and the last redo generated by its primary",
+	}, []string{"namespace", "database"})
+	applyRate = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "oracle_database_operator_dataguard_apply_rate_bytes_per_second",
+		Help: "Redo applied per second by the managed recovery of a standby database",
+	}, []string{"namespace", "database"})
+	redoGenerationRate = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "oracle_database_operator_redo_generation_rate_bytes_per_second",
+		Help: "Redo generated per second by a primary database over the last minute",
+	}, []string{"namespace", "database"})
+	replicationUpdated = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "oracle_database_operator_replication_updated_timestamp_seconds",
+		Help: "Unix time of the last measure of the replication of a database",
+	}, []string{"namespace", "database"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(transportLag, applyLag, applyRate, redoGenerationRate, replicationUpdated)
+}
+
+// Publishes the lags and the rates of a database, the unknown values are removed from the metrics
+func RecordReplicationStats(namespace string, database string, stats ReplicationStats) {
+	for gauge, value := range map[*prometheus.GaugeVec]*int64{
+		transportLag:       stats.TransportLagSeconds,
+		applyLag:           stats.ApplyLagSeconds,
+		applyRate:          stats.ApplyRateBytesPerSecond,
+		redoGenerationRate: stats.RedoGenerationBytesPerSecond,
+	} {
+		if value == nil {
+			gauge.DeleteLabelValues(namespace, database)
+		} else {
+			gauge.WithLabelValues(namespace, database).Set(float64(*value))
+		}
+	}
+	replicationUpdated.WithLabelValues(namespace, database).SetToCurrentTime()
+}
+
+// Removes the replication metrics of a database, including the time of their last measure
+func ForgetReplicationStats(namespace string, database string) {
+	for _, gauge := range []*prometheus.GaugeVec{transportLag, applyLag, applyRate, redoGenerationRate, replicationUpdated} {
+		gauge.DeleteLabelValues(namespace, database)
+	}
+}
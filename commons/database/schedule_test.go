@@ -0,0 +1,95 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schedules", func() {
+	Describe("ParseCronSchedule", func() {
+		It("Should reject invalid expressions", func() {
+			for _, spec := range []string{"0 8 * *", "60 8 * * *", "0 8-6 * * *", "0 8 * * mon", "*/0 * * * *"} {
+				_, err := ParseCronSchedule(spec)
+				Expect(err).To(HaveOccurred(), spec)
+			}
+		})
+
+		It("Should find the next matching time", func() {
+			s, err := ParseCronSchedule("30 8 * * 1-5")
+			Expect(err).ToNot(HaveOccurred())
+			// Friday evening to Monday morning
+			from := time.Date(2023, 6, 2, 19, 0, 0, 0, time.UTC)
+			Expect(s.Next(from)).To(Equal(time.Date(2023, 6, 5, 8, 30, 0, 0, time.UTC)))
+		})
+
+		It("Should support steps and Sunday as 7", func() {
+			s, err := ParseCronSchedule("*/15 * * * 7")
+			Expect(err).ToNot(HaveOccurred())
+			from := time.Date(2023, 6, 4, 10, 16, 0, 0, time.UTC)
+			Expect(s.Next(from)).To(Equal(time.Date(2023, 6, 4, 10, 30, 0, 0, time.UTC)))
+		})
+	})
+
+	Describe("GetScheduledState", func() {
+		It("Should be running during office hours", func() {
+			running, next, err := GetScheduledState("0 8 * * 1-5", "0 18 * * 1-5", "Europe/Paris",
+				time.Date(2023, 6, 5, 10, 0, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(running).To(BeTrue())
+			Expect(next.UTC()).To(Equal(time.Date(2023, 6, 5, 16, 0, 0, 0, time.UTC)))
+		})
+
+		It("Should be stopped over the weekend", func() {
+			running, next, err := GetScheduledState("0 8 * * 1-5", "0 18 * * 1-5", "",
+				time.Date(2023, 6, 3, 10, 0, 0, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(running).To(BeFalse())
+			Expect(next).To(Equal(time.Date(2023, 6, 5, 8, 0, 0, 0, time.UTC)))
+		})
+
+		It("Should reject unknown time zones", func() {
+			_, _, err := GetScheduledState("0 8 * * *", "0 18 * * *", "Mars/Olympus", time.Now())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
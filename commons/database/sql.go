@@ -0,0 +1,331 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Returns the shell command piping sql into the SQL*Plus client sqlClient
+func SQLPlusCommandWithClient(sql string, sqlClient string) string {
+	return fmt.Sprintf("echo -e  \"%s\"  | %s", sql, sqlClient)
+}
+
+// Returns the shell command piping sql into "sqlplus -s / as sysdba"
+func SQLPlusCommand(sql string) string {
+	return SQLPlusCommandWithClient(sql, SQLPlusCLI)
+}
+
+// Executes sql as sysdba in the database container of readyPod and returns the SQL*Plus output
+func ExecSQL(r client.Reader, config *rest.Config, readyPod corev1.Pod, ctx context.Context, req ctrl.Request,
+	nologCommand bool, sql string) (string, error) {
+	return ExecCommand(r, config, readyPod.Name, readyPod.Namespace, "", ctx, req, nologCommand, "bash", "-c", SQLPlusCommand(sql))
+}
+
+// Returns the SQL validating the sys password adminPassword
+func ValidateAdminPasswordSQL(adminPassword string) string {
+	return fmt.Sprintf(ValidateAdminPassword, adminPassword)
+}
+
+// Returns the shell command validating the sys password. With usePasswordStore the credential held in the
+// external password store is used, so adminPassword is not passed to the pod
+func ValidateAdminPasswordCommand(adminPassword string, sqlClient string, usePasswordStore bool) string {
+	if usePasswordStore {
+		return SQLPlusCommandWithClient("show user", PasswordStoreSQLClient)
+	}
+	return SQLPlusCommandWithClient(ValidateAdminPasswordSQL(adminPassword), sqlClient)
+}
+
+// Returns the SQL creating the CDB and PDB admin users used by ORDS with the given password, for the database
+// release version
+func SetAdminUsers(adminPassword string, version string) string {
+	return fmt.Sprintf(LoadSQL("set_admin_users", version), adminPassword)
+}
+
+// Returns the SQL dropping users along with their objects, for the database release version
+func DropUsers(users []string, version string) string {
+	var sqls []string
+	for _, user := range users {
+		sqls = append(sqls, fmt.Sprintf(LoadSQL("drop_user", version), user))
+	}
+	return strings.Join(sqls, "\n")
+}
+
+// Returns the SQL creating the DBMS_CLOUD credential credentialName in the PDB from an OCI API signing key.
+// DBMS_CLOUD takes the private key without its PEM header, footer and line breaks
+func CreateCloudCredential(pdbName string, credentialName string, user string, tenancy string, privateKey string,
+	fingerprint string) string {
+	var keyLines []string
+	for _, line := range strings.Split(privateKey, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "-----") {
+			keyLines = append(keyLines, line)
+		}
+	}
+	return fmt.Sprintf(CreateCloudCredentialSQL, pdbName, credentialName, quoteSQLString(strings.TrimSpace(user)),
+		quoteSQLString(strings.TrimSpace(tenancy)), quoteSQLString(strings.Join(keyLines, "")),
+		quoteSQLString(strings.TrimSpace(fingerprint)))
+}
+
+// Returns value as the content of a PL/SQL string literal piped by SQLPlusCommand: the single quotes are doubled, and
+// the backslashes, double quotes, dollars and backquotes are escaped from the double quoted "echo -e" of the shell
+func quoteSQLString(value string) string {
+	return strings.NewReplacer(`\`, `\\\\`, `"`, `\"`, "$", `\$`, "`", "\\`", "'", "''").Replace(value)
+}
+
+// Returns the SQL dropping the DBMS_CLOUD credential credentialName from the PDB
+func DropCloudCredential(pdbName string, credentialName string) string {
+	return fmt.Sprintf(DropCloudCredentialSQL, pdbName, credentialName)
+}
+
+// Returns the SQL killing the session identified by "sid,serial#"
+func KillSession(sessionInfo string) string {
+	return fmt.Sprintf(KillSessionSQL, sessionInfo)
+}
+
+// Returns the SQL fetching the open mode of pdbName
+func GetPdbOpenMode(pdbName string) string {
+	return fmt.Sprintf(GetPdbOpenModeSQL, pdbName)
+}
+
+// Returns the SQL fetching the ORDS status of schema in pdbName
+func GetUserORDSSchemaStatus(schema string, pdbName string) string {
+	return fmt.Sprintf(GetUserORDSSchemaStatusSQL, schema, pdbName)
+}
+
+// Returns the SQL creating the schema user in pdbName
+func CreateORDSSchema(schema string, password string, pdbName string) string {
+	return fmt.Sprintf(CreateORDSSchemaSQL, schema, password, pdbName)
+}
+
+// Returns the SQL enabling (or disabling) ORDS for schema in pdbName with the given url mapping
+func EnableORDSSchema(schema string, enable bool, urlMapping string, pdbName string) string {
+	return fmt.Sprintf(EnableORDSSchemaSQL, schema, strconv.FormatBool(enable), urlMapping, pdbName)
+}
+
+// Privilege of an OAuth client and the URL patterns it protects
+type OAuthPrivilege struct {
+	Name     string
+	Patterns []string
+}
+
+// Returns the SQL defining the privileges of the OAuth client name of schema in pdbName, creating or updating the
+// client, and printing its credentials, read with ParseOAuthClientCredentials
+func DefineOAuthClient(pdbName string, schema string, name string, grantType string, allowedOrigins []string,
+	redirectUri string, supportEmail string, privileges []OAuthPrivilege) string {
+	var privilegesSQL, grantsSQL string
+	var privilegeNames []string
+	for _, privilege := range privileges {
+		var patternsSQL string
+		for i, pattern := range privilege.Patterns {
+			patternsSQL += fmt.Sprintf(" l_patterns(%d) := '%s';", i+1, pattern)
+		}
+		privilegesSQL += fmt.Sprintf(DefineOAuthPrivilegeSQL, schema, privilege.Name, patternsSQL)
+		grantsSQL += fmt.Sprintf(GrantOAuthClientRoleSQL, schema, name, privilege.Name)
+		privilegeNames = append(privilegeNames, privilege.Name)
+	}
+	return fmt.Sprintf(DefineOAuthClientSQL, pdbName, schema, name, grantType, strings.Join(allowedOrigins, ","),
+		redirectUri, supportEmail, strings.Join(privilegeNames, ","), privilegesSQL, grantsSQL)
+}
+
+// Returns the client_id and client_secret printed by the SQL of DefineOAuthClient, empty when not found
+func ParseOAuthClientCredentials(out string) (string, string) {
+	var clientId, clientSecret string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "CLIENT_ID:") {
+			clientId = strings.TrimPrefix(line, "CLIENT_ID:")
+		} else if strings.HasPrefix(line, "CLIENT_SECRET:") {
+			clientSecret = strings.TrimPrefix(line, "CLIENT_SECRET:")
+		}
+	}
+	return clientId, clientSecret
+}
+
+// Returns the SQL deleting the OAuth client name of schema in pdbName
+func DeleteOAuthClient(pdbName string, schema string, name string) string {
+	return fmt.Sprintf(DeleteOAuthClientSQL, pdbName, schema, name)
+}
+
+// Returns the url mapping of schema in pdbName from a template with the {pdb} and {schema} placeholders,
+// or the name of the schema without template
+func ORDSUrlMapping(template string, schema string, pdbName string) string {
+	if template == "" {
+		return strings.ToLower(schema)
+	}
+	return strings.ToLower(strings.NewReplacer("{pdb}", pdbName, "{schema}", schema).Replace(template))
+}
+
+// Returns the command setting sga_target, pga_aggregate_target (in MB) and cpu_count using sqlClient
+func AlterSgaPgaCpu(sgaTarget int, pgaAggregateTarget int, cpuCount int, sqlClient string) string {
+	return fmt.Sprintf(AlterSgaPgaCpuCMD, sgaTarget, pgaAggregateTarget, cpuCount, sqlClient)
+}
+
+// Returns the command setting processes and restarting the database using sqlClient
+func AlterProcesses(processes int, sqlClient string) string {
+	return fmt.Sprintf(AlterProcessesCMD, processes, sqlClient, sqlClient)
+}
+
+// Returns the command resetting processes and restarting the database using sqlClient
+func ResetProcesses(sqlClient string) string {
+	return fmt.Sprintf(ResetProcessesCMD, sqlClient, sqlClient)
+}
+
+// Returns the SQL setting the shared servers and the dispatchers for ORDS, or reverting them when both are 0
+func SetOrdsSharedServers(sharedServers int, dispatchers int) string {
+	if sharedServers == 0 && dispatchers == 0 {
+		return ResetOrdsSharedServersSQL
+	}
+	return fmt.Sprintf(SetOrdsSharedServersSQL, sharedServers, dispatchers)
+}
+
+// Returns the command fetching the init parameters managed by the operator using sqlClient
+func GetInitParams(sqlClient string) string {
+	return fmt.Sprintf(GetInitParamsSQL, sqlClient)
+}
+
+// Returns the SQL fetching the AWR or Statspack snapshots, as "begin:end", enclosing the begin and end times
+// of a report. The times are converted to the time zone of the database host, in which the snapshots are
+// timed. A snapshot not found is returned as "none", or as "wait" for the end snapshot until
+// PerformanceReportSnapshotWaitHours have passed since end, in database time
+func GetPerformanceReportSnapshots(reportType string, begin time.Time, end time.Time) string {
+	sql := GetAwrSnapshotsSQL
+	if reportType == "statspack" {
+		sql = GetStatspackSnapshotsSQL
+	}
+	return fmt.Sprintf(sql, begin.UTC().Format("2006-01-02 15:04:05"), end.UTC().Format("2006-01-02 15:04:05"),
+		PerformanceReportSnapshotWaitHours)
+}
+
+// Returns the shell command printing the AWR (HTML) or Statspack (text) report between two snapshots
+func PerformanceReport(reportType string, numDays int, beginSnapshot int, endSnapshot int) string {
+	if reportType == "statspack" {
+		return fmt.Sprintf(PerformanceReportCMD, "alter session set current_schema=PERFSTAT;\\n", "text", numDays,
+			beginSnapshot, endSnapshot, "spreport")
+	}
+	return fmt.Sprintf(PerformanceReportCMD, "", "html", numDays, beginSnapshot, endSnapshot, "awrrpt")
+}
+
+// CPU allocated to a PDB by a CDB resource plan
+type CdbPlanDirective struct {
+	PdbName          string
+	Shares           int
+	UtilizationLimit int
+}
+
+// Returns the SQL replacing the CDB resource plan name with the given directives and activating it. With force,
+// the plan stays active during the maintenance windows of the scheduler
+func ReplaceCdbPlan(name string, force bool, directives []CdbPlanDirective) string {
+	name = strings.ToUpper(name)
+	var directivesSQL string
+	for _, directive := range directives {
+		directivesSQL += fmt.Sprintf(CreateCdbPlanDirectiveSQL, name, strings.ToUpper(directive.PdbName),
+			directive.Shares, directive.UtilizationLimit)
+	}
+	activePlan := name
+	if force {
+		activePlan = "FORCE:" + name
+	}
+	return strings.Join([]string{
+		DeactivateResourcePlanSQL,
+		fmt.Sprintf(DeleteCdbPlanSQL, name),
+		fmt.Sprintf(CreateCdbPlanSQL, name, directivesSQL),
+		fmt.Sprintf(ActivateResourcePlanSQL, activePlan),
+	}, "\n")
+}
+
+// Returns the SQL deactivating and deleting the CDB resource plan name
+func DropCdbPlan(name string) string {
+	return DeactivateResourcePlanSQL + "\n" + fmt.Sprintf(DeleteCdbPlanSQL, strings.ToUpper(name))
+}
+
+// Returns the SQL setting the resource limits of the PDB pdbName. Empty limits are left unchanged
+func SetPDBResourceLimits(pdbName string, cpuCount int, sgaTarget string, pgaAggregateLimit string, maxSize string) string {
+	statements := []string{"alter session set container=" + pdbName + ";"}
+	if cpuCount > 0 {
+		statements = append(statements, fmt.Sprintf(SetPDBParameterSQL, "cpu_count", strconv.Itoa(cpuCount)))
+	}
+	if sgaTarget != "" {
+		statements = append(statements, fmt.Sprintf(SetPDBParameterSQL, "sga_target", strings.ToUpper(sgaTarget)))
+	}
+	if pgaAggregateLimit != "" {
+		statements = append(statements, fmt.Sprintf(SetPDBParameterSQL, "pga_aggregate_limit", strings.ToUpper(pgaAggregateLimit)))
+	}
+	if maxSize != "" {
+		statements = append(statements, fmt.Sprintf(SetPDBMaxSizeSQL, strings.ToUpper(maxSize)))
+	}
+	return strings.Join(statements, "\n")
+}
+
+// Returns the command replacing the native network encryption settings of the sqlnet.ora of the database, read by
+// its new connections. An empty level removes them
+func ConfigureNetworkEncryption(level string, algorithms []string, checksumAlgorithms []string) string {
+	cmd := fmt.Sprintf("touch %s && sed -i '/^%s/,/^%s/d' %s", DatabaseSqlnetOra, NetworkEncryptionBegin,
+		NetworkEncryptionEnd, DatabaseSqlnetOra)
+	if level == "" {
+		return cmd
+	}
+	lines := []string{
+		NetworkEncryptionBegin,
+		"SQLNET.ENCRYPTION_SERVER = " + level,
+		"SQLNET.ENCRYPTION_TYPES_SERVER = (" + strings.Join(algorithms, ", ") + ")",
+		"SQLNET.CRYPTO_CHECKSUM_SERVER = " + level,
+		"SQLNET.CRYPTO_CHECKSUM_TYPES_SERVER = (" + strings.Join(checksumAlgorithms, ", ") + ")",
+		NetworkEncryptionEnd,
+	}
+	return cmd + " && printf '%s\\n' '" + strings.Join(lines, "' '") + "' >> " + DatabaseSqlnetOra
+}
+
+// Returns the JVM options setting the native network encryption of the JDBC thin driver to match the settings of
+// ConfigureNetworkEncryption
+func NetworkEncryptionJavaOptions(level string, algorithms []string, checksumAlgorithms []string) string {
+	return "-Doracle.net.encryption_client=" + level +
+		" -Doracle.net.encryption_types_client=(" + strings.Join(algorithms, ",") + ")" +
+		" -Doracle.net.crypto_checksum_client=" + level +
+		" -Doracle.net.crypto_checksum_types_client=(" + strings.Join(checksumAlgorithms, ",") + ")"
+}
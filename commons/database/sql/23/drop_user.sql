@@ -0,0 +1 @@
+drop user if exists %[1]s cascade;
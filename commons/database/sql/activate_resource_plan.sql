@@ -0,0 +1 @@
+alter system set resource_manager_plan='%[1]s' scope=both;
@@ -0,0 +1,4 @@
+select count(*) as sessions from v\$session s where s.type = 'USER'
+and s.username not in ('SYS', 'SYSTEM', 'DBSNMP', 'ORDS_PUBLIC_USER', 'ORDS_METADATA', 'APEX_PUBLIC_USER', 'APEX_REST_PUBLIC_USER', 'APEX_LISTENER')
+and nvl(s.program, '-') not like 'Oracle REST Data Services%'
+and not exists (select 1 from v\$session_connect_info c where c.sid = s.sid and c.serial# = s.serial# and c.authentication_type = 'PROXY');
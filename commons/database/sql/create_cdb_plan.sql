@@ -0,0 +1,7 @@
+BEGIN
+  DBMS_RESOURCE_MANAGER.CREATE_PENDING_AREA;
+  DBMS_RESOURCE_MANAGER.CREATE_CDB_PLAN(plan => '%[1]s', comment => 'Managed by the Oracle Database Operator');%[2]s
+  DBMS_RESOURCE_MANAGER.VALIDATE_PENDING_AREA;
+  DBMS_RESOURCE_MANAGER.SUBMIT_PENDING_AREA;
+END;
+/
@@ -0,0 +1,2 @@
+
+  DBMS_RESOURCE_MANAGER.CREATE_CDB_PLAN_DIRECTIVE(plan => '%[1]s', pluggable_database => '%[2]s', shares => %[3]d, utilization_limit => %[4]d);
@@ -0,0 +1,5 @@
+alter session set container=%[1]s;
+BEGIN DBMS_CLOUD.DROP_CREDENTIAL(credential_name => '%[2]s'); EXCEPTION WHEN OTHERS THEN NULL; END;
+/
+BEGIN DBMS_CLOUD.CREATE_CREDENTIAL(credential_name => '%[2]s', user_ocid => '%[3]s', tenancy_ocid => '%[4]s', private_key => '%[5]s', fingerprint => '%[6]s'); END;
+/
@@ -0,0 +1,4 @@
+
+ALTER SESSION SET CONTAINER=%[3]s;
+CREATE USER %[1]s IDENTIFIED BY \"%[2]s\";
+GRANT CONNECT, RESOURCE, DBA, PDB_DBA TO %[1]s;
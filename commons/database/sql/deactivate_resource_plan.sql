@@ -0,0 +1 @@
+alter system set resource_manager_plan='' scope=both;
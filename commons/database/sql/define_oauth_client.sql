@@ -0,0 +1,18 @@
+alter session set container=%[1]s;
+DECLARE
+  l_roles OWA.VC_ARR;
+  l_patterns OWA.VC_ARR;
+  l_count NUMBER;
+BEGIN%[9]s
+  SELECT COUNT(*) INTO l_count FROM ords_metadata.oauth_clients c JOIN ords_metadata.ords_schemas s ON c.schema_id = s.id
+    WHERE s.parsing_schema = UPPER('%[2]s') AND c.name = '%[3]s';
+  IF l_count = 0 THEN
+    OAUTH_ADMIN.CREATE_CLIENT(p_schema => UPPER('%[2]s'), p_name => '%[3]s', p_grant_type => '%[4]s', p_owner => 'Oracle Database Operator', p_description => 'Managed by the Oracle Database Operator', p_origins_allowed => '%[5]s', p_redirect_uri => '%[6]s', p_support_email => '%[7]s', p_privilege_names => '%[8]s');
+  ELSE
+    OAUTH_ADMIN.UPDATE_CLIENT(p_schema => UPPER('%[2]s'), p_name => '%[3]s', p_description => 'Managed by the Oracle Database Operator', p_origins_allowed => '%[5]s', p_redirect_uri => '%[6]s', p_support_email => '%[7]s', p_support_uri => NULL, p_privilege_names => '%[8]s');
+  END IF;%[10]s
+  COMMIT;
+END;
+/
+select 'CLIENT_ID:'||c.client_id from ords_metadata.oauth_clients c join ords_metadata.ords_schemas s on c.schema_id = s.id where s.parsing_schema = upper('%[2]s') and c.name = '%[3]s';
+select 'CLIENT_SECRET:'||c.client_secret from ords_metadata.oauth_clients c join ords_metadata.ords_schemas s on c.schema_id = s.id where s.parsing_schema = upper('%[2]s') and c.name = '%[3]s';
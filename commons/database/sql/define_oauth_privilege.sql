@@ -0,0 +1,5 @@
+
+  BEGIN ORDS_ADMIN.CREATE_ROLE(p_schema => UPPER('%[1]s'), p_role_name => '%[2]s'); EXCEPTION WHEN DUP_VAL_ON_INDEX THEN NULL; END;
+  l_roles.DELETE; l_patterns.DELETE;
+  l_roles(1) := '%[2]s';%[3]s
+  ORDS_ADMIN.DEFINE_PRIVILEGE(p_schema => UPPER('%[1]s'), p_privilege_name => '%[2]s', p_roles => l_roles, p_patterns => l_patterns, p_label => '%[2]s', p_description => 'Managed by the Oracle Database Operator');
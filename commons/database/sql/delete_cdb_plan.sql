@@ -0,0 +1,8 @@
+BEGIN
+  DBMS_RESOURCE_MANAGER.CLEAR_PENDING_AREA;
+  DBMS_RESOURCE_MANAGER.CREATE_PENDING_AREA;
+  DBMS_RESOURCE_MANAGER.DELETE_CDB_PLAN(plan => '%[1]s');
+  DBMS_RESOURCE_MANAGER.SUBMIT_PENDING_AREA;
+EXCEPTION WHEN OTHERS THEN DBMS_RESOURCE_MANAGER.CLEAR_PENDING_AREA;
+END;
+/
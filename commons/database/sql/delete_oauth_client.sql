@@ -0,0 +1,12 @@
+alter session set container=%[1]s;
+DECLARE
+  l_count NUMBER;
+BEGIN
+  SELECT COUNT(*) INTO l_count FROM ords_metadata.oauth_clients c JOIN ords_metadata.ords_schemas s ON c.schema_id = s.id
+    WHERE s.parsing_schema = UPPER('%[2]s') AND c.name = '%[3]s';
+  IF l_count > 0 THEN
+    OAUTH_ADMIN.DELETE_CLIENT(p_schema => UPPER('%[2]s'), p_name => '%[3]s');
+    COMMIT;
+  END IF;
+END;
+/
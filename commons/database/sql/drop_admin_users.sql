@@ -0,0 +1,2 @@
+drop user C##DBAPI_CDB_ADMIN cascade;
+drop user C##_DBAPI_PDB_ADMIN cascade;
@@ -0,0 +1,3 @@
+alter session set container=%[1]s;
+BEGIN DBMS_CLOUD.DROP_CREDENTIAL(credential_name => '%[2]s'); EXCEPTION WHEN OTHERS THEN NULL; END;
+/
@@ -0,0 +1 @@
+drop user %[1]s cascade;
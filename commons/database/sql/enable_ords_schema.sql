@@ -0,0 +1,4 @@
+
+ALTER SESSION SET CONTAINER=%[4]s;
+GRANT INHERIT PRIVILEGES ON USER SYS TO ORDS_METADATA;
+exec ORDS.enable_schema(p_enabled => %[2]s ,p_schema => '%[1]s',p_url_mapping_type => 'BASE_PATH',p_url_mapping_pattern => '%[3]s',p_auto_rest_auth => FALSE);
@@ -0,0 +1,3 @@
+SELECT flashback_on FROM v\$database;
+ALTER DATABASE FLASHBACK OFF;
+SELECT flashback_on FROM v\$database;
@@ -0,0 +1,3 @@
+SELECT force_logging FROM v\$database;
+ALTER DATABASE NO FORCE LOGGING;
+SELECT force_logging FROM v\$database;
@@ -0,0 +1,4 @@
+SELECT force_logging FROM v\$database;
+ALTER DATABASE FORCE LOGGING;
+ALTER SYSTEM SWITCH LOGFILE;
+SELECT force_logging FROM v\$database;
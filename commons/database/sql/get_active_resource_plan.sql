@@ -0,0 +1 @@
+select name from v\$rsrc_plan where is_top_plan = 'TRUE' and con_id = 1;
@@ -0,0 +1,2 @@
+ALTER SESSION SET CONTAINER=%[1]s;
+select 'APEXVERSION:'||version as version FROM DBA_REGISTRY WHERE COMP_ID='APEX';
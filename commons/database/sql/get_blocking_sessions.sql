@@ -0,0 +1 @@
+select b.sid || ',' || b.serial# || ',' || b.username || ',' || count(*) || ',' || round(max(w.wait_time_micro) / 1000000) as blockers from v\$session w, v\$session b where w.blocking_session = b.sid and w.blocking_session_status = 'VALID' and b.type = 'USER' group by b.sid, b.serial#, b.username order by 5 desc;
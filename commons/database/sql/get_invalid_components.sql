@@ -0,0 +1 @@
+select con_id || ':' || comp_id || ':' || status as components from cdb_registry where status not in ('VALID', 'OPTION OFF', 'REMOVED') order by con_id, comp_id;
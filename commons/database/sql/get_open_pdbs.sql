@@ -0,0 +1,2 @@
+set feedback off
+select name from v\$pdbs where name <> 'PDB\$SEED' and open_mode like 'READ%' order by name;
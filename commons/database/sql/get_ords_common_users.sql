@@ -0,0 +1 @@
+select username from dba_users where username in ('%[1]s','%[2]s');
@@ -0,0 +1 @@
+select 'OPEN_MODE:' || open_mode as open_mode from v\$pdbs where name = upper('%[1]s');
@@ -0,0 +1,3 @@
+alter session set container=%[1]s;
+select name || '=' || value as limits from v$parameter where name in ('cpu_count', 'sga_target', 'pga_aggregate_limit')
+union all select 'max_pdb_storage=' || property_value from database_properties where property_name = 'MAX_PDB_STORAGE';
@@ -0,0 +1 @@
+select name from v\$pdbs where name not like 'PDB\$SEED' and open_mode like 'READ WRITE';
@@ -0,0 +1 @@
+select name || ',' || value as limits from (select 'processes' as name, value from v\$parameter where name = 'processes' union all select 'background_processes', to_char(count(*)) from v\$process where background = 1);
@@ -0,0 +1 @@
+select name || ',' || value as stats from (select replace(name, ' ', '_') as name, value from v\$dataguard_stats where name in ('transport lag', 'apply lag') union all select 'apply_rate', to_char(sofar * 1024) from (select sofar from v\$recovery_progress where item = 'Active Apply Rate' order by start_time desc) where rownum = 1 union all select 'redo_generation_rate', to_char(round(value)) from v\$sysmetric where metric_name = 'Redo Generated Per Sec' and group_id = 2);
@@ -0,0 +1 @@
+select status from dba_registry_sqlpatch order by action_time desc;
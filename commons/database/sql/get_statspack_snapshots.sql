@@ -0,0 +1 @@
+select nvl(to_char(b.snap_id), 'none') || ':' || nvl(to_char(e.snap_id), case when systimestamp < from_tz(to_timestamp('%[2]s', 'YYYY-MM-DD HH24:MI:SS'), 'UTC') + numtodsinterval(%[3]d, 'HOUR') then 'wait' else 'none' end) as snapshots from (select max(snap_id) as snap_id from perfstat.stats\$snapshot where dbid = (select dbid from v\$database) and snap_time <= cast(from_tz(to_timestamp('%[1]s', 'YYYY-MM-DD HH24:MI:SS'), 'UTC') at time zone to_char(systimestamp, 'TZH:TZM') as date)) b, (select min(snap_id) as snap_id from perfstat.stats\$snapshot where dbid = (select dbid from v\$database) and snap_time >= cast(from_tz(to_timestamp('%[2]s', 'YYYY-MM-DD HH24:MI:SS'), 'UTC') at time zone to_char(systimestamp, 'TZH:TZM') as date)) e;
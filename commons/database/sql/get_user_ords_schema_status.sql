@@ -0,0 +1,2 @@
+alter session set container=%[2]s;
+select 'STATUS:'||status as status from ords_metadata.ords_schemas where upper(parsing_schema) = upper('%[1]s');
@@ -0,0 +1 @@
+SELECT VERSION_FULL FROM V\$INSTANCE;
@@ -0,0 +1,2 @@
+
+  BEGIN OAUTH_ADMIN.GRANT_CLIENT_ROLE(p_schema => UPPER('%[1]s'), p_client_name => '%[2]s', p_role_name => '%[3]s'); EXCEPTION WHEN DUP_VAL_ON_INDEX THEN NULL; END;
@@ -0,0 +1 @@
+alter system kill session '%[1]s';
@@ -0,0 +1,2 @@
+ALTER SESSION SET CONTAINER=%[1]s;
+CREATE OR REPLACE DIRECTORY ORDS_BACKUP_DIR AS '%[2]s';
@@ -0,0 +1,3 @@
+alter system set dispatchers='(PROTOCOL=TCP)(DISPATCHERS=0)(INDEX=1)' scope=both;
+alter system reset shared_servers scope=spfile;
+alter system set shared_servers=1 scope=memory;
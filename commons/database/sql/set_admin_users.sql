@@ -0,0 +1,9 @@
+CREATE USER C##DBAPI_CDB_ADMIN IDENTIFIED BY \"%[1]s\" ACCOUNT UNLOCK CONTAINER=ALL;
+alter user C##DBAPI_CDB_ADMIN identified by \"%[1]s\" account unlock;
+GRANT DBA TO C##DBAPI_CDB_ADMIN CONTAINER = ALL;
+GRANT PDB_DBA  TO C##DBAPI_CDB_ADMIN CONTAINER = ALL;
+CREATE USER C##_DBAPI_PDB_ADMIN IDENTIFIED BY \"%[1]s\" CONTAINER=ALL ACCOUNT UNLOCK;
+alter user C##_DBAPI_PDB_ADMIN identified by \"%[1]s\" account unlock;
+GRANT DBA TO C##_DBAPI_PDB_ADMIN CONTAINER = ALL;
+alter pluggable database pdb\$seed close;
+alter pluggable database pdb\$seed open read write force;
@@ -0,0 +1,4 @@
+SHOW PARAMETER db_recovery_file_dest;
+ALTER SYSTEM SET db_recovery_file_dest_size=50G scope=both sid='*';
+ALTER SYSTEM SET db_recovery_file_dest='${ORACLE_BASE}/oradata/fast_recovery_area' scope=both sid='*';
+SHOW PARAMETER db_recovery_file_dest;
@@ -0,0 +1,2 @@
+alter system set shared_servers=%[1]d scope=both;
+alter system set dispatchers='(PROTOCOL=TCP)(DISPATCHERS=%[2]d)(INDEX=1)' scope=both;
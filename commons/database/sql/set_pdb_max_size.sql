@@ -0,0 +1 @@
+alter pluggable database storage (maxsize %[1]s);
@@ -0,0 +1 @@
+alter system set %[1]s=%[2]s scope=both;
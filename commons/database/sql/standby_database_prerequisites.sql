@@ -0,0 +1,9 @@
+ALTER SYSTEM SET db_create_file_dest='/opt/oracle/oradata/';
+ALTER SYSTEM SET db_create_online_log_dest_1='/opt/oracle/oradata/';
+ALTER SYSTEM SWITCH LOGFILE;
+ALTER DATABASE ADD STANDBY LOGFILE THREAD 1 SIZE 200M;
+ALTER DATABASE ADD STANDBY LOGFILE THREAD 1 SIZE 200M;
+ALTER DATABASE ADD STANDBY LOGFILE THREAD 1 SIZE 200M;
+ALTER DATABASE ADD STANDBY LOGFILE THREAD 1 SIZE 200M;
+ALTER SYSTEM SET STANDBY_FILE_MANAGEMENT=AUTO;
+ALTER SYSTEM SET dg_broker_start=TRUE;
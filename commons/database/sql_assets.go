@@ -0,0 +1,69 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"embed"
+	"path"
+	"strings"
+)
+
+// SQL run by the operator in the database containers, in the sql directory. Each file is a template of fmt, piped
+// into SQL*Plus with echo -e, so its double quotes and dollar signs are escaped for the shell. A file of a directory
+// named after a major release, such as sql/23, replaces the file of the same name for the databases of that release
+//
+//go:embed sql
+var sqlAssets embed.FS
+
+// Returns the SQL of the asset name for the database release version, such as 19.3.0.0.0, or the SQL of all the
+// releases when version is empty or has no asset of its own
+// The assets with releases of their own are loaded where the release of the database is known, rather than into
+// package variables
+func LoadSQL(name string, version string) string {
+	if major, _, _ := strings.Cut(version, "."); major != "" {
+		if sql, err := sqlAssets.ReadFile(path.Join("sql", major, name+".sql")); err == nil {
+			return strings.TrimSuffix(string(sql), "\n")
+		}
+	}
+	sql, err := sqlAssets.ReadFile(path.Join("sql", name+".sql"))
+	if err != nil {
+		panic("SQL asset " + name + " not found")
+	}
+	return strings.TrimSuffix(string(sql), "\n")
+}
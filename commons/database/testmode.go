@@ -0,0 +1,80 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"time"
+)
+
+// Requeue interval used by the controllers in test mode
+const TestModeRequeueInterval time.Duration = 2 * time.Second
+
+// Image defaulted for SingleInstanceDatabase in test mode when no image is specified
+const TestModeFreeImage string = "container-registry.oracle.com/database/free:latest"
+
+// Test mode shortens waits and retries and defaults the database to the lightweight Free image,
+// so that e2e suites and CI pipelines can exercise full lifecycles in minutes. Not to be used in production.
+var testMode bool
+
+// Enables test mode for the operator process
+func EnableTestMode() {
+	testMode = true
+	requeueY.RequeueAfter = TestModeRequeueInterval
+}
+
+// Returns true if the operator runs in test mode
+func IsTestMode() bool {
+	return testMode
+}
+
+// Returns the number of attempts for an operation retried in place, a single attempt in test mode
+func RetryAttempts(attempts int) int {
+	if testMode {
+		return 1
+	}
+	return attempts
+}
+
+// Returns the wait between two attempts of an operation retried in place, shortened in test mode
+func RetryInterval(interval time.Duration) time.Duration {
+	if testMode && interval > time.Second {
+		return time.Second
+	}
+	return interval
+}
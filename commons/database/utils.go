@@ -42,14 +42,23 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 	"unicode"
 
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -74,6 +83,9 @@ var requeueN ctrl.Result = ctrl.Result{}
 func ResourceEventHandler() predicate.Predicate {
 	return predicate.Funcs{
 		DeleteFunc: func(e event.DeleteEvent) bool {
+			if pod, ok := e.Object.(*corev1.Pod); ok {
+				ForgetDatabasePod(pod.Namespace, pod.Name)
+			}
 			// Evaluates to false if the object has been confirmed deleted.
 			return !e.DeleteStateUnknown
 		},
@@ -102,6 +114,13 @@ func ResourceEventHandler() predicate.Predicate {
 				}
 
 			}
+			// Handling the address changes of the load balancers, which the status URLs are made of
+			oldSvcObject, oldOk := e.ObjectOld.(*corev1.Service)
+			newSvcObject, newOk := e.ObjectNew.(*corev1.Service)
+			if oldOk && newOk && GetLoadBalancerAddress(oldSvcObject) != GetLoadBalancerAddress(newSvcObject) {
+				return true
+			}
+
 			// Ignore updates to CR status in which case metadata.Generation does not change
 			// Reconcile if object Deletion Timestamp Set
 			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
@@ -227,15 +246,39 @@ func IsStatusChanged(r client.Reader, objName string, namespace string,
 
 }
 
-// Execs into podName and executes command
+// Execs into podName and executes command using the configured CommandExecutor
 func ExecCommand(r client.Reader, config *rest.Config, podName string, namespace string, containerName string,
 	ctx context.Context, req ctrl.Request, nologCommand bool, command ...string) (string, error) {
 
 	log := ctrllog.FromContext(ctx).WithValues("ExecCommand", req.NamespacedName)
 	if !nologCommand {
-		log.Info("Executing Command :")
-		log.Info(strings.Join(command, " "))
+		log.V(CommandLogVerbosity).Info("Executing Command", "command", strings.Join(command, " "))
+	}
+	// Fail fast while the database of the pod is unreachable, instead of waiting for each command to time out
+	isSQL := isSQLCommand(command)
+	if isSQL && !DatabaseUnreachableUntil(namespace, podName).IsZero() {
+		log.Info("Skipping the SQL command", "pod", podName, "reason", ErrDatabaseUnreachable.Error())
+		return "", ErrDatabaseUnreachable
+	}
+	// The command itself is not recorded, as it can hold passwords
+	ctx, span := StartSpan(ctx, "exec", attribute.String("pod", podName), attribute.String("container", containerName),
+		attribute.Bool("sql", isSQL))
+	out, err := GetCommandExecutor().ExecCommand(r, config, podName, namespace, containerName, ctx, req, command...)
+	EndSpan(span, err)
+	if isSQL {
+		recordSQLResult(namespace, podName, out, err)
+	}
+	if !nologCommand {
+		log.V(CommandLogVerbosity).Info("Command output", "output", out)
 	}
+	return out, err
+}
+
+// Execs into podName through the pods/exec subresource of the API server and executes command
+func (PodCommandExecutor) ExecCommand(r client.Reader, config *rest.Config, podName string, namespace string, containerName string,
+	ctx context.Context, req ctrl.Request, command ...string) (string, error) {
+
+	log := ctrllog.FromContext(ctx).WithValues("ExecCommand", req.NamespacedName)
 	if config == nil {
 		log.Info("r.Config nil")
 		return "Error", nil
@@ -249,6 +292,10 @@ func ExecCommand(r client.Reader, config *rest.Config, podName string, namespace
 	if err != nil {
 		return "", fmt.Errorf("could not find pod to execute command: %v", err)
 	}
+	// Like kubectl, default to the main container of pods with sidecars
+	if containerName == "" && len(pod.Spec.Containers) > 1 {
+		containerName = mainContainer(*pod)
+	}
 	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		log.Error(err, "config error")
@@ -282,6 +329,31 @@ func ExecCommand(r client.Reader, config *rest.Config, podName string, namespace
 	return execOut.String(), nil
 }
 
+// Returns the container annotated as the default one, like kubectl, or else the first container. Service meshes
+// may inject their proxy before the containers of the pod, and annotate the default container
+func mainContainer(pod corev1.Pod) string {
+	if defaultContainer := pod.Annotations["kubectl.kubernetes.io/default-container"]; defaultContainer != "" {
+		return defaultContainer
+	}
+	return pod.Spec.Containers[0].Name
+}
+
+// Returns true if the given container of the pod is ready. Container statuses are sorted by name,
+// so the status of the first container of the spec is not necessarily the first one
+func isContainerReady(pod corev1.Pod, containerName string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName {
+			return status.Ready
+		}
+	}
+	return false
+}
+
+// Returns true if the main container of the pod is ready
+func IsPodReady(pod corev1.Pod) bool {
+	return isContainerReady(pod, mainContainer(pod))
+}
+
 // returns a randomString
 func GenerateRandomString(n int) string {
 	var letters = []rune("abcdefghijklmnopqrstuvwxyz0123456789")
@@ -293,6 +365,49 @@ func GenerateRandomString(n int) string {
 	return string(s)
 }
 
+// Proxy variables of the operator, which are passed to the pods accessing external URLs
+var proxyEnvNames = []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy"}
+
+// ProxyEnv returns the proxy variables set in the environment of the operator
+func ProxyEnv() []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, name := range proxyEnvNames {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, corev1.EnvVar{Name: name, Value: value})
+		}
+	}
+	return env
+}
+
+// ProxyFromEnvironment returns the proxy of the operator for a request like http.ProxyFromEnvironment, except for the
+// services of the cluster, such as <cdb>-ords or <service>.<namespace>.svc, which are reached directly
+func ProxyFromEnvironment(req *http.Request) (*url.URL, error) {
+	host := req.URL.Hostname()
+	if net.ParseIP(host) == nil &&
+		(!strings.Contains(host, ".") || strings.HasSuffix(host, ".svc") || strings.Contains(host, ".svc.")) {
+		return nil, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// Set when the pods are indexed by PodAppIndex in the cache read by FindPods
+var podAppIndexed bool
+
+// IndexPodsByApp indexes the pods in the cache of the manager by their "app" label, so that FindPods
+// looks up the pods of a resource instead of filtering all the pods of the namespace
+func IndexPodsByApp(ctx context.Context, indexer client.FieldIndexer) error {
+	err := indexer.IndexField(ctx, &corev1.Pod{}, PodAppIndex, func(obj client.Object) []string {
+		if app, ok := obj.GetLabels()["app"]; ok {
+			return []string{app}
+		}
+		return nil
+	})
+	if err == nil {
+		podAppIndexed = true
+	}
+	return err
+}
+
 // retuns Ready Pod,No of replicas ( Only running and Pending Pods) ,available pods , Total No of Pods of a particular CRD
 func FindPods(r client.Reader, version string, image string, name string, namespace string, ctx context.Context,
 	req ctrl.Request) (corev1.Pod, int, []corev1.Pod, []corev1.Pod, error) {
@@ -307,11 +422,21 @@ func FindPods(r client.Reader, version string, image string, name string, namesp
 
 	podList := &corev1.PodList{}
 	listOpts := []client.ListOption{client.InNamespace(namespace), client.MatchingLabels(GetLabelsForController(version, name))}
+	var err error
+	if podAppIndexed {
+		err = r.List(ctx, podList, append(listOpts, client.MatchingFields{PodAppIndex: name})...)
+		if err != nil {
+			// A reader other than the cache of the manager, such as the API reader, has no such index
+			log.Info("Pods of "+name+" not listed by their index, listing them by their labels", "Error", err.Error())
+		}
+	}
 
 	// List retrieves list of objects for a given namespace and list options.
-	if err := r.List(ctx, podList, listOpts...); err != nil {
-		log.Error(err, "Failed to list pods of "+name, "Namespace", namespace, "Name", name)
-		return readyPod, 0, available, podsMarkedToBeDeleted, err
+	if !podAppIndexed || err != nil {
+		if err = r.List(ctx, podList, listOpts...); err != nil {
+			log.Error(err, "Failed to list pods of "+name, "Namespace", namespace, "Name", name)
+			return readyPod, 0, available, podsMarkedToBeDeleted, err
+		}
 	}
 
 	// r.List() lists all the pods in running, pending,terminating stage matching listOpts . so filter them
@@ -319,13 +444,13 @@ func FindPods(r client.Reader, version string, image string, name string, namesp
 
 	for _, pod := range podList.Items {
 		// Return pods having Image = image (or) if image = ""(Needed in case when called findpods with "" image)
-		if pod.Spec.Containers[0].Image == image || image == "" {
+		if GetPodImage(pod) == image || image == "" {
 			if pod.ObjectMeta.DeletionTimestamp != nil {
 				podsMarkedToBeDeleted = append(podsMarkedToBeDeleted, pod)
 				continue
 			}
 			if pod.Status.Phase == corev1.PodRunning || pod.Status.Phase == corev1.PodPending {
-				if len(pod.Status.ContainerStatuses) > 0 && pod.Status.ContainerStatuses[0].Ready && readyPod.Name == "" {
+				if isContainerReady(pod, mainContainer(pod)) && readyPod.Name == "" {
 					readyPod = pod
 				} else {
 					available = append(available, pod)
@@ -365,7 +490,7 @@ func CheckDBConfig(readyPod corev1.Pod, r client.Reader, config *rest.Config,
 
 	} else {
 		out, err := ExecCommand(r, config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
-			fmt.Sprintf("echo -e  \"%s\"  | %s", CheckModesSQL, SQLPlusCLI))
+			SQLPlusCommand(CheckModesSQL))
 		if err != nil {
 			log.Error(err, "Error in ExecCommand()")
 			return false, false, false, requeueY
@@ -373,24 +498,7 @@ func CheckDBConfig(readyPod corev1.Pod, r client.Reader, config *rest.Config,
 			log.Info("CheckModes Output")
 			log.Info(out)
 
-			if strings.Contains(out, "log_mode:NOARCHIVELOG") {
-				archiveLogStatus = false
-			}
-			if strings.Contains(out, "log_mode:ARCHIVELOG") {
-				archiveLogStatus = true
-			}
-			if strings.Contains(out, "flashback_on:NO") {
-				flashBackStatus = false
-			}
-			if strings.Contains(out, "flashback_on:YES") {
-				flashBackStatus = true
-			}
-			if strings.Contains(out, "force_logging:NO") {
-				forceLoggingStatus = false
-			}
-			if strings.Contains(out, "force_logging:YES") {
-				forceLoggingStatus = true
-			}
+			flashBackStatus, archiveLogStatus, forceLoggingStatus = ParseDBModes(out)
 		}
 		log.Info("FlashBackStatus ", "Status :", flashBackStatus)
 		log.Info("ArchiveLogStatus ", "Status :", archiveLogStatus)
@@ -444,16 +552,11 @@ func GetDatabasesInDgConfig(readyPod corev1.Pod, r client.Reader,
 	log.Info("GetDatabasesInDgConfig Output")
 	log.Info(out)
 
-	if !strings.Contains(out, "no rows selected") && !strings.Contains(out, "ORA-") {
-		out1 := strings.Replace(out, " ", "_", -1)
-		// filtering output and storing databses in dg configuration in  "databases" slice
-		databases := strings.Fields(out1)
-
-		// first 2 values in the slice will be column name(DATABASES) and a seperator(--------------) . so take the slice from position [2:]
-		databases = databases[2:]
-		return databases, out, nil
+	databases, err := ParseColumnValues(out)
+	if err != nil {
+		return []string{}, out, errors.New("databases in DG config is nil")
 	}
-	return []string{}, out, errors.New("databases in DG config is nil")
+	return databases, out, nil
 }
 
 // Returns Database version
@@ -463,23 +566,18 @@ func GetDatabaseVersion(readyPod corev1.Pod, r client.Reader,
 
 	// ## FIND DATABASES PRESENT IN DG CONFIGURATION
 	out, err := ExecCommand(r, config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
-		fmt.Sprintf("echo -e  \"%s\"  | %s", GetVersionSQL, SQLPlusCLI))
+		SQLPlusCommand(GetVersionSQL))
 	if err != nil {
 		return "", "", err
 	}
 	log.Info("GetDatabaseVersion Output")
 	log.Info(out)
 
-	if !strings.Contains(out, "no rows selected") && !strings.Contains(out, "ORA-") {
-		out1 := strings.Replace(out, " ", "_", -1)
-		// filtering output and storing databses in dg configuration in  "databases" slice
-		out2 := strings.Fields(out1)
-
-		// first 2 values in the slice will be column name(VERSION) and a seperator(--------------) . so the version would be out2[2]
-		version := out2[2]
-		return version, out, nil
+	version, err := ParseColumnValue(out)
+	if err != nil {
+		return "", out, errors.New("database version is nil")
 	}
-	return "", out, errors.New("database version is nil")
+	return version, out, nil
 
 }
 
@@ -489,20 +587,16 @@ func GetDatabaseRole(readyPod corev1.Pod, r client.Reader,
 	log := ctrllog.FromContext(ctx).WithValues("GetDatabaseRole", req.NamespacedName)
 
 	out, err := ExecCommand(r, config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
-		fmt.Sprintf("echo -e  \"%s\"  | %s", GetDatabaseRoleCMD, SQLPlusCLI))
+		SQLPlusCommand(GetDatabaseRoleCMD))
 	if err != nil {
 		return "", err
 	}
 	log.Info(out)
-	if !strings.Contains(out, "no rows selected") && !strings.Contains(out, "ORA-") {
-		out = strings.Replace(out, " ", "_", -1)
-		// filtering output and storing databse_role in  "database_role"
-		databaseRole := strings.Fields(out)[2]
-
-		// first 2 values in the slice will be column name(DATABASE_ROLE) and a seperator(--------------) .
-		return databaseRole, nil
+	databaseRole, err := ParseColumnValue(out)
+	if err != nil {
+		return "", errors.New("database role is nil")
 	}
-	return "", errors.New("database role is nil")
+	return databaseRole, nil
 }
 
 func GetDatabaseOpenMode(readyPod corev1.Pod, r client.Reader,
@@ -510,19 +604,16 @@ func GetDatabaseOpenMode(readyPod corev1.Pod, r client.Reader,
 	log := ctrllog.FromContext(ctx).WithValues("GetDatabaseOpenMode", req.NamespacedName)
 
 	out, err := ExecCommand(r, config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
-		fmt.Sprintf("echo -e \"%s\" | %s", GetDBOpenMode, SQLPlusCLI))
+		SQLPlusCommand(GetDBOpenMode))
 	if err != nil {
 		return "", err
 	}
 	log.Info(out)
-	if !strings.Contains(out, "no rows selected") && !strings.Contains(out, "ORA-") {
-		out1 := strings.Replace(out, " ", "_", -1)
-		// filtering output and storing databse_role in  "database_role"
-		databaseOpenMode := strings.Fields(out1)[2]
-		// first 2 values in the slice will be column name(DATABASE_ROLE) and a seperator(--------------) .
-		return databaseOpenMode, nil
+	databaseOpenMode, err := ParseColumnValue(out)
+	if err != nil {
+		return "", errors.New("database open mode is nil")
 	}
-	return "", errors.New("database open mode is nil")
+	return databaseOpenMode, nil
 }
 
 // Returns true if any of the pod in 'pods' is with pod.Status.Phase == phase
@@ -554,39 +645,235 @@ func StringToLines(s string) (lines []string, err error) {
 	return
 }
 
+// Returns hostname if set, so that published URLs survive node and load balancer IP changes; else address
+func GetExternalHost(hostname string, address string) string {
+	if hostname != "" {
+		return hostname
+	}
+	return address
+}
+
+// Sets the WAF policy annotation of svc, or removes it when policyId is empty. Returns true if the annotations changed
+func SetWafPolicyAnnotation(svc *corev1.Service, policyId string) bool {
+	if svc.Annotations[WafPolicyAnnotation] == policyId {
+		return false
+	}
+	if policyId == "" {
+		delete(svc.Annotations, WafPolicyAnnotation)
+		return true
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = make(map[string]string)
+	}
+	svc.Annotations[WafPolicyAnnotation] = policyId
+	return true
+}
+
+// Returns the address of a load balancer service, its hostname on the clouds publishing one, such as AWS, or its IP
+// address. Empty until the load balancer is provisioned
+func GetLoadBalancerAddress(svc *corev1.Service) string {
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			return ingress.Hostname
+		}
+		if ingress.IP != "" {
+			return ingress.IP
+		}
+	}
+	return ""
+}
+
+// Returns the Java XML properties file of the ORDS settings, such as defaults.xml, with the properties sorted so that
+// the same settings render the same file
+func RenderOrdsProperties(properties map[string]string) string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"no\"?>\n")
+	buf.WriteString("<!DOCTYPE properties SYSTEM \"http://java.sun.com/dtd/properties.dtd\">\n")
+	buf.WriteString("<properties>\n<comment>Rendered by the Oracle Database Operator</comment>\n")
+	for _, key := range keys {
+		buf.WriteString("<entry key=\"")
+		xml.EscapeText(&buf, []byte(key))
+		buf.WriteString("\">")
+		xml.EscapeText(&buf, []byte(properties[key]))
+		buf.WriteString("</entry>\n")
+	}
+	buf.WriteString("</properties>\n")
+	return buf.String()
+}
+
+// Returns the number of ORDS pods whose pools of maxLimit connections fit in the processes of the database left by
+// its background processes
+func OrdsMaxReplicas(processes int, background int, maxLimit int) int {
+	if maxLimit <= 0 || processes <= background {
+		return 0
+	}
+	return (processes - background) / maxLimit
+}
+
+// Returns the ORDS pool serving a PDB for its virtual hosts
+func OrdsVirtualHostPool(pdbName string) string {
+	return OrdsVirtualHostPoolPrefix + strings.ToLower(pdbName)
+}
+
+// Returns url-mapping.xml routing the requests of each base URL to its ORDS pool, sorted by base URL
+func RenderOrdsUrlMapping(pools map[string]string) string {
+	baseUrls := make([]string, 0, len(pools))
+	for baseUrl := range pools {
+		baseUrls = append(baseUrls, baseUrl)
+	}
+	sort.Strings(baseUrls)
+
+	var buf bytes.Buffer
+	buf.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"no\"?>\n")
+	buf.WriteString("<!-- Rendered by the Oracle Database Operator -->\n")
+	buf.WriteString("<pool-config xmlns=\"http://xmlns.oracle.com/apex/pool-config\">\n")
+	for _, baseUrl := range baseUrls {
+		buf.WriteString("<pool name=\"")
+		xml.EscapeText(&buf, []byte(pools[baseUrl]))
+		buf.WriteString("\" base-url=\"")
+		xml.EscapeText(&buf, []byte(baseUrl))
+		buf.WriteString("\"/>\n")
+	}
+	buf.WriteString("</pool-config>\n")
+	return buf.String()
+}
+
+// Sets the external-dns hostname annotation of svc. Returns true if the annotations changed
+func SetExternalDNSAnnotation(svc *corev1.Service, hostname string) bool {
+	if hostname == "" || svc.Annotations[ExternalDNSHostnameAnnotation] == hostname {
+		return false
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = make(map[string]string)
+	}
+	svc.Annotations[ExternalDNSHostnameAnnotation] = hostname
+	return true
+}
+
+// Sets the session affinity, external traffic policy and load balancer source ranges of svc. Returns true if the spec changed
+func SetServiceOptions(svc *corev1.Service, sessionAffinity string, externalTrafficPolicy string, sourceRanges []string) bool {
+	if sessionAffinity == "" {
+		sessionAffinity = string(corev1.ServiceAffinityNone)
+	}
+	if externalTrafficPolicy == "" {
+		externalTrafficPolicy = string(corev1.ServiceExternalTrafficPolicyTypeCluster)
+	}
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		sourceRanges = nil
+	}
+	changed := false
+	if string(svc.Spec.SessionAffinity) != sessionAffinity {
+		svc.Spec.SessionAffinity = corev1.ServiceAffinity(sessionAffinity)
+		if svc.Spec.SessionAffinity == corev1.ServiceAffinityNone {
+			svc.Spec.SessionAffinityConfig = nil
+		}
+		changed = true
+	}
+	if string(svc.Spec.ExternalTrafficPolicy) != externalTrafficPolicy {
+		svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyType(externalTrafficPolicy)
+		changed = true
+	}
+	if !reflect.DeepEqual(svc.Spec.LoadBalancerSourceRanges, sourceRanges) && (len(svc.Spec.LoadBalancerSourceRanges) != 0 || len(sourceRanges) != 0) {
+		svc.Spec.LoadBalancerSourceRanges = sourceRanges
+		changed = true
+	}
+	return changed
+}
+
+// GetPodImage returns the image of the spec a pod has been created from, which differs from the image of its first
+// container when the pod runs the image of the architecture of its node
+func GetPodImage(pod corev1.Pod) string {
+	if image, ok := pod.Annotations[ImageAnnotation]; ok {
+		return image
+	}
+	return pod.Spec.Containers[0].Image
+}
+
+// GetNodeArchitectures returns the sorted architectures of the schedulable nodes, from their kubernetes.io/arch label
+func GetNodeArchitectures(r client.Reader, ctx context.Context) ([]string, error) {
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool)
+	var archs []string
+	for _, node := range nodeList.Items {
+		arch := node.Labels[corev1.LabelArchStable]
+		if node.Spec.Unschedulable || arch == "" || found[arch] {
+			continue
+		}
+		found[arch] = true
+		archs = append(archs, arch)
+	}
+	sort.Strings(archs)
+	return archs, nil
+}
+
 // Get Node Ip to display in ConnectionString
 // Returns Node External Ip if exists ; else InternalIP
 func GetNodeIp(r client.Reader, ctx context.Context, req ctrl.Request) string {
+	nodeip, _ := GetNodeIpByPolicy(r, ctx, req, "", nil, "")
+	return nodeip
+}
+
+// GetNodeIpByPolicy returns the address of type addressType of a node matching nodeSelector and the name of that node,
+// preferring nodeName when it matches. An empty addressType prefers the ExternalIP over the InternalIP
+func GetNodeIpByPolicy(r client.Reader, ctx context.Context, req ctrl.Request, addressType string,
+	nodeSelector map[string]string, nodeName string) (string, string) {
 
 	log := ctrllog.FromContext(ctx).WithValues("GetNodeIp", req.NamespacedName)
 
 	//new workflow
 	nl := &corev1.NodeList{}
-	err := r.List(ctx, nl)
+	err := r.List(ctx, nl, client.MatchingLabels(nodeSelector))
 	nodeip := ""
 	if err != nil {
 		log.Error(err, err.Error())
-		return nodeip
+		return nodeip, ""
+	}
+	if len(nl.Items) == 0 {
+		log.Info("No nodes found")
+		return nodeip, ""
 	}
 
-	for _, address := range nl.Items[0].Status.Addresses {
-		if address.Type == "ExternalIP" {
-			nodeip = address.Address
+	nodes := nl.Items
+	for i := range nl.Items {
+		if nodeName != "" && nl.Items[i].Name == nodeName {
+			nodes = append([]corev1.Node{nl.Items[i]}, nl.Items...)
 			break
 		}
 	}
-	if nodeip == "" {
-		for _, address := range nl.Items[0].Status.Addresses {
-			if address.Type == "InternalIP" {
-				nodeip = address.Address
-				break
-			}
+
+	for _, node := range nodes {
+		nodeip = getNodeAddress(node, addressType)
+		if nodeip != "" {
+			log.Info("Node IP obtained ! ", "nodeip: ", nodeip, "node: ", node.Name)
+			return nodeip, node.Name
 		}
 	}
 
-	log.Info("Node IP obtained ! ", "nodeip: ", nodeip)
+	return "", ""
+}
 
-	return nodeip
+func getNodeAddress(node corev1.Node, addressType string) string {
+	addressTypes := []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP}
+	if addressType != "" {
+		addressTypes = []corev1.NodeAddressType{corev1.NodeAddressType(addressType)}
+	}
+	for _, t := range addressTypes {
+		for _, address := range node.Status.Addresses {
+			if address.Type == t {
+				return address.Address
+			}
+		}
+	}
+	return ""
 }
 
 // GetSidPdbEdition to display sid, pdbname, edition in ConnectionString
@@ -607,8 +894,7 @@ func GetSidPdbEdition(r client.Reader, config *rest.Config, ctx context.Context,
 			return "", "", "", err
 		}
 		log.Info("GetSidPdbEditionCMD output \n" + out)
-		splitstr := strings.Split((strings.TrimSpace(out)), ",")
-		return splitstr[0], splitstr[1], splitstr[2], nil
+		return ParseSidPdbEdition(out)
 	}
 	err = errors.New("ready pod name is nil")
 	log.Error(err, err.Error())
@@ -651,8 +937,15 @@ func GetSqlpatchStatus(r client.Reader, config *rest.Config, readyPod corev1.Pod
 		log.Error(err, err.Error())
 		return "", "", "", err
 	}
-	splitstr := strings.Split(sqlpatchVersions[0], ":")
-	return sqlpatchStatuses[0], splitstr[0], splitstr[1], nil
+	if len(sqlpatchVersions) == 0 {
+		return sqlpatchStatuses[0], "", "", nil
+	}
+	sourceVersion, targetVersion, err := ParseSqlpatchVersions(sqlpatchVersions[0])
+	if err != nil {
+		log.Error(err, err.Error())
+		return "", "", "", err
+	}
+	return sqlpatchStatuses[0], sourceVersion, targetVersion, nil
 }
 
 // Is Source Database On same Cluster
@@ -692,6 +985,55 @@ func ApexPasswordValidator(pwd string) bool {
 	return hasMinLen && hasUpper && hasLower && hasNumber && hasSpecial
 }
 
+// Simple passwords rejected by the ORA12C_VERIFY_FUNCTION password verify function
+var simplePasswords = []string{"welcome1", "database1", "account1", "user1234", "password1", "oracle123",
+	"computer1", "abcdefg1", "change_on_install"}
+
+// Password complexity validation function, following the rules of the ORA12C_VERIFY_FUNCTION password verify
+// function and the quoting of the password by the install scripts. Returns the requirement that the password
+// does not meet, or an empty string
+func PasswordComplexityError(pwd string, usernames ...string) string {
+	if len(pwd) < 8 {
+		return "must be at least 8 characters long"
+	}
+	if len(pwd) > 30 {
+		return "must be at most 30 bytes long"
+	}
+
+	var hasLetter, hasNumber bool
+	for _, c := range pwd {
+		switch {
+		case unicode.IsLetter(c):
+			hasLetter = true
+		case unicode.IsNumber(c):
+			hasNumber = true
+		case c == '"' || unicode.IsSpace(c):
+			return "must not contain double quotes or white spaces"
+		}
+	}
+	if !hasLetter || !hasNumber {
+		return "must contain at least one letter and one digit"
+	}
+
+	lowerPwd := strings.ToLower(pwd)
+	for _, username := range usernames {
+		username = strings.ToLower(username)
+		reversed := []rune(username)
+		for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+			reversed[i], reversed[j] = reversed[j], reversed[i]
+		}
+		if username != "" && (strings.Contains(lowerPwd, username) || strings.Contains(lowerPwd, string(reversed))) {
+			return "must not contain the user name " + strings.ToUpper(username) + ", or the user name reversed"
+		}
+	}
+	for _, simple := range simplePasswords {
+		if lowerPwd == simple {
+			return "is too simple"
+		}
+	}
+	return ""
+}
+
 func GetSqlClient(edition string) string {
 	if edition == "express" {
 		return "su -p oracle -c \"sqlplus -s / as sysdba\""
@@ -713,3 +1055,50 @@ func PatchService(config *rest.Config, namespace string, ctx context.Context, re
 	_, err = client.CoreV1().Services(namespace).Patch(ctx, svcName, types.MergePatchType, []byte(payload), metav1.PatchOptions{})
 	return err
 }
+
+// SetHealthConditions sets the Ready and Progressing conditions of a resource from its status, so that the
+// health of the resource can be assessed by the tools that follow these conventions, like Argo CD and Flux.
+// The resource is ready only if it is healthy and the latest generation of its spec has been processed,
+// i.e. observedGeneration equals the generation.
+func SetHealthConditions(conditions *[]metav1.Condition, generation int64, observedGeneration int64, status string) {
+	if status == "" {
+		status = StatusPending
+	}
+	ready := metav1.Condition{
+		Type:               ReadyCondition,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: generation,
+		Reason:             ProgressingReason,
+		Message:            "the resource is " + strings.ToLower(status),
+	}
+	progressing := metav1.Condition{
+		Type:               ProgressingCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             ProgressingReason,
+		Message:            "the resource is " + strings.ToLower(status),
+	}
+
+	switch {
+	case observedGeneration != generation:
+		ready.Reason = SpecNotObservedReason
+		ready.Message = "the latest spec has not been processed yet"
+		progressing.Reason = SpecNotObservedReason
+		progressing.Message = ready.Message
+	case status == StatusReady:
+		ready.Status = metav1.ConditionTrue
+		ready.Reason = ReadyReason
+		ready.Message = "the latest spec has been processed and the resource is healthy"
+		progressing.Status = metav1.ConditionFalse
+		progressing.Reason = ReadyReason
+		progressing.Message = ready.Message
+	case status == StatusError || status == StatusNotReady || status == StatusStopped:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = NotReadyReason
+		progressing.Status = metav1.ConditionFalse
+		progressing.Reason = NotReadyReason
+	}
+
+	meta.SetStatusCondition(conditions, ready)
+	meta.SetStatusCondition(conditions, progressing)
+}
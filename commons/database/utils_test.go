@@ -0,0 +1,245 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package commons
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// A reader without the index of the pods, failing the lists by field like the cache of another manager
+type unindexedReader struct {
+	client.Reader
+}
+
+func (r unindexedReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if listOpts.FieldSelector != nil {
+		return errors.New("Index with name field:" + PodAppIndex + " does not exist")
+	}
+	return r.Reader.List(ctx, list, opts...)
+}
+
+var _ = Describe("Utils", func() {
+	Describe("IsPodReady", func() {
+		It("Should check the readiness of the main container", func() {
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"kubectl.kubernetes.io/default-container": "ords"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "istio-proxy"}, {Name: "ords"}}},
+				Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "istio-proxy", Ready: true}, {Name: "ords", Ready: false}}},
+			}
+			Expect(IsPodReady(pod)).To(BeFalse())
+			pod.Status.ContainerStatuses[1].Ready = true
+			Expect(IsPodReady(pod)).To(BeTrue())
+		})
+	})
+
+	Describe("FindPods", func() {
+		AfterEach(func() {
+			podAppIndexed = false
+		})
+
+		It("Should fall back to the labels when the reader has no index of the pods", func() {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "ords-1", Namespace: "default", Labels: GetLabelsForController("", "ords")},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "ords", Image: "ords:23.1"}}},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			}
+			other := pod.DeepCopy()
+			other.Name = "sidb-1"
+			other.Labels = GetLabelsForController("", "sidb")
+			r := unindexedReader{fake.NewClientBuilder().WithObjects(pod, other).Build()}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "ords", Namespace: "default"}}
+
+			podAppIndexed = true
+			_, replicas, available, _, err := FindPods(r, "", "ords:23.1", "ords", "default", context.Background(), req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(replicas).To(Equal(1))
+			Expect(available[0].Name).To(Equal("ords-1"))
+		})
+	})
+
+	Describe("PasswordComplexityError", func() {
+		It("Should accept complex passwords", func() {
+			Expect(PasswordComplexityError("Tiger_2023x", "SYS", "SYSTEM")).To(BeEmpty())
+			Expect(PasswordComplexityError("l0ngEnough")).To(BeEmpty())
+		})
+
+		It("Should reject short, long and quoted passwords", func() {
+			Expect(PasswordComplexityError("Abc_123")).To(ContainSubstring("at least 8"))
+			Expect(PasswordComplexityError("Abcdefghij1234567890abcdefghij1")).To(ContainSubstring("at most 30"))
+			Expect(PasswordComplexityError("Abc\"12345")).To(ContainSubstring("double quotes"))
+			Expect(PasswordComplexityError("Abc 12345")).To(ContainSubstring("white spaces"))
+		})
+
+		It("Should require a letter and a digit", func() {
+			Expect(PasswordComplexityError("12345678")).To(ContainSubstring("one letter and one digit"))
+			Expect(PasswordComplexityError("abcdefgh")).To(ContainSubstring("one letter and one digit"))
+		})
+
+		It("Should reject the user name, reversed or not", func() {
+			Expect(PasswordComplexityError("System_2023", "SYS", "SYSTEM")).To(ContainSubstring("user name SYS"))
+			Expect(PasswordComplexityError("2023_sdro_x", "ORDS")).To(ContainSubstring("user name ORDS"))
+		})
+
+		It("Should reject simple passwords", func() {
+			Expect(PasswordComplexityError("Welcome1")).To(Equal("is too simple"))
+		})
+	})
+
+	Describe("ProxyEnv", func() {
+		BeforeEach(func() {
+			for _, name := range proxyEnvNames {
+				if value, ok := os.LookupEnv(name); ok {
+					DeferCleanup(os.Setenv, name, value)
+				} else {
+					DeferCleanup(os.Unsetenv, name)
+				}
+				os.Unsetenv(name)
+			}
+		})
+
+		It("Should be empty without a proxy", func() {
+			Expect(ProxyEnv()).To(BeEmpty())
+		})
+
+		It("Should reach the services of the cluster without the proxy", func() {
+			for _, host := range []string{"cdb-ords:8888", "cdb-ords.default.svc", "cdb-ords.default.svc.cluster.local:8888"} {
+				req, err := http.NewRequest("GET", "https://"+host+"/ords/_/db-api/stable/", nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ProxyFromEnvironment(req)).To(BeNil())
+			}
+		})
+
+		It("Should pass the proxy variables of the operator", func() {
+			os.Setenv("HTTPS_PROXY", "http://proxy.example.com:80")
+			os.Setenv("NO_PROXY", ".svc,.cluster.local")
+			env := ProxyEnv()
+			Expect(env).To(HaveLen(2))
+			Expect(env[0].Name).To(Equal("HTTPS_PROXY"))
+			Expect(env[0].Value).To(Equal("http://proxy.example.com:80"))
+			Expect(env[1].Name).To(Equal("NO_PROXY"))
+		})
+	})
+
+	Describe("GetLoadBalancerAddress", func() {
+		It("Should be empty until the load balancer is provisioned", func() {
+			Expect(GetLoadBalancerAddress(&corev1.Service{})).To(BeEmpty())
+		})
+
+		It("Should prefer the hostname to the IP address", func() {
+			svc := &corev1.Service{}
+			svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+			Expect(GetLoadBalancerAddress(svc)).To(Equal("10.0.0.1"))
+			svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "lb.elb.amazonaws.com", IP: "10.0.0.1"}}
+			Expect(GetLoadBalancerAddress(svc)).To(Equal("lb.elb.amazonaws.com"))
+		})
+
+		It("Should trigger a reconcile when the address changes", func() {
+			oldSvc, newSvc := &corev1.Service{}, &corev1.Service{}
+			oldSvc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+			newSvc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+			Expect(ResourceEventHandler().Update(event.UpdateEvent{ObjectOld: oldSvc, ObjectNew: newSvc})).To(BeFalse())
+			newSvc.Status.LoadBalancer.Ingress[0].IP = "10.0.0.2"
+			Expect(ResourceEventHandler().Update(event.UpdateEvent{ObjectOld: oldSvc, ObjectNew: newSvc})).To(BeTrue())
+		})
+	})
+
+	Describe("RenderOrdsProperties", func() {
+		It("Should render the sorted and escaped properties", func() {
+			xml := RenderOrdsProperties(map[string]string{"jdbc.MaxLimit": "40", "db.servicename": "ORCL", "misc.defaultPage": "a&b<c>"})
+			Expect(xml).To(HavePrefix("<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"no\"?>\n"))
+			Expect(xml).To(ContainSubstring("<entry key=\"db.servicename\">ORCL</entry>\n<entry key=\"jdbc.MaxLimit\">40</entry>\n"))
+			Expect(xml).To(ContainSubstring("<entry key=\"misc.defaultPage\">a&amp;b&lt;c&gt;</entry>"))
+			Expect(xml).To(HaveSuffix("</properties>\n"))
+			Expect(RenderOrdsProperties(map[string]string{"b": "2", "a": "1"})).To(Equal(RenderOrdsProperties(map[string]string{"a": "1", "b": "2"})))
+		})
+	})
+
+	Describe("OrdsMaxReplicas", func() {
+		It("Should fit the pools in the processes left by the background processes", func() {
+			Expect(OrdsMaxReplicas(320, 58, 20)).To(Equal(13))
+			Expect(OrdsMaxReplicas(300, 60, 40)).To(Equal(6))
+		})
+
+		It("Should return zero when no pool fits", func() {
+			Expect(OrdsMaxReplicas(60, 60, 20)).To(Equal(0))
+			Expect(OrdsMaxReplicas(300, 60, 0)).To(Equal(0))
+		})
+	})
+
+	Describe("RenderOrdsUrlMapping", func() {
+		It("Should map the sorted base URLs to their pools", func() {
+			xml := RenderOrdsUrlMapping(map[string]string{
+				"https://sales.example.com/ords/": OrdsVirtualHostPool("SALESPDB"),
+				"https://hr.example.com/ords/":    OrdsVirtualHostPool("HRPDB"),
+			})
+			Expect(xml).To(HavePrefix("<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"no\"?>\n"))
+			Expect(xml).To(ContainSubstring("<pool name=\"vhost_hrpdb\" base-url=\"https://hr.example.com/ords/\"/>\n" +
+				"<pool name=\"vhost_salespdb\" base-url=\"https://sales.example.com/ords/\"/>\n"))
+			Expect(xml).To(HaveSuffix("</pool-config>\n"))
+		})
+	})
+
+	Describe("SetWafPolicyAnnotation", func() {
+		It("Should set, change and remove the policy", func() {
+			svc := &corev1.Service{}
+			Expect(SetWafPolicyAnnotation(svc, "")).To(BeFalse())
+			Expect(SetWafPolicyAnnotation(svc, "ocid1.webappfirewallpolicy.oc1..a")).To(BeTrue())
+			Expect(svc.Annotations[WafPolicyAnnotation]).To(Equal("ocid1.webappfirewallpolicy.oc1..a"))
+			Expect(SetWafPolicyAnnotation(svc, "ocid1.webappfirewallpolicy.oc1..a")).To(BeFalse())
+			Expect(SetWafPolicyAnnotation(svc, "")).To(BeTrue())
+			Expect(svc.Annotations).NotTo(HaveKey(WafPolicyAnnotation))
+		})
+	})
+})
@@ -57,6 +57,7 @@ import (
 
 	databasev1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
 	"github.com/oracle/oracle-database-operator/commons/annotations"
+	"github.com/oracle/oracle-database-operator/commons/oci"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -472,6 +473,108 @@ func UpdateDbcsSystemIdInst(log logr.Logger, dbClient database.DatabaseClient, d
 	return nil
 }
 
+// UpdateDbcsSystemTags sets the tags mapped from the labels by spec.tagPropagation on the DB system
+func UpdateDbcsSystemTags(logger logr.Logger, dbClient database.DatabaseClient, dbcs *databasev1alpha1.DbcsSystem) error {
+	if dbcs.Spec.TagPropagation == nil || dbcs.Spec.Id == nil {
+		return nil
+	}
+
+	propFreeform, propDefined := oci.GetPropagatedTags(dbcs.GetLabels(), dbcs.Spec.TagPropagation)
+
+	resp, err := dbClient.GetDbSystem(context.TODO(), database.GetDbSystemRequest{DbSystemId: dbcs.Spec.Id})
+	if err != nil {
+		return err
+	}
+
+	freeform, defined, changed := oci.MergeTags(resp.FreeformTags, resp.DefinedTags, propFreeform, propDefined)
+	if !changed {
+		return nil
+	}
+
+	logger.Info("Propagating the labels as tags of the DB system")
+	_, err = dbClient.UpdateDbSystem(context.TODO(), database.UpdateDbSystemRequest{
+		DbSystemId: dbcs.Spec.Id,
+		UpdateDbSystemDetails: database.UpdateDbSystemDetails{
+			FreeformTags: freeform,
+			DefinedTags:  defined,
+		},
+	})
+	return err
+}
+
+// ValidateDbcsSystemDrift compares the spec with the OCI DB system, and merges, reverts or flags the changes
+// made outside of the operator according to spec.driftPolicy
+func ValidateDbcsSystemDrift(logger logr.Logger, kubeClient client.Client, dbClient database.DatabaseClient, dbcs *databasev1alpha1.DbcsSystem, nwClient core.VirtualNetworkClient, wrClient workrequests.WorkRequestClient) error {
+	resp, err := dbClient.GetDbSystem(context.TODO(), database.GetDbSystemRequest{DbSystemId: dbcs.Spec.Id})
+	if err != nil {
+		return err
+	}
+
+	driftSummary := []string{}
+	updateDbcsDetails := database.UpdateDbSystemDetails{}
+
+	if dbcs.Spec.DbSystem.CpuCoreCount > 0 && resp.CpuCoreCount != nil && dbcs.Spec.DbSystem.CpuCoreCount != *resp.CpuCoreCount {
+		driftSummary = append(driftSummary, fmt.Sprintf("cpuCoreCount: %d -> %d", dbcs.Spec.DbSystem.CpuCoreCount, *resp.CpuCoreCount))
+		updateDbcsDetails.CpuCoreCount = common.Int(dbcs.Spec.DbSystem.CpuCoreCount)
+	}
+	if dbcs.Spec.DbSystem.Shape != "" && resp.Shape != nil && dbcs.Spec.DbSystem.Shape != *resp.Shape {
+		driftSummary = append(driftSummary, fmt.Sprintf("shape: %s -> %s", dbcs.Spec.DbSystem.Shape, *resp.Shape))
+		updateDbcsDetails.Shape = common.String(dbcs.Spec.DbSystem.Shape)
+	}
+	if dbcs.Spec.DbSystem.LicenseModel != "" && getLicenceModel(dbcs) != resp.LicenseModel {
+		driftSummary = append(driftSummary, fmt.Sprintf("licenseModel: %s -> %s", getLicenceModel(dbcs), resp.LicenseModel))
+		updateDbcsDetails.LicenseModel = database.UpdateDbSystemDetailsLicenseModelEnum(getLicenceModel(dbcs))
+	}
+	if dbcs.Spec.DbSystem.InitialDataStorageSizeInGB != 0 && resp.DataStorageSizeInGBs != nil &&
+		dbcs.Spec.DbSystem.InitialDataStorageSizeInGB != *resp.DataStorageSizeInGBs {
+		driftSummary = append(driftSummary, fmt.Sprintf("initialDataStorageSizeInGB: %d -> %d", dbcs.Spec.DbSystem.InitialDataStorageSizeInGB, *resp.DataStorageSizeInGBs))
+		updateDbcsDetails.DataStorageSizeInGBs = common.Int(dbcs.Spec.DbSystem.InitialDataStorageSizeInGB)
+	}
+
+	if len(driftSummary) == 0 || dbcs.Spec.DriftPolicy == databasev1alpha1.DriftPolicyFlag {
+		if len(driftSummary) != 0 {
+			logger.Info("The DB system has been changed outside of the operator", "changes", driftSummary)
+		}
+		databasev1alpha1.SetDriftedCondition(&dbcs.Status.Conditions, dbcs.GetGeneration(), driftSummary)
+		dbcs.Status.DriftSummary = driftSummary
+		return nil
+	}
+
+	databasev1alpha1.SetDriftedCondition(&dbcs.Status.Conditions, dbcs.GetGeneration(), nil)
+	dbcs.Status.DriftSummary = nil
+
+	if dbcs.Spec.DriftPolicy == databasev1alpha1.DriftPolicyRevert {
+		logger.Info("The DB system has been changed outside of the operator; revert the changes", "changes", driftSummary)
+		if _, err := dbClient.UpdateDbSystem(context.TODO(), database.UpdateDbSystemRequest{
+			DbSystemId:            dbcs.Spec.Id,
+			UpdateDbSystemDetails: updateDbcsDetails,
+		}); err != nil {
+			return err
+		}
+
+		if statusErr := SetLifecycleState(kubeClient, dbClient, dbcs, databasev1alpha1.Update, nwClient, wrClient); statusErr != nil {
+			return statusErr
+		}
+		_, err = CheckResourceState(logger, dbClient, *dbcs.Spec.Id, "UPDATING", "AVAILABLE")
+		return err
+	}
+
+	logger.Info("The DB system has been changed outside of the operator; merge the changes into the spec", "changes", driftSummary)
+	if updateDbcsDetails.CpuCoreCount != nil {
+		dbcs.Spec.DbSystem.CpuCoreCount = *resp.CpuCoreCount
+	}
+	if updateDbcsDetails.Shape != nil {
+		dbcs.Spec.DbSystem.Shape = *resp.Shape
+	}
+	if updateDbcsDetails.LicenseModel != "" {
+		dbcs.Spec.DbSystem.LicenseModel = string(resp.LicenseModel)
+	}
+	if updateDbcsDetails.DataStorageSizeInGBs != nil {
+		dbcs.Spec.DbSystem.InitialDataStorageSizeInGB = *resp.DataStorageSizeInGBs
+	}
+	return kubeClient.Update(context.TODO(), dbcs)
+}
+
 func UpdateDbcsSystemId(kubeClient client.Client, dbcs *databasev1alpha1.DbcsSystem) error {
 	payload := []annotations.PatchValue{{
 		Op:    "replace",
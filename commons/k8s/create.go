@@ -80,11 +80,18 @@ func CreateAutonomousBackup(kubeClient client.Client,
 	backupSummary database.AutonomousDatabaseBackupSummary,
 	ownerADB *dbv1alpha1.AutonomousDatabase) error {
 
+	// The backup has the labels of its database, so that it is selected by the watch selector of the operator like its database
+	labels := make(map[string]string)
+	for key, value := range ownerADB.GetLabels() {
+		labels[key] = value
+	}
+
 	backup := &dbv1alpha1.AutonomousDatabaseBackup{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:       ownerADB.GetNamespace(),
 			Name:            backupName,
 			OwnerReferences: NewOwnerReference(ownerADB),
+			Labels:          labels,
 		},
 		Spec: dbv1alpha1.AutonomousDatabaseBackupSpec{
 			Target: dbv1alpha1.TargetSpec{
@@ -0,0 +1,76 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package k8s
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/database"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("Create AutonomousDatabaseBackup", func() {
+	It("Should label the backup like its database", func() {
+		scheme := runtime.NewScheme()
+		Expect(dbv1alpha1.AddToScheme(scheme)).To(Succeed())
+		kubeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		adb := &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: "adb", Namespace: "default", UID: "uid-adb", Labels: map[string]string{"fleet": "team-a"}},
+		}
+		summary := database.AutonomousDatabaseBackupSummary{
+			Id:          common.String("ocid1.autonomousdatabasebackup.oc1..backup"),
+			DisplayName: common.String("backup"),
+		}
+		Expect(CreateAutonomousBackup(kubeClient, "backup", summary, adb)).To(Succeed())
+
+		backup := &dbv1alpha1.AutonomousDatabaseBackup{}
+		Expect(kubeClient.Get(context.Background(), client.ObjectKey{Name: "backup", Namespace: "default"}, backup)).To(Succeed())
+		Expect(backup.Labels).To(Equal(map[string]string{"fleet": "team-a"}))
+		Expect(*backup.Spec.Target.K8sADB.Name).To(Equal("adb"))
+	})
+})
@@ -41,10 +41,14 @@ package k8s
 import (
 	"context"
 	"encoding/json"
+	"sync"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilErrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -84,3 +88,103 @@ func Patch(kubeClient client.Client, obj client.Object, path string, value inter
 	patch := client.RawPatch(types.JSONPatchType, payloadBytes)
 	return kubeClient.Patch(context.TODO(), obj, patch)
 }
+
+/**********************
+ Patch status
+**********************/
+
+type statusBasesKey struct{}
+
+type statusBases struct {
+	sync.Mutex
+	objects map[types.UID]client.Object
+}
+
+// WithStatusTracking returns a context in which PatchStatus only sends the changes made to the status of the tracked objects
+func WithStatusTracking(ctx context.Context) context.Context {
+	return context.WithValue(ctx, statusBasesKey{}, &statusBases{objects: map[types.UID]client.Object{}})
+}
+
+// TrackStatus records the object as read from the cluster. It should be called right after getting the object.
+func TrackStatus(ctx context.Context, obj client.Object) {
+	bases, ok := ctx.Value(statusBasesKey{}).(*statusBases)
+	if !ok || obj.GetUID() == "" {
+		return
+	}
+	bases.Lock()
+	defer bases.Unlock()
+	bases.objects[obj.GetUID()] = obj.DeepCopyObject().(client.Object)
+}
+
+func getStatusBase(ctx context.Context, obj client.Object) client.Object {
+	bases, ok := ctx.Value(statusBasesKey{}).(*statusBases)
+	if !ok {
+		return nil
+	}
+	bases.Lock()
+	defer bases.Unlock()
+	if base, ok := bases.objects[obj.GetUID()]; ok {
+		return base.DeepCopyObject().(client.Object)
+	}
+	return nil
+}
+
+// statusChanged returns whether the status of obj differs from the status of base
+func statusChanged(obj client.Object, base client.Object) bool {
+	desired, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return true
+	}
+	current, err := runtime.DefaultUnstructuredConverter.ToUnstructured(base)
+	if err != nil {
+		return true
+	}
+	return !equality.Semantic.DeepEqual(desired["status"], current["status"])
+}
+
+// PatchStatus patches the status subresource with the status of obj.
+// If obj is tracked, only the changes made since it was read or last patched are sent, so that the concurrent
+// changes made by the other controllers are kept. Otherwise the status replaces the latest status in the cluster,
+// and the patch is retried on conflicts.
+// No request is sent when the status is unchanged, so that the controllers can patch the status on every
+// reconcile without writing to etcd.
+func PatchStatus(ctx context.Context, kubeClient client.Client, obj client.Object) error {
+	if obj.GetName() == "" {
+		return nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		desired := obj.DeepCopyObject().(client.Object)
+
+		var patch client.Patch
+		if base := getStatusBase(ctx, obj); base != nil {
+			if !statusChanged(desired, base) {
+				return nil
+			}
+			desired.SetResourceVersion(base.GetResourceVersion())
+			patch = client.MergeFrom(base)
+		} else {
+			latest := obj.DeepCopyObject().(client.Object)
+			if err := kubeClient.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+				return err
+			}
+			if !statusChanged(desired, latest) {
+				return nil
+			}
+			desired.SetResourceVersion(latest.GetResourceVersion())
+			patch = client.MergeFromWithOptions(latest, client.MergeFromWithOptimisticLock{})
+		}
+
+		if err := kubeClient.Status().Patch(ctx, desired, patch); err != nil {
+			return err
+		}
+		obj.SetResourceVersion(desired.GetResourceVersion())
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	TrackStatus(ctx, obj)
+	return nil
+}
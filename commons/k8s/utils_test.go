@@ -0,0 +1,140 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package k8s
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Patch status", func() {
+	var kubeClient client.Client
+	var pod *corev1.Pod
+
+	BeforeEach(func() {
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default", UID: "uid-pod"},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		}
+		kubeClient = fake.NewClientBuilder().WithObjects(pod).Build()
+		Expect(kubeClient.Get(context.Background(), client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+	})
+
+	// Sets the message of the pod in the cluster, as another controller would
+	setMessage := func(message string) {
+		other := &corev1.Pod{}
+		Expect(kubeClient.Get(context.Background(), client.ObjectKeyFromObject(pod), other)).To(Succeed())
+		other.Status.Message = message
+		Expect(kubeClient.Status().Update(context.Background(), other)).To(Succeed())
+	}
+
+	getPod := func() *corev1.Pod {
+		latest := &corev1.Pod{}
+		Expect(kubeClient.Get(context.Background(), client.ObjectKeyFromObject(pod), latest)).To(Succeed())
+		return latest
+	}
+
+	It("Should compare the status only", func() {
+		changed := pod.DeepCopy()
+		changed.Labels = map[string]string{"app": "pod"}
+		Expect(statusChanged(changed, pod)).To(BeFalse())
+		changed.Status.Phase = corev1.PodRunning
+		Expect(statusChanged(changed, pod)).To(BeTrue())
+	})
+
+	It("Should only return the tracked objects, as copies", func() {
+		Expect(getStatusBase(context.Background(), pod)).To(BeNil())
+
+		ctx := WithStatusTracking(context.Background())
+		Expect(getStatusBase(ctx, pod)).To(BeNil())
+		TrackStatus(ctx, pod)
+		pod.Status.Phase = corev1.PodRunning
+		base := getStatusBase(ctx, pod).(*corev1.Pod)
+		Expect(base.Status.Phase).To(Equal(corev1.PodPending))
+
+		TrackStatus(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "new"}})
+		Expect(getStatusBase(ctx, &corev1.Pod{})).To(BeNil())
+	})
+
+	It("Should send no request when the status is unchanged", func() {
+		resourceVersion := pod.GetResourceVersion()
+		Expect(PatchStatus(context.Background(), kubeClient, pod)).To(Succeed())
+		Expect(getPod().GetResourceVersion()).To(Equal(resourceVersion))
+
+		ctx := WithStatusTracking(context.Background())
+		TrackStatus(ctx, pod)
+		Expect(PatchStatus(ctx, kubeClient, pod)).To(Succeed())
+		Expect(getPod().GetResourceVersion()).To(Equal(resourceVersion))
+	})
+
+	It("Should keep the concurrent changes when the object is tracked", func() {
+		ctx := WithStatusTracking(context.Background())
+		TrackStatus(ctx, pod)
+		setMessage("set by another controller")
+
+		pod.Status.Phase = corev1.PodRunning
+		Expect(PatchStatus(ctx, kubeClient, pod)).To(Succeed())
+		latest := getPod()
+		Expect(latest.Status.Phase).To(Equal(corev1.PodRunning))
+		Expect(latest.Status.Message).To(Equal("set by another controller"))
+		Expect(pod.GetResourceVersion()).To(Equal(latest.GetResourceVersion()))
+
+		// The patched status becomes the base of the next patch
+		Expect(getStatusBase(ctx, pod).(*corev1.Pod).Status.Phase).To(Equal(corev1.PodRunning))
+	})
+
+	It("Should replace the latest status when the object is not tracked", func() {
+		setMessage("set by another controller")
+
+		pod.Status.Phase = corev1.PodRunning
+		Expect(PatchStatus(context.Background(), kubeClient, pod)).To(Succeed())
+		latest := getPod()
+		Expect(latest.Status.Phase).To(Equal(corev1.PodRunning))
+		Expect(latest.Status.Message).To(BeEmpty())
+	})
+
+	It("Should not patch an object with no name", func() {
+		Expect(PatchStatus(context.Background(), kubeClient, &corev1.Pod{})).To(Succeed())
+	})
+})
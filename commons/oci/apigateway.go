@@ -0,0 +1,193 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oci
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/oracle/oci-go-sdk/v65/apigateway"
+	"github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// DeploymentOwnerTag is the freeform tag holding the resource a deployment was created for
+const DeploymentOwnerTag string = "oracle-database-operator-owner"
+
+type ApiGatewayService interface {
+	CreateDeployment(gatewayOCID string, compartmentOCID string, pathPrefix string, displayName string, owner string,
+		backendURL string) (apigateway.Deployment, error)
+	FindDeployment(gatewayOCID string, compartmentOCID string, displayName string, owner string) (*apigateway.DeploymentSummary, error)
+	GetDeployment(deploymentOCID string) (apigateway.Deployment, error)
+	UpdateDeploymentBackend(deploymentOCID string, backendURL string) error
+	DeleteDeployment(deploymentOCID string) error
+}
+
+type apiGatewayService struct {
+	logger           logr.Logger
+	deploymentClient apigateway.DeploymentClient
+}
+
+func NewApiGatewayService(
+	logger logr.Logger,
+	provider common.ConfigurationProvider) (ApiGatewayService, error) {
+
+	deploymentClient, err := apigateway.NewDeploymentClientWithConfigurationProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiGatewayService{
+		logger:           logger.WithName("apiGatewayService"),
+		deploymentClient: deploymentClient,
+	}, nil
+}
+
+// deploymentSpecification routes all the paths of a deployment to the same paths of the backend
+func deploymentSpecification(backendURL string) *apigateway.ApiSpecification {
+	return &apigateway.ApiSpecification{
+		Routes: []apigateway.ApiSpecificationRoute{{
+			Path:    common.String("/{path*}"),
+			Methods: []apigateway.ApiSpecificationRouteMethodsEnum{apigateway.ApiSpecificationRouteMethodsAny},
+			Backend: apigateway.HttpBackend{
+				Url: common.String(backendURL + "/${request.path[path]}"),
+				// The gateway must trust the certificate of the backend, with a CA bundle of the gateway
+				IsSslVerifyDisabled: common.Bool(false),
+			},
+		}},
+	}
+}
+
+func (a *apiGatewayService) CreateDeployment(gatewayOCID string, compartmentOCID string, pathPrefix string,
+	displayName string, owner string, backendURL string) (apigateway.Deployment, error) {
+
+	request := apigateway.CreateDeploymentRequest{
+		CreateDeploymentDetails: apigateway.CreateDeploymentDetails{
+			GatewayId:     common.String(gatewayOCID),
+			CompartmentId: common.String(compartmentOCID),
+			PathPrefix:    common.String(pathPrefix),
+			DisplayName:   common.String(displayName),
+			Specification: deploymentSpecification(backendURL),
+			FreeformTags:  map[string]string{DeploymentOwnerTag: owner},
+		},
+	}
+
+	response, err := a.deploymentClient.CreateDeployment(context.TODO(), request)
+	if err != nil {
+		return apigateway.Deployment{}, err
+	}
+	return response.Deployment, nil
+}
+
+// FindDeployment returns the deployment of a gateway created for an owner, nil if there is none. A deployment whose
+// creation was not recorded is found again instead of being created twice
+func (a *apiGatewayService) FindDeployment(gatewayOCID string, compartmentOCID string, displayName string,
+	owner string) (*apigateway.DeploymentSummary, error) {
+
+	request := apigateway.ListDeploymentsRequest{
+		CompartmentId: common.String(compartmentOCID),
+		GatewayId:     common.String(gatewayOCID),
+		DisplayName:   common.String(displayName),
+	}
+	for {
+		response, err := a.deploymentClient.ListDeployments(context.TODO(), request)
+		if err != nil {
+			return nil, err
+		}
+		if deployment := ownedDeployment(response.Items, owner); deployment != nil {
+			return deployment, nil
+		}
+		if response.OpcNextPage == nil {
+			return nil, nil
+		}
+		request.Page = response.OpcNextPage
+	}
+}
+
+// ownedDeployment returns the deployment tagged with an owner, leaving out the deleted ones
+func ownedDeployment(deployments []apigateway.DeploymentSummary, owner string) *apigateway.DeploymentSummary {
+	for i := range deployments {
+		switch deployments[i].LifecycleState {
+		case apigateway.DeploymentLifecycleStateDeleting, apigateway.DeploymentLifecycleStateDeleted:
+			continue
+		}
+		if deployments[i].FreeformTags[DeploymentOwnerTag] == owner {
+			return &deployments[i]
+		}
+	}
+	return nil
+}
+
+func (a *apiGatewayService) GetDeployment(deploymentOCID string) (apigateway.Deployment, error) {
+	request := apigateway.GetDeploymentRequest{
+		DeploymentId: common.String(deploymentOCID),
+	}
+
+	response, err := a.deploymentClient.GetDeployment(context.TODO(), request)
+	if err != nil {
+		return apigateway.Deployment{}, err
+	}
+	return response.Deployment, nil
+}
+
+func (a *apiGatewayService) UpdateDeploymentBackend(deploymentOCID string, backendURL string) error {
+	request := apigateway.UpdateDeploymentRequest{
+		DeploymentId: common.String(deploymentOCID),
+		UpdateDeploymentDetails: apigateway.UpdateDeploymentDetails{
+			Specification: deploymentSpecification(backendURL),
+		},
+	}
+
+	_, err := a.deploymentClient.UpdateDeployment(context.TODO(), request)
+	return err
+}
+
+// IsNotFoundError returns true for the errors of the OCI resources that do not exist
+func IsNotFoundError(err error) bool {
+	serviceErr, ok := common.IsServiceError(err)
+	return ok && serviceErr.GetHTTPStatusCode() == 404
+}
+
+func (a *apiGatewayService) DeleteDeployment(deploymentOCID string) error {
+	request := apigateway.DeleteDeploymentRequest{
+		DeploymentId: common.String(deploymentOCID),
+	}
+
+	_, err := a.deploymentClient.DeleteDeployment(context.TODO(), request)
+	return err
+}
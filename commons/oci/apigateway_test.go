@@ -0,0 +1,84 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oci
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oracle/oci-go-sdk/v65/apigateway"
+	"github.com/oracle/oci-go-sdk/v65/common"
+)
+
+var _ = Describe("API Gateway deployments", func() {
+	It("Should route all the paths to the backend, verifying its certificate", func() {
+		spec := deploymentSpecification("https://ords.example.com:8443/ords")
+		Expect(spec.Routes).To(HaveLen(1))
+		Expect(*spec.Routes[0].Path).To(Equal("/{path*}"))
+		backend, ok := spec.Routes[0].Backend.(apigateway.HttpBackend)
+		Expect(ok).To(BeTrue())
+		Expect(*backend.Url).To(Equal("https://ords.example.com:8443/ords/${request.path[path]}"))
+		Expect(*backend.IsSslVerifyDisabled).To(BeFalse())
+	})
+
+	It("Should find the deployment tagged with its owner", func() {
+		deployments := []apigateway.DeploymentSummary{
+			{Id: common.String("other"), FreeformTags: map[string]string{DeploymentOwnerTag: "tenant/other"},
+				LifecycleState: apigateway.DeploymentLifecycleStateActive},
+			{Id: common.String("untagged"), LifecycleState: apigateway.DeploymentLifecycleStateActive},
+			{Id: common.String("deleted"), FreeformTags: map[string]string{DeploymentOwnerTag: "tenant/ords"},
+				LifecycleState: apigateway.DeploymentLifecycleStateDeleted},
+			{Id: common.String("owned"), FreeformTags: map[string]string{DeploymentOwnerTag: "tenant/ords"},
+				LifecycleState: apigateway.DeploymentLifecycleStateCreating},
+		}
+		deployment := ownedDeployment(deployments, "tenant/ords")
+		Expect(deployment).NotTo(BeNil())
+		Expect(*deployment.Id).To(Equal("owned"))
+	})
+
+	It("Should find no deployment without its tag", func() {
+		deployments := []apigateway.DeploymentSummary{
+			{Id: common.String("deleting"), FreeformTags: map[string]string{DeploymentOwnerTag: "tenant/ords"},
+				LifecycleState: apigateway.DeploymentLifecycleStateDeleting},
+			{Id: common.String("untagged"), LifecycleState: apigateway.DeploymentLifecycleStateActive},
+		}
+		Expect(ownedDeployment(deployments, "tenant/ords")).To(BeNil())
+		Expect(ownedDeployment(nil, "tenant/ords")).To(BeNil())
+	})
+})
@@ -51,12 +51,18 @@ import (
  * Autonomous Container Database
  *******************************/
 func (d *databaseService) CreateAutonomousContainerDatabase(acd *dbv1alpha1.AutonomousContainerDatabase) (database.CreateAutonomousContainerDatabaseResponse, error) {
+	patchModel := database.CreateAutonomousContainerDatabaseDetailsPatchModelUpdates
+	if acd.Spec.PatchModel != "" {
+		patchModel = database.CreateAutonomousContainerDatabaseDetailsPatchModelEnum(acd.Spec.PatchModel)
+	}
+
 	createAutonomousContainerDatabaseRequest := database.CreateAutonomousContainerDatabaseRequest{
 		CreateAutonomousContainerDatabaseDetails: database.CreateAutonomousContainerDatabaseDetails{
 			CompartmentId:              acd.Spec.CompartmentOCID,
 			DisplayName:                acd.Spec.DisplayName,
 			CloudAutonomousVmClusterId: acd.Spec.AutonomousExadataVMClusterOCID,
-			PatchModel:                 database.CreateAutonomousContainerDatabaseDetailsPatchModelUpdates,
+			AutonomousVmClusterId:      acd.Spec.AutonomousVMClusterOCID,
+			PatchModel:                 patchModel,
 		},
 	}
 
@@ -55,6 +55,7 @@ type DatabaseService interface {
 	CreateAutonomousDatabase(adb *dbv1alpha1.AutonomousDatabase) (database.CreateAutonomousDatabaseResponse, error)
 	GetAutonomousDatabase(adbOCID string) (database.GetAutonomousDatabaseResponse, error)
 	UpdateAutonomousDatabaseGeneralFields(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
+	UpdateAutonomousDatabaseTags(adbOCID string, freeformTags map[string]string, definedTags map[string]map[string]interface{}) (resp database.UpdateAutonomousDatabaseResponse, err error)
 	UpdateAutonomousDatabaseDBWorkload(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
 	UpdateAutonomousDatabaseLicenseModel(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
 	UpdateAutonomousDatabaseAdminPassword(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error)
@@ -228,6 +229,17 @@ func (d *databaseService) UpdateAutonomousDatabaseGeneralFields(adbOCID string,
 	return d.dbClient.UpdateAutonomousDatabase(context.TODO(), updateAutonomousDatabaseRequest)
 }
 
+func (d *databaseService) UpdateAutonomousDatabaseTags(adbOCID string, freeformTags map[string]string, definedTags map[string]map[string]interface{}) (resp database.UpdateAutonomousDatabaseResponse, err error) {
+	updateAutonomousDatabaseRequest := database.UpdateAutonomousDatabaseRequest{
+		AutonomousDatabaseId: common.String(adbOCID),
+		UpdateAutonomousDatabaseDetails: database.UpdateAutonomousDatabaseDetails{
+			FreeformTags: freeformTags,
+			DefinedTags:  definedTags,
+		},
+	}
+	return d.dbClient.UpdateAutonomousDatabase(context.TODO(), updateAutonomousDatabaseRequest)
+}
+
 func (d *databaseService) UpdateAutonomousDatabaseDBWorkload(adbOCID string, difADB *dbv1alpha1.AutonomousDatabase) (resp database.UpdateAutonomousDatabaseResponse, err error) {
 	updateAutonomousDatabaseRequest := database.UpdateAutonomousDatabaseRequest{
 		AutonomousDatabaseId: common.String(adbOCID),
@@ -40,6 +40,7 @@ package oci
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/common/auth"
@@ -58,13 +59,44 @@ const (
 	privatekeyKey  = "privatekey"
 )
 
+// Authentication modes of the OCI clients
+const (
+	AuthModeAPIKey            = "APIKey"
+	AuthModeInstancePrincipal = "InstancePrincipal"
+	AuthModeResourcePrincipal = "ResourcePrincipal"
+	AuthModeWorkloadIdentity  = "WorkloadIdentity"
+)
+
 type APIKeyAuth struct {
 	ConfigMapName *string
 	SecretName    *string
 	Namespace     string
+	// One of the AuthMode constants. If empty, APIKey is used when both the ConfigMap and the Secret are set,
+	// InstancePrincipal when none is
+	AuthMode string
 }
 
+// The principal providers are shared by all the controllers. They fetch their security tokens from the instance
+// metadata, the resource principal endpoint or the OKE proxymux, and refresh them before they expire
+var (
+	principalProviders     = map[string]common.ConfigurationProvider{}
+	principalProvidersLock sync.Mutex
+)
+
 func GetOCIProvider(kubeClient client.Client, authData APIKeyAuth) (common.ConfigurationProvider, error) {
+	switch authData.AuthMode {
+	case AuthModeInstancePrincipal, AuthModeResourcePrincipal, AuthModeWorkloadIdentity:
+		return getPrincipalProvider(authData.AuthMode)
+	case AuthModeAPIKey:
+		if authData.ConfigMapName == nil || authData.SecretName == nil {
+			return nil, errors.New("both the OCI ConfigMap and the privateKey are required to authorize with API signing key")
+		}
+		return getProviderWithAPIKey(kubeClient, authData)
+	case "":
+	default:
+		return nil, errors.New("unknown OCI authentication mode " + authData.AuthMode)
+	}
+
 	if authData.ConfigMapName != nil && authData.SecretName != nil {
 		provider, err := getProviderWithAPIKey(kubeClient, authData)
 		if err != nil {
@@ -73,13 +105,39 @@ func GetOCIProvider(kubeClient client.Client, authData APIKeyAuth) (common.Confi
 
 		return provider, nil
 	} else if authData.ConfigMapName == nil && authData.SecretName == nil {
-		return auth.InstancePrincipalConfigurationProvider()
+		return getPrincipalProvider(AuthModeInstancePrincipal)
 	} else {
 		return nil, errors.New("both the OCI ConfigMap and the privateKey are required to authorize with API signing key; " +
 			"leave them both empty to authorize with Instance Principal")
 	}
 }
 
+func getPrincipalProvider(authMode string) (common.ConfigurationProvider, error) {
+	principalProvidersLock.Lock()
+	defer principalProvidersLock.Unlock()
+
+	if provider, ok := principalProviders[authMode]; ok {
+		return provider, nil
+	}
+
+	var provider common.ConfigurationProvider
+	var err error
+	switch authMode {
+	case AuthModeResourcePrincipal:
+		provider, err = auth.ResourcePrincipalConfigurationProvider()
+	case AuthModeWorkloadIdentity:
+		// Exchanges the token of the service account of the operator pod for an OCI security token
+		provider, err = auth.OkeWorkloadIdentityConfigurationProvider()
+	default:
+		provider, err = auth.InstancePrincipalConfigurationProvider()
+	}
+	if err != nil {
+		return nil, err
+	}
+	principalProviders[authMode] = provider
+	return provider, nil
+}
+
 func getProviderWithAPIKey(kubeClient client.Client, authData APIKeyAuth) (common.ConfigurationProvider, error) {
 	var region, fingerprint, user, tenancy, passphrase, privatekeyValue string
 
@@ -0,0 +1,116 @@
+/*
+** Copyright (c) 2022 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oci
+
+import (
+	"reflect"
+	"strings"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+// GetPropagatedTags returns the freeform and defined tags mapped from the labels of a resource
+func GetPropagatedTags(labels map[string]string, spec *dbv1alpha1.TagPropagationSpec) (map[string]string, map[string]map[string]interface{}) {
+	freeform := map[string]string{}
+	defined := map[string]map[string]interface{}{}
+	if spec == nil {
+		return freeform, defined
+	}
+
+	for label, key := range spec.FreeformTags {
+		value, ok := labels[label]
+		if !ok {
+			continue
+		}
+		if key == "" {
+			key = label
+		}
+		freeform[key] = value
+	}
+	for label, key := range spec.DefinedTags {
+		value, ok := labels[label]
+		if !ok {
+			continue
+		}
+		// The key is <namespace>.<key>
+		parts := strings.SplitN(key, ".", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if defined[parts[0]] == nil {
+			defined[parts[0]] = map[string]interface{}{}
+		}
+		defined[parts[0]][parts[1]] = value
+	}
+	return freeform, defined
+}
+
+// MergeTags adds the propagated tags to the current tags of an OCI resource. Returns false if they are all set already.
+// The tags of labels that have been removed are kept
+func MergeTags(freeform map[string]string, defined map[string]map[string]interface{},
+	propFreeform map[string]string, propDefined map[string]map[string]interface{}) (map[string]string, map[string]map[string]interface{}, bool) {
+
+	mergedFreeform := map[string]string{}
+	for key, value := range freeform {
+		mergedFreeform[key] = value
+	}
+	mergedDefined := map[string]map[string]interface{}{}
+	for namespace, tags := range defined {
+		mergedDefined[namespace] = map[string]interface{}{}
+		for key, value := range tags {
+			mergedDefined[namespace][key] = value
+		}
+	}
+
+	for key, value := range propFreeform {
+		mergedFreeform[key] = value
+	}
+	for namespace, tags := range propDefined {
+		if mergedDefined[namespace] == nil {
+			mergedDefined[namespace] = map[string]interface{}{}
+		}
+		for key, value := range tags {
+			mergedDefined[namespace][key] = value
+		}
+	}
+
+	changed := !reflect.DeepEqual(mergedFreeform, freeform) && (len(mergedFreeform) != 0 || len(freeform) != 0) ||
+		!reflect.DeepEqual(mergedDefined, defined) && (len(mergedDefined) != 0 || len(defined) != 0)
+	return mergedFreeform, mergedDefined, changed
+}
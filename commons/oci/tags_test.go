@@ -0,0 +1,82 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oci
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+)
+
+var _ = Describe("Tag propagation", func() {
+	labels := map[string]string{"team": "payments", "cost-center": "cc-42", "tier": "gold"}
+
+	It("Should map the labels to freeform and defined tags", func() {
+		freeform, defined := GetPropagatedTags(labels, &dbv1alpha1.TagPropagationSpec{
+			FreeformTags: map[string]string{"team": "", "tier": "ServiceTier", "missing": ""},
+			DefinedTags:  map[string]string{"cost-center": "Finance.CostCenter", "team": "NoNamespace"},
+		})
+		Expect(freeform).To(Equal(map[string]string{"team": "payments", "ServiceTier": "gold"}))
+		Expect(defined).To(Equal(map[string]map[string]interface{}{"Finance": {"CostCenter": "cc-42"}}))
+	})
+
+	It("Should map no tags without a tagPropagation", func() {
+		freeform, defined := GetPropagatedTags(labels, nil)
+		Expect(freeform).To(BeEmpty())
+		Expect(defined).To(BeEmpty())
+	})
+
+	It("Should add the propagated tags to the tags of the OCI resource", func() {
+		freeform, defined, changed := MergeTags(
+			map[string]string{"owner": "dba"}, map[string]map[string]interface{}{"Finance": {"Budget": "b1"}},
+			map[string]string{"team": "payments"}, map[string]map[string]interface{}{"Finance": {"CostCenter": "cc-42"}})
+		Expect(changed).To(BeTrue())
+		Expect(freeform).To(Equal(map[string]string{"owner": "dba", "team": "payments"}))
+		Expect(defined).To(Equal(map[string]map[string]interface{}{"Finance": {"Budget": "b1", "CostCenter": "cc-42"}}))
+	})
+
+	It("Should report no change when the propagated tags are set already", func() {
+		_, _, changed := MergeTags(
+			map[string]string{"team": "payments"}, map[string]map[string]interface{}{"Finance": {"CostCenter": "cc-42"}},
+			map[string]string{"team": "payments"}, map[string]map[string]interface{}{"Finance": {"CostCenter": "cc-42"}})
+		Expect(changed).To(BeFalse())
+		_, _, changed = MergeTags(nil, nil, map[string]string{}, map[string]map[string]interface{}{})
+		Expect(changed).To(BeFalse())
+	})
+})
@@ -159,6 +159,10 @@ func buildPodSpecForCatalog(instance *databasev1alpha1.ShardingDatabase, OraCata
 			spec.NodeSelector[key] = value
 		}
 	}
+
+	if OraCatalogSpex.Affinity != nil {
+		spec.Affinity = OraCatalogSpex.Affinity.DeepCopy()
+	}
 	return spec
 }
 
@@ -327,7 +331,7 @@ func volumeClaimTemplatesForCatalog(instance *databasev1alpha1.ShardingDatabase,
 				AccessModes: []corev1.PersistentVolumeAccessMode{
 					corev1.ReadWriteOnce,
 				},
-				StorageClassName: &instance.Spec.StorageClass,
+				StorageClassName: getStorageClass(instance, OraCatalogSpex.StorageClass),
 				Resources: corev1.ResourceRequirements{
 					Requests: corev1.ResourceList{
 						corev1.ResourceStorage: resource.MustParse(strconv.FormatInt(int64(OraCatalogSpex.StorageSizeInGb), 10) + "Gi"),
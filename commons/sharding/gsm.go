@@ -160,6 +160,10 @@ func buildPodSpecForGsm(instance *databasev1alpha1.ShardingDatabase, OraGsmSpex
 			spec.NodeSelector[key] = value
 		}
 	}
+
+	if OraGsmSpex.Affinity != nil {
+		spec.Affinity = OraGsmSpex.Affinity.DeepCopy()
+	}
 	return spec
 }
 
@@ -335,7 +339,7 @@ func volumeClaimTemplatesForGsm(instance *databasev1alpha1.ShardingDatabase, Ora
 				AccessModes: []corev1.PersistentVolumeAccessMode{
 					corev1.ReadWriteOnce,
 				},
-				StorageClassName: &instance.Spec.StorageClass,
+				StorageClassName: getStorageClass(instance, OraGsmSpex.StorageClass),
 				Resources: corev1.ResourceRequirements{
 					Requests: corev1.ResourceList{
 						corev1.ResourceStorage: resource.MustParse(strconv.FormatInt(int64(OraGsmSpex.StorageSizeInGb), 10) + "Gi"),
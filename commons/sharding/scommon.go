@@ -78,6 +78,7 @@ const (
 	oraFsGroup                = int64(54321)
 	oraScriptMount            = "/opt/oracle/scripts/sharding/scripts"
 	oraDataMount              = "/opt/oracle/oradata"
+	oraBackupDir              = "/opt/oracle/oradata/backup"
 	oraGsmDataMount           = "/opt/oracle/gsmdata"
 	oraConfigMapMount         = "/mnt/config-map"
 	oraEnvFileMount           = "/mnt/conf.d"
@@ -618,6 +619,14 @@ func getOwnerRef(instance *databasealphav1.ShardingDatabase,
 	return ownerRef
 }
 
+// The storage class of a shard, catalog or GSM entry overrides the one of the spec
+func getStorageClass(instance *databasealphav1.ShardingDatabase, storageClass string) *string {
+	if len(storageClass) != 0 {
+		return &storageClass
+	}
+	return &instance.Spec.StorageClass
+}
+
 func buildCatalogParams(instance *databasealphav1.ShardingDatabase) string {
 	var variables []databasealphav1.EnvironmentVariable = instance.Spec.Catalog[0].EnvVars
 	var result string
@@ -918,6 +927,25 @@ func getdeployShardCmd() []string {
 	return depCmd
 }
 
+func getCreateRestorePointCmd(name string) []string {
+	var restorePointCmd = []string{"/bin/bash", "-c", "$ORACLE_HOME/bin/gdsctl create restorepoint -name " + name}
+	return restorePointCmd
+}
+
+// The backup runs in the background, and its exit code is written to rman_<tag>.rc once it completes
+func getBackupCmd(tag string, level int) []string {
+	prefix := oraBackupDir + "/rman_" + tag
+	backupCmd := "mkdir -p " + oraBackupDir + "; rm -f " + prefix + ".rc;" +
+		" echo \"run { backup as compressed backupset incremental level " + strconv.Itoa(level) + " database tag '" + tag + "' plus archivelog tag '" + tag + "'; }\" > " + prefix + ".rcv;" +
+		" setsid nohup /bin/bash -c 'rman target / cmdfile=" + prefix + ".rcv log=" + prefix + ".log; echo $? > " + prefix + ".rc' > /dev/null 2>&1 &"
+	return []string{"/bin/bash", "-c", backupCmd}
+}
+
+func getBackupStateCmd(tag string) []string {
+	var backupStateCmd = []string{"/bin/bash", "-c", "cat " + oraBackupDir + "/rman_" + tag + ".rc 2>/dev/null || echo RUNNING"}
+	return backupStateCmd
+}
+
 func getGsmvalidateCmd() []string {
 	var depCmd []string = []string{oraScriptMount + "/cmdExec", "/bin/python", oraScriptMount + "/main.py ", "--checkliveness=true", "--optype=gsm"}
 	return depCmd
@@ -1205,6 +1233,43 @@ func GetDbOpenMode(PodName string, instance *databasealphav1.ShardingDatabase, k
 	return strings.TrimSpace(stdoutput)
 }
 
+// CreateGlobalRestorePoint creates a restore point in the catalog and all the shards at a consistent SCN through the GSM
+func CreateGlobalRestorePoint(gsmPodName string, name string, instance *databasealphav1.ShardingDatabase, kubeClient kubernetes.Interface, kubeconfig clientcmd.ClientConfig, logger logr.Logger,
+) error {
+	stdoutput, _, err := ExecCommand(gsmPodName, getCreateRestorePointCmd(name), kubeClient, kubeconfig, instance, logger)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(stdoutput, "GSM-") || strings.Contains(stdoutput, "ORA-") {
+		return fmt.Errorf("unable to create the global restore point %s: %s", name, strings.TrimSpace(stdoutput))
+	}
+	return nil
+}
+
+// StartBackup starts an RMAN backup of the database in the pod, incremental level 0 for a full backup
+func StartBackup(podName string, tag string, level int, instance *databasealphav1.ShardingDatabase, kubeClient kubernetes.Interface, kubeconfig clientcmd.ClientConfig, logger logr.Logger,
+) error {
+	_, _, err := ExecCommand(podName, getBackupCmd(tag, level), kubeClient, kubeconfig, instance, logger)
+	return err
+}
+
+// GetBackupState returns the state of the backup started by StartBackup
+func GetBackupState(podName string, tag string, instance *databasealphav1.ShardingDatabase, kubeClient kubernetes.Interface, kubeconfig clientcmd.ClientConfig, logger logr.Logger,
+) string {
+	stdoutput, _, err := ExecCommand(podName, getBackupStateCmd(tag), kubeClient, kubeconfig, instance, logger)
+	if err != nil {
+		// The pod might be restarting; check again later
+		return string(databasealphav1.BackupRunningState)
+	}
+	switch strings.TrimSpace(stdoutput) {
+	case "RUNNING":
+		return string(databasealphav1.BackupRunningState)
+	case "0":
+		return string(databasealphav1.BackupCompletedState)
+	}
+	return string(databasealphav1.BackupFailedState)
+}
+
 func SfsetLabelPatch(sfSetFound *appsv1.StatefulSet, sfSetPod *corev1.Pod, instance *databasealphav1.ShardingDatabase, kClient client.Client,
 ) error {
 
@@ -160,6 +160,10 @@ func buildPodSpecForShard(instance *databasev1alpha1.ShardingDatabase, OraShardS
 		}
 	}
 
+	if OraShardSpex.Affinity != nil {
+		spec.Affinity = OraShardSpex.Affinity.DeepCopy()
+	}
+
 	return spec
 }
 
@@ -330,7 +334,7 @@ func volumeClaimTemplatesForShard(instance *databasev1alpha1.ShardingDatabase, O
 				AccessModes: []corev1.PersistentVolumeAccessMode{
 					corev1.ReadWriteOnce,
 				},
-				StorageClassName: &instance.Spec.StorageClass,
+				StorageClassName: getStorageClass(instance, OraShardSpex.StorageClass),
 				Resources: corev1.ResourceRequirements{
 					Requests: corev1.ResourceList{
 						corev1.ResourceStorage: resource.MustParse(strconv.FormatInt(int64(OraShardSpex.StorageSizeInGb), 10) + "Gi"),
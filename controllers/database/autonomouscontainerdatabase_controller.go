@@ -78,6 +78,7 @@ type AutonomousContainerDatabaseReconciler struct {
 func (r *AutonomousContainerDatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbv1alpha1.AutonomousContainerDatabase{}).
+		WithEventFilter(fleetPredicate()).
 		WithEventFilter(r.eventFilterPredicate()).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 5}).
 		Complete(r)
@@ -214,6 +215,10 @@ func (r *AutonomousContainerDatabaseReconciler) Reconcile(ctx context.Context, r
 	/******************************************************************
 	*	Update the status and requeue if it's in an intermediate state
 	******************************************************************/
+	if !dbv1alpha1.IsACDIntermediateState(acd.Status.LifecycleState) {
+		acd.Status.ObservedGeneration = acd.GetGeneration()
+	}
+
 	if err := r.KubeClient.Status().Update(context.TODO(), acd); err != nil {
 		return r.manageError(logger, acd, err)
 	}
@@ -239,6 +244,7 @@ func (r *AutonomousContainerDatabaseReconciler) setupOCIClients(logger logr.Logg
 		ConfigMapName: acd.Spec.OCIConfig.ConfigMapName,
 		SecretName:    acd.Spec.OCIConfig.SecretName,
 		Namespace:     acd.GetNamespace(),
+		AuthMode:      acd.Spec.OCIConfig.AuthMode,
 	}
 
 	provider, err := oci.GetOCIProvider(r.KubeClient, authData)
@@ -46,6 +46,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -54,6 +55,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -70,6 +72,7 @@ import (
 
 	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
 	"github.com/oracle/oracle-database-operator/commons/annotations"
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
 	"github.com/oracle/oracle-database-operator/commons/k8s"
 	"github.com/oracle/oracle-database-operator/commons/oci"
 )
@@ -77,6 +80,10 @@ import (
 var requeueResult ctrl.Result = ctrl.Result{Requeue: true, RequeueAfter: 15 * time.Second}
 var emptyResult ctrl.Result = ctrl.Result{}
 
+// scheduleRetryInterval is the time between two scheduled start or stop requests, while the ADB is not in the state of
+// its schedule
+var scheduleRetryInterval time.Duration = 5 * time.Minute
+
 // *AutonomousDatabaseReconciler reconciles a AutonomousDatabase object
 type AutonomousDatabaseReconciler struct {
 	KubeClient client.Client
@@ -85,12 +92,15 @@ type AutonomousDatabaseReconciler struct {
 	Recorder   record.EventRecorder
 
 	dbService oci.DatabaseService
+	// The OCI ADBs fetched by getADB, by OCID, read by validateTags later in the same reconcile
+	fetchedADBs sync.Map
 }
 
 // SetupWithManager function
 func (r *AutonomousDatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbv1alpha1.AutonomousDatabase{}).
+		WithEventFilter(fleetPredicate()).
 		Watches(
 			&source.Kind{Type: &dbv1alpha1.AutonomousDatabaseBackup{}},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueMapFn()),
@@ -205,6 +215,11 @@ func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return emptyResult, err
 	}
 
+	// Drop the OCI ADB left by a reconcile that exited before validateTags
+	if desiredADB.Spec.Details.AutonomousDatabaseOCID != nil {
+		r.fetchedADBs.Delete(*desiredADB.Spec.Details.AutonomousDatabaseOCID)
+	}
+
 	/******************************************************************
 	* Get OCI database client
 	******************************************************************/
@@ -244,6 +259,18 @@ func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return emptyResult, nil
 	}
 
+	/******************************************************************
+	* Start or stop the ADB on its schedule
+	******************************************************************/
+	exit, err = r.validateSchedule(logger, desiredADB)
+	if err != nil {
+		return r.manageError(logger.WithName("validateSchedule"), desiredADB, err)
+	}
+
+	if exit {
+		return emptyResult, nil
+	}
+
 	/******************************************************************
 	* Validate operations
 	******************************************************************/
@@ -270,6 +297,13 @@ func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return r.manageError(logger.WithName("validateWallet"), modifiedADB, err)
 	}
 
+	/*****************************************************
+	*	Propagate labels as OCI tags
+	*****************************************************/
+	if err := r.validateTags(logger, modifiedADB); err != nil {
+		return r.manageError(logger.WithName("validateTags"), modifiedADB, err)
+	}
+
 	/******************************************************************
 	*	Requeue if it's in an intermediate state. Update the status right before
 	* exiting the reconcile, otherwise the modifiedADB will be overwritten
@@ -277,6 +311,8 @@ func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.R
 	******************************************************************/
 	if dbv1alpha1.IsADBIntermediateState(modifiedADB.Status.LifecycleState) {
 		logger.WithName("IsADBIntermediateState").Info("LifecycleState is " + string(modifiedADB.Status.LifecycleState) + "; reconcile queued")
+		modifiedADB.SetHealthConditions()
+		modifiedADB.SetStoppedSince()
 
 		if err := r.KubeClient.Status().Update(context.TODO(), modifiedADB); err != nil {
 			return r.manageError(logger.WithName("IsADBIntermediateState"), modifiedADB, err)
@@ -307,6 +343,16 @@ func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return r.manageError(logger.WithName("patchLastSuccessfulSpec"), modifiedADB, err)
 	}
 
+	if !requeue {
+		modifiedADB.Status.ObservedGeneration = modifiedADB.GetGeneration()
+		if modifiedADB.Status.LifecycleState == database.AutonomousDatabaseLifecycleStateAvailable {
+			modifiedADB.SetNetworkAccessCondition("", "")
+			modifiedADB.SetWorkloadCondition("", "")
+		}
+	}
+	modifiedADB.SetHealthConditions()
+	modifiedADB.SetStoppedSince()
+
 	if err := r.KubeClient.Status().Update(context.TODO(), modifiedADB); err != nil {
 		return r.manageError(logger.WithName("Status().Update"), modifiedADB, err)
 	}
@@ -317,7 +363,7 @@ func (r *AutonomousDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	} else {
 		logger.Info("AutonomousDatabase reconciles successfully")
-		return emptyResult, nil
+		return scheduleResult(modifiedADB), nil
 	}
 }
 
@@ -328,6 +374,7 @@ func (r *AutonomousDatabaseReconciler) setupOCIClients(logger logr.Logger, adb *
 		ConfigMapName: adb.Spec.OCIConfig.ConfigMapName,
 		SecretName:    adb.Spec.OCIConfig.SecretName,
 		Namespace:     adb.GetNamespace(),
+		AuthMode:      adb.Spec.OCIConfig.AuthMode,
 	}
 
 	provider, err := oci.GetOCIProvider(r.KubeClient, authData)
@@ -401,7 +448,17 @@ func (r *AutonomousDatabaseReconciler) validateOperation(
 	if lastSpec == nil {
 		if adb.Spec.Details.AutonomousDatabaseOCID == nil {
 			l.Info("Create operation")
-			err := r.createADB(logger, adb)
+
+			// A database in an AutonomousContainerDatabase resource is created once the ACD is provisioned
+			available, err := r.isK8sACDAvailable(logger, adb)
+			if err != nil {
+				return false, emptyResult, err
+			}
+			if !available {
+				return true, requeueResult, nil
+			}
+
+			err = r.createADB(logger, adb)
 			if err != nil {
 				return false, emptyResult, err
 			}
@@ -441,7 +498,11 @@ func (r *AutonomousDatabaseReconciler) validateOperation(
 		return false, emptyResult, err
 	}
 
-	if lastDetailsChanged {
+	if lastDetailsChanged && adb.Spec.SyncPolicy == dbv1alpha1.SyncPolicyObserveOnly {
+		l.Info("The syncPolicy is ObserveOnly; discard the changes and sync the resource")
+	}
+
+	if lastDetailsChanged && adb.Spec.SyncPolicy != dbv1alpha1.SyncPolicyObserveOnly {
 		// Double check if the user input spec is actually different from the spec in OCI. If so, then update the resource.
 		// When the update completes and the status changes from UPDATING to AVAILABLE, the lastSucSpec is not updated yet,
 		// so we compare with the oci ADB again to make sure that the updates are completed.
@@ -458,6 +519,14 @@ func (r *AutonomousDatabaseReconciler) validateOperation(
 	} else {
 		l.Info("No operation specified; sync the resource")
 
+		if adb.Spec.SyncPolicy != dbv1alpha1.SyncPolicyObserveOnly &&
+			(adb.Spec.DriftPolicy == dbv1alpha1.DriftPolicyRevert || adb.Spec.DriftPolicy == dbv1alpha1.DriftPolicyFlag) {
+			exit, err := r.validateDrift(logger, adb)
+			return exit, emptyResult, err
+		}
+		dbv1alpha1.SetDriftedCondition(&adb.Status.Conditions, adb.GetGeneration(), nil)
+		adb.Status.DriftSummary = nil
+
 		testOldADB := adb.DeepCopy()
 
 		// The user doesn't change the spec and the controller should pull the spec from the OCI.
@@ -487,6 +556,138 @@ func (r *AutonomousDatabaseReconciler) validateOperation(
 	}
 }
 
+// validateDrift compares the spec with the OCI ADB when the driftPolicy is Revert or Flag.
+// Revert sends the update requests to apply the spec again, Flag reports the changes in the Drifted condition.
+func (r *AutonomousDatabaseReconciler) validateDrift(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (exit bool, err error) {
+	l := logger.WithName("validateDrift")
+
+	ociADB := adb.DeepCopy()
+	if _, err := r.getADB(logger, ociADB); err != nil {
+		return false, err
+	}
+	adb.Status = *ociADB.Status.DeepCopy()
+
+	// The tags propagated from the labels are not part of the spec
+	if adb.Spec.TagPropagation != nil {
+		ociADB.Spec.Details.FreeformTags = adb.Spec.Details.FreeformTags
+	}
+
+	driftSummary, err := adb.GetDriftSummary(ociADB.Spec)
+	if err != nil {
+		return false, err
+	}
+
+	if len(driftSummary) == 0 || adb.Spec.DriftPolicy == dbv1alpha1.DriftPolicyFlag {
+		if len(driftSummary) != 0 {
+			l.Info("The OCI ADB has been changed outside of the operator", "changes", driftSummary)
+		}
+		dbv1alpha1.SetDriftedCondition(&adb.Status.Conditions, adb.GetGeneration(), driftSummary)
+		adb.Status.DriftSummary = driftSummary
+		return false, nil
+	}
+
+	l.Info("The OCI ADB has been changed outside of the operator; revert the changes", "changes", driftSummary)
+	dbv1alpha1.SetDriftedCondition(&adb.Status.Conditions, adb.GetGeneration(), nil)
+	adb.Status.DriftSummary = nil
+
+	return r.updateADB(logger, adb)
+}
+
+// validateSchedule sets the lifecycleState to AVAILABLE or STOPPED at the transitions of the schedule, and sets it
+// again every scheduleRetryInterval until the OCI ADB reaches that state, e.g. after a failed start or stop request.
+// Between the transitions, the lifecycleState can still be changed by hand.
+func (r *AutonomousDatabaseReconciler) validateSchedule(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (exit bool, err error) {
+	if adb.Spec.Schedule == nil {
+		adb.Status.ScheduledState = ""
+		adb.Status.NextScheduledTransition = ""
+		adb.Status.LastScheduledRequest = ""
+		meta.RemoveStatusCondition(&adb.Status.Conditions, dbv1alpha1.ScheduleCondition)
+		return false, nil
+	}
+
+	// Nothing to start or stop before the ADB is provisioned, or during another operation
+	if adb.Spec.Details.AutonomousDatabaseOCID == nil || adb.Status.LifecycleState == "" ||
+		dbv1alpha1.IsADBIntermediateState(adb.Status.LifecycleState) {
+		return false, nil
+	}
+
+	l := logger.WithName("validateSchedule")
+
+	running, next, err := dbcommons.GetScheduledState(adb.Spec.Schedule.Start, adb.Spec.Schedule.Stop,
+		adb.Spec.Schedule.TimeZone, time.Now())
+	if err != nil {
+		return false, err
+	}
+	adb.Status.NextScheduledTransition = next.Format(time.RFC3339)
+
+	scheduledState := dbcommons.ScheduledStateRunning
+	lifecycleState := database.AutonomousDatabaseLifecycleStateAvailable
+	if !running {
+		scheduledState = dbcommons.ScheduledStateStopped
+		lifecycleState = database.AutonomousDatabaseLifecycleStateStopped
+	}
+
+	transition := adb.Status.ScheduledState != scheduledState
+	adb.Status.ScheduledState = scheduledState
+	if adb.Status.LifecycleState == lifecycleState {
+		adb.SetScheduleCondition("", "")
+		return false, nil
+	}
+
+	// The lifecycleState was changed by hand since the last transition
+	if !transition && !meta.IsStatusConditionFalse(adb.Status.Conditions, dbv1alpha1.ScheduleCondition) {
+		return false, nil
+	}
+
+	// The request is about to be sent, or failed and the spec was rolled back
+	if adb.Spec.Details.LifecycleState == lifecycleState {
+		return false, nil
+	}
+
+	reason := "Scheduled" + scheduledState
+	message := "Setting the lifecycleState to " + string(lifecycleState) + ", next transition at " +
+		adb.Status.NextScheduledTransition
+	if !transition {
+		if last, err := time.Parse(time.RFC3339, adb.Status.LastScheduledRequest); err == nil &&
+			time.Since(last) < scheduleRetryInterval {
+			return false, nil
+		}
+		reason = "Retrying"
+		message = "The ADB is " + string(adb.Status.LifecycleState) + " after the scheduled request; " + message
+		r.Recorder.Event(adb, corev1.EventTypeWarning, reason, message)
+	} else {
+		r.Recorder.Event(adb, corev1.EventTypeNormal, reason, message)
+	}
+	l.Info(message)
+
+	adb.Status.LastScheduledRequest = time.Now().UTC().Format(time.RFC3339)
+	adb.SetScheduleCondition(reason, message)
+	if err := r.KubeClient.Status().Update(context.TODO(), adb); err != nil {
+		return false, err
+	}
+
+	adb.Spec.Details.LifecycleState = lifecycleState
+	if err := r.KubeClient.Update(context.TODO(), adb); err != nil {
+		return false, err
+	}
+	// Exit the reconcile since we have updated the spec
+	return true, nil
+}
+
+// scheduleResult requeues the reconcile at the next transition of the schedule, or at the next retry of the
+// scheduled request
+func scheduleResult(adb *dbv1alpha1.AutonomousDatabase) ctrl.Result {
+	if adb.Spec.Schedule == nil || adb.Status.NextScheduledTransition == "" {
+		return emptyResult
+	}
+	result := requeueUntil(adb.Status.NextScheduledTransition)
+	if meta.IsStatusConditionFalse(adb.Status.Conditions, dbv1alpha1.ScheduleCondition) &&
+		result.RequeueAfter > scheduleRetryInterval {
+		result.RequeueAfter = scheduleRetryInterval
+	}
+	return result
+}
+
 func (r *AutonomousDatabaseReconciler) validateCleanup(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (exitReconcile bool, err error) {
 	l := logger.WithName("validateCleanup")
 
@@ -610,6 +811,27 @@ func (r *AutonomousDatabaseReconciler) patchLastSuccessfulSpec(adb *dbv1alpha1.A
 	return nil
 }
 
+// isK8sACDAvailable returns false if the ADB is created in an AutonomousContainerDatabase resource that is not AVAILABLE yet
+func (r *AutonomousDatabaseReconciler) isK8sACDAvailable(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) (bool, error) {
+	acdSpec := adb.Spec.Details.AutonomousContainerDatabase
+	if acdSpec.OCIACD.OCID != nil || acdSpec.K8sACD.Name == nil {
+		return true, nil
+	}
+
+	acd := &dbv1alpha1.AutonomousContainerDatabase{}
+	if err := k8s.FetchResource(r.KubeClient, adb.Namespace, *acdSpec.K8sACD.Name, acd); err != nil {
+		return false, err
+	}
+
+	if acd.Spec.AutonomousContainerDatabaseOCID == nil ||
+		acd.Status.LifecycleState != database.AutonomousContainerDatabaseLifecycleStateAvailable {
+		logger.WithName("isK8sACDAvailable").Info("AutonomousContainerDatabase " + *acdSpec.K8sACD.Name +
+			" is " + string(acd.Status.LifecycleState) + "; reconcile queued")
+		return false, nil
+	}
+	return true, nil
+}
+
 func (r *AutonomousDatabaseReconciler) createADB(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
 	logger.WithName("createADB").Info("Sending CreateAutonomousDatabase request to OCI")
 	resp, err := r.dbService.CreateAutonomousDatabase(adb)
@@ -641,6 +863,7 @@ func (r *AutonomousDatabaseReconciler) getADB(logger logr.Logger, adb *dbv1alpha
 	}
 
 	specChanged := adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	r.fetchedADBs.Store(*adb.Spec.Details.AutonomousDatabaseOCID, resp.AutonomousDatabase)
 
 	return specChanged, nil
 }
@@ -795,7 +1018,14 @@ func (r *AutonomousDatabaseReconciler) validateDbWorkload(
 		return false, nil
 	}
 
+	// The webhook can be disabled, check the change against the workload type in OCI
+	if !dbv1alpha1.ValidADBWorkloadTransition(ociADB.Spec.Details.DbWorkload, difADB.Spec.Details.DbWorkload) {
+		return false, fmt.Errorf("the workload type cannot be changed from %s to %s",
+			ociADB.Spec.Details.DbWorkload, difADB.Spec.Details.DbWorkload)
+	}
+
 	if ociADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		adb.SetWorkloadCondition("WaitingForAvailable", "The workload type is changed once the ADB is AVAILABLE")
 		return false, nil
 	}
 
@@ -808,6 +1038,8 @@ func (r *AutonomousDatabaseReconciler) validateDbWorkload(
 	}
 
 	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.SetWorkloadCondition("UpdatingWorkload", "Changing the workload type from "+
+		string(ociADB.Spec.Details.DbWorkload)+" to "+string(difADB.Spec.Details.DbWorkload))
 
 	return true, nil
 }
@@ -966,6 +1198,7 @@ func (r *AutonomousDatabaseReconciler) validateGeneralNetworkAccess(
 	}
 
 	if ociADB.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		adb.SetNetworkAccessCondition("WaitingForAvailable", "The network access is configured once the ADB is AVAILABLE")
 		return false, nil
 	}
 
@@ -1090,6 +1323,7 @@ func (r *AutonomousDatabaseReconciler) setMTLSRequired(logger logr.Logger, adb *
 	}
 
 	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.SetNetworkAccessCondition("EnablingMTLS", "Requiring mTLS connections before changing the network access type")
 
 	return nil
 }
@@ -1114,6 +1348,7 @@ func (r *AutonomousDatabaseReconciler) validateMTLS(
 	}
 
 	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.SetNetworkAccessCondition("UpdatingMTLS", "Configuring whether mTLS connections are required")
 
 	return true, nil
 }
@@ -1135,6 +1370,7 @@ func (r *AutonomousDatabaseReconciler) setNetworkAccessPublic(logger logr.Logger
 	}
 
 	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
+	adb.SetNetworkAccessCondition("SwitchingToPublic", "Changing the network access type from "+string(lastAcessType)+" to PUBLIC")
 
 	return nil
 }
@@ -1163,6 +1399,14 @@ func (r *AutonomousDatabaseReconciler) validateNetworkAccess(
 		return false, err
 	}
 
+	// Converting a public ADB to a private endpoint takes the longest, so it is reported on its own
+	lastAccessType := ociADB.Spec.Details.NetworkAccess.AccessType
+	if difADB.Spec.Details.NetworkAccess.AccessType == dbv1alpha1.NetworkAccessTypePrivate && lastAccessType != dbv1alpha1.NetworkAccessTypePrivate {
+		adb.SetNetworkAccessCondition("ConvertingToPrivateEndpoint", "Changing the network access type from "+string(lastAccessType)+" to PRIVATE")
+	} else {
+		adb.SetNetworkAccessCondition("UpdatingNetworkAccess", "Configuring the access control list and the private endpoint")
+	}
+
 	adb.UpdateFromOCIADB(resp.AutonomousDatabase)
 
 	return true, nil
@@ -1224,6 +1468,46 @@ func (r *AutonomousDatabaseReconciler) validateWallet(logger logr.Logger, adb *d
 	return nil
 }
 
+// validateTags sets the tags mapped from the labels by spec.tagPropagation on the OCI ADB
+func (r *AutonomousDatabaseReconciler) validateTags(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
+	if adb.Spec.TagPropagation == nil || adb.Spec.Details.AutonomousDatabaseOCID == nil ||
+		adb.Spec.SyncPolicy == dbv1alpha1.SyncPolicyObserveOnly ||
+		adb.Status.LifecycleState != database.AutonomousDatabaseLifecycleStateAvailable {
+		return nil
+	}
+
+	l := logger.WithName("validateTags")
+
+	propFreeform, propDefined := oci.GetPropagatedTags(adb.GetLabels(), adb.Spec.TagPropagation)
+
+	// Reuse the OCI ADB fetched by getADB in this reconcile
+	var ociADB database.AutonomousDatabase
+	if fetched, ok := r.fetchedADBs.LoadAndDelete(*adb.Spec.Details.AutonomousDatabaseOCID); ok {
+		ociADB = fetched.(database.AutonomousDatabase)
+	} else {
+		resp, err := r.dbService.GetAutonomousDatabase(*adb.Spec.Details.AutonomousDatabaseOCID)
+		if err != nil {
+			return err
+		}
+		ociADB = resp.AutonomousDatabase
+	}
+
+	freeform, defined, changed := oci.MergeTags(ociADB.FreeformTags, ociADB.DefinedTags, propFreeform, propDefined)
+	if !changed {
+		return nil
+	}
+
+	l.Info("Sending UpdateAutonomousDatabase request to OCI to propagate the labels as tags")
+	updateResp, err := r.dbService.UpdateAutonomousDatabaseTags(*adb.Spec.Details.AutonomousDatabaseOCID, freeform, defined)
+	if err != nil {
+		return err
+	}
+
+	adb.UpdateFromOCIADB(updateResp.AutonomousDatabase)
+
+	return nil
+}
+
 // updateBackupResources get the list of AutonomousDatabasBackups and
 // create a backup object if it's not found in the same namespace
 func (r *AutonomousDatabaseReconciler) syncBackupResources(logger logr.Logger, adb *dbv1alpha1.AutonomousDatabase) error {
@@ -1262,7 +1546,12 @@ func (r *AutonomousDatabaseReconciler) syncBackupResources(logger logr.Logger, a
 			}
 
 			if err := k8s.CreateAutonomousBackup(r.KubeClient, validBackupName, backupSummary, adb); err != nil {
-				return err
+				if !apiErrors.IsAlreadyExists(err) {
+					return err
+				}
+				// A backup created without the labels of its database is not selected by the watch selector of the operator
+				l.Info("AutonomousDatabaseBackup " + validBackupName + " already exists outside of the watch selector, skipped")
+				continue
 			}
 
 			// Add the used name and ocid
@@ -0,0 +1,115 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/database"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dbv1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	"github.com/oracle/oracle-database-operator/commons/oci"
+)
+
+// fakeDatabaseService serves the OCI ADB from memory and counts the requests. The other methods of the
+// DatabaseService are not implemented
+type fakeDatabaseService struct {
+	oci.DatabaseService
+	adb     database.AutonomousDatabase
+	gets    int
+	updates int
+}
+
+func (f *fakeDatabaseService) GetAutonomousDatabase(adbOCID string) (database.GetAutonomousDatabaseResponse, error) {
+	f.gets++
+	return database.GetAutonomousDatabaseResponse{AutonomousDatabase: f.adb}, nil
+}
+
+func (f *fakeDatabaseService) UpdateAutonomousDatabaseTags(adbOCID string, freeformTags map[string]string,
+	definedTags map[string]map[string]interface{}) (database.UpdateAutonomousDatabaseResponse, error) {
+	f.updates++
+	f.adb.FreeformTags = freeformTags
+	f.adb.DefinedTags = definedTags
+	return database.UpdateAutonomousDatabaseResponse{AutonomousDatabase: f.adb}, nil
+}
+
+var _ = Describe("AutonomousDatabase tag propagation", func() {
+	const adbOCID = "ocid1.autonomousdatabase.oc1..tags"
+
+	var dbService *fakeDatabaseService
+	var reconciler *AutonomousDatabaseReconciler
+	var adb *dbv1alpha1.AutonomousDatabase
+
+	BeforeEach(func() {
+		dbService = &fakeDatabaseService{adb: database.AutonomousDatabase{
+			Id:                common.String(adbOCID),
+			IsDedicated:       common.Bool(false),
+			ConnectionStrings: &database.AutonomousDatabaseConnectionStrings{},
+			LifecycleState:    database.AutonomousDatabaseLifecycleStateAvailable,
+			FreeformTags:      map[string]string{"owner": "dba"},
+		}}
+		reconciler = &AutonomousDatabaseReconciler{Log: logr.Discard(), dbService: dbService}
+		adb = &dbv1alpha1.AutonomousDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: "adb-tags", Labels: map[string]string{"team": "payments"}},
+			Spec: dbv1alpha1.AutonomousDatabaseSpec{
+				Details:        dbv1alpha1.AutonomousDatabaseDetails{AutonomousDatabaseOCID: common.String(adbOCID)},
+				TagPropagation: &dbv1alpha1.TagPropagationSpec{FreeformTags: map[string]string{"team": ""}},
+			},
+		}
+		adb.Status.LifecycleState = database.AutonomousDatabaseLifecycleStateAvailable
+	})
+
+	It("Should reuse the OCI ADB fetched earlier in the reconcile", func() {
+		_, err := reconciler.getADB(logr.Discard(), adb)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reconciler.validateTags(logr.Discard(), adb)).To(Succeed())
+		Expect(dbService.gets).To(Equal(1))
+		Expect(dbService.updates).To(Equal(1))
+		Expect(dbService.adb.FreeformTags).To(Equal(map[string]string{"owner": "dba", "team": "payments"}))
+	})
+
+	It("Should fetch the OCI ADB when no ADB was fetched in the reconcile", func() {
+		dbService.adb.FreeformTags = map[string]string{"team": "payments"}
+		Expect(reconciler.validateTags(logr.Discard(), adb)).To(Succeed())
+		Expect(dbService.gets).To(Equal(1))
+		Expect(dbService.updates).To(Equal(0))
+	})
+})
@@ -73,6 +73,7 @@ type AutonomousDatabaseBackupReconciler struct {
 func (r *AutonomousDatabaseBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbv1alpha1.AutonomousDatabaseBackup{}).
+		WithEventFilter(fleetPredicate()).
 		WithEventFilter(predicate.GenerationChangedPredicate{}).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 100}). // ReconcileHandler is never invoked concurrently with the same object.
 		Complete(r)
@@ -183,6 +184,10 @@ func (r *AutonomousDatabaseBackupReconciler) Reconcile(ctx context.Context, req
 	/******************************************************************
 	*	Update the status and requeue if it's in an intermediate state
 	******************************************************************/
+	if !dbv1alpha1.IsBackupIntermediateState(backup.Status.LifecycleState) {
+		backup.Status.ObservedGeneration = backup.GetGeneration()
+	}
+
 	if err := r.KubeClient.Status().Update(context.TODO(), backup); err != nil {
 		return r.manageError(backup, err)
 	}
@@ -244,6 +249,7 @@ func (r *AutonomousDatabaseBackupReconciler) setupOCIClients(backup *dbv1alpha1.
 		ConfigMapName: backup.Spec.OCIConfig.ConfigMapName,
 		SecretName:    backup.Spec.OCIConfig.SecretName,
 		Namespace:     backup.GetNamespace(),
+		AuthMode:      backup.Spec.OCIConfig.AuthMode,
 	}
 
 	provider, err := oci.GetOCIProvider(r.KubeClient, authData)
@@ -75,6 +75,7 @@ type AutonomousDatabaseRestoreReconciler struct {
 func (r *AutonomousDatabaseRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbv1alpha1.AutonomousDatabaseRestore{}).
+		WithEventFilter(fleetPredicate()).
 		WithEventFilter(predicate.GenerationChangedPredicate{}).
 		Complete(r)
 }
@@ -148,6 +149,9 @@ func (r *AutonomousDatabaseRestoreReconciler) Reconcile(ctx context.Context, req
 		}
 
 		restore.UpdateStatus(adbResp.AutonomousDatabase, workResp)
+		if !dbv1alpha1.IsRestoreIntermediateState(restore.Status.Status) {
+			restore.Status.ObservedGeneration = restore.GetGeneration()
+		}
 		if err := r.KubeClient.Status().Update(context.TODO(), restore); err != nil {
 			return r.manageError(restore, err)
 		}
@@ -166,6 +170,9 @@ func (r *AutonomousDatabaseRestoreReconciler) Reconcile(ctx context.Context, req
 		}
 
 		restore.UpdateStatus(adbResp.AutonomousDatabase, workResp)
+		if !dbv1alpha1.IsRestoreIntermediateState(restore.Status.Status) {
+			restore.Status.ObservedGeneration = restore.GetGeneration()
+		}
 		if err := r.KubeClient.Status().Update(context.TODO(), restore); err != nil {
 			return r.manageError(restore, err)
 		}
@@ -253,6 +260,7 @@ func (r *AutonomousDatabaseRestoreReconciler) setupOCIClients(restore *dbv1alpha
 		ConfigMapName: restore.Spec.OCIConfig.ConfigMapName,
 		SecretName:    restore.Spec.OCIConfig.SecretName,
 		Namespace:     restore.GetNamespace(),
+		AuthMode:      restore.Spec.OCIConfig.AuthMode,
 	}
 
 	provider, err := oci.GetOCIProvider(r.KubeClient, authData)
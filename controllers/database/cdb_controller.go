@@ -93,7 +93,7 @@ const CDBFinalizer = "database.oracle.com/CDBfinalizer"
 //+kubebuilder:rbac:groups=database.oracle.com,resources=cdbs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=database.oracle.com,resources=cdbs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=database.oracle.com,resources=cdbs/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=pods;pods/log;pods/exec;services;configmaps;events;replicasets,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups="",resources=pods;pods/log;services;configmaps;events;replicasets,verbs=create;delete;get;list;patch;update;watch
 //+kubebuilder:rbac:groups=core,resources=pods;secrets;services;configmaps;namespaces,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch;create;update;patch;delete
 
@@ -203,6 +203,7 @@ func (r *CDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 			cdb.Status.Msg = "Success"
 		case cdbPhaseReady:
 			cdb.Status.Status = true
+			cdb.Status.ObservedGeneration = cdb.GetGeneration()
 			r.Status().Update(ctx, cdb)
 			return requeueN, nil
 		default:
@@ -941,6 +942,7 @@ func (r *CDBReconciler) deleteSecrets(ctx context.Context, req ctrl.Request, cdb
 func (r *CDBReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbapi.CDB{}).
+		WithEventFilter(fleetPredicate()).
 		Owns(&appsv1.ReplicaSet{}). //Watch for deleted RS owned by this controller
 		WithEventFilter(predicate.Funcs{
 			UpdateFunc: func(e event.UpdateEvent) bool {
@@ -60,6 +60,7 @@ import (
 
 	dbapi "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
 	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+	"github.com/oracle/oracle-database-operator/commons/k8s"
 )
 
 // DataguardBrokerReconciler reconciles a DataguardBroker object
@@ -73,10 +74,16 @@ type DataguardBrokerReconciler struct {
 
 const dataguardBrokerFinalizer = "database.oracle.com/dataguardbrokerfinalizer"
 
+// Records the primary SID the observer pod was started against
+const observerPrimaryAnnotation = "database.oracle.com/observer-primary"
+
+// Interval at which the observer health is refreshed in the status
+var observerHealthCheckInterval = ctrl.Result{Requeue: true, RequeueAfter: 60 * time.Second}
+
 //+kubebuilder:rbac:groups=database.oracle.com,resources=dataguardbrokers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=database.oracle.com,resources=dataguardbrokers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=database.oracle.com,resources=dataguardbrokers/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=pods;pods/log;pods/exec;persistentvolumeclaims;services;nodes;events,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups="",resources=pods;pods/log;persistentvolumeclaims;services;nodes;events,verbs=create;delete;get;list;patch;update;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -88,6 +95,7 @@ const dataguardBrokerFinalizer = "database.oracle.com/dataguardbrokerfinalizer"
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
 func (r *DataguardBrokerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = k8s.WithStatusTracking(ctx)
 
 	r.Log.Info("Reconcile requested")
 
@@ -100,6 +108,9 @@ func (r *DataguardBrokerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		return requeueN, err
 	}
+	k8s.TrackStatus(ctx, dataguardBroker)
+	// Log the commands of a resource annotated with database.oracle.com/log-level: debug
+	ctx = dbcommons.WithLogLevel(ctx, dataguardBroker)
 
 	// Manage DataguardBroker Deletion
 	result, err := r.manageDataguardBrokerDeletion(req, ctx, dataguardBroker)
@@ -122,17 +133,22 @@ func (r *DataguardBrokerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		return requeueN, err
 	}
+	k8s.TrackStatus(ctx, singleInstanceDatabase)
 
 	/* Initialize Status */
 	if dataguardBroker.Status.Status == "" {
 		dataguardBroker.Status.Status = dbcommons.StatusCreating
 		dataguardBroker.Status.ExternalConnectString = dbcommons.ValueUnavailable
 		dataguardBroker.Status.ClusterConnectString = dbcommons.ValueUnavailable
-		r.Status().Update(ctx, dataguardBroker)
+		k8s.PatchStatus(ctx, r.Client, dataguardBroker)
 	}
 
 	// Always refresh status before a reconcile
-	defer r.Status().Update(ctx, dataguardBroker)
+	defer func() {
+		dbcommons.SetHealthConditions(&dataguardBroker.Status.Conditions, dataguardBroker.GetGeneration(),
+			dataguardBroker.Status.ObservedGeneration, dataguardBroker.Status.Status)
+		k8s.PatchStatus(ctx, r.Client, dataguardBroker)
+	}()
 
 	// Create Service to point to primary database always
 	result = r.createSVC(ctx, req, dataguardBroker)
@@ -163,14 +179,27 @@ func (r *DataguardBrokerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return result, nil
 	}
 
+	// Configure Fast-Start Failover and its observer
+	result = r.manageFastStartFailover(dataguardBroker, singleInstanceDatabase, sidbReadyPod, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
 	// If LoadBalancer = true , ensure Connect String is updated
 	if dataguardBroker.Status.ExternalConnectString == dbcommons.ValueUnavailable {
 		return requeueY, nil
 	}
 
 	dataguardBroker.Status.Status = dbcommons.StatusReady
+	dataguardBroker.Status.ObservedGeneration = dataguardBroker.GetGeneration()
 
 	r.Log.Info("Reconcile completed")
+
+	// Keep the observer health current
+	if dataguardBroker.Spec.FastStartFailOver.Enable && dataguardBroker.Spec.FastStartFailOver.Observer.Enable {
+		return observerHealthCheckInterval, nil
+	}
 	return ctrl.Result{}, nil
 
 }
@@ -219,7 +248,7 @@ func (r *DataguardBrokerReconciler) validateSidbReadiness(m *dbapi.DataguardBrok
 	adminPassword = string(adminPasswordSecret.Data[n.Spec.AdminPassword.SecretKey])
 
 	out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
-		fmt.Sprintf("echo -e  \"%s\"  | %s", fmt.Sprintf(dbcommons.ValidateAdminPassword, adminPassword), dbcommons.GetSqlClient(n.Spec.Edition)))
+		dbcommons.ValidateAdminPasswordCommand(adminPassword, dbcommons.GetSqlClient(n.Spec.Edition), IsPasswordStoreCurrent(n, adminPasswordSecret)))
 	if err != nil {
 		log.Error(err, err.Error())
 		return requeueY, sidbReadyPod, adminPassword
@@ -328,7 +357,7 @@ func (r *DataguardBrokerReconciler) createSVC(ctx context.Context, req ctrl.Requ
 			}
 			log.Info("Succesfully Created New Service ", "Service.Name : ", svc.Name)
 		}
-		time.Sleep(10 * time.Second)
+		time.Sleep(dbcommons.RetryInterval(10 * time.Second))
 
 	} else if err != nil {
 		log.Error(err, "Failed to get Service")
@@ -354,7 +383,7 @@ func (r *DataguardBrokerReconciler) createSVC(ctx context.Context, req ctrl.Requ
 			m.Status.ExternalConnectString = nodeip + ":" + fmt.Sprint(svc.Spec.Ports[0].NodePort) + "/DATAGUARD"
 		}
 	}
-	r.Status().Update(ctx, m)
+	k8s.PatchStatus(ctx, r.Client, m)
 
 	return requeueN
 }
@@ -397,6 +426,8 @@ func (r *DataguardBrokerReconciler) setupDataguardBrokerConfiguration(m *dbapi.D
 			log.Error(err, err.Error())
 			return requeueY
 		}
+		k8s.TrackStatus(ctx, standbyDatabase)
+
 		_, ok := dbSet[standbyDatabase.Status.Sid]
 		if ok {
 			log.Info("A database with the same SID is already configured in the DG")
@@ -411,7 +442,7 @@ func (r *DataguardBrokerReconciler) setupDataguardBrokerConfiguration(m *dbapi.D
 		}
 
 		m.Status.Status = dbcommons.StatusCreating
-		r.Status().Update(ctx, m)
+		k8s.PatchStatus(ctx, r.Client, m)
 
 		// ## FETCH THE STANDBY REPLICAS .
 		standbyDatabaseReadyPod, _, _, _, err := dbcommons.FindPods(r, n.Spec.Image.Version,
@@ -603,8 +634,8 @@ func (r *DataguardBrokerReconciler) setupDataguardBrokerConfigurationForGivenDB(
 		// Set DG Configured status to true for this standbyDatabase and primary Database. so that in next reconcilation, we dont configure this again
 		n.Status.DgBrokerConfigured = true
 		standbyDatabase.Status.DgBrokerConfigured = true
-		r.Status().Update(ctx, standbyDatabase)
-		r.Status().Update(ctx, n)
+		k8s.PatchStatus(ctx, r.Client, standbyDatabase)
+		k8s.PatchStatus(ctx, r.Client, n)
 		// Remove admin pwd file
 		_, err = dbcommons.ExecCommand(r, r.Config, standbyDatabaseReadyPod.Name, standbyDatabaseReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
 			dbcommons.RemoveAdminPasswordFile)
@@ -724,7 +755,7 @@ func (r *DataguardBrokerReconciler) setupDataguardBrokerConfigurationForGivenDB(
 
 	// Set DG Configured status to true for this standbyDatabase. so that in next reconcilation, we dont configure this again
 	standbyDatabase.Status.DgBrokerConfigured = true
-	r.Status().Update(ctx, standbyDatabase)
+	k8s.PatchStatus(ctx, r.Client, standbyDatabase)
 
 	return requeueN
 }
@@ -779,11 +810,253 @@ func (r *DataguardBrokerReconciler) removeDatabaseFromDGConfig(m *dbapi.Dataguar
 	log.Info(out)
 	// Set DG Configured status to false for this standbyDatabase. so that in next reconcilation, we dont configure this again
 	standbyDatabase.Status.DgBrokerConfigured = false
-	r.Status().Update(ctx, standbyDatabase)
+	k8s.PatchStatus(ctx, r.Client, standbyDatabase)
 
 	return requeueN
 }
 
+// #############################################################################
+//
+//	Instantiate Observer POD spec from DataguardBroker spec
+//	n = primaryDatabaseRef, whose image and admin password the observer uses
+//
+// #############################################################################
+func (r *DataguardBrokerReconciler) instantiateObserverPodSpec(m *dbapi.DataguardBroker, n *dbapi.SingleInstanceDatabase,
+	primarySid string, primaryConnectString string) *corev1.Pod {
+
+	observerName := m.Name + "-observer"
+
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      observerName,
+			Namespace: m.Namespace,
+			Labels: map[string]string{
+				"app": observerName,
+			},
+			Annotations: map[string]string{
+				observerPrimaryAnnotation: strings.ToUpper(primarySid),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name:         "observer-vol",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}, {
+				Name: "oracle-pwd-vol",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: n.Spec.AdminPassword.SecretName,
+						Items: []corev1.KeyToPath{{
+							Key:  n.Spec.AdminPassword.SecretKey,
+							Path: "oracle_pwd",
+						}},
+					},
+				},
+			}},
+			Containers: []corev1.Container{{
+				Name:       observerName,
+				Image:      n.Spec.Image.PullFrom,
+				Command:    []string{"/bin/bash", "-c", fmt.Sprintf(dbcommons.StartObserverCMD, primaryConnectString, observerName)},
+				WorkingDir: "/opt/oracle/observer",
+				VolumeMounts: []corev1.VolumeMount{{
+					MountPath: "/opt/oracle/observer",
+					Name:      "observer-vol",
+				}, {
+					MountPath: "/run/secrets/oracle_pwd",
+					ReadOnly:  true,
+					Name:      "oracle-pwd-vol",
+					SubPath:   "oracle_pwd",
+				}},
+			}},
+
+			TerminationGracePeriodSeconds: func() *int64 { i := int64(30); return &i }(),
+
+			NodeSelector: func() map[string]string {
+				ns := make(map[string]string)
+				for key, value := range m.Spec.FastStartFailOver.Observer.NodeSelector {
+					ns[key] = value
+				}
+				return ns
+			}(),
+
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser: func() *int64 {
+					i := int64(dbcommons.ORACLE_UID)
+					return &i
+				}(),
+				RunAsGroup: func() *int64 {
+					i := int64(dbcommons.ORACLE_GUID)
+					return &i
+				}(),
+				FSGroup: func() *int64 {
+					i := int64(dbcommons.ORACLE_GUID)
+					return &i
+				}(),
+			},
+			ImagePullSecrets: []corev1.LocalObjectReference{
+				{
+					Name: n.Spec.Image.PullSecrets,
+				},
+			},
+		},
+	}
+
+	// Set DataguardBroker instance as the owner and controller
+	ctrl.SetControllerReference(m, pod, r.Scheme)
+	return pod
+}
+
+// #############################################################################
+//
+//	Configure Fast-Start Failover and manage its Observer
+//
+// #############################################################################
+func (r *DataguardBrokerReconciler) manageFastStartFailover(m *dbapi.DataguardBroker, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) ctrl.Result {
+
+	log := r.Log.WithValues("manageFastStartFailover", req.NamespacedName)
+
+	if sidbReadyPod.Name == "" {
+		return requeueY
+	}
+
+	out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		fmt.Sprintf("echo -e  \"%s\"  | dgmgrl / as sysdba ", dbcommons.ShowFSFOCMD))
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	log.Info("ShowFastStartFailover Output")
+	log.Info(out)
+	enabled, threshold, lagLimit, observer := dbcommons.ParseFSFOStatus(out)
+
+	// Build the dgmgrl commands needed to reach the desired FSFO configuration
+	fsfoCmds := []string{}
+	fsfo := m.Spec.FastStartFailOver
+	if fsfo.Enable {
+		if fsfo.Threshold != 0 && fsfo.Threshold != threshold {
+			fsfoCmds = append(fsfoCmds, fmt.Sprintf(dbcommons.SetFSFOThresholdCMD, fsfo.Threshold))
+		}
+		if fsfo.LagLimit != 0 && fsfo.LagLimit != lagLimit {
+			fsfoCmds = append(fsfoCmds, fmt.Sprintf(dbcommons.SetFSFOLagLimitCMD, fsfo.LagLimit))
+		}
+		if !enabled {
+			fsfoCmds = append(fsfoCmds, dbcommons.EnableFSFOCMD)
+		}
+	} else if enabled {
+		fsfoCmds = append(fsfoCmds, dbcommons.DisableFSFOCMD)
+	}
+
+	if len(fsfoCmds) > 0 {
+		out, err = dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, false, "bash", "-c",
+			fmt.Sprintf("echo -e  \"%s\"  | dgmgrl / as sysdba ", strings.Join(fsfoCmds, "\n")))
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+		log.Info("ConfigureFastStartFailover Output")
+		log.Info(out)
+		// ORA-16819 (observer not started) is expected until the observer registers
+		if strings.Contains(strings.ReplaceAll(out, "ORA-16819", ""), "ORA-") {
+			eventReason := "FSFO Configuration Failed"
+			eventMsg := "failed to configure fast-start failover, check the operator logs"
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			return requeueY
+		}
+		enabled = fsfo.Enable
+		eventReason := "FSFO Configured"
+		eventMsg := "fast-start failover disabled"
+		if enabled {
+			eventMsg = "fast-start failover enabled"
+		}
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		r.Log.Info(eventMsg)
+	}
+	m.Status.FastStartFailOver = "Disabled"
+	if enabled {
+		m.Status.FastStartFailOver = "Enabled"
+	}
+
+	// Observer pod
+	observerName := m.Name + "-observer"
+	observerPod := &corev1.Pod{}
+	err = r.Get(ctx, types.NamespacedName{Name: observerName, Namespace: m.Namespace}, observerPod)
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	observerFound := err == nil
+
+	if !fsfo.Enable || !fsfo.Observer.Enable {
+		if observerFound {
+			log.Info("Deleting observer POD", "POD.Name", observerName)
+			if err := r.Delete(ctx, observerPod); err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, err.Error())
+				return requeueY
+			}
+		}
+		m.Status.Observer = ""
+		m.Status.ObserverStatus = ""
+		return requeueN
+	}
+
+	// The observer connects to the current primary, which changes after a failover or switchover
+	databases, _, err := dbcommons.GetDatabasesInDgConfig(sidbReadyPod, r, r.Config, ctx, req)
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	primarySid := strings.ToUpper(dbcommons.GetPrimaryDatabase(databases))
+	if primarySid == "" {
+		log.Info("Primary database not found in DG config")
+		return requeueY
+	}
+	primaryConnectString := n.Name + ":1521/" + primarySid
+	if !strings.EqualFold(primarySid, n.Spec.Sid) {
+		primaryConnectString = n.Status.StandbyDatabases[primarySid] + ":1521/" + primarySid
+	}
+
+	if observerFound && observerPod.DeletionTimestamp == nil && observerPod.Annotations[observerPrimaryAnnotation] != primarySid {
+		// Re-register the observer against the new primary
+		eventReason := "Observer Re-registering"
+		eventMsg := "primary changed to " + primarySid + ", restarting observer"
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		r.Log.Info(eventMsg)
+		if err := r.Delete(ctx, observerPod); err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, err.Error())
+		}
+		m.Status.ObserverStatus = dbcommons.StatusPending
+		return requeueY
+	}
+	if observerFound && observerPod.DeletionTimestamp != nil {
+		log.Info("Waiting for old observer POD to terminate", "POD.Name", observerName)
+		return requeueY
+	}
+
+	if !observerFound {
+		pod := r.instantiateObserverPodSpec(m, n, primarySid, primaryConnectString)
+		log.Info("Creating observer POD", "POD.Namespace", pod.Namespace, "POD.Name", pod.Name)
+		if err := r.Create(ctx, pod); err != nil {
+			log.Error(err, "Failed to create observer POD", "POD.Namespace", pod.Namespace, "POD.Name", pod.Name)
+			return requeueY
+		}
+		m.Status.Observer = observerName
+		m.Status.ObserverStatus = dbcommons.StatusPending
+		return requeueY
+	}
+
+	m.Status.Observer = observerName
+	if observerPod.Status.Phase == corev1.PodRunning && observer != "" {
+		m.Status.ObserverStatus = dbcommons.StatusReady
+	} else {
+		m.Status.ObserverStatus = dbcommons.StatusNotReady
+	}
+	return requeueN
+}
+
 // #############################################################################
 //
 //	Return FSFO targets of each StandbyDatabase
@@ -842,7 +1115,7 @@ func (r *DataguardBrokerReconciler) SetAsPrimaryDatabase(sidbSid string, targetS
 	}
 
 	m.Status.Status = dbcommons.StatusUpdating
-	r.Status().Update(ctx, m)
+	k8s.PatchStatus(ctx, r.Client, m)
 
 	found, _ := dbcommons.IsDatabaseFound(targetSid, databases, "")
 	if !found {
@@ -961,11 +1234,13 @@ func (r *DataguardBrokerReconciler) SetAsPrimaryDatabase(sidbSid string, targetS
 		if err != nil {
 			return requeueN
 		}
+		k8s.TrackStatus(ctx, standbyDatabase)
+
 		out, err := dbcommons.GetDatabaseRole(primaryReadyPod, r, r.Config, ctx, primaryReq, n.Spec.Edition)
 		if err == nil {
 			standbyDatabase.Status.Role = strings.ToUpper(out)
 		}
-		r.Status().Update(ctx, standbyDatabase)
+		k8s.PatchStatus(ctx, r.Client, standbyDatabase)
 
 	} else {
 		sidbReq := ctrl.Request{
@@ -978,7 +1253,7 @@ func (r *DataguardBrokerReconciler) SetAsPrimaryDatabase(sidbSid string, targetS
 		if err == nil {
 			n.Status.Role = strings.ToUpper(out)
 		}
-		r.Status().Update(ctx, n)
+		k8s.PatchStatus(ctx, r.Client, n)
 	}
 
 	// Update status of Primary true/false on 'sid' db (To which switchover initiated)
@@ -989,11 +1264,13 @@ func (r *DataguardBrokerReconciler) SetAsPrimaryDatabase(sidbSid string, targetS
 		if err != nil {
 			return requeueN
 		}
+		k8s.TrackStatus(ctx, standbyDatabase)
+
 		out, err := dbcommons.GetDatabaseRole(targetReadyPod, r, r.Config, ctx, targetReq, n.Spec.Edition)
 		if err == nil {
 			standbyDatabase.Status.Role = strings.ToUpper(out)
 		}
-		r.Status().Update(ctx, standbyDatabase)
+		k8s.PatchStatus(ctx, r.Client, standbyDatabase)
 
 	} else {
 		sidbReq := ctrl.Request{
@@ -1006,7 +1283,7 @@ func (r *DataguardBrokerReconciler) SetAsPrimaryDatabase(sidbSid string, targetS
 		if err == nil {
 			n.Status.Role = strings.ToUpper(out)
 		}
-		r.Status().Update(ctx, n)
+		k8s.PatchStatus(ctx, r.Client, n)
 	}
 
 	// Patch DataguardBroker Service to point selector to Current Primary Name and updates client db connection strings on dataguardBroker
@@ -1037,7 +1314,8 @@ func (r *DataguardBrokerReconciler) updateReconcileStatus(m *dbapi.DataguardBrok
 			if strings.ToUpper(splitstr[1]) == "PRIMARY" {
 				primaryDatabase = strings.ToUpper(splitstr[0])
 			}
-			if strings.ToUpper(splitstr[1]) == "PHYSICAL_STANDBY" {
+			// Snapshot standbys remain standbys of the configuration until converted back
+			if strings.ToUpper(splitstr[1]) == "PHYSICAL_STANDBY" || strings.ToUpper(splitstr[1]) == "SNAPSHOT_STANDBY" {
 				if standbyDatabases != "" {
 					standbyDatabases += "," + strings.ToUpper(splitstr[0])
 				} else {
@@ -1117,6 +1395,16 @@ func (r *DataguardBrokerReconciler) cleanupDataguardBroker(req ctrl.Request, ctx
 		}
 		return requeueY, err
 	}
+	k8s.TrackStatus(ctx, singleInstanceDatabase)
+
+	// The primary is lost, so its Data Guard configuration is not removed
+	if m.Spec.DeletionPolicy == dbcommons.DeletionPolicyAbandon {
+		eventReason := "Deletion"
+		eventMsg := "skipping the removal of the Data Guard configuration from " + singleInstanceDatabase.Name + " as the deletion policy is Abandon"
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		log.Info(eventMsg)
+		return r.clearDgBrokerConfigured(m, singleInstanceDatabase, ctx, req)
+	}
 
 	// Validate if Primary Database Reference is ready
 	result, sidbReadyPod, _ := r.validateSidbReadiness(m, singleInstanceDatabase, ctx, req)
@@ -1157,6 +1445,18 @@ func (r *DataguardBrokerReconciler) cleanupDataguardBroker(req ctrl.Request, ctx
 	log.Info("RemoveDataguardConfiguration Output")
 	log.Info(out)
 
+	return r.clearDgBrokerConfigured(m, singleInstanceDatabase, ctx, req)
+}
+
+// #############################################################################
+//
+//	Mark the databases of a deleted DataguardBroker as out of a broker configuration
+//
+// #############################################################################
+func (r *DataguardBrokerReconciler) clearDgBrokerConfigured(m *dbapi.DataguardBroker, singleInstanceDatabase *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("clearDgBrokerConfigured", req.NamespacedName)
+
 	for i := 0; i < len(m.Spec.StandbyDatabaseRefs); i++ {
 
 		standbyDatabase := &dbapi.SingleInstanceDatabase{}
@@ -1168,14 +1468,15 @@ func (r *DataguardBrokerReconciler) cleanupDataguardBroker(req ctrl.Request, ctx
 			log.Error(err, err.Error())
 			return requeueY, err
 		}
+		k8s.TrackStatus(ctx, standbyDatabase)
 
 		// Set DgBrokerConfigured to false
 		standbyDatabase.Status.DgBrokerConfigured = false
-		r.Status().Update(ctx, standbyDatabase)
+		k8s.PatchStatus(ctx, r.Client, standbyDatabase)
 	}
 
 	singleInstanceDatabase.Status.DgBrokerConfigured = false
-	r.Status().Update(ctx, singleInstanceDatabase)
+	k8s.PatchStatus(ctx, r.Client, singleInstanceDatabase)
 
 	log.Info("Successfully cleaned up Dataguard Broker")
 	return requeueN, nil
@@ -1189,6 +1490,7 @@ func (r *DataguardBrokerReconciler) cleanupDataguardBroker(req ctrl.Request, ctx
 func (r *DataguardBrokerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbapi.DataguardBroker{}).
+		WithEventFilter(fleetPredicate()).
 		Owns(&corev1.Pod{}). //Watch for deleted pods of DataguardBroker Owner
 		WithEventFilter(dbcommons.ResourceEventHandler()).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 100}). //ReconcileHandler is never invoked concurrently with the same object.
@@ -0,0 +1,106 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dbapi "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+)
+
+var _ = Describe("DataguardBroker deletion policy", func() {
+	const namespace = "default"
+
+	ctx := context.Background()
+	keepSecret := true
+
+	newDatabase := func(name string) *dbapi.SingleInstanceDatabase {
+		sidb := &dbapi.SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: dbapi.SingleInstanceDatabaseSpec{
+				Edition:  "enterprise",
+				Sid:      "ORCLCDB",
+				Replicas: 1,
+				Image: dbapi.SingleInstanceDatabaseImage{
+					PullFrom: "container-registry.oracle.com/database/enterprise:latest",
+				},
+				AdminPassword: dbapi.SingleInstanceDatabaseAdminPassword{SecretName: name, KeepSecret: &keepSecret},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sidb)).To(Succeed())
+		sidb.Status.DgBrokerConfigured = true
+		Expect(k8sClient.Status().Update(ctx, sidb)).To(Succeed())
+		return sidb
+	}
+
+	It("Should only mark the databases as out of the broker configuration with the Abandon policy", func() {
+		primary := newDatabase("dg-abandon-primary")
+		standby := newDatabase("dg-abandon-standby")
+		m := &dbapi.DataguardBroker{
+			ObjectMeta: metav1.ObjectMeta{Name: "dg-abandon", Namespace: namespace},
+			Spec: dbapi.DataguardBrokerSpec{
+				PrimaryDatabaseRef:  primary.Name,
+				StandbyDatabaseRefs: []string{standby.Name, "dg-abandon-missing"},
+				ProtectionMode:      "MaxPerformance",
+				DeletionPolicy:      dbcommons.DeletionPolicyAbandon,
+			},
+		}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: m.Name, Namespace: namespace}}
+
+		// The primary has no ready pod, so any command run in it would fail
+		fakeExecutor.Reset()
+		result, err := dataguardBrokerReconciler.cleanupDataguardBroker(req, ctx, m)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+		Expect(fakeExecutor.Commands()).To(BeEmpty())
+
+		for _, sidb := range []*dbapi.SingleInstanceDatabase{primary, standby} {
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(sidb), sidb)).To(Succeed())
+			Expect(sidb.Status.DgBrokerConfigured).To(BeFalse())
+		}
+	})
+})
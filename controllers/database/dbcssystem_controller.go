@@ -109,6 +109,10 @@ func (r *DbcsSystemReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		ConfigMapName: &dbcsInst.Spec.OCIConfigMap,
 		SecretName:    &dbcsInst.Spec.OCISecret,
 		Namespace:     dbcsInst.GetNamespace(),
+		AuthMode:      dbcsInst.Spec.OCIAuthMode,
+	}
+	if authData.AuthMode == "" {
+		authData.AuthMode = oci.AuthModeAPIKey
 	}
 	provider, err := oci.GetOCIProvider(r.KubeClient, authData)
 	if err != nil {
@@ -254,12 +258,23 @@ func (r *DbcsSystemReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				// Change the status to required state
 				return ctrl.Result{}, err
 			}
+
+			if err := dbcsv1.ValidateDbcsSystemDrift(r.Logger, r.KubeClient, r.dbClient, dbcsInst, r.nwClient, r.wrClient); err != nil {
+				r.Logger.Error(err, "Fail to validate the drift of the DbcsSystem")
+				return ctrl.Result{}, err
+			}
 		}
 	}
 
 	// Update the Wallet Secret when the secret name is given
 	//r.updateWalletSecret(dbcs)
 
+	// Propagate the labels as tags of the DB system
+	if err := dbcsv1.UpdateDbcsSystemTags(r.Logger, r.dbClient, dbcsInst); err != nil {
+		r.Logger.Error(err, "Fail to propagate the labels as tags of the DbcsSystem")
+		return ctrl.Result{}, err
+	}
+
 	// Update the last succesful spec
 	dbcsInstId := *dbcsInst.Spec.Id
 	if err := dbcsInst.UpdateLastSuccessfulSpec(r.KubeClient); err != nil {
@@ -268,6 +283,7 @@ func (r *DbcsSystemReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	//assignDBCSID(dbcsInst,dbcsI)
 	// Change the phase to "Available"
 	assignDBCSID(dbcsInst, dbcsInstId)
+	dbcsInst.Status.ObservedGeneration = dbcsInst.GetGeneration()
 	if statusErr := dbcsv1.SetLifecycleState(r.KubeClient, r.dbClient, dbcsInst, databasev1alpha1.Available, r.nwClient, r.wrClient); statusErr != nil {
 		return ctrl.Result{}, statusErr
 	}
@@ -292,7 +308,9 @@ func (r *DbcsSystemReconciler) eventFilterPredicate() predicate.Predicate {
 
 			deletionTimeStamp := !reflect.DeepEqual(oldObject.GetDeletionTimestamp(), newObject.GetDeletionTimestamp())
 
-			if specObject || deletionTimeStamp {
+			labelObject := newObject.Spec.TagPropagation != nil && !reflect.DeepEqual(oldObject.GetLabels(), newObject.GetLabels())
+
+			if specObject || deletionTimeStamp || labelObject {
 				return true
 			}
 
@@ -308,6 +326,7 @@ func (r *DbcsSystemReconciler) eventFilterPredicate() predicate.Predicate {
 func (r *DbcsSystemReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&databasev1alpha1.DbcsSystem{}).
+		WithEventFilter(fleetPredicate()).
 		WithEventFilter(r.eventFilterPredicate()).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 50}).
 		Complete(r)
@@ -0,0 +1,79 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Number of the operator deployments dividing the resources, and the one of this deployment
+var fleetShards, fleetShard uint32
+
+// EnableFleetSharding divides the resources between count operator deployments by the hash of their namespace.
+// This deployment only reconciles the resources of the namespaces of the given shard, from 0 to count-1.
+func EnableFleetSharding(count int, shard int) error {
+	if count < 1 || shard < 0 || shard >= count {
+		return fmt.Errorf("shard %d is not in the range 0 to %d", shard, count-1)
+	}
+	fleetShards, fleetShard = uint32(count), uint32(shard)
+	return nil
+}
+
+// InFleetShard returns whether the resources of the namespace are reconciled by this operator deployment.
+// All the resources of a namespace are reconciled by the same deployment, so that the resources referencing
+// each other, such as ORDS and its database, are handled together.
+func InFleetShard(namespace string) bool {
+	if fleetShards <= 1 {
+		return true
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(namespace))
+	return hash.Sum32()%fleetShards == fleetShard
+}
+
+// fleetPredicate filters out the events of the namespaces reconciled by the other operator deployments
+func fleetPredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return InFleetShard(obj.GetNamespace())
+	})
+}
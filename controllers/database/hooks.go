@@ -0,0 +1,342 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dbapi "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+	"github.com/oracle/oracle-database-operator/commons/k8s"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// #############################################################################
+//
+//	Launch the Jobs of the postProvision and postFailover hooks
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) manageHooks(m *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	log := r.Log.WithValues("manageHooks", req.NamespacedName)
+
+	role := strings.ToUpper(m.Status.Role)
+	if m.Status.Role == "" || m.Status.Role == dbcommons.ValueUnavailable {
+		return requeueN, nil
+	}
+	previousRole := m.Status.HookRole
+	hooks := m.Spec.Hooks
+	if hooks == nil {
+		m.Status.HookRole = role
+		return requeueN, nil
+	}
+
+	if hooks.PostProvision != nil && m.Status.HookJobs[dbcommons.HookPostProvision] == "" {
+		if err := runHook(ctx, r.Client, r.Scheme, r.Recorder, m, dbcommons.HookPostProvision, hooks.PostProvision); err != nil {
+			log.Error(err, err.Error())
+			return requeueY, nil
+		}
+	}
+	// A standby became the primary
+	if hooks.PostFailover != nil && strings.Contains(previousRole, "STANDBY") && role == "PRIMARY" {
+		if err := runHook(ctx, r.Client, r.Scheme, r.Recorder, m, dbcommons.HookPostFailover, hooks.PostFailover); err != nil {
+			log.Error(err, err.Error())
+			return requeueY, nil
+		}
+	}
+	m.Status.HookRole = role
+	return requeueN, nil
+}
+
+// #############################################################################
+//
+//	Launch the Job of the preDelete hook and wait for it to finish
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) runPreDeleteHook(m *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) ctrl.Result {
+
+	log := r.Log.WithValues("runPreDeleteHook", req.NamespacedName)
+
+	if m.Spec.Hooks == nil || m.Spec.Hooks.PreDelete == nil {
+		return requeueN
+	}
+	jobName := m.Status.HookJobs[dbcommons.HookPreDelete]
+	if jobName == "" {
+		if err := runHook(ctx, r.Client, r.Scheme, r.Recorder, m, dbcommons.HookPreDelete, m.Spec.Hooks.PreDelete); err != nil {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+		k8s.PatchStatus(ctx, r.Client, m)
+		return requeueY
+	}
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: jobName, Namespace: m.Namespace}, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return requeueN
+		}
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return requeueN
+		case batchv1.JobFailed:
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, "Database Hook", "%s job %s failed: %s, deleting the database",
+				dbcommons.HookPreDelete, jobName, condition.Message)
+			return requeueN
+		}
+	}
+	log.Info("Waiting for the preDelete job", "Job.Name", jobName)
+	return requeueY
+}
+
+// runPostBackupHook launches the Job of the postBackup hook of the database once a backup is taken, with its location
+// in BACKUP_LOCATION. A hook failing to launch does not fail the backup
+func (r *OracleRestDataServiceReconciler) runPostBackupHook(n *dbapi.SingleInstanceDatabase, location string,
+	ctx context.Context) {
+	if n.Spec.Hooks == nil || n.Spec.Hooks.PostBackup == nil {
+		return
+	}
+	if err := runHook(ctx, r.Client, r.Scheme, r.Recorder, n, dbcommons.HookPostBackup, n.Spec.Hooks.PostBackup,
+		corev1.EnvVar{Name: "BACKUP_LOCATION", Value: location}); err != nil {
+		r.Log.Error(err, err.Error())
+		return
+	}
+	k8s.PatchStatus(ctx, r.Client, n)
+}
+
+// runHook launches the Job of a hook of the database and records it in the status
+func runHook(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder,
+	m *dbapi.SingleInstanceDatabase, hook string, ref *dbapi.SingleInstanceDatabaseHook, env ...corev1.EnvVar) error {
+
+	job, err := hookJob(ctx, c, m, hook, ref, env...)
+	if err != nil {
+		recorder.Eventf(m, corev1.EventTypeWarning, "Database Hook", "%s job not launched: %s", hook, err.Error())
+		return err
+	}
+	if err := createHookServiceAccount(ctx, c, m.Namespace); err != nil {
+		recorder.Eventf(m, corev1.EventTypeWarning, "Database Hook", "%s job not launched: %s", hook, err.Error())
+		return err
+	}
+	ctrl.SetControllerReference(m, job, scheme)
+	if err := c.Create(ctx, job); err != nil {
+		recorder.Eventf(m, corev1.EventTypeWarning, "Database Hook", "%s job not launched: %s", hook, err.Error())
+		return err
+	}
+	if m.Status.HookJobs == nil {
+		m.Status.HookJobs = make(map[string]string)
+	}
+	m.Status.HookJobs[hook] = job.Name
+	recorder.Eventf(m, corev1.EventTypeNormal, "Database Hook", "%s job %s launched", hook, job.Name)
+	return nil
+}
+
+// hookJob returns the Job of a hook, read from the manifest of its ConfigMap, with the database and the hook in the
+// environment of its containers
+func hookJob(ctx context.Context, c client.Client, m *dbapi.SingleInstanceDatabase, hook string,
+	ref *dbapi.SingleInstanceDatabaseHook, env ...corev1.EnvVar) (*batchv1.Job, error) {
+
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.ConfigMap, Namespace: m.Namespace}, configMap); err != nil {
+		return nil, err
+	}
+	key := ref.Key
+	if key == "" {
+		key = dbcommons.HookJobKey
+	}
+	manifest, ok := configMap.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in config map %s", key, ref.ConfigMap)
+	}
+	manifestJob := &batchv1.Job{}
+	if err := yaml.UnmarshalStrict([]byte(manifest), manifestJob); err != nil {
+		return nil, fmt.Errorf("invalid job in config map %s: %w", ref.ConfigMap, err)
+	}
+	// Whoever can edit the ConfigMap gets the Job run by the operator, only the fields that grant no privilege are kept
+	podSpec, err := hookPodSpec(manifestJob.Spec.Template.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid job in config map %s: %w", ref.ConfigMap, err)
+	}
+
+	// Named after the database and the hook, such as sidb-sample-postfailover-x7k2p
+	labels := make(map[string]string)
+	for key, value := range manifestJob.ObjectMeta.Labels {
+		labels[key] = value
+	}
+	labels[dbcommons.HookLabel] = hook
+	labels[dbcommons.HookDatabaseLabel] = m.Name
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: m.Name + "-" + strings.ToLower(hook) + "-",
+			Namespace:    m.Namespace,
+			Labels:       labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            manifestJob.Spec.BackoffLimit,
+			ActiveDeadlineSeconds:   manifestJob.Spec.ActiveDeadlineSeconds,
+			TTLSecondsAfterFinished: manifestJob.Spec.TTLSecondsAfterFinished,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: manifestJob.Spec.Template.ObjectMeta.Labels},
+				Spec:       podSpec,
+			},
+		},
+	}
+
+	env = append([]corev1.EnvVar{
+		{Name: "DATABASE_NAME", Value: m.Name},
+		{Name: "DATABASE_NAMESPACE", Value: m.Namespace},
+		{Name: "DATABASE_HOOK", Value: hook},
+		{Name: "DATABASE_ROLE", Value: m.Status.Role},
+		{Name: "DATABASE_CONNECT_STRING", Value: m.Status.ConnectString},
+	}, env...)
+	spec := &job.Spec.Template.Spec
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].Env = append(spec.InitContainers[i].Env, env...)
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].Env = append(spec.Containers[i].Env, env...)
+	}
+	return job, nil
+}
+
+// hookPodSpec returns the pod of a hook Job with the fields of its manifest that grant no privilege. The pod runs as the
+// hook service account, as a non-root user, without privilege escalation nor capabilities, and mounts only ConfigMaps,
+// Secrets, projections and empty directories of its namespace
+func hookPodSpec(template corev1.PodSpec) (corev1.PodSpec, error) {
+	for _, volume := range template.Volumes {
+		source := volume.VolumeSource
+		if source.ConfigMap == nil && source.Secret == nil && source.EmptyDir == nil && source.DownwardAPI == nil &&
+			source.Projected == nil {
+			return corev1.PodSpec{}, fmt.Errorf("volume %s is not a configMap, secret, emptyDir, downwardAPI or projected volume",
+				volume.Name)
+		}
+	}
+
+	runAsNonRoot := true
+	securityContext := &corev1.PodSecurityContext{
+		RunAsNonRoot:   &runAsNonRoot,
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	if template.SecurityContext != nil {
+		securityContext.RunAsUser = template.SecurityContext.RunAsUser
+		securityContext.RunAsGroup = template.SecurityContext.RunAsGroup
+		securityContext.FSGroup = template.SecurityContext.FSGroup
+	}
+	restartPolicy := template.RestartPolicy
+	if restartPolicy == "" {
+		restartPolicy = corev1.RestartPolicyNever
+	}
+	return corev1.PodSpec{
+		InitContainers:                hookContainers(template.InitContainers),
+		Containers:                    hookContainers(template.Containers),
+		Volumes:                       template.Volumes,
+		RestartPolicy:                 restartPolicy,
+		ActiveDeadlineSeconds:         template.ActiveDeadlineSeconds,
+		TerminationGracePeriodSeconds: template.TerminationGracePeriodSeconds,
+		NodeSelector:                  template.NodeSelector,
+		Affinity:                      template.Affinity,
+		Tolerations:                   template.Tolerations,
+		ImagePullSecrets:              template.ImagePullSecrets,
+		ServiceAccountName:            dbcommons.HookServiceAccount,
+		SecurityContext:               securityContext,
+	}, nil
+}
+
+// hookContainers returns the containers of a hook Job with the fields of their manifest that grant no privilege
+func hookContainers(manifestContainers []corev1.Container) []corev1.Container {
+	var containers []corev1.Container
+	for _, c := range manifestContainers {
+		allowPrivilegeEscalation := false
+		containers = append(containers, corev1.Container{
+			Name:            c.Name,
+			Image:           c.Image,
+			ImagePullPolicy: c.ImagePullPolicy,
+			Command:         c.Command,
+			Args:            c.Args,
+			WorkingDir:      c.WorkingDir,
+			Env:             c.Env,
+			EnvFrom:         c.EnvFrom,
+			Resources:       c.Resources,
+			VolumeMounts:    c.VolumeMounts,
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+				Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			},
+		})
+	}
+	return containers
+}
+
+// createHookServiceAccount creates the service account of the hook Jobs in the namespace, without any role nor token. It
+// is left to the administrators of the namespace to bind it the roles the hooks need
+func createHookServiceAccount(ctx context.Context, c client.Client, namespace string) error {
+	serviceAccount := &corev1.ServiceAccount{}
+	err := c.Get(ctx, types.NamespacedName{Name: dbcommons.HookServiceAccount, Namespace: namespace}, serviceAccount)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+	automountToken := false
+	serviceAccount = &corev1.ServiceAccount{
+		ObjectMeta:                   metav1.ObjectMeta{Name: dbcommons.HookServiceAccount, Namespace: namespace},
+		AutomountServiceAccountToken: &automountToken,
+	}
+	if err := c.Create(ctx, serviceAccount); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
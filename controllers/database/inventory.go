@@ -0,0 +1,276 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dbapi "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// InventoryPath is the path of the inventory, and the non-resource URL the clients need the get verb on
+const InventoryPath = "/inventory"
+
+// InventoryItem is a database or an ORDS managed by the operator
+type InventoryItem struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	Status    string `json:"status,omitempty"`
+	// Database served by an ORDS
+	Database string            `json:"database,omitempty"`
+	URLs     map[string]string `json:"urls,omitempty"`
+}
+
+// InventoryServer serves a read-only inventory of the databases and ORDS, read from the cache of the manager,
+// to the clients whose bearer token is allowed to get the InventoryPath
+type InventoryServer struct {
+	// Address the HTTPS server binds to, and directory of its tls.crt and tls.key. Defaults to the directory of the
+	// certificate of the webhooks
+	Addr    string
+	CertDir string
+	Reader  client.Reader
+	Config  *rest.Config
+	Log     logr.Logger
+
+	clientset kubernetes.Interface
+}
+
+// Start serves the inventory until the context is done
+func (s *InventoryServer) Start(ctx context.Context) error {
+	clientset, err := kubernetes.NewForConfig(s.Config)
+	if err != nil {
+		return err
+	}
+	s.clientset = clientset
+	certDir := s.CertDir
+	if certDir == "" {
+		certDir = filepath.Join("/tmp", "k8s-webhook-server", "serving-certs")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(InventoryPath, s.authorize(http.HandlerFunc(s.serveInventory)))
+	server := &http.Server{Addr: s.Addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	s.Log.Info("Serving the inventory", "address", s.Addr, "path", InventoryPath)
+	err = server.ListenAndServeTLS(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// NeedLeaderElection returns false, so that all the replicas of the manager serve the inventory
+func (s *InventoryServer) NeedLeaderElection() bool {
+	return false
+}
+
+// authorize authenticates the bearer token of the request with a TokenReview, and checks with a SubjectAccessReview
+// that its user may get the InventoryPath
+func (s *InventoryServer) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if token == "" || token == req.Header.Get("Authorization") {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		review, err := s.clientset.AuthenticationV1().TokenReviews().Create(req.Context(),
+			&authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}, metav1.CreateOptions{})
+		if err != nil {
+			s.Log.Error(err, "Failed to review the token of an inventory request")
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if !review.Status.Authenticated {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		user := review.Status.User
+		extra := map[string]authorizationv1.ExtraValue{}
+		for key, value := range user.Extra {
+			extra[key] = authorizationv1.ExtraValue(value)
+		}
+		access, err := s.clientset.AuthorizationV1().SubjectAccessReviews().Create(req.Context(),
+			&authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:                  user.Username,
+				UID:                   user.UID,
+				Groups:                user.Groups,
+				Extra:                 extra,
+				NonResourceAttributes: &authorizationv1.NonResourceAttributes{Path: InventoryPath, Verb: "get"},
+			}}, metav1.CreateOptions{})
+		if err != nil {
+			s.Log.Error(err, "Failed to review the access of an inventory request", "user", user.Username)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if !access.Status.Allowed {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// serveInventory writes the inventory as JSON, restricted to the namespace query parameter if set
+func (s *InventoryServer) serveInventory(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	items, err := Inventory(req.Context(), s.Reader, req.URL.Query().Get("namespace"))
+	if err != nil {
+		s.Log.Error(err, "Failed to list the inventory")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]InventoryItem{"items": items})
+}
+
+// Inventory lists the databases and ORDS of a namespace, or of all the namespaces if it is empty
+func Inventory(ctx context.Context, reader client.Reader, namespace string) ([]InventoryItem, error) {
+	items := []InventoryItem{}
+
+	sidbs := &dbapi.SingleInstanceDatabaseList{}
+	if err := reader.List(ctx, sidbs, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for _, m := range sidbs.Items {
+		items = append(items, InventoryItem{
+			Kind:      "SingleInstanceDatabase",
+			Namespace: m.Namespace,
+			Name:      m.Name,
+			Version:   inventoryValue(m.Status.ReleaseUpdate),
+			Status:    m.Status.Status,
+			URLs: inventoryURLs(map[string]string{
+				"connectString":     m.Status.ConnectString,
+				"pdbConnectString":  m.Status.PdbConnectString,
+				"tcpsConnectString": m.Status.TcpsConnectString,
+				"oemExpressUrl":     m.Status.OemExpressUrl,
+			}),
+		})
+	}
+
+	ordss := &dbapi.OracleRestDataServiceList{}
+	if err := reader.List(ctx, ordss, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for _, n := range ordss.Items {
+		items = append(items, InventoryItem{
+			Kind:      "OracleRestDataService",
+			Namespace: n.Namespace,
+			Name:      n.Name,
+			Version:   n.Status.Image.Version,
+			Status:    n.Status.Status,
+			Database:  n.Spec.DatabaseRef,
+			URLs: inventoryURLs(map[string]string{
+				"databaseApiUrl":     n.Status.DatabaseApiUrl,
+				"databaseActionsUrl": n.Status.DatabaseActionsUrl,
+				"apexUrl":            n.Status.ApxeUrl,
+			}),
+		})
+	}
+
+	adbs := &dbapi.AutonomousDatabaseList{}
+	if err := reader.List(ctx, adbs, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for _, adb := range adbs.Items {
+		item := InventoryItem{
+			Kind:      "AutonomousDatabase",
+			Namespace: adb.Namespace,
+			Name:      adb.Name,
+			Status:    string(adb.Status.LifecycleState),
+		}
+		if adb.Spec.Details.DbVersion != nil {
+			item.Version = *adb.Spec.Details.DbVersion
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// inventoryValue returns the value, or an empty string if it is not known yet
+func inventoryValue(value string) string {
+	if value == dbcommons.ValueUnavailable {
+		return ""
+	}
+	return value
+}
+
+// inventoryURLs returns the URLs which are known
+func inventoryURLs(urls map[string]string) map[string]string {
+	for key, value := range urls {
+		if inventoryValue(value) == "" {
+			delete(urls, key)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+	return urls
+}
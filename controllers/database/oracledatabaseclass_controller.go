@@ -0,0 +1,201 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	dbapi "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+)
+
+// OracleDatabaseClassReconciler reconciles a OracleDatabaseClass object
+type OracleDatabaseClassReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=database.oracle.com,resources=oracledatabaseclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=database.oracle.com,resources=oracledatabaseclasses/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=database.oracle.com,resources=singleinstancedatabases;oraclerestdataservices,verbs=get;list;watch;patch
+
+// Reconcile counts the resources of a class, and applies the changes of the class to them. The classes are merged
+// into the specs by the mutating webhooks, so the resources are only annotated with the generation of the class
+// to have them merged again
+func (r *OracleDatabaseClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Log.Info("Reconcile requested")
+
+	class := &dbapi.OracleDatabaseClass{}
+	if err := r.Get(ctx, req.NamespacedName, class); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Log.Info("Resource not found", "Name", req.Name)
+			return requeueN, nil
+		}
+		return requeueY, err
+	}
+	generation := strconv.FormatInt(class.Generation, 10)
+
+	sidbList := &dbapi.SingleInstanceDatabaseList{}
+	if err := r.List(ctx, sidbList); err != nil {
+		return requeueY, err
+	}
+	databases := 0
+	for i := range sidbList.Items {
+		sidb := &sidbList.Items[i]
+		if sidb.Spec.ClassName != class.Name {
+			continue
+		}
+		databases++
+		if InFleetShard(sidb.Namespace) && sidb.DeletionTimestamp == nil &&
+			sidb.Annotations[dbcommons.ClassGenerationAnnotation] != generation {
+			r.applyClass(ctx, class, sidb, generation)
+		}
+	}
+
+	ordsList := &dbapi.OracleRestDataServiceList{}
+	if err := r.List(ctx, ordsList); err != nil {
+		return requeueY, err
+	}
+	oracleRestDataServices := 0
+	for i := range ordsList.Items {
+		ords := &ordsList.Items[i]
+		if ords.Spec.ClassName != class.Name {
+			continue
+		}
+		oracleRestDataServices++
+		if InFleetShard(ords.Namespace) && ords.DeletionTimestamp == nil &&
+			ords.Annotations[dbcommons.ClassGenerationAnnotation] != generation {
+			r.applyClass(ctx, class, ords, generation)
+		}
+	}
+
+	if class.Status.Databases != databases || class.Status.OracleRestDataServices != oracleRestDataServices ||
+		class.Status.ObservedGeneration != class.Generation {
+		class.Status.Databases = databases
+		class.Status.OracleRestDataServices = oracleRestDataServices
+		class.Status.ObservedGeneration = class.Generation
+		if err := r.Status().Update(ctx, class); err != nil {
+			return requeueY, err
+		}
+	}
+
+	r.Log.Info("Reconcile completed")
+	return requeueN, nil
+}
+
+// #############################################################################
+//
+//	Have the mutating webhook merge the current generation of the class into a resource
+//
+// #############################################################################
+func (r *OracleDatabaseClassReconciler) applyClass(ctx context.Context, class *dbapi.OracleDatabaseClass,
+	obj client.Object, generation string) {
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[dbcommons.ClassGenerationAnnotation] = generation
+	obj.SetAnnotations(annotations)
+	if err := r.Patch(ctx, obj, patch); err != nil {
+		r.Log.Error(err, "Failed to apply the class", "Namespace", obj.GetNamespace(), "Name", obj.GetName())
+		r.Recorder.Eventf(class, corev1.EventTypeWarning, "Class Not Applied",
+			"Failed to apply the class to %s/%s: %s", obj.GetNamespace(), obj.GetName(), err.Error())
+		return
+	}
+	r.Log.Info("Class applied", "Namespace", obj.GetNamespace(), "Name", obj.GetName(), "Generation", generation)
+}
+
+// classOf returns the class of a database or an ORDS
+func classOf(obj client.Object) string {
+	switch o := obj.(type) {
+	case *dbapi.SingleInstanceDatabase:
+		return o.Spec.ClassName
+	case *dbapi.OracleRestDataService:
+		return o.Spec.ClassName
+	}
+	return ""
+}
+
+// enqueueClassRefs enqueues the classes of the databases and ORDS, to count their resources. Both classes are
+// enqueued when a resource changes class
+func (r *OracleDatabaseClassReconciler) enqueueClassRefs() handler.EventHandler {
+	enqueue := func(obj client.Object, q workqueue.RateLimitingInterface) {
+		if className := classOf(obj); className != "" {
+			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: className}})
+		}
+	}
+	return handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, q workqueue.RateLimitingInterface) { enqueue(e.Object, q) },
+		UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			if classOf(e.ObjectOld) != classOf(e.ObjectNew) {
+				enqueue(e.ObjectOld, q)
+				enqueue(e.ObjectNew, q)
+			}
+		},
+		DeleteFunc: func(e event.DeleteEvent, q workqueue.RateLimitingInterface) { enqueue(e.Object, q) },
+	}
+}
+
+func (r *OracleDatabaseClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&dbapi.OracleDatabaseClass{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &dbapi.SingleInstanceDatabase{}}, r.enqueueClassRefs()).
+		Watches(&source.Kind{Type: &dbapi.OracleRestDataService{}}, r.enqueueClassRefs()).
+		Complete(r)
+}
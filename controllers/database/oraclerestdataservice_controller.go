@@ -39,35 +39,61 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/oracle/oci-go-sdk/v65/apigateway"
+	"go.opentelemetry.io/otel/attribute"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	dbapi "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
 	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+	"github.com/oracle/oracle-database-operator/commons/k8s"
+	"github.com/oracle/oracle-database-operator/commons/oci"
 
 	"github.com/go-logr/logr"
 )
 
 const oracleRestDataServiceFinalizer = "database.oracle.com/oraclerestdataservicefinalizer"
 
+// Returned by the cleanup while the uninstall job has not completed
+var errUninstallJobRunning = errors.New("waiting for the ORDS uninstall job to complete")
+
+// Time between two checks of the APEX installation running in the background
+const apexInstallPollInterval = 30 * time.Second
+
 // OracleRestDataServiceReconciler reconciles a OracleRestDataService object
 type OracleRestDataServiceReconciler struct {
 	client.Client
@@ -80,7 +106,13 @@ type OracleRestDataServiceReconciler struct {
 //+kubebuilder:rbac:groups=database.oracle.com,resources=oraclerestdataservices,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=database.oracle.com,resources=oraclerestdataservices/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=database.oracle.com,resources=oraclerestdataservices/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=pods;pods/log;pods/exec;persistentvolumeclaims;services;nodes;events,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups="",resources=pods;pods/log;persistentvolumeclaims;services;nodes;events,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups="",resources=pods/status,verbs=get;patch;update
+//+kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;delete;get;list;watch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=create;delete;get;list;patch;update;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -92,11 +124,19 @@ type OracleRestDataServiceReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
 func (r *OracleRestDataServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = k8s.WithStatusTracking(ctx)
 	_ = log.FromContext(ctx)
+	ctx, reconcileSpan := dbcommons.StartSpan(ctx, "Reconcile OracleRestDataService",
+		attribute.String("namespace", req.Namespace), attribute.String("name", req.Name))
+	defer reconcileSpan.End()
 
 	oracleRestDataService := &dbapi.OracleRestDataService{}
 	// Always refresh status before a reconcile
-	defer r.Status().Update(ctx, oracleRestDataService)
+	defer func() {
+		dbcommons.SetHealthConditions(&oracleRestDataService.Status.Conditions, oracleRestDataService.GetGeneration(),
+			oracleRestDataService.Status.ObservedGeneration, oracleRestDataService.Status.Status)
+		k8s.PatchStatus(ctx, r.Client, oracleRestDataService)
+	}()
 
 	err := r.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: req.Name}, oracleRestDataService)
 	if err != nil {
@@ -107,6 +147,9 @@ func (r *OracleRestDataServiceReconciler) Reconcile(ctx context.Context, req ctr
 		r.Log.Error(err, err.Error())
 		return requeueY, err
 	}
+	k8s.TrackStatus(ctx, oracleRestDataService)
+	// Log the commands of a resource annotated with database.oracle.com/log-level: debug
+	ctx = dbcommons.WithLogLevel(ctx, oracleRestDataService)
 
 	/* Initialize Status */
 	if oracleRestDataService.Status.Status == "" {
@@ -114,30 +157,38 @@ func (r *OracleRestDataServiceReconciler) Reconcile(ctx context.Context, req ctr
 		oracleRestDataService.Status.ApxeUrl = dbcommons.ValueUnavailable
 		oracleRestDataService.Status.DatabaseApiUrl = dbcommons.ValueUnavailable
 		oracleRestDataService.Status.DatabaseActionsUrl = dbcommons.ValueUnavailable
-		r.Status().Update(ctx, oracleRestDataService)
+		// Recreated from the state exported on another cluster
+		if _, ok := oracleRestDataService.Annotations[dbcommons.ImportedStatusAnnotation]; ok {
+			r.importOrdsState(oracleRestDataService)
+		}
 	}
 	oracleRestDataService.Status.LoadBalancer = strconv.FormatBool(oracleRestDataService.Spec.LoadBalancer)
-	oracleRestDataService.Status.Image = oracleRestDataService.Spec.Image
+	if !oracleRestDataService.Status.OrdsInstalled {
+		oracleRestDataService.Status.Image = oracleRestDataService.Spec.Image
+	}
 
 	// Fetch Primary Database Reference
 	singleInstanceDatabase := &dbapi.SingleInstanceDatabase{}
 	// Always refresh status before a reconcile
-	defer r.Status().Update(ctx, singleInstanceDatabase)
+	defer k8s.PatchStatus(ctx, r.Client, singleInstanceDatabase)
 
 	err = r.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: oracleRestDataService.Spec.DatabaseRef}, singleInstanceDatabase)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			oracleRestDataService.Status.Status = dbcommons.StatusError
-			oracleRestDataService.Status.DatabaseRef = ""
-			eventReason := "Error"
-			eventMsg := "database reference " + oracleRestDataService.Spec.DatabaseRef + " not found"
-			r.Recorder.Eventf(oracleRestDataService, corev1.EventTypeWarning, eventReason, eventMsg)
-			r.Log.Info(eventMsg)
-			return requeueY, nil
+			return r.manageDatabaseMissing(oracleRestDataService, ctx, req), nil
 		}
 		r.Log.Error(err, err.Error())
 		return requeueY, err
 	} else {
+		if meta.IsStatusConditionTrue(oracleRestDataService.Status.Conditions, dbcommons.DatabaseMissingCondition) {
+			meta.SetStatusCondition(&oracleRestDataService.Status.Conditions, metav1.Condition{
+				Type:               dbcommons.DatabaseMissingCondition,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: oracleRestDataService.GetGeneration(),
+				Reason:             dbcommons.DatabaseFoundReason,
+				Message:            "database reference " + oracleRestDataService.Spec.DatabaseRef + " found",
+			})
+		}
 		if oracleRestDataService.Status.DatabaseRef == "" {
 			oracleRestDataService.Status.Status = dbcommons.StatusPending
 			oracleRestDataService.Status.DatabaseRef = oracleRestDataService.Spec.DatabaseRef
@@ -146,6 +197,20 @@ func (r *OracleRestDataServiceReconciler) Reconcile(ctx context.Context, req ctr
 			r.Recorder.Eventf(oracleRestDataService, corev1.EventTypeNormal, eventReason, eventMsg)
 		}
 	}
+	k8s.TrackStatus(ctx, singleInstanceDatabase)
+
+	// Serialize administrative SQL against the referred database
+	lockHolder := dbcommons.DatabaseLockHolder("OracleRestDataService", req.Namespace, req.Name)
+	locked, err := dbcommons.LockDatabase(ctx, r.Client, req.Namespace, singleInstanceDatabase.Name, lockHolder)
+	if err != nil {
+		r.Log.Error(err, err.Error())
+		return requeueY, err
+	}
+	if !locked {
+		r.Log.Info("Database " + singleInstanceDatabase.Name + " is locked by another operation, Reconcile queued")
+		return requeueY, nil
+	}
+	defer dbcommons.UnlockDatabase(ctx, r.Client, req.Namespace, singleInstanceDatabase.Name, lockHolder)
 
 	// Manage OracleRestDataService Deletion
 	result := r.manageOracleRestDataServiceDeletion(req, ctx, oracleRestDataService, singleInstanceDatabase)
@@ -155,21 +220,48 @@ func (r *OracleRestDataServiceReconciler) Reconcile(ctx context.Context, req ctr
 	}
 
 	// First validate
-	result, err = r.validate(oracleRestDataService, singleInstanceDatabase, ctx)
+	phaseCtx, phase := dbcommons.StartSpan(ctx, "validate")
+	result, err = r.validate(oracleRestDataService, singleInstanceDatabase, phaseCtx)
+	dbcommons.EndSpan(phase, err)
 	if result.Requeue || err != nil {
 		r.Log.Info("Spec validation failed")
 		return result, nil
 	}
 
+	// Run the action requested by the action annotation
+	result = r.manageActions(oracleRestDataService, singleInstanceDatabase, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
+	// Stop ORDS while the database is stopped on its schedule
+	result = r.followDatabaseSchedule(oracleRestDataService, singleInstanceDatabase, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
+	// Register this ORDS with the database
+	result = r.registerOrdsReference(oracleRestDataService, singleInstanceDatabase, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
 	// Create Service
-	result = r.createSVC(ctx, req, oracleRestDataService, singleInstanceDatabase)
+	phaseCtx, phase = dbcommons.StartSpan(ctx, "createSVC")
+	result = r.createSVC(phaseCtx, req, oracleRestDataService, singleInstanceDatabase)
+	phase.End()
 	if result.Requeue {
 		r.Log.Info("Reconcile queued")
 		return result, nil
 	}
 
 	// PVC Creation
-	result, _ = r.createPVC(ctx, req, oracleRestDataService)
+	phaseCtx, phase = dbcommons.StartSpan(ctx, "createPVC")
+	result, pvcErr := r.createPVC(phaseCtx, req, oracleRestDataService)
+	dbcommons.EndSpan(phase, pvcErr)
 	if result.Requeue {
 		r.Log.Info("Reconcile queued")
 		return result, nil
@@ -182,41 +274,324 @@ func (r *OracleRestDataServiceReconciler) Reconcile(ctx context.Context, req ctr
 		return result, nil
 	}
 
-	// Create ORDS Pods
-	result = r.createPods(oracleRestDataService, singleInstanceDatabase, ctx, req)
+	// Backup ORDS metadata before the pods are replaced with the upgraded image
+	result = r.upgradeImage(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ctx, req)
 	if result.Requeue {
 		r.Log.Info("Reconcile queued")
 		return result, nil
 	}
 
-	var ordsReadyPod corev1.Pod
-	result, ordsReadyPod = r.checkHealthStatus(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ctx, req)
+	// Restore ORDS metadata from a backup
+	result = r.restoreMetadataBackup(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
+	// Report the downloads of the pods skipped in offline mode
+	r.updateOfflineCondition(oracleRestDataService)
+
+	// Render the ORDS settings mounted in the pods
+	result = r.manageSettingsSecret(oracleRestDataService, singleInstanceDatabase, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
+	// Create ORDS Pods
+	phaseCtx, phase = dbcommons.StartSpan(ctx, "createPods")
+	result = r.createPods(oracleRestDataService, singleInstanceDatabase, phaseCtx, req)
+	phase.End()
 	if result.Requeue {
 		r.Log.Info("Reconcile queued")
 		return result, nil
 	}
+	lostPodsResult := result
+
+	// Admit the pods to the service once their pool is validated
+	poolsValidated := r.manageReadinessGates(oracleRestDataService, ctx, req)
 
-	result = r.restEnableSchemas(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ordsReadyPod, ctx, req)
+	var ordsReadyPod corev1.Pod
+	result, ordsReadyPod = r.checkHealthStatus(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ctx, req)
 	if result.Requeue {
 		r.Log.Info("Reconcile queued")
 		return result, nil
 	}
 
-	// Configure Apex
-	result = r.configureApex(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ordsReadyPod, ctx, req)
+	// Publish the Database API URL and Ingress route of each PDB
+	result = r.managePdbRoutes(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ctx, req)
 	if result.Requeue {
 		r.Log.Info("Reconcile queued")
 		return result, nil
 	}
 
-	// Delete Secrets
-	r.deleteSecrets(oracleRestDataService, ctx, req)
+	// The optional features are retried on their own, without holding back the rest of the reconcile
+	featuresResult := r.manageOptionalFeatures(oracleRestDataService, []ordsFeature{{
+		name:      "restEnableSchemas",
+		condition: dbcommons.SchemasEnabledCondition,
+		requested: len(oracleRestDataService.Spec.RestEnableSchemas) > 0,
+		reconcile: func(ctx context.Context) ctrl.Result {
+			return r.restEnableSchemas(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ordsReadyPod, ctx, req)
+		},
+	}, {
+		// The schemas of the clients are REST enabled first
+		name:      "manageOAuthClients",
+		condition: dbcommons.OAuthClientsConfiguredCondition,
+		requested: len(oracleRestDataService.Spec.OAuthClients) > 0 || len(oracleRestDataService.Status.OAuthClients) > 0,
+		reconcile: func(ctx context.Context) ctrl.Result {
+			return r.manageOAuthClients(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ctx, req)
+		},
+	}, {
+		name:      "configureApex",
+		condition: dbcommons.ApexConfiguredCondition,
+		requested: oracleRestDataService.Spec.ApexPassword.SecretName != "" || oracleRestDataService.Status.ApexConfigured,
+		reconcile: func(ctx context.Context) ctrl.Result {
+			return r.configureApex(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ordsReadyPod, ctx, req)
+		},
+	}, {
+		name:      "manageApiGateway",
+		condition: dbcommons.ApiGatewayRegisteredCondition,
+		requested: (oracleRestDataService.Spec.Oci != nil && oracleRestDataService.Spec.Oci.ApiGateway != nil) ||
+			oracleRestDataService.Status.ApiGatewayDeploymentId != "",
+		reconcile: func(ctx context.Context) ctrl.Result {
+			return r.manageApiGateway(oracleRestDataService, ctx, req)
+		},
+	}, {
+		// Installed once APEX is configured
+		name:      "installApexLanguages",
+		condition: dbcommons.ApexLanguagesInstalledCondition,
+		requested: len(oracleRestDataService.Spec.Apex.Languages) > 0 && oracleRestDataService.Status.ApexConfigured &&
+			singleInstanceDatabase.Status.ApexInstalled,
+		reconcile: func(ctx context.Context) ctrl.Result {
+			return r.installApexLanguages(oracleRestDataService, singleInstanceDatabase, ordsReadyPod, ctx, req)
+		},
+	}}, ctx)
+
+	// Record the versions of the components
+	r.updateComponentVersions(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ordsReadyPod, ctx, req)
 
-	if oracleRestDataService.Status.ServiceIP == "" {
+	// Record the replicas the processes of the database can serve
+	r.updateMaxReplicas(oracleRestDataService, singleInstanceDatabase, sidbReadyPod, ctx, req)
+
+	// Delete Secrets, once the features being retried no longer need them
+	if !featuresResult.Requeue {
+		r.deleteSecrets(oracleRestDataService, ctx, req)
+	}
+
+	if oracleRestDataService.Status.ServiceIP == "" || !poolsValidated {
 		return requeueY, nil
 	}
 
-	return ctrl.Result{}, nil
+	oracleRestDataService.Status.ObservedGeneration = oracleRestDataService.GetGeneration()
+	// Come back to retry the optional features, or to force delete the pods of the unreachable nodes
+	if featuresResult.Requeue && (!lostPodsResult.Requeue || featuresResult.RequeueAfter < lostPodsResult.RequeueAfter) {
+		r.Log.Info("Reconcile queued for the optional features")
+		return featuresResult, nil
+	}
+	return lostPodsResult, nil
+}
+
+// An optional feature of ORDS, reconciled once the pods are healthy. A feature being retried does not hold back the
+// others, nor the rest of the reconcile, and reports its progress in a condition of its own
+type ordsFeature struct {
+	name      string
+	condition string
+	requested bool
+	reconcile func(ctx context.Context) ctrl.Result
+}
+
+// #############################################################################
+//
+//	Reconcile the optional features, each retried on its own
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) manageOptionalFeatures(m *dbapi.OracleRestDataService,
+	features []ordsFeature, ctx context.Context) ctrl.Result {
+
+	result := requeueN
+	for _, feature := range features {
+		if !feature.requested {
+			meta.RemoveStatusCondition(&m.Status.Conditions, feature.condition)
+			continue
+		}
+		featureCtx, span := dbcommons.StartSpan(ctx, feature.name)
+		featureResult := feature.reconcile(featureCtx)
+		span.End()
+
+		condition := metav1.Condition{
+			Type:               feature.condition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: m.GetGeneration(),
+			Reason:             dbcommons.FeatureReconciledReason,
+			Message:            "reconciled",
+		}
+		if featureResult.Requeue {
+			r.Log.Info("Optional feature queued", "Feature", feature.name)
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = dbcommons.FeatureRetryingReason
+			condition.Message = "retrying, see the events of the resource"
+			// Retry at the earliest time asked by a feature
+			if !result.Requeue || featureResult.RequeueAfter < result.RequeueAfter {
+				result = featureResult
+			}
+		}
+		meta.SetStatusCondition(&m.Status.Conditions, condition)
+	}
+	return result
+}
+
+// #############################################################################
+//
+//	Register the REST endpoints of the load balancer with an OCI API Gateway
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) manageApiGateway(m *dbapi.OracleRestDataService, ctx context.Context,
+	req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("manageApiGateway", req.NamespacedName)
+	eventReason := "API Gateway"
+
+	if m.Spec.Oci == nil || m.Spec.Oci.ApiGateway == nil {
+		// Removed from the spec
+		if err := r.deleteApiGatewayDeployment(m, ctx); err != nil {
+			log.Error(err, err.Error())
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, "failed to delete the deployment: "+err.Error())
+			return requeueY
+		}
+		return requeueN
+	}
+	gateway := m.Spec.Oci.ApiGateway
+
+	if m.Status.ServiceIP == "" {
+		log.Info("Waiting for the address of the load balancer")
+		return requeueY
+	}
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Name: m.Name, Namespace: m.Namespace}, svc); err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	backend := "https://" + dbcommons.GetExternalHost(m.Spec.Hostname, m.Status.ServiceIP) + ":" +
+		fmt.Sprint(svc.Spec.Ports[0].Port) + getOrdsContextPath(m)
+
+	apiGatewayService, err := r.getApiGatewayService(m)
+	if err != nil {
+		log.Error(err, err.Error())
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, "failed to authenticate with OCI: "+err.Error())
+		return requeueY
+	}
+
+	if m.Status.ApiGatewayDeploymentId == "" {
+		pathPrefix := gateway.PathPrefix
+		if pathPrefix == "" {
+			pathPrefix = "/" + m.Name
+		}
+		displayName := m.Namespace + "-" + m.Name
+		owner := m.Namespace + "/" + m.Name
+		existing, err := apiGatewayService.FindDeployment(gateway.GatewayId, gateway.CompartmentId, displayName, owner)
+		if err != nil {
+			log.Error(err, err.Error())
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, "failed to list the deployments: "+err.Error())
+			return requeueY
+		}
+		if existing != nil {
+			// Created before its OCID could be recorded, the backend is updated below if it changed
+			m.Status.ApiGatewayDeploymentId = *existing.Id
+			r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, "found deployment "+*existing.Id)
+		} else {
+			deployment, err := apiGatewayService.CreateDeployment(gateway.GatewayId, gateway.CompartmentId, pathPrefix,
+				displayName, owner, backend)
+			if err != nil {
+				log.Error(err, err.Error())
+				r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, "failed to create the deployment: "+err.Error())
+				return requeueY
+			}
+			m.Status.ApiGatewayDeploymentId = *deployment.Id
+			m.Status.ApiGatewayBackend = backend
+			r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason,
+				"creating deployment "+*deployment.Id+" routing "+pathPrefix+" to "+backend)
+		}
+		// Recorded right away, so that the deployment is not created twice
+		if err := k8s.PatchStatus(ctx, r.Client, m); err != nil {
+			log.Error(err, err.Error())
+		}
+	}
+	if m.Status.ApiGatewayBackend != backend {
+		// The address of the load balancer changed
+		if err := apiGatewayService.UpdateDeploymentBackend(m.Status.ApiGatewayDeploymentId, backend); err != nil {
+			log.Error(err, err.Error())
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, "failed to update the deployment: "+err.Error())
+			return requeueY
+		}
+		m.Status.ApiGatewayBackend = backend
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason,
+			"updating deployment "+m.Status.ApiGatewayDeploymentId+" to route to "+backend)
+	}
+
+	deployment, err := apiGatewayService.GetDeployment(m.Status.ApiGatewayDeploymentId)
+	if err != nil {
+		log.Error(err, err.Error())
+		if oci.IsNotFoundError(err) {
+			m.Status.ApiGatewayDeploymentId = ""
+		}
+		return requeueY
+	}
+	switch deployment.LifecycleState {
+	case apigateway.DeploymentLifecycleStateActive:
+		if m.Status.ApiGatewayUrl != *deployment.Endpoint {
+			m.Status.ApiGatewayUrl = *deployment.Endpoint
+			r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, "REST endpoints published at "+m.Status.ApiGatewayUrl)
+		}
+		return requeueN
+	case apigateway.DeploymentLifecycleStateDeleted:
+		// Deleted outside of the operator, created again
+		m.Status.ApiGatewayDeploymentId = ""
+		m.Status.ApiGatewayUrl = ""
+		return requeueY
+	case apigateway.DeploymentLifecycleStateFailed:
+		details := ""
+		if deployment.LifecycleDetails != nil {
+			details = *deployment.LifecycleDetails
+		}
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason,
+			"deployment "+m.Status.ApiGatewayDeploymentId+" failed: "+details)
+		return requeueY
+	}
+	log.Info("Waiting for the deployment", "state", deployment.LifecycleState)
+	return requeueY
+}
+
+// Deletes the deployment of the REST endpoints from the OCI API Gateway
+func (r *OracleRestDataServiceReconciler) deleteApiGatewayDeployment(m *dbapi.OracleRestDataService, ctx context.Context) error {
+	if m.Status.ApiGatewayDeploymentId == "" {
+		return nil
+	}
+	apiGatewayService, err := r.getApiGatewayService(m)
+	if err != nil {
+		return err
+	}
+	if err := apiGatewayService.DeleteDeployment(m.Status.ApiGatewayDeploymentId); err != nil && !oci.IsNotFoundError(err) {
+		return err
+	}
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, "API Gateway", "deleted deployment "+m.Status.ApiGatewayDeploymentId)
+	m.Status.ApiGatewayDeploymentId = ""
+	m.Status.ApiGatewayBackend = ""
+	m.Status.ApiGatewayUrl = ""
+	return k8s.PatchStatus(ctx, r.Client, m)
+}
+
+// Returns the client of the OCI API Gateway, authenticated with the ociConfig of ORDS
+func (r *OracleRestDataServiceReconciler) getApiGatewayService(m *dbapi.OracleRestDataService) (oci.ApiGatewayService, error) {
+	authData := oci.APIKeyAuth{Namespace: m.Namespace}
+	if m.Spec.Oci != nil {
+		authData.ConfigMapName = m.Spec.Oci.OCIConfig.ConfigMapName
+		authData.SecretName = m.Spec.Oci.OCIConfig.SecretName
+		authData.AuthMode = m.Spec.Oci.OCIConfig.AuthMode
+	}
+	provider, err := oci.GetOCIProvider(r.Client, authData)
+	if err != nil {
+		return nil, err
+	}
+	return oci.NewApiGatewayService(r.Log, provider)
 }
 
 // #############################################################################
@@ -252,46 +627,9 @@ func (r *OracleRestDataServiceReconciler) validate(m *dbapi.OracleRestDataServic
 	if m.Spec.Persistence.Size == "" && n.Spec.Persistence.AccessMode == "" {
 		eventMsgs = append(eventMsgs, "cannot configure ORDS for database "+m.Spec.DatabaseRef+" that has no attached persistent volume")
 	}
-	if !m.Status.OrdsInstalled && n.Status.OrdsReference != "" {
-		eventMsgs = append(eventMsgs, "database "+m.Spec.DatabaseRef+" is already configured with ORDS "+n.Status.OrdsReference)
-	}
 	if m.Status.DatabaseRef != "" && m.Status.DatabaseRef != m.Spec.DatabaseRef {
 		eventMsgs = append(eventMsgs, "databaseRef cannot be updated")
 	}
-	if m.Status.Image.PullFrom != "" && m.Status.Image != m.Spec.Image {
-		eventMsgs = append(eventMsgs, "image patching is not available currently")
-	}
-
-	// Validate the apex ADMIN password if it is specified
-
-	if !m.Status.ApexConfigured && m.Spec.ApexPassword.SecretName != "" {
-		apexPasswordSecret := &corev1.Secret{}
-		err = r.Get(ctx, types.NamespacedName{Name: m.Spec.ApexPassword.SecretName, Namespace: m.Namespace}, apexPasswordSecret)
-		if err != nil {
-			if apierrors.IsNotFound(err) {
-				m.Status.Status = dbcommons.StatusError
-				eventReason := "Apex Password"
-				eventMsg := "password secret " + m.Spec.ApexPassword.SecretName + " not found, retrying..."
-				r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
-				r.Log.Info(eventMsg)
-				return requeueY, nil
-			}
-			r.Log.Error(err, err.Error())
-			return requeueY, err
-		}
-		// APEX_LISTENER , APEX_REST_PUBLIC_USER , APEX_PUBLIC_USER passwords
-		apexPassword := string(apexPasswordSecret.Data[m.Spec.ApexPassword.SecretKey])
-
-		// Validate apexPassword
-		if !dbcommons.ApexPasswordValidator(apexPassword) {
-			m.Status.Status = dbcommons.StatusError
-			eventReason := "Apex Password"
-			eventMsg := "password for Apex is invalid, it should contain at least 6 chars, at least one numeric character, at least one punctuation character (!\"#$%&()``*+,-/:;?_), at least one upper-case alphabet"
-			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
-			r.Log.Info("APEX password does not conform to the requirements")
-			return requeueY, nil
-		}
-	}
 
 	if len(eventMsgs) > 0 {
 		m.Status.Status = dbcommons.StatusError
@@ -338,6 +676,11 @@ func (r *OracleRestDataServiceReconciler) validateSIDBReadiness(m *dbapi.OracleR
 		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
 	}
 
+	// The database is ready once its CDB is open, ORDS is installed once its PDB is open too
+	if result := r.waitForPdb(m, n, sidbReadyPod, ctx, req); result.Requeue {
+		return result, sidbReadyPod
+	}
+
 	// Validate databaseRef Admin Password
 	adminPasswordSecret := &corev1.Secret{}
 	err = r.Get(ctx, types.NamespacedName{Name: m.Spec.AdminPassword.SecretName, Namespace: m.Namespace}, adminPasswordSecret)
@@ -355,7 +698,7 @@ func (r *OracleRestDataServiceReconciler) validateSIDBReadiness(m *dbapi.OracleR
 	adminPassword := string(adminPasswordSecret.Data[m.Spec.AdminPassword.SecretKey])
 
 	out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
-		fmt.Sprintf("echo -e  \"%s\"  | %s", fmt.Sprintf(dbcommons.ValidateAdminPassword, adminPassword), dbcommons.SQLPlusCLI))
+		dbcommons.ValidateAdminPasswordCommand(adminPassword, dbcommons.SQLPlusCLI, IsPasswordStoreCurrent(n, adminPasswordSecret)))
 	if err != nil {
 		log.Error(err, err.Error())
 		return requeueY, sidbReadyPod
@@ -375,9 +718,29 @@ func (r *OracleRestDataServiceReconciler) validateSIDBReadiness(m *dbapi.OracleR
 		return requeueY, sidbReadyPod
 	}
 
+	if m.Spec.InstallScope == dbcommons.OrdsInstallScopePDB {
+		// No common users are used by ORDS installed in a PDB
+		return requeueN, sidbReadyPod
+	}
+
+	// Common users may be shared with other ORDS or tools, only the ones created here are dropped on deletion
+	out, err = dbcommons.ExecSQL(r, r.Config, sidbReadyPod, ctx, req, false, dbcommons.GetOrdsCommonUsersSQL)
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY, sidbReadyPod
+	}
+	existingUsers := []string{}
+	if !strings.Contains(out, "no rows selected") {
+		existingUsers, err = dbcommons.ParseColumnValues(out)
+		if err != nil {
+			log.Info("Unable to list the existing common users: " + out)
+			return requeueY, sidbReadyPod
+		}
+	}
+
 	// Create PDB , CDB Admin users and grant permissions. ORDS installation on CDB level
 	out, err = dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
-		fmt.Sprintf("echo -e  \"%s\"  | %s", fmt.Sprintf(dbcommons.SetAdminUsersSQL, adminPassword), dbcommons.SQLPlusCLI))
+		dbcommons.SQLPlusCommand(dbcommons.SetAdminUsers(adminPassword, n.Status.ReleaseUpdate)))
 	if err != nil {
 		log.Error(err, err.Error())
 		return requeueY, sidbReadyPod
@@ -385,10 +748,81 @@ func (r *OracleRestDataServiceReconciler) validateSIDBReadiness(m *dbapi.OracleR
 	if !strings.Contains(out, "ERROR") || !strings.Contains(out, "ORA-") ||
 		strings.Contains(out, "ERROR") && strings.Contains(out, "ORA-01920") {
 		m.Status.CommonUsersCreated = true
+		m.Status.CreatedUsers = nil
+		for _, user := range []string{dbcommons.OrdsCdbAdminUser, dbcommons.OrdsPdbAdminUser} {
+			existing := false
+			for _, existingUser := range existingUsers {
+				existing = existing || existingUser == user
+			}
+			if !existing {
+				m.Status.CreatedUsers = append(m.Status.CreatedUsers, user)
+			}
+		}
 	}
 	return requeueN, sidbReadyPod
 }
 
+// #############################################################################
+//
+//	Wait for the PDB of the database to open read write before installing ORDS
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) waitForPdb(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("waitForPdb", req.NamespacedName)
+
+	if n.Status.Pdbname == "" {
+		return requeueN
+	}
+	out, err := dbcommons.ExecSQL(r, r.Config, sidbReadyPod, ctx, req, false, dbcommons.GetPdbOpenMode(n.Status.Pdbname))
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	if strings.Contains(out, "OPEN_MODE:READ WRITE") {
+		if meta.IsStatusConditionTrue(m.Status.Conditions, dbcommons.WaitingForPDBCondition) {
+			meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+				Type:               dbcommons.WaitingForPDBCondition,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: m.GetGeneration(),
+				Reason:             dbcommons.PdbOpenReason,
+				Message:            "PDB " + n.Status.Pdbname + " is open",
+			})
+		}
+		return requeueN
+	}
+
+	openMode := "not found"
+	if idx := strings.Index(out, "OPEN_MODE:"); idx != -1 {
+		openMode = strings.TrimSpace(strings.SplitN(out[idx+len("OPEN_MODE:"):], "\n", 2)[0])
+	}
+	condition := meta.FindStatusCondition(m.Status.Conditions, dbcommons.WaitingForPDBCondition)
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+			Type:               dbcommons.WaitingForPDBCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: m.GetGeneration(),
+			Reason:             dbcommons.PdbNotOpenReason,
+			Message:            "PDB " + n.Status.Pdbname + " is " + openMode + ", waiting for it to open read write",
+		})
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, "PDB Check", "waiting for PDB %s to open read write before installing ORDS",
+			n.Status.Pdbname)
+		return requeueY
+	}
+	if condition.Reason == dbcommons.PdbNotOpenReason &&
+		time.Since(condition.LastTransitionTime.Time) > time.Duration(dbcommons.OrdsPdbOpenTimeout)*time.Second {
+		// The condition keeps its transition time, only its reason changes
+		condition.Reason = dbcommons.PdbOpenTimedOutReason
+		condition.Message = fmt.Sprintf("PDB %s is %s after %d seconds, still waiting for it to open read write",
+			n.Status.Pdbname, openMode, dbcommons.OrdsPdbOpenTimeout)
+		m.Status.Status = dbcommons.StatusError
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, "PDB Check", condition.Message)
+	} else if condition.Reason == dbcommons.PdbOpenTimedOutReason {
+		m.Status.Status = dbcommons.StatusError
+	}
+	return requeueY
+}
+
 // #####################################################################################################
 //
 //	Check ORDS Health Status
@@ -398,8 +832,8 @@ func (r *OracleRestDataServiceReconciler) checkHealthStatus(m *dbapi.OracleRestD
 	sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) (ctrl.Result, corev1.Pod) {
 	log := r.Log.WithValues("checkHealthStatus", req.NamespacedName)
 
-	readyPod, _, _, _, err := dbcommons.FindPods(r, m.Spec.Image.Version,
-		m.Spec.Image.PullFrom, m.Name, m.Namespace, ctx, req)
+	readyPod, _, _, _, err := dbcommons.FindPods(r, ordsPodImage(m).Version,
+		ordsPodImage(m).PullFrom, m.Name, m.Namespace, ctx, req)
 	if err != nil {
 		log.Error(err, err.Error())
 		return requeueY, readyPod
@@ -410,31 +844,50 @@ func (r *OracleRestDataServiceReconciler) checkHealthStatus(m *dbapi.OracleRestD
 	}
 
 	// Get ORDS Status
-	out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
-		dbcommons.GetORDSStatus)
-	log.Info("GetORDSStatus Output")
-	log.Info(out)
-	if strings.Contains(strings.ToUpper(out), "ERROR") {
-		return requeueY, readyPod
-	}
-	if err != nil {
-		log.Info(err.Error())
-		if strings.Contains(strings.ToUpper(err.Error()), "ERROR") {
+	healthy := false
+	if ordsHealthCheck(m) == dbcommons.OrdsHealthCheckHTTP {
+		// The ready pod passed the readiness probe
+		healthy = true
+	} else {
+		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+			withContextPath(m, dbcommons.GetORDSStatus))
+		log.Info("GetORDSStatus Output")
+		log.Info(out)
+		if strings.Contains(strings.ToUpper(out), "ERROR") {
 			return requeueY, readyPod
 		}
+		if err != nil {
+			log.Info(err.Error())
+			if strings.Contains(strings.ToUpper(err.Error()), "ERROR") {
+				return requeueY, readyPod
+			}
+		}
+		healthy = strings.Contains(out, "HTTP/1.1 200 OK") || (err != nil && strings.Contains(strings.ToUpper(err.Error()), "HTTP/1.1 200 OK"))
 	}
 
+	// ORDS may answer over HTTP while its pool cannot run queries on the database
+	r.checkDatabaseConnectivity(m, readyPod, ctx, req)
+
 	m.Status.Status = dbcommons.StatusNotReady
-	if strings.Contains(out, "HTTP/1.1 200 OK") || strings.Contains(strings.ToUpper(err.Error()), "HTTP/1.1 200 OK") {
+	if healthy {
 		if n.Status.Status == dbcommons.StatusReady || n.Status.Status == dbcommons.StatusUpdating || n.Status.Status == dbcommons.StatusPatching {
 			m.Status.Status = dbcommons.StatusReady
 		}
 		if !m.Status.OrdsInstalled {
+			r.validateOrdsImageOwnership(m, readyPod, ctx, req)
 			m.Status.OrdsInstalled = true
-			n.Status.OrdsReference = m.Name
-			r.Status().Update(ctx, n)
+			// The first ORDS installed owns the ORDS repository
+			if n.GetOwnerConsumer(dbapi.OracleRestDataServiceKind) == nil {
+				consumers := n.GetConsumers(dbapi.OracleRestDataServiceKind)
+				for i := range consumers {
+					consumers[i].Owner = consumers[i].Name == m.Name
+				}
+				n.SetConsumers(dbapi.OracleRestDataServiceKind, consumers)
+			}
+			k8s.PatchStatus(ctx, r.Client, n)
 			eventReason := "ORDS Installation"
-			eventMsg := "installation of ORDS completed"
+			eventMsg := "installation of ORDS completed, logs saved in " + withConfigDir(m, dbcommons.OrdsInstallLogDir) +
+				" of the init-ords container"
 			r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
 			out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "",
 				ctx, req, false, "bash", "-c", fmt.Sprintf("echo -e  \"%s\"  | %s", dbcommons.OpenPDBSeed, dbcommons.SQLPlusCLI))
@@ -454,54 +907,446 @@ func (r *OracleRestDataServiceReconciler) checkHealthStatus(m *dbapi.OracleRestD
 
 // #############################################################################
 //
-//	Instantiate Service spec from OracleRestDataService spec
+//	Check that the pool of the ready ORDS pod queries the database
 //
 // #############################################################################
-func (r *OracleRestDataServiceReconciler) instantiateSVCSpec(m *dbapi.OracleRestDataService) *corev1.Service {
-	svc := &corev1.Service{
-		TypeMeta: metav1.TypeMeta{
-			Kind: "Service",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      m.Name,
-			Namespace: m.Namespace,
-			Labels: map[string]string{
-				"app": m.Name,
-			},
-			Annotations: func() map[string]string {
-				annotations := make(map[string]string)
-				if len(m.Spec.ServiceAnnotations) != 0 {
-					for key, value := range m.Spec.ServiceAnnotations {
-						annotations[key] = value
-					}
-				}
-				return annotations
-			}(),
-		},
-		Spec: corev1.ServiceSpec{
-			Ports: []corev1.ServicePort{
-				{
-					Name:     "client",
-					Port:     8443,
-					Protocol: corev1.ProtocolTCP,
-				},
-			},
-			Selector: map[string]string{
-				"app": m.Name,
-			},
-			Type: corev1.ServiceType(func() string {
-				if m.Spec.LoadBalancer {
-					return "LoadBalancer"
-				}
-				return "NodePort"
-			}()),
-		},
+func (r *OracleRestDataServiceReconciler) checkDatabaseConnectivity(m *dbapi.OracleRestDataService, readyPod corev1.Pod,
+	ctx context.Context, req ctrl.Request) {
+	log := r.Log.WithValues("checkDatabaseConnectivity", req.NamespacedName)
+
+	condition := metav1.Condition{
+		Type:               dbcommons.DatabaseConnectivityCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: m.GetGeneration(),
 	}
-	// Set StandbyDatabase instance as the owner and controller
-	ctrl.SetControllerReference(m, svc, r.Scheme)
-	return svc
-}
-
+	// The metadata catalog is queried from the database through the pool of the pod, without credentials. REST-enabled SQL
+	// on /ords/_/sql only authenticates the ORDS users with the SQL Administrator role, not the database users of the pool
+	out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		fmt.Sprintf(dbcommons.GetORDSPathBody, withContextPath(m, dbcommons.OrdsPoolValidationPath)))
+	if err == nil && strings.Contains(out, "\"items\"") && !strings.Contains(out, "\"code\"") {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = dbcommons.QuerySucceededReason
+		condition.Message = "the ORDS pool of pod " + readyPod.Name + " queried the metadata catalog of the database"
+	} else {
+		if err != nil {
+			log.Error(err, err.Error())
+		}
+		log.Info("Metadata catalog output: \n" + out)
+		condition.Reason = dbcommons.QueryFailedReason
+		condition.Message = "the ORDS pool of pod " + readyPod.Name + " failed to query the metadata catalog of the database"
+	}
+
+	if current := meta.FindStatusCondition(m.Status.Conditions, dbcommons.DatabaseConnectivityCondition); current == nil ||
+		current.Status != condition.Status {
+		eventType := corev1.EventTypeNormal
+		if condition.Status != metav1.ConditionTrue {
+			eventType = corev1.EventTypeWarning
+		}
+		r.Recorder.Eventf(m, eventType, "Database Connectivity", condition.Message)
+	}
+	meta.SetStatusCondition(&m.Status.Conditions, condition)
+}
+
+// #############################################################################
+//
+//	Report the downloads skipped or redirected to a mirror in offline mode
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) updateOfflineCondition(m *dbapi.OracleRestDataService) {
+	if !dbcommons.IsOfflineMode() {
+		meta.RemoveStatusCondition(&m.Status.Conditions, dbcommons.OfflineCondition)
+		return
+	}
+
+	var skipped, mirrored []string
+	for _, a := range specArtifacts(m) {
+		if a.source.URL == "" {
+			continue
+		}
+		if url, reachable := dbcommons.ResolveExternalLocation(a.source.URL); reachable {
+			mirrored = append(mirrored, "the "+a.name+" distribution is downloaded from "+url)
+		} else {
+			skipped = append(skipped, "the download of the "+a.name+" distribution from "+a.source.URL)
+		}
+	}
+	condition := metav1.Condition{
+		Type:               dbcommons.OfflineCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             dbcommons.ExternalStepsMirroredReason,
+		Message:            "no step reaches out to the internet",
+		ObservedGeneration: m.GetGeneration(),
+	}
+	if len(mirrored) != 0 {
+		condition.Message = strings.Join(mirrored, ", ")
+	}
+	if len(skipped) != 0 {
+		condition.Reason = dbcommons.ExternalStepsSkippedReason
+		condition.Message = "skipped " + strings.Join(skipped, ", ") + " with no mirror, the image provides the distribution"
+	}
+
+	if current := meta.FindStatusCondition(m.Status.Conditions, dbcommons.OfflineCondition); condition.Reason ==
+		dbcommons.ExternalStepsSkippedReason && (current == nil || current.Message != condition.Message) {
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, "Offline Mode", condition.Message)
+	}
+	meta.SetStatusCondition(&m.Status.Conditions, condition)
+}
+
+// #############################################################################
+//
+//	Record the versions of ORDS, APEX and the database in the status
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) updateComponentVersions(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ordsReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) {
+	log := r.Log.WithValues("updateComponentVersions", req.NamespacedName)
+
+	if n.Status.ReleaseUpdate != dbcommons.ValueUnavailable {
+		m.Status.DatabaseVersion = n.Status.ReleaseUpdate
+	}
+	if n.Status.Edition != dbcommons.ValueUnavailable {
+		m.Status.DatabaseEdition = n.Status.Edition
+	}
+	if !n.Status.ApexInstalled {
+		m.Status.ApexVersion = ""
+	}
+	if ordsReadyPod.Name == "" {
+		return
+	}
+
+	// The version of ORDS is read once per pod, the pods being replaced when the image or the ORDS distribution changes
+	ordsVersion, ok := ordsReadyPod.Annotations[dbcommons.OrdsVersionAnnotation]
+	if !ok {
+		out, err := dbcommons.ExecCommand(r, r.Config, ordsReadyPod.Name, ordsReadyPod.Namespace, "", ctx, req, false,
+			"bash", "-c", dbcommons.GetOrdsVersionCMD)
+		if err != nil {
+			log.Error(err, err.Error())
+			return
+		}
+		if ordsVersion = dbcommons.ParseOrdsVersion(out); ordsVersion == "" {
+			log.Info("Failed to read the version of ORDS: " + out)
+			return
+		}
+		patch := client.MergeFrom(ordsReadyPod.DeepCopy())
+		if ordsReadyPod.Annotations == nil {
+			ordsReadyPod.Annotations = make(map[string]string)
+		}
+		ordsReadyPod.Annotations[dbcommons.OrdsVersionAnnotation] = ordsVersion
+		if err := r.Patch(ctx, &ordsReadyPod, patch); err != nil {
+			log.Error(err, err.Error())
+		}
+		// A new pod may come with another APEX distribution
+		m.Status.ApexVersion = ""
+	}
+	m.Status.OrdsVersion = ordsVersion
+
+	if m.Status.ApexVersion == "" && n.Status.ApexInstalled && sidbReadyPod.Name != "" {
+		out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, false, "bash", "-c",
+			fmt.Sprintf("echo -e \"%s\" | %s", fmt.Sprintf(dbcommons.GetApexVersionSQL, n.Status.Pdbname), dbcommons.SQLPlusCLI))
+		if err != nil {
+			log.Error(err, err.Error())
+			return
+		}
+		m.Status.ApexVersion = dbcommons.ParseApexVersion(out)
+	}
+}
+
+// #############################################################################
+//
+//	Record the replicas whose pools fit in the processes of the database
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) updateMaxReplicas(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) {
+	log := r.Log.WithValues("updateMaxReplicas", req.NamespacedName)
+
+	if sidbReadyPod.Name == "" {
+		return
+	}
+	out, err := dbcommons.ExecSQL(r, r.Config, sidbReadyPod, ctx, req, false, dbcommons.GetProcessLimitsSQL)
+	if err != nil {
+		log.Error(err, err.Error())
+		return
+	}
+	processes, background, err := dbcommons.ParseProcessLimits(out)
+	if err != nil {
+		log.Info(err.Error())
+		return
+	}
+	maxLimit := ordsJdbcMaxLimit(m)
+	pools := ordsPoolsPerPod(m)
+	maxReplicas := dbcommons.OrdsMaxReplicas(processes, background, pools*maxLimit)
+	if maxReplicas != m.Status.MaxReplicas && m.Spec.Replicas > maxReplicas {
+		eventReason := "Replicas"
+		eventMsg := fmt.Sprintf("%d replicas with %d pools of %d connections exceed the %d processes of database %s, %d replicas fit",
+			m.Spec.Replicas, pools, maxLimit, processes, n.Name, maxReplicas)
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		log.Info(eventMsg)
+	}
+	m.Status.MaxReplicas = maxReplicas
+}
+
+// Pools of each ORDS pod, the default pool and the pool of each PDB of the virtual hosts
+func ordsPoolsPerPod(m *dbapi.OracleRestDataService) int {
+	pools := map[string]bool{}
+	for _, virtualHost := range m.Spec.VirtualHosts {
+		pools[dbcommons.OrdsVirtualHostPool(virtualHost.PdbName)] = true
+	}
+	return 1 + len(pools)
+}
+
+// Connections of the pool of each ORDS pod, the jdbc.MaxLimit of the settings or else of the ORDS setup
+func ordsJdbcMaxLimit(m *dbapi.OracleRestDataService) int {
+	if m.Spec.Settings != nil {
+		if maxLimit, err := strconv.Atoi(m.Spec.Settings.Properties["jdbc.MaxLimit"]); err == nil && maxLimit > 0 {
+			return maxLimit
+		}
+	}
+	return dbcommons.OrdsJdbcMaxLimit
+}
+
+// #############################################################################
+//
+//	Set the readiness gate of the ORDS pods from the validation of their pool
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) manageReadinessGates(m *dbapi.OracleRestDataService,
+	ctx context.Context, req ctrl.Request) bool {
+	log := r.Log.WithValues("manageReadinessGates", req.NamespacedName)
+
+	readyPod, _, available, _, err := dbcommons.FindPods(r, "", "", m.Name, m.Namespace, ctx, req)
+	if err != nil {
+		log.Error(err, err.Error())
+		return false
+	}
+	if readyPod.Name != "" {
+		available = append(available, readyPod)
+	}
+
+	validated := true
+	for i := range available {
+		pod := &available[i]
+		if !hasOrdsReadinessGate(pod) {
+			// Pods created before the readiness gate
+			continue
+		}
+		status := corev1.ConditionFalse
+		reason := dbcommons.PoolNotValidatedReason
+		message := "the ORDS pool has not connected to the database yet"
+		if pod.Status.Phase == corev1.PodRunning && ordsHealthCheck(m) == dbcommons.OrdsHealthCheckHTTP {
+			// The readiness probe requests the same path
+			for _, containerStatus := range pod.Status.ContainerStatuses {
+				if containerStatus.Name == m.Name && containerStatus.Ready {
+					status = corev1.ConditionTrue
+					reason = dbcommons.PoolValidatedReason
+					message = "the ORDS pool is connected to the database"
+				}
+			}
+		} else if pod.Status.Phase == corev1.PodRunning {
+			out, err := dbcommons.ExecCommand(r, r.Config, pod.Name, pod.Namespace, "", ctx, req, false, "bash", "-c",
+				fmt.Sprintf(dbcommons.GetORDSPathStatus, withContextPath(m, dbcommons.OrdsPoolValidationPath)))
+			if err == nil && strings.TrimSpace(out) == "200" {
+				status = corev1.ConditionTrue
+				reason = dbcommons.PoolValidatedReason
+				message = "the ORDS pool is connected to the database"
+			}
+		}
+		// The pool of a pod is warmed up before the pod is admitted to the service
+		if status == corev1.ConditionTrue && m.Spec.WarmUp != nil && m.Spec.WarmUp.Requests > 0 && !isOrdsPoolReady(pod) &&
+			!r.warmUpPod(m, pod, ctx, req) {
+			status = corev1.ConditionFalse
+			reason = dbcommons.PoolWarmingUpReason
+			message = "the warm-up requests of the ORDS pool have not all succeeded yet"
+		}
+		if status != corev1.ConditionTrue {
+			validated = false
+		}
+		if err := r.setPodCondition(pod, status, reason, message, ctx); err != nil {
+			log.Error(err, err.Error())
+			validated = false
+		}
+	}
+	return validated
+}
+
+func hasOrdsReadinessGate(pod *corev1.Pod) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if string(gate.ConditionType) == dbcommons.OrdsPoolReadyCondition {
+			return true
+		}
+	}
+	return false
+}
+
+func isOrdsPoolReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if string(condition.Type) == dbcommons.OrdsPoolReadyCondition {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Send the warm-up requests to the pod, and return whether they all succeeded
+func (r *OracleRestDataServiceReconciler) warmUpPod(m *dbapi.OracleRestDataService, pod *corev1.Pod,
+	ctx context.Context, req ctrl.Request) bool {
+
+	paths := m.Spec.WarmUp.Paths
+	if len(paths) == 0 {
+		paths = []string{withContextPath(m, dbcommons.OrdsPoolValidationPath)}
+	}
+	// As many requests at a time as connections in the pool, jdbc.InitialLimit being 5 in the ORDS setup
+	concurrency := 5
+	if m.Spec.WarmUp.InitialPoolSize > 0 {
+		concurrency = m.Spec.WarmUp.InitialPoolSize
+	}
+	// The paths are passed as the arguments of the script, after the name of the script
+	command := append([]string{"bash", "-c", fmt.Sprintf(dbcommons.WarmUpORDSCMD, m.Spec.WarmUp.Requests, concurrency),
+		"warm-up"}, paths...)
+	out, err := dbcommons.ExecCommand(r, r.Config, pod.Name, pod.Namespace, "", ctx, req, false, command...)
+	succeeded, _ := strconv.Atoi(strings.TrimSpace(out))
+	r.Log.Info("Warm-up of the ORDS pool", "pod", pod.Name, "succeeded", succeeded, "requests", m.Spec.WarmUp.Requests*len(paths))
+	return err == nil && succeeded == m.Spec.WarmUp.Requests*len(paths)
+}
+
+// Patch the readiness gate condition of the pod if its status changed
+func (r *OracleRestDataServiceReconciler) setPodCondition(pod *corev1.Pod, status corev1.ConditionStatus,
+	reason string, message string, ctx context.Context) error {
+
+	conditionType := corev1.PodConditionType(dbcommons.OrdsPoolReadyCondition)
+	index := -1
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			if pod.Status.Conditions[i].Status == status {
+				return nil
+			}
+			index = i
+		}
+	}
+
+	original := pod.DeepCopy()
+	condition := corev1.PodCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	if index < 0 {
+		pod.Status.Conditions = append(pod.Status.Conditions, condition)
+	} else {
+		pod.Status.Conditions[index] = condition
+	}
+	r.Log.Info("Setting the readiness gate of the pod", "pod", pod.Name, "status", status)
+	return r.Status().Patch(ctx, pod, client.StrategicMergeFrom(original))
+}
+
+// #############################################################################
+//
+//	Warn if the ORDS image files are owned by a different user than the pods run as
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) validateOrdsImageOwnership(m *dbapi.OracleRestDataService,
+	readyPod corev1.Pod, ctx context.Context, req ctrl.Request) {
+
+	runAsUser, _, _ := getOrdsPodSecurityIds(m)
+	out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		dbcommons.GetORDSHomeOwnerCMD)
+	if err != nil {
+		r.Log.Info("Unable to determine ORDS image ownership", "error", err.Error())
+		return
+	}
+	owner, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return
+	}
+	if owner != runAsUser {
+		eventReason := "Pod Security Context"
+		eventMsg := fmt.Sprintf("ORDS image files are owned by UID %d, but the pods run as UID %d", owner, runAsUser)
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		r.Log.Info(eventMsg)
+	}
+}
+
+// #############################################################################
+//
+//	Instantiate Service spec from OracleRestDataService spec
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) instantiateSVCSpec(m *dbapi.OracleRestDataService) *corev1.Service {
+	svc := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.Name,
+			Namespace: m.Namespace,
+			Labels: map[string]string{
+				"app": m.Name,
+			},
+			Annotations: func() map[string]string {
+				annotations := make(map[string]string)
+				if len(m.Spec.ServiceAnnotations) != 0 {
+					for key, value := range m.Spec.ServiceAnnotations {
+						annotations[key] = value
+					}
+				}
+				return annotations
+			}(),
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:        dbcommons.OrdsPortName,
+					Port:        8443,
+					Protocol:    corev1.ProtocolTCP,
+					AppProtocol: func() *string { p := dbcommons.OrdsPortName; return &p }(),
+				},
+				{
+					Name:        dbcommons.OrdsMongoPortName,
+					Port:        dbcommons.OrdsMongoPort,
+					Protocol:    corev1.ProtocolTCP,
+					AppProtocol: func() *string { p := dbcommons.OrdsMongoPortName; return &p }(),
+				},
+			},
+			Selector: map[string]string{
+				"app": m.Name,
+			},
+			Type: corev1.ServiceType(func() string {
+				if m.Spec.LoadBalancer {
+					return "LoadBalancer"
+				}
+				return "NodePort"
+			}()),
+		},
+	}
+	// Set StandbyDatabase instance as the owner and controller
+	ctrl.SetControllerReference(m, svc, r.Scheme)
+	return svc
+}
+
+// #############################################################################
+//
+//	Returns the UID, GID and fsGroup of the ORDS pods, defaulting to the oracle user
+//	and dba group of the ORDS image
+//
+// #############################################################################
+func getOrdsPodSecurityIds(m *dbapi.OracleRestDataService) (int64, int64, int64) {
+	runAsUser, runAsGroup, fsGroup := dbcommons.ORACLE_UID, dbcommons.DBA_GUID, dbcommons.DBA_GUID
+	if m.Spec.PodSecurityContext != nil {
+		if m.Spec.PodSecurityContext.RunAsUser != nil {
+			runAsUser = *m.Spec.PodSecurityContext.RunAsUser
+		}
+		if m.Spec.PodSecurityContext.RunAsGroup != nil {
+			runAsGroup = *m.Spec.PodSecurityContext.RunAsGroup
+			fsGroup = runAsGroup
+		}
+		if m.Spec.PodSecurityContext.FSGroup != nil {
+			fsGroup = *m.Spec.PodSecurityContext.FSGroup
+		}
+	}
+	return runAsUser, runAsGroup, fsGroup
+}
+
 // #############################################################################
 //
 //	Instantiate POD spec from OracleRestDataService spec
@@ -510,6 +1355,8 @@ func (r *OracleRestDataServiceReconciler) instantiateSVCSpec(m *dbapi.OracleRest
 func (r *OracleRestDataServiceReconciler) instantiatePodSpec(m *dbapi.OracleRestDataService,
 	n *dbapi.SingleInstanceDatabase) (*corev1.Pod, *corev1.Secret) {
 
+	runAsUser, runAsGroup, fsGroup := getOrdsPodSecurityIds(m)
+
 	initSecret := &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			Kind: "Secret",
@@ -523,7 +1370,12 @@ func (r *OracleRestDataServiceReconciler) instantiatePodSpec(m *dbapi.OracleRest
 		},
 		Type: corev1.SecretTypeOpaque,
 		StringData: map[string]string{
-			"init-cmd": dbcommons.InitORDSCMD,
+			"init-cmd": func() string {
+				if m.Spec.InstallScope == dbcommons.OrdsInstallScopePDB {
+					return withConfigDir(m, dbcommons.InitORDSPdbCMD)
+				}
+				return withConfigDir(m, dbcommons.InitORDSCMD)
+			}(),
 		},
 	}
 
@@ -536,8 +1388,14 @@ func (r *OracleRestDataServiceReconciler) instantiatePodSpec(m *dbapi.OracleRest
 			Namespace: m.Namespace,
 			Labels: map[string]string{
 				"app":     m.Name,
-				"version": m.Spec.Image.Version,
+				"version": ordsPodImage(m).Version,
 			},
+			Annotations: func() map[string]string {
+				if ordsEnvHash(m, n) == "" {
+					return nil
+				}
+				return map[string]string{dbcommons.EnvHashAnnotation: ordsEnvHash(m, n)}
+			}(),
 		},
 		Spec: corev1.PodSpec{
 			Affinity: func() *corev1.Affinity {
@@ -561,7 +1419,7 @@ func (r *OracleRestDataServiceReconciler) instantiatePodSpec(m *dbapi.OracleRest
 				}
 				return nil
 			}(),
-			Volumes: []corev1.Volume{
+			Volumes: append(append([]corev1.Volume{
 				{
 					Name: "datamount",
 					VolumeSource: corev1.VolumeSource{
@@ -589,35 +1447,54 @@ func (r *OracleRestDataServiceReconciler) instantiatePodSpec(m *dbapi.OracleRest
 						},
 					},
 				},
-			},
-			InitContainers: []corev1.Container{
+			}, func() []corev1.Volume {
+				if m.Spec.ConfigStrategy != dbcommons.OrdsConfigStrategyPerPod {
+					return nil
+				}
+				return []corev1.Volume{{
+					Name:         "ords-config",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				}}
+			}()...), append(artifactVolumes(m), settingsVolumes(m)...)...),
+			InitContainers: append(fetchArtifactsContainers(m, n, runAsUser, runAsGroup), []corev1.Container{
 				{
 					Name:    "init-permissions",
-					Image:   m.Spec.Image.PullFrom,
-					Command: []string{"/bin/sh", "-c", fmt.Sprintf("chown %d:%d /opt/oracle/ords/config/ords || true", int(dbcommons.ORACLE_UID), int(dbcommons.DBA_GUID))},
+					Image:   ordsPodImage(m).PullFrom,
+					Command: []string{"/bin/sh", "-c", fmt.Sprintf("chown %d:%d %s || true", runAsUser, runAsGroup, getOrdsImageConfigDir(m))},
 					SecurityContext: &corev1.SecurityContext{
 						// User ID 0 means, root user
 						RunAsUser: func() *int64 { i := int64(0); return &i }(),
 					},
 					VolumeMounts: []corev1.VolumeMount{{
-						MountPath: "/opt/oracle/ords/config/ords",
+						MountPath: getOrdsImageConfigDir(m),
 						Name:      "datamount",
-						SubPath:   strings.ToUpper(n.Spec.Sid) + "_ORDS",
+						SubPath:   getOrdsConfigDir(m, n),
 					}},
 				},
 				{
-					Name:    "init-ords",
-					Image:   m.Spec.Image.PullFrom,
-					Command: []string{"/bin/sh", "/run/secrets/init-cmd"},
+					Name:  "init-ords",
+					Image: ordsPodImage(m).PullFrom,
+					Command: []string{"/bin/sh", "-c", func() string {
+						// The properties of spec.settings are merged last, overriding the ones set by the operator
+						cmd := installLogCMD(m, "init-ords", "/bin/sh /run/secrets/init-cmd && "+withConfigDir(m, dbcommons.ResetOrdsSettingsCMD)+
+							" && "+dbcommons.SetOrdsContextPathCMD+" && "+withConfigDir(m, dbcommons.SetOrdsServiceNameCMD)+
+							" && "+withConfigDir(m, dbcommons.SetOrdsJdbcInitialLimitCMD)+" && "+withConfigDir(m, dbcommons.MergeOrdsSettingsCMD)+
+							" && "+withConfigDir(m, dbcommons.SetOrdsVirtualHostsCMD))
+						if m.Spec.ConfigStrategy == dbcommons.OrdsConfigStrategyPerPod {
+							// The shared configuration set up, it is copied to the directory of the pod
+							cmd = "(" + cmd + ") && " + withConfigDir(m, dbcommons.CopyOrdsConfigToPodCMD)
+						}
+						return cmd
+					}()},
 					SecurityContext: &corev1.SecurityContext{
-						RunAsUser:  func() *int64 { i := int64(dbcommons.ORACLE_UID); return &i }(),
-						RunAsGroup: func() *int64 { i := int64(dbcommons.DBA_GUID); return &i }(),
+						RunAsUser:  &runAsUser,
+						RunAsGroup: &runAsGroup,
 					},
-					VolumeMounts: []corev1.VolumeMount{
+					VolumeMounts: append([]corev1.VolumeMount{
 						{
-							MountPath: "/opt/oracle/ords/config/ords",
+							MountPath: getOrdsImageConfigDir(m),
 							Name:      "datamount",
-							SubPath:   strings.ToUpper(n.Spec.Sid) + "_ORDS",
+							SubPath:   getOrdsConfigDir(m, n),
 						},
 						{
 							MountPath: "/run/secrets/init-cmd",
@@ -625,8 +1502,9 @@ func (r *OracleRestDataServiceReconciler) instantiatePodSpec(m *dbapi.OracleRest
 							Name:      "init-ords-vol",
 							SubPath:   "init-cmd",
 						},
-					},
-					Env: []corev1.EnvVar{
+					}, append(append(podConfigMounts(m, withConfigDir(m, dbcommons.OrdsPodConfigDir)), artifactMounts(m)...),
+						settingsMounts(m)...)...),
+					Env: append([]corev1.EnvVar{
 						{
 							Name:  "ORACLE_HOST",
 							Value: n.Name,
@@ -636,22 +1514,12 @@ func (r *OracleRestDataServiceReconciler) instantiatePodSpec(m *dbapi.OracleRest
 							Value: "1521",
 						},
 						{
-							Name: "ORACLE_SERVICE",
-							Value: func() string {
-								if m.Spec.OracleService != "" {
-									return m.Spec.OracleService
-								}
-								return n.Spec.Sid
-							}(),
+							Name:  "ORACLE_SERVICE",
+							Value: getOrdsServiceName(m, n),
 						},
 						{
-							Name: "ORDS_USER",
-							Value: func() string {
-								if m.Spec.OrdsUser != "" {
-									return m.Spec.OrdsUser
-								}
-								return "ORDS_PUBLIC_USER"
-							}(),
+							Name:  "ORDS_USER",
+							Value: getOrdsUser(m),
 						},
 						{
 							Name: "ORDS_PWD",
@@ -675,21 +1543,42 @@ func (r *OracleRestDataServiceReconciler) instantiatePodSpec(m *dbapi.OracleRest
 								},
 							},
 						},
-					},
+					}, ordsEnv(m, n)...),
 				},
-			},
+			}...),
 			Containers: []corev1.Container{{
 				Name:  m.Name,
-				Image: m.Spec.Image.PullFrom,
-				Ports: []corev1.ContainerPort{{ContainerPort: 8443}},
-				VolumeMounts: []corev1.VolumeMount{{
-					MountPath: "/opt/oracle/ords/config/ords/",
-					Name:      "datamount",
-					SubPath:   strings.ToUpper(n.Spec.Sid) + "_ORDS",
-				}},
+				Image: ordsPodImage(m).PullFrom,
+				Resources: func() corev1.ResourceRequirements {
+					if m.Spec.Resources == nil {
+						return corev1.ResourceRequirements{}
+					}
+					return *m.Spec.Resources
+				}(),
+				Ports: []corev1.ContainerPort{{Name: dbcommons.OrdsPortName, ContainerPort: 8443},
+					{Name: dbcommons.OrdsMongoPortName, ContainerPort: dbcommons.OrdsMongoPort}},
+				ReadinessProbe: func() *corev1.Probe {
+					if ordsHealthCheck(m) != dbcommons.OrdsHealthCheckHTTP {
+						return nil
+					}
+					return &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{
+								Path:   withContextPath(m, dbcommons.OrdsPoolValidationPath),
+								Port:   intstr.FromString(dbcommons.OrdsPortName),
+								Scheme: corev1.URISchemeHTTPS,
+							},
+						},
+						InitialDelaySeconds: 20,
+						PeriodSeconds:       10,
+						TimeoutSeconds:      5,
+						FailureThreshold:    3,
+					}
+				}(),
+				VolumeMounts: append(ordsConfigMounts(m, n), artifactMounts(m)...),
 				Env: func() []corev1.EnvVar {
 					// After ORDS is Installed, we DELETE THE OLD ORDS Pod and create new ones ONLY USING BELOW ENV VARIABLES.
-					return []corev1.EnvVar{
+					return append([]corev1.EnvVar{
 						{
 							Name:  "ORACLE_HOST",
 							Value: n.Name,
@@ -699,29 +1588,24 @@ func (r *OracleRestDataServiceReconciler) instantiatePodSpec(m *dbapi.OracleRest
 							Value: "1521",
 						},
 						{
-							Name: "ORACLE_SERVICE",
-							Value: func() string {
-								if m.Spec.OracleService != "" {
-									return m.Spec.OracleService
-								}
-								return n.Spec.Sid
-							}(),
+							Name:  "ORACLE_SERVICE",
+							Value: getOrdsServiceName(m, n),
 						},
 						{
-							Name: "ORDS_USER",
-							Value: func() string {
-								if m.Spec.OrdsUser != "" {
-									return m.Spec.OrdsUser
-								}
-								return "ORDS_PUBLIC_USER"
-							}(),
+							Name:  "ORDS_USER",
+							Value: getOrdsUser(m),
 						},
-					}
+					}, ordsEnv(m, n)...)
 				}(),
 			}},
 
 			TerminationGracePeriodSeconds: func() *int64 { i := int64(30); return &i }(),
 
+			// The pod is added to the endpoints of the service once its pool is validated
+			ReadinessGates: []corev1.PodReadinessGate{{
+				ConditionType: corev1.PodConditionType(dbcommons.OrdsPoolReadyCondition),
+			}},
+
 			NodeSelector: func() map[string]string {
 				ns := make(map[string]string)
 				if len(m.Spec.NodeSelector) != 0 {
@@ -738,14 +1622,14 @@ func (r *OracleRestDataServiceReconciler) instantiatePodSpec(m *dbapi.OracleRest
 				return "default"
 			}(),
 			SecurityContext: &corev1.PodSecurityContext{
-				RunAsUser:  func() *int64 { i := int64(dbcommons.ORACLE_UID); return &i }(),
-				RunAsGroup: func() *int64 { i := int64(dbcommons.DBA_GUID); return &i }(),
-				FSGroup:    func() *int64 { i := int64(dbcommons.DBA_GUID); return &i }(),
+				RunAsUser:  &runAsUser,
+				RunAsGroup: &runAsGroup,
+				FSGroup:    &fsGroup,
 			},
 
 			ImagePullSecrets: []corev1.LocalObjectReference{
 				{
-					Name: m.Spec.Image.PullSecrets,
+					Name: ordsPodImage(m).PullSecrets,
 				},
 			},
 		},
@@ -791,24 +1675,16 @@ func (r *OracleRestDataServiceReconciler) instantiatePVCSpec(m *dbapi.OracleRest
 					"storage": resource.MustParse(m.Spec.Persistence.Size),
 				},
 			},
-			StorageClassName: &m.Spec.Persistence.StorageClass,
-			VolumeName:       m.Spec.Persistence.VolumeName,
-			Selector: func() *metav1.LabelSelector {
-				if m.Spec.Persistence.StorageClass != "oci" {
+			// The default storage class is used if none is specified, except for volumes bound by name. Classes
+			// binding volumes on the first consumer provision them in the zone where the pod is scheduled
+			StorageClassName: func() *string {
+				if m.Spec.Persistence.StorageClass == "" && m.Spec.Persistence.VolumeName == "" {
 					return nil
 				}
-				return &metav1.LabelSelector{
-					MatchLabels: func() map[string]string {
-						ns := make(map[string]string)
-						if len(m.Spec.NodeSelector) != 0 {
-							for key, value := range m.Spec.NodeSelector {
-								ns[key] = value
-							}
-						}
-						return ns
-					}(),
-				}
+				return &m.Spec.Persistence.StorageClass
 			}(),
+			VolumeName: m.Spec.Persistence.VolumeName,
+			DataSource: m.Spec.Persistence.DataSource,
 		},
 	}
 	// Set SingleInstanceDatabase instance as the owner and controller
@@ -852,6 +1728,7 @@ func (r *OracleRestDataServiceReconciler) createSVC(ctx context.Context, req ctr
 	if svcDeleted || (err != nil && apierrors.IsNotFound(err)) {
 		// Define a new Service
 		svc = r.instantiateSVCSpec(m)
+		dbcommons.SetExternalDNSAnnotation(svc, m.Spec.Hostname)
 		log.Info("Creating a new Service", "Service.Namespace", svc.Namespace, "Service.Name", svc.Name)
 		err = r.Create(ctx, svc)
 		if err != nil {
@@ -869,36 +1746,116 @@ func (r *OracleRestDataServiceReconciler) createSVC(ctx context.Context, req ctr
 		return requeueY
 	}
 
-	m.Status.ServiceIP = ""
-	if m.Spec.LoadBalancer {
-		if len(svc.Status.LoadBalancer.Ingress) > 0 {
-			// 'lbAddress' will contain the Fully Qualified Hostname of the LB. If the hostname is not available it will contain the IP address of the LB
-			lbAddress := svc.Status.LoadBalancer.Ingress[0].Hostname
-			if lbAddress == "" {
-				lbAddress = svc.Status.LoadBalancer.Ingress[0].IP
-			}
-			m.Status.DatabaseApiUrl = "https://" + lbAddress + ":" +
-				fmt.Sprint(svc.Spec.Ports[0].Port) + "/ords/" + n.Status.Pdbname + "/_/db-api/stable/"
-			m.Status.ServiceIP = lbAddress
-			m.Status.DatabaseActionsUrl = "https://" + lbAddress + ":" +
-				fmt.Sprint(svc.Spec.Ports[0].Port) + "/ords/sql-developer"
-			if m.Status.ApexConfigured {
-				m.Status.ApxeUrl = "https://" + lbAddress + ":" +
-					fmt.Sprint(svc.Spec.Ports[0].Port) + "/ords/" + n.Status.Pdbname + "/apex"
-			}
+	if dbcommons.SetExternalDNSAnnotation(svc, m.Spec.Hostname) {
+		log.Info("Updating the external-dns hostname of the service", "Service.Name", svc.Name, "hostname", m.Spec.Hostname)
+		if err := r.Update(ctx, svc); err != nil {
+			log.Error(err, "Failed to update Service")
+			return requeueY
 		}
-		return requeueN
 	}
-	nodeip := dbcommons.GetNodeIp(r, ctx, req)
-	if nodeip != "" {
-		m.Status.ServiceIP = nodeip
-		m.Status.DatabaseApiUrl = "https://" + nodeip + ":" + fmt.Sprint(svc.Spec.Ports[0].NodePort) +
-			"/ords/" + n.Status.Pdbname + "/_/db-api/stable/"
-		m.Status.DatabaseActionsUrl = "https://" + nodeip + ":" + fmt.Sprint(svc.Spec.Ports[0].NodePort) +
-			"/ords/sql-developer"
-		if m.Status.ApexConfigured {
-			m.Status.ApxeUrl = "https://" + nodeip + ":" + fmt.Sprint(svc.Spec.Ports[0].NodePort) + "/ords/" +
-				n.Status.Pdbname + "/apex"
+
+	// Services created by earlier releases have an unnamed protocol, and no mongo port
+	portsUpdated := false
+	if svc.Spec.Ports[0].Name != dbcommons.OrdsPortName {
+		svc.Spec.Ports[0].Name = dbcommons.OrdsPortName
+		svc.Spec.Ports[0].AppProtocol = func() *string { p := dbcommons.OrdsPortName; return &p }()
+		portsUpdated = true
+	}
+	mongoPort := false
+	for _, port := range svc.Spec.Ports {
+		mongoPort = mongoPort || port.Name == dbcommons.OrdsMongoPortName
+	}
+	if !mongoPort {
+		svc.Spec.Ports = append(svc.Spec.Ports, r.instantiateSVCSpec(m).Spec.Ports[1])
+		portsUpdated = true
+	}
+	if portsUpdated {
+		log.Info("Updating the ports of the service", "Service.Name", svc.Name)
+		if err := r.Update(ctx, svc); err != nil {
+			log.Error(err, "Failed to update Service")
+			return requeueY
+		}
+	}
+
+	if dbcommons.SetServiceOptions(svc, m.Spec.SessionAffinity, m.Spec.ExternalTrafficPolicy, m.Spec.LoadBalancerSourceRanges) {
+		log.Info("Updating the options of the service", "Service.Name", svc.Name)
+		if err := r.Update(ctx, svc); err != nil {
+			log.Error(err, "Failed to update Service")
+			return requeueY
+		}
+	}
+
+	// The WAF policy may also be given with the service annotations
+	wafPolicyId := m.Spec.ServiceAnnotations[dbcommons.WafPolicyAnnotation]
+	if m.Spec.Oci != nil && m.Spec.Oci.WafPolicyId != "" {
+		wafPolicyId = m.Spec.Oci.WafPolicyId
+	}
+	if m.Spec.LoadBalancer && dbcommons.SetWafPolicyAnnotation(svc, wafPolicyId) {
+		log.Info("Updating the WAF policy of the load balancer", "Service.Name", svc.Name, "policy", wafPolicyId)
+		if err := r.Update(ctx, svc); err != nil {
+			log.Error(err, "Failed to update Service")
+			return requeueY
+		}
+	}
+
+	previousServiceIP := m.Status.ServiceIP
+	m.Status.ServiceIP = ""
+	if m.Spec.LoadBalancer {
+		// 'lbAddress' will contain the Fully Qualified Hostname of the LB. If the hostname is not available it will contain the IP address of the LB
+		lbAddress := dbcommons.GetLoadBalancerAddress(svc)
+		if lbAddress == "" {
+			// The load balancer is being provisioned again, the URLs of the previous one no longer apply
+			if previousServiceIP != "" {
+				m.Status.DatabaseApiUrl = dbcommons.ValueUnavailable
+				m.Status.DatabaseActionsUrl = dbcommons.ValueUnavailable
+				m.Status.ApxeUrl = dbcommons.ValueUnavailable
+			}
+		} else {
+			if previousServiceIP != "" && previousServiceIP != lbAddress {
+				eventReason := "Service Address"
+				eventMsg := "address of the load balancer changed from " + previousServiceIP + " to " + lbAddress + ", status updated"
+				r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+				log.Info(eventMsg)
+			}
+			m.Status.ServiceIP = lbAddress
+			lbAddress = dbcommons.GetExternalHost(m.Spec.Hostname, lbAddress)
+			m.Status.DatabaseApiUrl = "https://" + lbAddress + ":" +
+				fmt.Sprint(svc.Spec.Ports[0].Port) + getOrdsContextPath(m) + "/" + getOrdsPdbPath(m, n) + "_/db-api/stable/"
+			m.Status.DatabaseActionsUrl = "https://" + lbAddress + ":" +
+				fmt.Sprint(svc.Spec.Ports[0].Port) + getOrdsContextPath(m) + "/sql-developer"
+			if m.Status.ApexConfigured {
+				m.Status.ApxeUrl = "https://" + lbAddress + ":" +
+					fmt.Sprint(svc.Spec.Ports[0].Port) + getOrdsContextPath(m) + "/" + getOrdsPdbPath(m, n) + "apex"
+			}
+		}
+		return requeueN
+	}
+	// Prefer the node running a ready ORDS pod, so that the URLs follow the pod when it moves
+	ordsReadyPod, _, _, _, err := dbcommons.FindPods(r, ordsPodImage(m).Version,
+		ordsPodImage(m).PullFrom, m.Name, m.Namespace, ctx, req)
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	addressType, nodeSelector := "", map[string]string(nil)
+	if m.Spec.NodePortAddress != nil {
+		addressType, nodeSelector = m.Spec.NodePortAddress.Type, m.Spec.NodePortAddress.NodeSelector
+	}
+	nodeip, nodeName := dbcommons.GetNodeIpByPolicy(r, ctx, req, addressType, nodeSelector, ordsReadyPod.Spec.NodeName)
+	if nodeip != "" {
+		if nodeName != m.Status.NodeName {
+			m.Status.NodeName = nodeName
+			log.Info("Publishing the address of the node running ORDS", "node", m.Status.NodeName, "nodeip", nodeip)
+		}
+		m.Status.ServiceIP = nodeip
+		nodeip = dbcommons.GetExternalHost(m.Spec.Hostname, nodeip)
+		m.Status.DatabaseApiUrl = "https://" + nodeip + ":" + fmt.Sprint(svc.Spec.Ports[0].NodePort) +
+			getOrdsContextPath(m) + "/" + getOrdsPdbPath(m, n) + "_/db-api/stable/"
+		m.Status.DatabaseActionsUrl = "https://" + nodeip + ":" + fmt.Sprint(svc.Spec.Ports[0].NodePort) +
+			getOrdsContextPath(m) + "/sql-developer"
+		if m.Status.ApexConfigured {
+			m.Status.ApxeUrl = "https://" + nodeip + ":" + fmt.Sprint(svc.Spec.Ports[0].NodePort) + getOrdsContextPath(m) + "/" +
+				getOrdsPdbPath(m, n) + "apex"
 		}
 	}
 	return requeueN
@@ -940,6 +1897,395 @@ func (r *OracleRestDataServiceReconciler) createPVC(ctx context.Context, req ctr
 	return requeueN, nil
 }
 
+// Returns the directory of the ORDS configuration on the volume. The first ORDS of a database uses <SID>_ORDS,
+// additional ones <SID>_ORDS_<NAME>
+func getOrdsConfigDir(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase) string {
+	if m.Status.ConfigDir != "" {
+		return m.Status.ConfigDir
+	}
+	return strings.ToUpper(n.Spec.Sid) + "_ORDS"
+}
+
+// Returns the ORDS other than m serving the database n. A reference with the name of m is m, even with another UID,
+// as it is replaced when m registers
+func getOtherOrdsReferences(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase) []dbapi.SingleInstanceDatabaseConsumerRef {
+	var others []dbapi.SingleInstanceDatabaseConsumerRef
+	for _, consumer := range n.GetConsumers(dbapi.OracleRestDataServiceKind) {
+		if consumer.Name != m.Name {
+			others = append(others, consumer)
+		}
+	}
+	return others
+}
+
+// Returns the names of the consumers
+func consumerNames(consumers []dbapi.SingleInstanceDatabaseConsumerRef) []string {
+	var names []string
+	for _, consumer := range consumers {
+		names = append(names, consumer.Name)
+	}
+	return names
+}
+
+// #############################################################################
+//
+//	Stop the ORDS pods while the database is stopped on its schedule
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) followDatabaseSchedule(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("followDatabaseSchedule", req.NamespacedName)
+
+	if n.Status.ScheduledState != dbcommons.ScheduledStateStopped {
+		if m.Status.Status == dbcommons.StatusStopped {
+			m.Status.Status = dbcommons.StatusPending
+		}
+		return requeueN
+	}
+
+	if m.Status.Status != dbcommons.StatusStopped {
+		eventReason := "Scheduled Stop"
+		eventMsg := "stopping ORDS with database " + n.Name + ", next scheduled start at " + n.Status.NextScheduledTransition
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		log.Info(eventMsg)
+	}
+	if err := r.deleteOrdsPods(m, ctx, req); err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	m.Status.Status = dbcommons.StatusStopped
+	return requeueUntil(n.Status.NextScheduledTransition)
+}
+
+// #############################################################################
+//
+//	Register the ORDS in the list of ORDS serving the database
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) registerOrdsReference(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("registerOrdsReference", req.NamespacedName)
+
+	// The ORDS registered by name only are registered again with their UID
+	registered := dbapi.SingleInstanceDatabaseConsumerRef{
+		Kind:      dbapi.OracleRestDataServiceKind,
+		Name:      m.Name,
+		Namespace: m.Namespace,
+		UID:       m.UID,
+	}
+	for _, consumer := range n.GetConsumers(dbapi.OracleRestDataServiceKind) {
+		if consumer.Name == m.Name {
+			if consumer.UID == m.UID && m.Status.ConfigDir != "" && m.Annotations[dbcommons.DatabaseUIDAnnotation] == string(n.UID) {
+				return requeueN
+			}
+			registered.Owner = consumer.Owner
+		}
+	}
+
+	// The database registered with is recorded on the ORDS, so that it does not deregister from another database
+	// created with the same name
+	if m.Annotations[dbcommons.DatabaseUIDAnnotation] != string(n.UID) {
+		if m.Annotations == nil {
+			m.Annotations = make(map[string]string)
+		}
+		m.Annotations[dbcommons.DatabaseUIDAnnotation] = string(n.UID)
+		if err := r.Update(ctx, m); err != nil {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+	}
+
+	others := getOtherOrdsReferences(m, n)
+	if m.Status.ConfigDir == "" && m.Spec.ConfigSubPath != "" {
+		m.Status.ConfigDir = m.Spec.ConfigSubPath
+	} else if m.Status.ConfigDir == "" {
+		m.Status.ConfigDir = strings.ToUpper(n.Spec.Sid) + "_ORDS"
+		if !m.Status.OrdsInstalled && len(others) > 0 {
+			// Additional ORDS keep their own configuration, sharing the ORDS repository in the database
+			m.Status.ConfigDir = strings.ToUpper(n.Spec.Sid) + "_ORDS_" + strings.ToUpper(m.Name)
+		}
+	}
+	n.SetConsumers(dbapi.OracleRestDataServiceKind, append(others, registered))
+	if err := k8s.PatchStatus(ctx, r.Client, n); err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	if len(others) > 0 {
+		eventReason := "Database Check"
+		eventMsg := "database " + n.Name + " is also served by ORDS " + strings.Join(consumerNames(others), ",")
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	}
+	return requeueN
+}
+
+// Returns the path of the PDB in the ORDS URLs. ORDS installed in a PDB serves it at the root path
+func getOrdsPdbPath(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase) string {
+	if m.Spec.InstallScope == dbcommons.OrdsInstallScopePDB {
+		return ""
+	}
+	return n.Status.Pdbname + "/"
+}
+
+// #############################################################################
+//
+//	Publish the Database API URL and Ingress route of each open PDB
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) managePdbRoutes(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("managePdbRoutes", req.NamespacedName)
+
+	if sidbReadyPod.Name == "" {
+		return requeueN
+	}
+
+	// ORDS installed in a PDB serves only that PDB
+	pdbs := []string{n.Status.Pdbname}
+	if m.Spec.InstallScope != dbcommons.OrdsInstallScopePDB {
+		out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, false, "bash", "-c",
+			fmt.Sprintf("echo -e  \"%s\"  | %s ", dbcommons.GetOpenPdbsSQL, dbcommons.SQLPlusCLI))
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+		pdbs, err = dbcommons.ParseColumnValues(out)
+		if err != nil {
+			log.Info("Unable to list the open PDBs: " + out)
+			return requeueN
+		}
+	}
+
+	// URLs go through the Ingress when it is configured, else through the service
+	baseUrl := ""
+	if m.Spec.Ingress != nil && m.Spec.Ingress.Host != "" {
+		baseUrl = "http://" + m.Spec.Ingress.Host + getOrdsContextPath(m) + "/"
+		if m.Spec.Ingress.TlsSecret != "" {
+			baseUrl = "https://" + m.Spec.Ingress.Host + getOrdsContextPath(m) + "/"
+		}
+	} else if idx := strings.Index(m.Status.DatabaseApiUrl, getOrdsContextPath(m)+"/"); idx != -1 {
+		baseUrl = m.Status.DatabaseApiUrl[:idx+len(getOrdsContextPath(m)+"/")]
+	}
+	if baseUrl != "" {
+		m.Status.DatabaseApiUrls = make(map[string]string)
+		for _, pdb := range pdbs {
+			if m.Spec.InstallScope == dbcommons.OrdsInstallScopePDB {
+				m.Status.DatabaseApiUrls[pdb] = baseUrl + "_/db-api/stable/"
+			} else {
+				m.Status.DatabaseApiUrls[pdb] = baseUrl + pdb + "/_/db-api/stable/"
+			}
+		}
+	}
+	// The PDBs of the virtual hosts are published at their first host, only routed by the Ingress
+	for i := len(m.Spec.VirtualHosts) - 1; i >= 0 && m.Spec.Ingress != nil; i-- {
+		virtualHost := m.Spec.VirtualHosts[i]
+		scheme := "http://"
+		if virtualHost.TlsSecret != "" || m.Spec.Ingress.TlsSecret != "" {
+			scheme = "https://"
+		}
+		for _, pdb := range pdbs {
+			if strings.EqualFold(pdb, virtualHost.PdbName) {
+				if m.Status.DatabaseApiUrls == nil {
+					m.Status.DatabaseApiUrls = make(map[string]string)
+				}
+				m.Status.DatabaseApiUrls[pdb] = scheme + virtualHost.Host + getOrdsContextPath(m) + "/_/db-api/stable/"
+			}
+		}
+	}
+
+	ingress := &networkingv1.Ingress{}
+	err := r.Get(ctx, types.NamespacedName{Name: m.Name, Namespace: m.Namespace}, ingress)
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	ingressFound := err == nil
+
+	if m.Spec.Ingress == nil {
+		if ingressFound {
+			log.Info("Deleting Ingress", "Ingress.Name", ingress.Name)
+			if err := r.Delete(ctx, ingress); err != nil {
+				log.Error(err, "Failed to delete Ingress")
+				return requeueY
+			}
+		}
+		return requeueN
+	}
+
+	desired := r.instantiateIngressSpec(m, n, pdbs)
+	if desired.Spec.IngressClassName == nil {
+		// Keep the default class assigned by the cluster
+		desired.Spec.IngressClassName = ingress.Spec.IngressClassName
+	}
+	if !ingressFound {
+		log.Info("Creating a new Ingress", "Ingress.Namespace", desired.Namespace, "Ingress.Name", desired.Name)
+		if err := r.Create(ctx, desired); err != nil {
+			log.Error(err, "Failed to create Ingress")
+			return requeueY
+		}
+		eventReason := "Ingress creation"
+		eventMsg := "successfully created ingress with routes for PDBs " + strings.Join(pdbs, ",")
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		return requeueN
+	}
+	if !reflect.DeepEqual(ingress.Spec, desired.Spec) || !reflect.DeepEqual(ingress.Annotations, desired.Annotations) {
+		ingress.Spec = desired.Spec
+		ingress.Annotations = desired.Annotations
+		log.Info("Updating Ingress", "Ingress.Name", ingress.Name, "PDBs", pdbs)
+		if err := r.Update(ctx, ingress); err != nil {
+			log.Error(err, "Failed to update Ingress")
+			return requeueY
+		}
+	}
+	return requeueN
+}
+
+// #############################################################################
+//
+//	Instantiate Ingress spec routing <contextPath>/<pdb>/ and the virtual hosts to the ORDS service
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) instantiateIngressSpec(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	pdbs []string) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypePrefix
+	var paths []networkingv1.HTTPIngressPath
+	for _, pdb := range pdbs {
+		path := getOrdsContextPath(m) + "/" + pdb + "/"
+		if m.Spec.InstallScope == dbcommons.OrdsInstallScopePDB {
+			path = getOrdsContextPath(m) + "/"
+		}
+		paths = append(paths, networkingv1.HTTPIngressPath{
+			Path:     path,
+			PathType: &pathType,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: m.Name,
+					Port: networkingv1.ServiceBackendPort{Number: 8443},
+				},
+			},
+		})
+	}
+	ingress := &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Ingress",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.Name,
+			Namespace: m.Namespace,
+			Labels: map[string]string{
+				"app": m.Name,
+			},
+			Annotations: m.Spec.Ingress.Annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: m.Spec.Ingress.ClassName,
+			Rules: []networkingv1.IngressRule{{
+				Host: m.Spec.Ingress.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths},
+				},
+			}},
+		},
+	}
+	if m.Spec.Ingress.TlsSecret != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{
+			Hosts: func() []string {
+				if m.Spec.Ingress.Host != "" {
+					return []string{m.Spec.Ingress.Host}
+				}
+				return nil
+			}(),
+			SecretName: m.Spec.Ingress.TlsSecret,
+		}}
+	}
+
+	// A rule per virtual host routes the context path to the service, ORDS mapping the host to the pool of its PDB
+	for _, virtualHost := range m.Spec.VirtualHosts {
+		ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{
+			Host: virtualHost.Host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{
+					Path:     getOrdsContextPath(m) + "/",
+					PathType: &pathType,
+					Backend: networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{
+							Name: m.Name,
+							Port: networkingv1.ServiceBackendPort{Number: 8443},
+						},
+					},
+				}}},
+			},
+		})
+		tlsSecret := virtualHost.TlsSecret
+		if tlsSecret == "" {
+			tlsSecret = m.Spec.Ingress.TlsSecret
+		}
+		if tlsSecret != "" {
+			ingress.Spec.TLS = append(ingress.Spec.TLS, networkingv1.IngressTLS{
+				Hosts:      []string{virtualHost.Host},
+				SecretName: tlsSecret,
+			})
+		}
+	}
+	// Set OracleRestDataService instance as the owner and controller
+	ctrl.SetControllerReference(m, ingress, r.Scheme)
+	return ingress
+}
+
+// #############################################################################
+//
+//	Render the ORDS properties of spec.settings into a Secret
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) manageSettingsSecret(m *dbapi.OracleRestDataService,
+	n *dbapi.SingleInstanceDatabase, ctx context.Context, req ctrl.Request) ctrl.Result {
+
+	log := r.Log.WithValues("manageSettingsSecret", req.NamespacedName)
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: m.Name + dbcommons.OrdsSettingsSecretSuffix, Namespace: m.Namespace}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+
+	// The pods without the settings are the ones of the environment without the checksum
+	if m.Spec.Settings == nil {
+		if err == nil {
+			log.Info("Deleting the settings secret", "name", secret.Name)
+			if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, err.Error())
+				return requeueY
+			}
+		}
+		return requeueN
+	}
+
+	settings := []byte(dbcommons.RenderOrdsProperties(m.Spec.Settings.Properties))
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.Name + dbcommons.OrdsSettingsSecretSuffix,
+				Namespace: m.Namespace,
+				Labels:    map[string]string{"app": m.Name},
+			},
+			Data: map[string][]byte{dbcommons.OrdsSettingsFile: settings},
+		}
+		ctrl.SetControllerReference(m, secret, r.Scheme)
+		log.Info("Creating the settings secret", "name", secret.Name)
+		err = r.Create(ctx, secret)
+	} else if !bytes.Equal(secret.Data[dbcommons.OrdsSettingsFile], settings) {
+		// The pods are replaced on the change of the checksum in their environment
+		secret.Data = map[string][]byte{dbcommons.OrdsSettingsFile: settings}
+		log.Info("Updating the settings secret", "name", secret.Name)
+		err = r.Update(ctx, secret)
+	}
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	return requeueN
+}
+
 // #############################################################################
 //
 //	Create the requested POD replicas
@@ -950,8 +2296,8 @@ func (r *OracleRestDataServiceReconciler) createPods(m *dbapi.OracleRestDataServ
 
 	log := r.Log.WithValues("createPods", req.NamespacedName)
 
-	readyPod, replicasFound, available, podsMarkedToBeDeleted, err := dbcommons.FindPods(r, m.Spec.Image.Version,
-		m.Spec.Image.PullFrom, m.Name, m.Namespace, ctx, req)
+	readyPod, replicasFound, available, podsMarkedToBeDeleted, err := dbcommons.FindPods(r, ordsPodImage(m).Version,
+		ordsPodImage(m).PullFrom, m.Name, m.Namespace, ctx, req)
 	if err != nil {
 		log.Error(err, err.Error())
 		return requeueY
@@ -966,9 +2312,93 @@ func (r *OracleRestDataServiceReconciler) createPods(m *dbapi.OracleRestDataServ
 			GracePeriodSeconds: &gracePeriodSeconds, PropagationPolicy: &policy})
 	}
 
+	// Replace the pods of the unreachable nodes without waiting for their eviction
+	lostPodsResult := requeueN
+	if timeout := getNodeFailureTimeout(m); timeout > 0 {
+		pods := available
+		if readyPod.Name != "" {
+			pods = append(pods, readyPod)
+		}
+		available = []corev1.Pod{}
+		for i := range pods {
+			since, unreachable, err := r.nodeUnreachableSince(pods[i].Spec.NodeName, ctx)
+			if err != nil {
+				log.Error(err, err.Error())
+				return requeueY
+			}
+			if !unreachable {
+				if pods[i].Name != readyPod.Name {
+					available = append(available, pods[i])
+				}
+				continue
+			}
+			if pods[i].Name == readyPod.Name {
+				readyPod = corev1.Pod{}
+			}
+			replicasFound--
+			remaining := time.Until(since.Add(timeout))
+			if remaining > 0 {
+				log.Info("Replacing the pod of an unreachable node", "POD.Name", pods[i].Name, "node", pods[i].Spec.NodeName,
+					"forceDeletionIn", remaining.Round(time.Second).String())
+				if lostPodsResult.RequeueAfter == 0 || remaining < lostPodsResult.RequeueAfter {
+					lostPodsResult = ctrl.Result{RequeueAfter: remaining + time.Second}
+				}
+				continue
+			}
+			eventReason := "Node Unreachable"
+			eventMsg := "force deleting pod " + pods[i].Name + " of unreachable node " + pods[i].Spec.NodeName
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			log.Info(eventMsg)
+			var gracePeriodSeconds int64 = 0
+			if err := r.Delete(ctx, &pods[i], &client.DeleteOptions{GracePeriodSeconds: &gracePeriodSeconds}); err != nil &&
+				!apierrors.IsNotFound(err) {
+				log.Error(err, "Failed to force delete the pod", "POD.Name", pods[i].Name)
+			}
+		}
+	}
+
 	log.Info(m.Name, " pods other than one of Ready Pods : ", dbcommons.GetPodNames(available))
 	log.Info(m.Name, " Ready Pod : ", readyPod.Name)
 
+	// Replace the pods created with another environment
+	envHash := ordsEnvHash(m, n)
+	stale := []corev1.Pod{}
+	for _, pod := range append(available, readyPod) {
+		if pod.Name != "" && pod.Annotations[dbcommons.EnvHashAnnotation] != envHash {
+			stale = append(stale, pod)
+		}
+	}
+	// With the Recreate update strategy the pods are replaced at once, or else one at a time, once all the other pods are ready
+	staleResult := requeueN
+	if len(stale) > 0 && (m.Spec.UpdateStrategy == nil || m.Spec.UpdateStrategy.Type != "Recreate") {
+		ready := replicasFound >= m.Spec.Replicas && readyPod.Name != ""
+		for _, pod := range available {
+			ready = ready && dbcommons.IsPodReady(pod)
+		}
+		if ready {
+			stale = stale[:1]
+		} else {
+			log.Info("Waiting for the pods to be ready before replacing pods " + strings.Join(dbcommons.GetPodNames(stale), ","))
+			stale = nil
+			staleResult = ctrl.Result{RequeueAfter: requeueY.RequeueAfter}
+		}
+	}
+	if len(stale) > 0 {
+		if disruptionBlocked(ctx, r.Client, r.Recorder, m, "replacement of the pods with another environment", stale...) {
+			return requeueY
+		}
+		eventReason := "ORDS Environment"
+		eventMsg := "recreating pods " + strings.Join(dbcommons.GetPodNames(stale), ",") + " with the environment of the spec"
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		log.Info(eventMsg)
+		for i := range stale {
+			if err := r.Delete(ctx, &stale[i]); err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, err.Error())
+			}
+		}
+		return requeueY
+	}
+
 	replicasReq := m.Spec.Replicas
 	if replicasFound == 0 {
 		m.Status.Status = dbcommons.StatusPending
@@ -980,6 +2410,10 @@ func (r *OracleRestDataServiceReconciler) createPods(m *dbapi.OracleRestDataServ
 		// Create New Pods , Name of Pods are generated Randomly
 		for i := replicasFound; i < replicasReq; i++ {
 			pod, initSecret := r.instantiatePodSpec(m, n)
+			if err := r.setVolumeZoneAffinity(&pod.Spec, m.Namespace, ctx); err != nil {
+				log.Error(err, err.Error())
+				return requeueY
+			}
 			// Check if init-secret is present
 			err := r.Get(ctx, types.NamespacedName{Name: m.Name, Namespace: m.Namespace}, &corev1.Secret{})
 			if err != nil && apierrors.IsNotFound(err) {
@@ -1000,6 +2434,10 @@ func (r *OracleRestDataServiceReconciler) createPods(m *dbapi.OracleRestDataServ
 	} else {
 		// Delete extra pods
 		noDeleted := 0
+		// The ready pod is kept
+		if disruptionBlocked(ctx, r.Client, r.Recorder, m, "scaling in of the pods", available...) {
+			return requeueY
+		}
 		if readyPod.Name != "" {
 			available = append(available, readyPod)
 		}
@@ -1025,11 +2463,141 @@ func (r *OracleRestDataServiceReconciler) createPods(m *dbapi.OracleRestDataServ
 
 	m.Status.Replicas = m.Spec.Replicas
 
-	return requeueN
+	// Come back to replace the next pod with another environment, without holding back the rest of the reconcile
+	if staleResult.RequeueAfter > 0 && (lostPodsResult.RequeueAfter == 0 || staleResult.RequeueAfter < lostPodsResult.RequeueAfter) {
+		return staleResult
+	}
+	return lostPodsResult
 }
 
-// #############################################################################
-//
+// getNodeFailureTimeout returns the time after which the pods of an unreachable node are force deleted,
+// or 0 if they are left to Kubernetes
+func getNodeFailureTimeout(m *dbapi.OracleRestDataService) time.Duration {
+	if m.Spec.NodeFailureTimeout == nil {
+		return 60 * time.Second
+	}
+	return time.Duration(*m.Spec.NodeFailureTimeout) * time.Second
+}
+
+// nodeUnreachableSince returns whether the node no longer reports its status to the control plane, or is deleted,
+// and since when
+func (r *OracleRestDataServiceReconciler) nodeUnreachableSince(nodeName string, ctx context.Context) (time.Time, bool, error) {
+	if nodeName == "" {
+		return time.Time{}, false, nil
+	}
+	node := &corev1.Node{}
+	if err := r.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return time.Time{}, true, nil
+		}
+		return time.Time{}, false, err
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionUnknown {
+			return condition.LastTransitionTime.Time, true, nil
+		}
+	}
+	return time.Time{}, false, nil
+}
+
+// setVolumeZoneAffinity restricts the pod to the zones its persistent volume is accessible from, so that a pod
+// sharing a zonal filesystem with the database is not scheduled in a zone where the volume cannot be mounted
+func (r *OracleRestDataServiceReconciler) setVolumeZoneAffinity(spec *corev1.PodSpec, namespace string, ctx context.Context) error {
+	claimName := ""
+	for _, volume := range spec.Volumes {
+		if volume.Name == "datamount" && volume.PersistentVolumeClaim != nil {
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+	if claimName == "" {
+		return nil
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, types.NamespacedName{Name: claimName, Namespace: namespace}, pvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if pvc.Spec.VolumeName == "" {
+		// The volume is not provisioned yet, the scheduler binds it in the zone of the pod
+		return nil
+	}
+	pv := &corev1.PersistentVolume{}
+	if err := r.Get(ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	key, zones := volumeZones(pv)
+	if len(zones) == 0 {
+		return nil
+	}
+
+	requirement := corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpIn, Values: zones}
+	if spec.Affinity == nil {
+		spec.Affinity = &corev1.Affinity{}
+	}
+	if spec.Affinity.NodeAffinity == nil {
+		spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{requirement}}},
+		}
+		return nil
+	}
+	// The terms are ORed, the zones must be required by each of them
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+	return nil
+}
+
+// volumeZones returns the zone label and the zones a persistent volume is accessible from, from its node affinity,
+// or else from its zone labels
+func volumeZones(pv *corev1.PersistentVolume) (string, []string) {
+	zoneKeys := []string{corev1.LabelTopologyZone, corev1.LabelFailureDomainBetaZone}
+	if pv.Spec.NodeAffinity != nil && pv.Spec.NodeAffinity.Required != nil {
+		for _, key := range zoneKeys {
+			var zones []string
+			for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+				for _, expr := range term.MatchExpressions {
+					if expr.Key == key && expr.Operator == corev1.NodeSelectorOpIn {
+						zones = append(zones, expr.Values...)
+					}
+				}
+			}
+			if len(zones) > 0 {
+				return key, dedupeSorted(zones)
+			}
+		}
+	}
+	for _, key := range zoneKeys {
+		if value := pv.Labels[key]; value != "" {
+			// Volumes available in several zones list them separated by "__"
+			return key, dedupeSorted(strings.Split(value, "__"))
+		}
+	}
+	return "", nil
+}
+
+// dedupeSorted returns the distinct values in order
+func dedupeSorted(values []string) []string {
+	sort.Strings(values)
+	result := []string{}
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// #############################################################################
+//
 //	Manage Finalizer to cleanup before deletion of OracleRestDataService
 //
 // #############################################################################
@@ -1046,15 +2614,31 @@ func (r *OracleRestDataServiceReconciler) manageOracleRestDataServiceDeletion(re
 			// finalization logic fails, don't remove the finalizer so
 			// that we can retry during the next reconciliation.
 			if err := r.cleanupOracleRestDataService(req, ctx, m, n); err != nil {
-				log.Error(err, err.Error())
+				if errors.Is(err, errUninstallJobRunning) {
+					log.Info(err.Error())
+				} else {
+					log.Error(err, err.Error())
+				}
 				return requeueY
 			}
 
-			n.Status.OrdsReference = ""
+			// Hand over the ORDS repository to the remaining ORDS, if any. A database recreated with the name of the one
+			// this ORDS registered with does not reference it
+			if uid, ok := m.Annotations[dbcommons.DatabaseUIDAnnotation]; !ok || uid == string(n.UID) {
+				others := getOtherOrdsReferences(m, n)
+				if len(others) > 0 {
+					owned := false
+					for _, other := range others {
+						owned = owned || other.Owner
+					}
+					others[0].Owner = others[0].Owner || !owned
+				}
+				n.SetConsumers(dbapi.OracleRestDataServiceKind, others)
+			}
 			// Make sure n.Status.OrdsInstalled is set to false or else it blocks .spec.databaseRef deletion
 			for i := 0; i < 10; i++ {
 				log.Info("Clearing the OrdsReference from DB", "name", n.Name)
-				err := r.Status().Update(ctx, n)
+				err := k8s.PatchStatus(ctx, r.Client, n)
 				if err != nil {
 					log.Error(err, err.Error())
 					time.Sleep(1 * time.Second)
@@ -1096,6 +2680,45 @@ func (r *OracleRestDataServiceReconciler) cleanupOracleRestDataService(req ctrl.
 	m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase) error {
 	log := r.Log.WithValues("cleanupOracleRestDataService", req.NamespacedName)
 
+	// The deployment on the API Gateway routes to the load balancer deleted with ORDS
+	if m.Status.ApiGatewayDeploymentId != "" {
+		if err := r.deleteApiGatewayDeployment(m, ctx); err != nil {
+			return err
+		}
+	}
+
+	// The database is lost, so neither ORDS nor the users it created are removed from it
+	if m.Spec.DeletionPolicy == dbcommons.DeletionPolicyAbandon {
+		// An uninstall job started before the policy was changed cannot complete
+		job := &batchv1.Job{}
+		err := r.Get(ctx, types.NamespacedName{Name: m.Name + dbcommons.UninstallJobSuffix, Namespace: m.Namespace}, job)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, err.Error())
+			return err
+		}
+		if err == nil {
+			policy := metav1.DeletePropagationBackground
+			if err := r.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &policy}); err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, "Failed to delete the uninstall Job")
+				return err
+			}
+		}
+		eventReason := "ORDS Uninstallation"
+		eventMsg := "skipping ORDS uninstallation from database " + n.Name + " as the deletion policy is Abandon"
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		log.Info(eventMsg)
+		return r.deleteOrdsPods(m, ctx, req)
+	}
+
+	if others := getOtherOrdsReferences(m, n); len(others) > 0 {
+		// The ORDS repository and common users are still used by the other ORDS
+		eventReason := "ORDS Uninstallation"
+		eventMsg := "skipping ORDS uninstallation as database " + n.Name + " is still served by " + strings.Join(consumerNames(others), ",")
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		log.Info(eventMsg)
+		return r.deleteOrdsPods(m, ctx, req)
+	}
+
 	if m.Status.OrdsInstalled {
 		// ## FETCH THE SIDB REPLICAS .
 		sidbReadyPod, _, _, _, err := dbcommons.FindPods(r, n.Spec.Image.Version,
@@ -1112,39 +2735,11 @@ func (r *OracleRestDataServiceReconciler) cleanupOracleRestDataService(req ctrl.
 			return nil
 		}
 
-		// Get Session id , serial# for ORDS_PUBLIC_USER to kill the sessions
-		out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, false, "bash", "-c",
-			fmt.Sprintf("echo -e  \"%s\"  | %s ", dbcommons.GetSessionInfoSQL, dbcommons.SQLPlusCLI))
-		if err != nil {
-			log.Error(err, err.Error())
-			return err
-		}
-		log.Info("GetSessionInfoSQL Output : " + out)
-
-		sessionInfos, _ := dbcommons.StringToLines(out)
-		killSessions := ""
-		for _, sessionInfo := range sessionInfos {
-			if !strings.Contains(sessionInfo, ",") {
-				// May be a column name or (-----)
-				continue
-			}
-			killSessions += "\n" + fmt.Sprintf(dbcommons.KillSessionSQL, sessionInfo)
-		}
-
-		//kill all the sessions with given sid,serial#
-		out, err = dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, false, "bash", "-c",
-			fmt.Sprintf("echo -e  \"%s\"  | %s ", killSessions, dbcommons.SQLPlusCLI))
-
-		if err != nil {
-			log.Error(err, err.Error())
-			return err
-		}
-		log.Info("KillSession Output : " + out)
-
 		// Fetch admin Password of database to uninstall ORDS
 		adminPasswordSecret := &corev1.Secret{}
 		adminPasswordSecretFound := false
-		for i := 0; i < 5; i++ {
+		attempts := dbcommons.RetryAttempts(5)
+		for i := 0; i < attempts; i++ {
 			err := r.Get(ctx, types.NamespacedName{Name: m.Spec.AdminPassword.SecretName, Namespace: n.Namespace}, adminPasswordSecret)
 			if err != nil {
 				if apierrors.IsNotFound(err) {
@@ -1153,8 +2748,8 @@ func (r *OracleRestDataServiceReconciler) cleanupOracleRestDataService(req ctrl.
 					eventMsg := "database admin password secret " + m.Spec.AdminPassword.SecretName + " required for ORDS uninstall not found, retrying..."
 					r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
 					r.Log.Info(eventMsg)
-					if i < 4 {
-						time.Sleep(15 * time.Second)
+					if i < attempts-1 {
+						time.Sleep(dbcommons.RetryInterval(15 * time.Second))
 						continue
 					}
 				} else {
@@ -1165,227 +2760,1559 @@ func (r *OracleRestDataServiceReconciler) cleanupOracleRestDataService(req ctrl.
 				break
 			}
 		}
-		// Find ORDS ready pod
-		readyPod, _, _, _, err := dbcommons.FindPods(r, m.Spec.Image.Version,
-			m.Spec.Image.PullFrom, m.Name, m.Namespace, ctx, req)
-		if err != nil {
-			log.Error(err, err.Error())
-			return err
-		}
-		if adminPasswordSecretFound && readyPod.Name != "" {
-			adminPassword := string(adminPasswordSecret.Data[m.Spec.AdminPassword.SecretKey])
-			if n.Status.ApexInstalled {
-				//Uninstall Apex
-				eventReason := "Apex Uninstallation"
-				eventMsg := "Uninstalling Apex..."
+
+		if adminPasswordSecretFound {
+			// Uninstall ORDS and APEX through a Job, so that no ORDS pod is needed
+			job := &batchv1.Job{}
+			err = r.Get(ctx, types.NamespacedName{Name: m.Name + dbcommons.UninstallJobSuffix, Namespace: m.Namespace}, job)
+			if err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, err.Error())
+				return err
+			}
+			if apierrors.IsNotFound(err) {
+				// Stop ORDS and kill its sessions before uninstalling
+				if err := r.deleteOrdsPods(m, ctx, req); err != nil {
+					log.Error(err, err.Error())
+					return err
+				}
+				if err := r.killOrdsSessions(sidbReadyPod, ctx, req); err != nil {
+					log.Error(err, err.Error())
+					return err
+				}
+
+				job = r.instantiateUninstallJobSpec(m, n)
+				if err := r.setVolumeZoneAffinity(&job.Spec.Template.Spec, m.Namespace, ctx); err != nil {
+					log.Error(err, err.Error())
+					return err
+				}
+				eventReason := "ORDS Uninstallation"
+				eventMsg := "Uninstalling ORDS with job " + job.Name + "..."
 				r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
 				log.Info(eventMsg)
-				out, err = dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, true, "bash", "-c",
-					fmt.Sprintf(dbcommons.UninstallApex, adminPassword, n.Status.Pdbname))
-				if err != nil {
-					log.Info(err.Error())
+				if err := r.Create(ctx, job); err != nil {
+					log.Error(err, "Failed to create uninstall job", "Job.Name", job.Name)
+					return err
 				}
-				n.Status.ApexInstalled = false // To reinstall Apex when ORDS is reinstalled
-				log.Info("Apex uninstall output: " + out)
+				return errUninstallJobRunning
 			}
-			//Uninstall ORDS
-			eventReason := "ORDS Uninstallation"
-			eventMsg := "Uninstalling ORDS..."
-			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
-			log.Info(eventMsg)
-			uninstallORDS := fmt.Sprintf(dbcommons.UninstallORDSCMD, adminPassword)
-			out, err = dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, true, "bash", "-c",
-				uninstallORDS)
-			log.Info("ORDS uninstall output: " + out)
-			if strings.Contains(strings.ToUpper(out), "ERROR") {
-				return errors.New(out)
+
+			var jobComplete, jobFailed bool
+			for _, condition := range job.Status.Conditions {
+				if condition.Status != corev1.ConditionTrue {
+					continue
+				}
+				if condition.Type == batchv1.JobComplete {
+					jobComplete = true
+				} else if condition.Type == batchv1.JobFailed {
+					jobFailed = true
+				}
 			}
+			if jobFailed {
+				// Delete the failed job so that the uninstall is retried
+				policy := metav1.DeletePropagationBackground
+				r.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &policy})
+				eventReason := "ORDS Uninstallation"
+				eventMsg := "job " + job.Name + " failed to uninstall ORDS, check the logs of its pod. Retrying..."
+				r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+				return errors.New(eventMsg)
+			}
+			if !jobComplete {
+				return errUninstallJobRunning
+			}
+			n.Status.ApexInstalled = false // To reinstall Apex when ORDS is reinstalled
+			policy := metav1.DeletePropagationBackground
+			r.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &policy})
+		}
+
+		// Drop the Admin Users created for this ORDS
+		if !m.Spec.KeepUsers && len(m.Status.CreatedUsers) > 0 {
+			out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
+				fmt.Sprintf("echo -e  \"%s\"  | %s ", dbcommons.DropUsers(m.Status.CreatedUsers, n.Status.ReleaseUpdate), dbcommons.SQLPlusCLI))
 			if err != nil {
 				log.Info(err.Error())
 			}
+			log.Info("Drop admin users: " + out)
+		}
+
+		//Delete Database Admin Password Secret
+		if !*m.Spec.AdminPassword.KeepSecret {
+			err = r.Delete(ctx, adminPasswordSecret, &client.DeleteOptions{})
+			if err == nil {
+				r.Log.Info("Deleted Admin Password Secret :" + adminPasswordSecret.Name)
+			}
+		}
+	}
+
+	// Cleanup steps that the operator needs to do before the CR can be deleted.
+	log.Info("Successfully cleaned up OracleRestDataService ")
+	return nil
+}
+
+// #############################################################################
+//
+//	Instantiate the Job uninstalling ORDS and APEX from the database
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) instantiateUninstallJobSpec(m *dbapi.OracleRestDataService,
+	n *dbapi.SingleInstanceDatabase) *batchv1.Job {
+
+	// The job runs with the scheduling, volume and security settings of the ORDS pods
+	pod, _ := r.instantiatePodSpec(m, n)
+
+	script := ""
+	if n.Status.ApexInstalled {
+		script += fmt.Sprintf(dbcommons.UninstallApex, "${ORACLE_PWD}", n.Status.Pdbname)
+	}
+	script += fmt.Sprintf(dbcommons.UninstallORDSCMD, "${ORACLE_PWD}")
+
+	// The containers fetching the artifacts come first, the settings of init-ords and its configuration volume are looked up by name
+	var env []corev1.EnvVar
+	var mounts []corev1.VolumeMount
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name != "init-ords" {
+			continue
+		}
+		for _, e := range container.Env {
+			if e.Name == "ORACLE_HOST" || e.Name == "ORACLE_PORT" || e.Name == "ORACLE_SERVICE" || e.Name == "ORACLE_PWD" {
+				env = append(env, e)
+			}
+		}
+		for _, mount := range container.VolumeMounts {
+			if mount.Name == "datamount" {
+				mounts = append(mounts, mount)
+			}
+		}
+	}
+	var volumes []corev1.Volume
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == "datamount" {
+			volumes = append(volumes, volume)
+		}
+	}
+
+	backoffLimit := int32(1)
+	job := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.Name + dbcommons.UninstallJobSuffix,
+			Namespace: m.Namespace,
+			Labels: map[string]string{
+				"app": m.Name + dbcommons.UninstallJobSuffix,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app": m.Name + dbcommons.UninstallJobSuffix,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Affinity:           pod.Spec.Affinity,
+					Volumes:            volumes,
+					NodeSelector:       pod.Spec.NodeSelector,
+					ServiceAccountName: pod.Spec.ServiceAccountName,
+					SecurityContext:    pod.Spec.SecurityContext,
+					ImagePullSecrets:   pod.Spec.ImagePullSecrets,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:         "uninstall-ords",
+						Image:        ordsPodImage(m).PullFrom,
+						Command:      []string{"/bin/bash", "-c", withConfigDir(m, fmt.Sprintf(dbcommons.UninstallORDSJobCMD, script))},
+						VolumeMounts: mounts,
+						Env:          env,
+					}},
+				},
+			},
+		},
+	}
+	// Set OracleRestDataService instance as the owner and controller
+	ctrl.SetControllerReference(m, job, r.Scheme)
+	return job
+}
+
+// #############################################################################
+//
+//	Delete all the ORDS pods
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) deleteOrdsPods(m *dbapi.OracleRestDataService, ctx context.Context, req ctrl.Request) error {
+	return r.deleteOrdsPodsOfImage(m, ordsPodImage(m), ctx, req)
+}
+
+// #############################################################################
+//
+//	Delete the ORDS pods running an image
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) deleteOrdsPodsOfImage(m *dbapi.OracleRestDataService, image dbapi.OracleRestDataServiceImage,
+	ctx context.Context, req ctrl.Request) error {
+	readyPod, _, available, _, err := dbcommons.FindPods(r, image.Version,
+		image.PullFrom, m.Name, m.Namespace, ctx, req)
+	if err != nil {
+		return err
+	}
+	if readyPod.Name != "" {
+		available = append(available, readyPod)
+	}
+	// Only the deletion of the ORDS interrupts the critical operations of the pods
+	if m.DeletionTimestamp == nil && disruptionBlocked(ctx, r.Client, r.Recorder, m, "deletion of the ORDS pods", available...) {
+		return errors.New("ORDS pods run a critical operation")
+	}
+	for _, pod := range available {
+		r.Log.Info("Deleting Pod : ", "POD.NAME", pod.Name)
+		var gracePeriodSeconds int64 = 0
+		policy := metav1.DeletePropagationForeground
+		if err := r.Delete(ctx, &pod, &client.DeleteOptions{
+			GracePeriodSeconds: &gracePeriodSeconds, PropagationPolicy: &policy}); err != nil {
+			r.Log.Error(err, "Failed to delete existing POD", "POD.Name", pod.Name)
+		}
+	}
+	return nil
+}
+
+// #############################################################################
+//
+//	Kill the database sessions of the ORDS and APEX users
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) killOrdsSessions(sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) error {
+	log := r.Log.WithValues("killOrdsSessions", req.NamespacedName)
+
+	// Get Session id , serial# for ORDS_PUBLIC_USER to kill the sessions
+	out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		fmt.Sprintf("echo -e  \"%s\"  | %s ", dbcommons.GetSessionInfoSQL, dbcommons.SQLPlusCLI))
+	if err != nil {
+		return err
+	}
+	log.Info("GetSessionInfoSQL Output : " + out)
+
+	sessionInfos, _ := dbcommons.StringToLines(out)
+	killSessions := ""
+	for _, sessionInfo := range sessionInfos {
+		if !strings.Contains(sessionInfo, ",") {
+			// May be a column name or (-----)
+			continue
+		}
+		killSessions += "\n" + dbcommons.KillSession(sessionInfo)
+	}
+
+	//kill all the sessions with given sid,serial#
+	out, err = dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		fmt.Sprintf("echo -e  \"%s\"  | %s ", killSessions, dbcommons.SQLPlusCLI))
+	if err != nil {
+		return err
+	}
+	log.Info("KillSession Output : " + out)
+	return nil
+}
+
+// #############################################################################
+//
+//	Backup ORDS metadata and replace the pods on image upgrade
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) upgradeImage(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("upgradeImage", req.NamespacedName)
+
+	if m.Status.Image == m.Spec.Image {
+		return requeueN
+	}
+	if m.Status.Image.PullFrom == m.Spec.Image.PullFrom && m.Status.Image.Version == m.Spec.Image.Version {
+		// Only the pull secret changed
+		m.Status.Image = m.Spec.Image
+		return requeueN
+	}
+
+	if m.Spec.UpdateStrategy != nil && m.Spec.UpdateStrategy.Type == "Canary" {
+		return r.canaryRollout(m, n, sidbReadyPod, ctx, req)
+	}
+
+	if !r.backupOrdsMetadata(m, n, sidbReadyPod, ctx, req) {
+		return requeueY
+	}
+
+	// Delete the pods of the previous image, createPods replaces them
+	if err := r.deleteOrdsPodsOfImage(m, m.Status.Image, ctx, req); err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+
+	m.Status.Image = m.Spec.Image
+	k8s.PatchStatus(ctx, r.Client, m)
+	eventMsg := "ORDS metadata backed up to " + m.Status.MetadataBackupLocation + ", upgrading to image " + m.Spec.Image.PullFrom
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, "ORDS Upgrade", eventMsg)
+	log.Info(eventMsg)
+	return requeueN
+}
+
+// #############################################################################
+//
+//	Backup ORDS metadata before an image upgrade
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) backupOrdsMetadata(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) bool {
+	log := r.Log.WithValues("backupOrdsMetadata", req.NamespacedName)
+
+	eventReason := "ORDS Upgrade"
+	if sidbReadyPod.Name == "" {
+		eventMsg := "database " + n.Name + " is not ready to backup ORDS metadata before the upgrade, retrying..."
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		r.Log.Info(eventMsg)
+		return false
+	}
+
+	adminPasswordSecret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: m.Spec.AdminPassword.SecretName, Namespace: m.Namespace}, adminPasswordSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			eventMsg := "password secret " + m.Spec.AdminPassword.SecretName + " required to backup ORDS metadata before the upgrade not found, retrying..."
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			r.Log.Info(eventMsg)
+			return false
+		}
+		log.Error(err, err.Error())
+		return false
+	}
+	adminPassword := string(adminPasswordSecret.Data[m.Spec.AdminPassword.SecretKey])
+
+	// Export ORDS_METADATA before the new image upgrades the ORDS repository
+	m.Status.Status = dbcommons.StatusUpdating
+	backup := "ords_metadata_" + time.Now().Format("20060102150405")
+	eventMsg := "backing up ORDS metadata to " + backup + " before upgrading to image " + m.Spec.Image.PullFrom
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+	out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
+		fmt.Sprintf(dbcommons.ExportORDSMetadataCMD, n.Status.Pdbname, adminPassword, backup))
+	if err != nil {
+		out += err.Error()
+	}
+	log.Info("ORDS metadata export output: \n" + out)
+	// A job "completed with N error(s)" leaves an incomplete dump
+	if !strings.Contains(out, "successfully completed") {
+		eventMsg = "backup of ORDS metadata failed, the upgrade will be retried"
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		return false
+	}
+	m.Status.MetadataBackup = backup
+	m.Status.MetadataBackupLocation = strings.Replace(dbcommons.ORDSMetadataBackupDir, "${ORACLE_SID^^}", strings.ToUpper(n.Spec.Sid), 1) +
+		"/" + backup + ".dmp"
+	r.runPostBackupHook(n, m.Status.MetadataBackupLocation, ctx)
+
+	return true
+}
+
+// #############################################################################
+//
+//	Upgrade the image through a canary pod, rolled back if it fails the checks
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) canaryRollout(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("canaryRollout", req.NamespacedName)
+
+	eventReason := "ORDS Upgrade"
+	rollout := meta.FindStatusCondition(m.Status.Conditions, dbcommons.RolloutCondition)
+	if m.Status.CanaryImage == m.Spec.Image.PullFrom && rollout != nil && rollout.Reason == dbcommons.RolledBackReason {
+		// Keep the pods of the previous image until the image is changed again
+		return requeueN
+	}
+
+	if m.Status.CanaryImage != m.Spec.Image.PullFrom || rollout == nil {
+		if !r.backupOrdsMetadata(m, n, sidbReadyPod, ctx, req) {
+			return requeueY
+		}
+		if result := r.createCanaryPod(m, n, ctx, req); result.Requeue {
+			return result
+		}
+		m.Status.CanaryImage = m.Spec.Image.PullFrom
+		eventMsg := "deploying a canary pod with image " + m.Spec.Image.PullFrom
+		// The canary timeout starts from the transition time of the condition of this rollout
+		meta.RemoveStatusCondition(&m.Status.Conditions, dbcommons.RolloutCondition)
+		setRolloutCondition(m, metav1.ConditionFalse, dbcommons.CanaryDeployingReason, eventMsg)
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		log.Info(eventMsg)
+		return requeueY
+	}
+
+	readyPod, replicasFound, _, _, err := dbcommons.FindPods(r, m.Spec.Image.Version,
+		m.Spec.Image.PullFrom, m.Name, m.Namespace, ctx, req)
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	if replicasFound == 0 {
+		// The canary pod has been lost
+		return r.createCanaryPod(m, n, ctx, req)
+	}
+	if readyPod.Name == "" {
+		timeout := 600
+		if m.Spec.UpdateStrategy.CanaryTimeoutSeconds > 0 {
+			timeout = m.Spec.UpdateStrategy.CanaryTimeoutSeconds
+		}
+		if time.Since(rollout.LastTransitionTime.Time) > time.Duration(timeout)*time.Second {
+			return r.rollbackCanary(m, fmt.Sprintf("the canary pod did not become ready within %d seconds", timeout), ctx, req)
+		}
+		log.Info("Waiting for the canary pod to be ready")
+		return requeueY
+	}
+
+	// Health and smoke checks of the canary
+	setRolloutCondition(m, metav1.ConditionFalse, dbcommons.CanaryVerifyingReason, "verifying the canary pod "+readyPod.Name)
+	paths := append([]string{withContextPath(m, dbcommons.OrdsPoolValidationPath)}, m.Spec.UpdateStrategy.SmokeTestPaths...)
+	for _, path := range paths {
+		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+			fmt.Sprintf(dbcommons.GetORDSPathStatus, path))
+		if err != nil {
+			out += err.Error()
+		}
+		log.Info("Smoke check of " + path + ": " + out)
+		status, convErr := strconv.Atoi(strings.TrimSpace(out))
+		if err != nil || convErr != nil || status < 200 || status >= 400 {
+			return r.rollbackCanary(m, "the canary pod failed the check of "+path+": "+strings.TrimSpace(out), ctx, req)
+		}
+	}
+
+	// Promote the canary, createPods replaces the pods of the previous image
+	if err := r.deleteOrdsPodsOfImage(m, m.Status.Image, ctx, req); err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	m.Status.Image = m.Spec.Image
+	eventMsg := "canary pod " + readyPod.Name + " passed the checks, upgrading all the pods to image " + m.Spec.Image.PullFrom
+	setRolloutCondition(m, metav1.ConditionTrue, dbcommons.RolloutCompleteReason, eventMsg)
+	k8s.PatchStatus(ctx, r.Client, m)
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+	return requeueN
+}
+
+func (r *OracleRestDataServiceReconciler) createCanaryPod(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("createCanaryPod", req.NamespacedName)
+
+	pod, _ := r.instantiatePodSpec(m, n)
+	if err := r.setVolumeZoneAffinity(&pod.Spec, m.Namespace, ctx); err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	log.Info("Creating a new canary POD", "POD.Namespace", pod.Namespace, "POD.Name", pod.Name)
+	if err := r.Create(ctx, pod); err != nil {
+		log.Error(err, "Failed to create new canary POD", "POD.Namespace", pod.Namespace, "POD.Name", pod.Name)
+		return requeueY
+	}
+	return requeueN
+}
+
+func (r *OracleRestDataServiceReconciler) rollbackCanary(m *dbapi.OracleRestDataService, reason string,
+	ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("rollbackCanary", req.NamespacedName)
+
+	// The previous pods are kept, and the rest of the reconcile runs on them until the image is changed again
+	if err := r.deleteOrdsPodsOfImage(m, m.Spec.Image, ctx, req); err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	m.Status.Status = dbcommons.StatusError
+	eventMsg := reason + ", rolled back to image " + m.Status.Image.PullFrom
+	if m.Status.MetadataBackup != "" {
+		eventMsg += ". ORDS metadata can be restored from " + m.Status.MetadataBackup
+	}
+	setRolloutCondition(m, metav1.ConditionFalse, dbcommons.RolledBackReason, eventMsg)
+	r.Recorder.Eventf(m, corev1.EventTypeWarning, "ORDS Upgrade", eventMsg)
+	log.Info(eventMsg)
+	return requeueN
+}
+
+func setRolloutCondition(m *dbapi.OracleRestDataService, status metav1.ConditionStatus, reason string, message string) {
+	meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+		Type:               dbcommons.RolloutCondition,
+		Status:             status,
+		ObservedGeneration: m.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// #############################################################################
+//
+//	Restore ORDS metadata from a backup
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) restoreMetadataBackup(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("restoreMetadataBackup", req.NamespacedName)
+
+	if !m.Status.OrdsInstalled || m.Spec.RestoreMetadataBackup == "" || m.Spec.RestoreMetadataBackup == m.Status.MetadataRestored {
+		return requeueN
+	}
+
+	eventReason := "ORDS Metadata Restore"
+	if sidbReadyPod.Name == "" {
+		eventMsg := "database " + n.Name + " is not ready to restore ORDS metadata, retrying..."
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		r.Log.Info(eventMsg)
+		return requeueY
+	}
+
+	adminPasswordSecret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: m.Spec.AdminPassword.SecretName, Namespace: m.Namespace}, adminPasswordSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			eventMsg := "password secret " + m.Spec.AdminPassword.SecretName + " required to restore ORDS metadata not found, retrying..."
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			r.Log.Info(eventMsg)
+			return requeueY
+		}
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	adminPassword := string(adminPasswordSecret.Data[m.Spec.AdminPassword.SecretKey])
+
+	// ORDS must not use the repository while it is replaced
+	if err := r.deleteOrdsPods(m, ctx, req); err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	if err := r.killOrdsSessions(sidbReadyPod, ctx, req); err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+
+	m.Status.Status = dbcommons.StatusUpdating
+	eventMsg := "restoring ORDS metadata from " + m.Spec.RestoreMetadataBackup
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+	out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
+		fmt.Sprintf(dbcommons.ImportORDSMetadataCMD, n.Status.Pdbname, adminPassword, m.Spec.RestoreMetadataBackup))
+	if err != nil {
+		out += err.Error()
+	}
+	log.Info("ORDS metadata import output: \n" + out)
+	if !strings.Contains(out, dbcommons.ORDSMetadataRestoredMsg) {
+		eventMsg = "restore of ORDS metadata from " + m.Spec.RestoreMetadataBackup + " failed, retrying..."
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		return requeueY
+	}
+
+	m.Status.MetadataRestored = m.Spec.RestoreMetadataBackup
+	k8s.PatchStatus(ctx, r.Client, m)
+	eventMsg = "restore of ORDS metadata from " + m.Spec.RestoreMetadataBackup + " completed"
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+	return requeueN
+}
+
+// #############################################################################
+//
+//	Configure APEX
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) configureApex(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ordsReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("configureApex", req.NamespacedName)
+
+	if m.Spec.ApexPassword.SecretName == "" {
+		m.Status.ApexConfigured = false
+		return requeueN
+	}
+	if m.Status.ApexConfigured {
+		return requeueN
+	}
+
+	apexPasswordSecret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: m.Spec.ApexPassword.SecretName, Namespace: m.Namespace}, apexPasswordSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			m.Status.Status = dbcommons.StatusError
+			eventReason := "Apex Password"
+			eventMsg := "password secret " + m.Spec.ApexPassword.SecretName + " not found, retrying..."
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			r.Log.Info(eventMsg)
+			return requeueY
+		}
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	// APEX_LISTENER , APEX_REST_PUBLIC_USER , APEX_PUBLIC_USER passwords
+	apexPassword := string(apexPasswordSecret.Data[m.Spec.ApexPassword.SecretKey])
+
+	// Validate apexPassword
+	if !dbcommons.ApexPasswordValidator(apexPassword) {
+		m.Status.Status = dbcommons.StatusError
+		eventReason := "Apex Password"
+		eventMsg := "password for Apex is invalid, it should contain at least 6 chars, at least one numeric character, at least one punctuation character (!\"#$%&()``*+,-/:;?_), at least one upper-case alphabet"
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		r.Log.Info("APEX password does not conform to the requirements")
+		return requeueY
+	}
+
+	if !n.Status.ApexInstalled {
+		m.Status.Status = dbcommons.StatusUpdating
+		installCtx, span := dbcommons.StartSpan(ctx, "installApex")
+		result := r.installApex(m, n, sidbReadyPod, ordsReadyPod, apexPassword, installCtx, req)
+		span.End()
+		if result.Requeue {
+			log.Info("Reconcile requeued until apex is installed")
+			return result
+		}
+		// Cancelled or timed out
+		if !n.Status.ApexInstalled {
+			return requeueN
+		}
+	} else {
+		// Alter Apex Users
+		log.Info("Alter APEX Users")
+		_, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "",
+			ctx, req, true, "bash", "-c", fmt.Sprintf("echo -e  \"%s\"  | %s",
+				fmt.Sprintf(dbcommons.AlterApexUsers, apexPassword, n.Spec.Pdbname), dbcommons.SQLPlusCLI))
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+	}
+
+	// Set Apex users in apex_rt,apex_al,apex files
+	out, err := dbcommons.ExecCommand(r, r.Config, ordsReadyPod.Name, ordsReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
+		withPublishedConfig(m, fmt.Sprintf(dbcommons.SetApexUsers, apexPassword)))
+	log.Info("SetApexUsers Output: \n" + out)
+	if strings.Contains(strings.ToUpper(out), "ERROR") {
+		return requeueY
+	}
+	if err != nil {
+		log.Info(err.Error())
+		if strings.Contains(strings.ToUpper(err.Error()), "ERROR") {
+			return requeueY
+		}
+	}
+
+	// ORDS needs to be restarted to configure APEX
+	r.Log.Info("Restarting ORDS Pod to complete APEX configuration: " + ordsReadyPod.Name)
+	var gracePeriodSeconds int64 = 0
+	policy := metav1.DeletePropagationForeground
+	err = r.Delete(ctx, &ordsReadyPod, &client.DeleteOptions{
+		GracePeriodSeconds: &gracePeriodSeconds, PropagationPolicy: &policy})
+	if err != nil {
+		r.Log.Error(err, err.Error())
+	}
+
+	m.Status.ApexConfigured = true
+	k8s.PatchStatus(ctx, r.Client, m)
+	eventReason := "Apex Configuration"
+	eventMsg := "configuration of Apex completed!"
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+
+	// Cannot return requeue as the secrets will be deleted if keepSecert is false, which cause problem in pod restart
+	return requeueY
+}
+
+// #############################################################################
+//
+//	Wait for a deleted database reference, or suspend or delete the ORDS
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) manageDatabaseMissing(m *dbapi.OracleRestDataService,
+	ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("manageDatabaseMissing", req.NamespacedName)
+
+	eventReason := "Database Missing"
+	eventMsg := "database reference " + m.Spec.DatabaseRef + " not found"
+
+	// There is no database left to uninstall ORDS from
+	if m.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(m, oracleRestDataServiceFinalizer) {
+			controllerutil.RemoveFinalizer(m, oracleRestDataServiceFinalizer)
+			if err := r.Update(ctx, m); err != nil {
+				log.Error(err, err.Error())
+				return requeueY
+			}
+			r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg+", ORDS is not uninstalled from the database")
+		}
+		return requeueN
+	}
+
+	m.Status.Status = dbcommons.StatusError
+	m.Status.DatabaseRef = ""
+	if !meta.IsStatusConditionTrue(m.Status.Conditions, dbcommons.DatabaseMissingCondition) {
+		meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+			Type:               dbcommons.DatabaseMissingCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: m.GetGeneration(),
+			Reason:             dbcommons.DatabaseNotFoundReason,
+			Message:            eventMsg,
+		})
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		log.Info(eventMsg)
+	}
+
+	switch m.Spec.DatabaseMissingPolicy {
+	case dbcommons.DatabaseMissingDelete:
+		if err := r.Delete(ctx, m); err != nil {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, "deleting the OracleRestDataService")
+		return requeueN
+	case dbcommons.DatabaseMissingSuspend:
+		if err := r.deleteOrdsPods(m, ctx, req); err != nil {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+		m.Status.Status = dbcommons.StatusStopped
+	}
+
+	// The creation of the database is watched, so the requeue backs off up to ten minutes
+	delay := requeueY.RequeueAfter
+	if condition := meta.FindStatusCondition(m.Status.Conditions, dbcommons.DatabaseMissingCondition); condition != nil {
+		if missingFor := time.Since(condition.LastTransitionTime.Time).Round(time.Second); missingFor > delay {
+			delay = missingFor
+		}
+	}
+	if delay > 10*time.Minute {
+		delay = 10 * time.Minute
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: delay}
+}
+
+// #############################################################################
+//
+//	Consume the action annotation of the ORDS
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) manageActions(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("manageActions", req.NamespacedName)
+
+	action, ok := m.Annotations[dbcommons.ActionAnnotation]
+	if !ok {
+		return requeueN
+	}
+
+	// The restart waits for the end of the critical operations of the pods, with the annotation kept until then
+	if action == dbcommons.ActionRestartOrds {
+		readyPod, _, available, _, err := dbcommons.FindPods(r, ordsPodImage(m).Version, ordsPodImage(m).PullFrom, m.Name, m.Namespace, ctx, req)
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+		if disruptionBlocked(ctx, r.Client, r.Recorder, m, "restart of the ORDS pods", append(available, readyPod)...) {
+			return requeueY
+		}
+	}
+
+	// Remove the annotation first, so that the action runs once even if the reconcile fails afterwards
+	annotated := m.DeepCopy()
+	delete(annotated.Annotations, dbcommons.ActionAnnotation)
+	if err := r.Patch(ctx, annotated, client.MergeFromWithOptions(m, client.MergeFromWithOptimisticLock{})); err != nil {
+		log.Error(err, "Failed to remove the action annotation")
+		return requeueY
+	}
+	delete(m.Annotations, dbcommons.ActionAnnotation)
+	m.ResourceVersion = annotated.ResourceVersion
+
+	eventReason := "Action"
+	switch action {
+	case dbcommons.ActionRestartOrds:
+		if err := r.deleteOrdsPods(m, ctx, req); err != nil {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, "restarting the ORDS pods")
+	case dbcommons.ActionReinstallApex:
+		if m.Spec.ApexPassword.SecretName == "" {
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, action+" ignored, apexPassword is not set")
+			return requeueN
+		}
+		if m.Status.ApexInstallation != nil && m.Status.ApexInstallation.Phase == dbcommons.ApexInstallRunning {
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, action+" ignored, Apex is being installed in pod "+
+				m.Status.ApexInstallation.Pod+", set the "+dbcommons.ActionCancelApexInstall+" action to stop it first")
+			return requeueN
+		}
+		// The install needs the passwords, that may have been deleted after the first install
+		for _, secretName := range []string{m.Spec.ApexPassword.SecretName, m.Spec.AdminPassword.SecretName} {
+			if err := r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: m.Namespace}, &corev1.Secret{}); err != nil {
+				if apierrors.IsNotFound(err) {
+					r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, action+" ignored, password secret "+secretName+" not found")
+					return requeueN
+				}
+				log.Error(err, err.Error())
+				return requeueY
+			}
+		}
+		// APEX is installed and configured again with the next ready ORDS pod
+		m.Status.ApexConfigured = false
+		m.Status.ApexInstallation = nil
+		n.Status.ApexInstalled = false
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, "reinstalling Apex in database "+n.Name)
+	case dbcommons.ActionCancelApexInstall:
+		installation := m.Status.ApexInstallation
+		if installation == nil || installation.Phase != dbcommons.ApexInstallRunning {
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, action+" ignored, Apex is not being installed")
+			return requeueN
+		}
+		// The process is gone with its pod
+		if _, err := dbcommons.ExecCommand(r, r.Config, installation.Pod, m.Namespace, "", ctx, req, false, "bash", "-c",
+			withConfigDir(m, fmt.Sprintf(dbcommons.StopBackgroundCMD, "apex-install"))); err != nil {
+			log.Info("Failed to stop the Apex installation: " + err.Error())
+		}
+		r.endApexInstallOperation(m, n, installation.Pod, ctx, req)
+		installation.Phase = dbcommons.ApexInstallCancelled
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, "Apex installation in pod "+installation.Pod+
+			" cancelled, set the "+dbcommons.ActionReinstallApex+" action to restart it")
+	case dbcommons.ActionRefreshUrls:
+		// The URLs are published again from the service and the pods of this reconcile
+		m.Status.ServiceIP = ""
+		m.Status.NodeName = ""
+		m.Status.DatabaseApiUrl = dbcommons.ValueUnavailable
+		m.Status.DatabaseActionsUrl = dbcommons.ValueUnavailable
+		m.Status.ApxeUrl = dbcommons.ValueUnavailable
+		m.Status.DatabaseApiUrls = nil
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, "refreshing the ORDS URLs")
+	default:
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, "unknown action "+action+", expected one of "+
+			strings.Join([]string{dbcommons.ActionRestartOrds, dbcommons.ActionReinstallApex, dbcommons.ActionRefreshUrls,
+				dbcommons.ActionCancelApexInstall}, ", "))
+		return requeueN
+	}
+	log.Info("Action started", "action", action)
+	return requeueN
+}
+
+// #############################################################################
+//
+//	Install APEX in SIDB
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) installApex(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ordsReadyPod corev1.Pod, apexPassword string, ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("installApex", req.NamespacedName)
+
+	// Obtain admin password of the referred database
+	adminPasswordSecret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: m.Spec.AdminPassword.SecretName, Namespace: m.Namespace}, adminPasswordSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			m.Status.Status = dbcommons.StatusError
+			eventReason := "Database Password"
+			eventMsg := "password secret " + m.Spec.AdminPassword.SecretName + " not found, retrying..."
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			r.Log.Info(eventMsg)
+			return requeueY
+		}
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	sidbPassword := string(adminPasswordSecret.Data[m.Spec.AdminPassword.SecretKey])
+
+	installCmd := installLogCMD(m, "apex-install", fmt.Sprintf(dbcommons.InstallApexInContainer, apexPassword, sidbPassword, n.Status.Pdbname))
+	eventReason := "Apex Installation"
+
+	// Without pods/exec, the installation runs in the Job of the command
+	if !dbcommons.PodExecPermitted() {
+		if !r.exportApex(m, n, sidbReadyPod, ctx, req) {
+			return requeueY
+		}
+		if !r.beginApexInstallOperation(m, ordsReadyPod, sidbReadyPod, ctx) {
+			return requeueY
+		}
+		defer r.endApexInstallOperation(m, n, ordsReadyPod.Name, ctx, req)
+		m.Status.Status = dbcommons.StatusUpdating
+		k8s.PatchStatus(ctx, r.Client, m)
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, "performing install of Apex in database "+m.Spec.DatabaseRef)
+		out, err := dbcommons.ExecCommand(r, r.Config, ordsReadyPod.Name, ordsReadyPod.Namespace, "", ctx, req, true, "bash", "-c", installCmd)
+		if err != nil {
+			log.Info(err.Error())
+		}
+		return r.completeApexInstall(m, n, ordsReadyPod.Name, out, sidbPassword, ctx, req)
+	}
+
+	installation := m.Status.ApexInstallation
+	if installation != nil && installation.Phase != dbcommons.ApexInstallRunning {
+		// Stopped until the reinstall-apex action
+		return requeueN
+	}
+
+	if installation != nil {
+		// The installation is interrupted with its pod or its container
+		status := "LOST"
+		pod := &corev1.Pod{}
+		err := r.Get(ctx, types.NamespacedName{Name: installation.Pod, Namespace: m.Namespace}, pod)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+		if err == nil && pod.DeletionTimestamp == nil {
+			status, err = dbcommons.ExecCommand(r, r.Config, installation.Pod, m.Namespace, "", ctx, req, false, "bash", "-c",
+				withConfigDir(m, fmt.Sprintf(dbcommons.PollBackgroundCMD, "apex-install")))
+			if err != nil {
+				log.Error(err, err.Error())
+				return requeueY
+			}
+			status = strings.TrimSpace(status)
+		}
+
+		switch {
+		case strings.HasPrefix(status, "EXIT:"):
+			r.endApexInstallOperation(m, n, installation.Pod, ctx, req)
+			m.Status.ApexInstallation = nil
+			return r.completeApexInstall(m, n, installation.Pod, status, sidbPassword, ctx, req)
+		case strings.HasPrefix(status, "RUNNING"):
+			startTime, _ := time.Parse(time.RFC3339, installation.StartTime)
+			if time.Since(startTime) < getApexInstallTimeout(m) {
+				log.Info("Apex installation running", "pod", installation.Pod, "since", installation.StartTime)
+				r.beginApexInstallOperation(m, *pod, sidbReadyPod, ctx)
+				return ctrl.Result{Requeue: true, RequeueAfter: apexInstallPollInterval}
+			}
+			if _, err := dbcommons.ExecCommand(r, r.Config, installation.Pod, m.Namespace, "", ctx, req, false, "bash", "-c",
+				withConfigDir(m, fmt.Sprintf(dbcommons.StopBackgroundCMD, "apex-install"))); err != nil {
+				log.Error(err, err.Error())
+				return requeueY
+			}
+			r.endApexInstallOperation(m, n, installation.Pod, ctx, req)
+			installation.Phase = dbcommons.ApexInstallTimedOut
+			m.Status.Status = dbcommons.StatusError
+			eventMsg := "Apex installation in pod " + installation.Pod + " stopped after " + getApexInstallTimeout(m).String() +
+				", set the " + dbcommons.ActionReinstallApex + " action to restart it"
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			log.Info(eventMsg)
+			return requeueN
+		default:
+			r.endApexInstallOperation(m, n, installation.Pod, ctx, req)
+			eventMsg := "Apex installation interrupted in pod " + installation.Pod + ", restarting it in pod " + ordsReadyPod.Name
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			log.Info(eventMsg)
+		}
+	}
+
+	// An upgrade of the APEX of the database starts with an export of the workspaces and applications
+	if installation == nil && !r.exportApex(m, n, sidbReadyPod, ctx, req) {
+		return requeueY
+	}
+
+	// Run the installation in the background, polled by the next reconciles
+	if !r.beginApexInstallOperation(m, ordsReadyPod, sidbReadyPod, ctx) {
+		return requeueY
+	}
+	out, err := dbcommons.ExecCommand(r, r.Config, ordsReadyPod.Name, ordsReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
+		withConfigDir(m, fmt.Sprintf(dbcommons.StartBackgroundCMD, "apex-install", base64.StdEncoding.EncodeToString([]byte(installCmd)))))
+	if err != nil || !strings.Contains(out, "STARTED:") {
+		log.Info("Failed to start the Apex installation: " + out)
+		r.endApexInstallOperation(m, n, ordsReadyPod.Name, ctx, req)
+		return requeueY
+	}
+	m.Status.Status = dbcommons.StatusUpdating
+	m.Status.ApexInstallation = &dbapi.OracleRestDataServiceApexInstallation{
+		Phase:     dbcommons.ApexInstallRunning,
+		Pod:       ordsReadyPod.Name,
+		StartTime: time.Now().UTC().Format(time.RFC3339),
+	}
+	k8s.PatchStatus(ctx, r.Client, m)
+	eventMsg := "performing install of Apex in database " + m.Spec.DatabaseRef + " in the background in pod " + ordsReadyPod.Name
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+	return ctrl.Result{Requeue: true, RequeueAfter: apexInstallPollInterval}
+}
+
+// Export the APEX workspaces and applications of the database before APEX is upgraded, as a rollback artifact. Returns
+// true once exported, or if APEX is not installed yet
+func (r *OracleRestDataServiceReconciler) exportApex(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) bool {
+	log := r.Log.WithValues("exportApex", req.NamespacedName)
+
+	eventReason := "Apex Export"
+	if sidbReadyPod.Name == "" {
+		eventMsg := "database " + n.Name + " is not ready to export Apex before the upgrade, retrying..."
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		log.Info(eventMsg)
+		return false
+	}
+
+	out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		fmt.Sprintf("echo -e \"%s\" | %s", fmt.Sprintf(dbcommons.GetApexVersionSQL, n.Status.Pdbname), dbcommons.SQLPlusCLI))
+	if err != nil {
+		log.Error(err, err.Error())
+		return false
+	}
+	apexVersion := ""
+	if i := strings.Index(out, "APEXVERSION:"); i >= 0 {
+		apexVersion = strings.TrimSpace(strings.SplitN(out[i+len("APEXVERSION:"):], "\n", 2)[0])
+	}
+	if apexVersion == "" {
+		return true
+	}
+
+	export := "apex_export_" + time.Now().Format("20060102150405")
+	eventMsg := "exporting the workspaces and applications of Apex " + apexVersion + " to " + export + " before the upgrade"
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+	out, err = dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		fmt.Sprintf(dbcommons.ExportApexCMD, n.Status.Pdbname, export))
+	if err != nil {
+		out += err.Error()
+	}
+	log.Info("Apex export output: \n" + out)
+	if !strings.Contains(out, "PL/SQL procedure successfully completed") {
+		eventMsg = "export of Apex failed, the upgrade will be retried"
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		return false
+	}
+	m.Status.ApexExport = export
+	m.Status.ApexExportLocation = strings.Replace(dbcommons.ORDSMetadataBackupDir, "${ORACLE_SID^^}", strings.ToUpper(n.Spec.Sid), 1) +
+		"/" + export
+	m.Status.ApexExportVersion = apexVersion
+	k8s.PatchStatus(ctx, r.Client, m)
+	eventMsg = fmt.Sprintf("%d Apex workspace and application files exported to %s", strings.Count(out, "APEXEXPORT:"),
+		m.Status.ApexExportLocation)
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+	r.runPostBackupHook(n, m.Status.ApexExportLocation, ctx)
+	return true
+}
+
+// Saves the output of a finished APEX installation and checks that APEX is installed
+func (r *OracleRestDataServiceReconciler) completeApexInstall(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	podName string, out string, sidbPassword string, ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("completeApexInstall", req.NamespacedName)
+
+	r.saveInstallLog(m, "apex-install", out, ctx)
+	eventReason := "Apex Installation"
+	eventMsg := "Apex installation output saved in configmap " + m.Name + dbcommons.InstallLogsSuffix +
+		" and in " + withConfigDir(m, dbcommons.OrdsInstallLogDir) + " of pod " + podName
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+
+	// Checking if Apex is installed successfully or not
+	out, err := dbcommons.ExecCommand(r, r.Config, podName, m.Namespace, "", ctx, req, true, "bash", "-c",
+		fmt.Sprintf(dbcommons.IsApexInstalled, sidbPassword, n.Status.Pdbname))
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	log.Info("Is Apex installed: \n" + out)
+
+	apexVersion := dbcommons.ParseApexVersion(out)
+	if apexVersion == "" {
+		eventReason = "Apex Installation"
+		eventMsg = "Unable to determine Apex version, retrying install..."
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		return requeueY
+	}
+
+	m.Status.Status = dbcommons.StatusReady
+	m.Status.ApexVersion = apexVersion
+	eventReason = "Apex Installation"
+	eventMsg = "installation of Apex " + apexVersion + " completed"
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	n.Status.ApexInstalled = true
+	k8s.PatchStatus(ctx, r.Client, n)
+	return requeueN
+}
+
+// Grabs or renews the lease of the APEX installation, keeping the ORDS pod running it and the ready pod of the database
+// from being deleted or restarted until the installation ends
+func (r *OracleRestDataServiceReconciler) beginApexInstallOperation(m *dbapi.OracleRestDataService, ordsPod corev1.Pod,
+	sidbReadyPod corev1.Pod, ctx context.Context) bool {
+	leaseName := dbcommons.CriticalOperationLeaseName(m.Name, dbcommons.CriticalOperationApexInstall)
+	holder := dbcommons.DatabaseLockHolder("OracleRestDataService", m.Namespace, m.Name)
+	acquired, err := dbcommons.BeginCriticalOperation(ctx, r.Client, m.Namespace, leaseName, holder,
+		m.Spec.BlockEvictionDuringOperations, ordsPod, sidbReadyPod)
+	if err != nil {
+		r.Log.Error(err, err.Error())
+	}
+	return acquired
+}
+
+// Releases the lease of the APEX installation run in the ORDS pod podName, with the annotations of the pods
+func (r *OracleRestDataServiceReconciler) endApexInstallOperation(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	podName string, ctx context.Context, req ctrl.Request) {
+	leaseName := dbcommons.CriticalOperationLeaseName(m.Name, dbcommons.CriticalOperationApexInstall)
+	holder := dbcommons.DatabaseLockHolder("OracleRestDataService", m.Namespace, m.Name)
+	readyPod, _, pods, _, err := dbcommons.FindPods(r, "", "", n.Name, n.Namespace, ctx, req)
+	if err != nil {
+		r.Log.Error(err, err.Error())
+	}
+	pods = append(pods, readyPod, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName}})
+	if err := dbcommons.EndCriticalOperation(ctx, r.Client, m.Namespace, leaseName, holder, pods...); err != nil {
+		r.Log.Error(err, err.Error())
+	}
+}
+
+// Database user of the ORDS pool, also the SQL Administrator user of ORDS
+func getOrdsUser(m *dbapi.OracleRestDataService) string {
+	if m.Spec.OrdsUser != "" {
+		return m.Spec.OrdsUser
+	}
+	return "ORDS_PUBLIC_USER"
+}
+
+// Time the installation of APEX may run before it is stopped
+func getApexInstallTimeout(m *dbapi.OracleRestDataService) time.Duration {
+	if m.Spec.Apex.InstallTimeout == nil {
+		return 60 * time.Minute
+	}
+	return time.Duration(*m.Spec.Apex.InstallTimeout) * time.Minute
+}
+
+// Environment of the ORDS containers added to the variables set by the operator: the proxy of the operator and the
+// network encryption of the database, overridden by the environment of the spec
+func ordsEnv(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase) []corev1.EnvVar {
+	env := dbcommons.ProxyEnv()
+	// Only set for another context path, to keep the environment hash of the pods created before contextPath
+	if contextPath := getOrdsContextPath(m); contextPath != dbcommons.OrdsDefaultContextPath {
+		env = append(env, corev1.EnvVar{Name: "ORDS_CONTEXT_PATH", Value: contextPath})
+	}
+	// Likewise, the pods are replaced when the configuration strategy changes
+	if m.Spec.ConfigStrategy == dbcommons.OrdsConfigStrategyPerPod {
+		env = append(env, corev1.EnvVar{Name: "ORDS_CONFIG_STRATEGY", Value: m.Spec.ConfigStrategy})
+	}
+	// and when the service of the pool changes
+	if m.Spec.OracleService != "" {
+		env = append(env, corev1.EnvVar{Name: "ORDS_POOL_SERVICE", Value: m.Spec.OracleService})
+	}
+	// and when the initial size of the pool changes
+	if m.Spec.WarmUp != nil && m.Spec.WarmUp.InitialPoolSize > 0 {
+		env = append(env, corev1.EnvVar{Name: "ORDS_JDBC_INITIAL_LIMIT", Value: strconv.Itoa(m.Spec.WarmUp.InitialPoolSize)})
+	}
+	// and when the virtual hosts change
+	if len(m.Spec.VirtualHosts) != 0 {
+		// Several hosts may share the pool of a PDB
+		pools := map[string]string{}
+		poolPdbs := []string{}
+		created := map[string]bool{}
+		for _, virtualHost := range m.Spec.VirtualHosts {
+			pool := dbcommons.OrdsVirtualHostPool(virtualHost.PdbName)
+			pools[getOrdsVirtualHostUrl(m, virtualHost)] = pool
+			if !created[pool] {
+				created[pool] = true
+				poolPdbs = append(poolPdbs, pool+"="+strings.ToUpper(virtualHost.PdbName))
+			}
+		}
+		env = append(env, corev1.EnvVar{Name: "ORDS_URL_MAPPING", Value: dbcommons.RenderOrdsUrlMapping(pools)},
+			corev1.EnvVar{Name: "ORDS_VIRTUAL_HOST_POOLS", Value: strings.Join(poolPdbs, " ")})
+	}
+	// and when the rendered settings change
+	if m.Spec.Settings != nil {
+		checksum := fnv.New32a()
+		checksum.Write([]byte(dbcommons.RenderOrdsProperties(m.Spec.Settings.Properties)))
+		env = append(env, corev1.EnvVar{Name: "ORDS_SETTINGS_CHECKSUM", Value: fmt.Sprintf("%08x", checksum.Sum32())})
+	}
+	// and when the distributions installed instead of the ones of the image change
+	if artifacts := ordsArtifacts(m); len(artifacts) != 0 {
+		locations := []string{}
+		for _, a := range artifacts {
+			locations = append(locations, a.name+"="+artifactLocation(a))
+		}
+		env = append(env, corev1.EnvVar{Name: "ORDS_ARTIFACTS", Value: strings.Join(locations, ",")})
+	}
+	// The JDBC thin driver encrypts the connections like the database requires
+	if level, algorithms, checksumAlgorithms := networkEncryptionSettings(n); level != "" {
+		env = append(env, corev1.EnvVar{Name: "JAVA_TOOL_OPTIONS",
+			Value: dbcommons.NetworkEncryptionJavaOptions(level, algorithms, checksumAlgorithms)})
+	}
+	return append(env, m.Spec.Env...)
+}
+
+// Returns the service ORDS connects to, the CDB, or the PDB with the PDB install scope
+func getOrdsServiceName(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase) string {
+	if m.Spec.OracleService != "" {
+		return m.Spec.OracleService
+	}
+	if m.Spec.InstallScope == dbcommons.OrdsInstallScopePDB {
+		return n.Spec.Pdbname
+	}
+	return n.Spec.Sid
+}
+
+// The rendered properties are mounted in the init container, which merges them into the settings file of the volume
+func settingsVolumes(m *dbapi.OracleRestDataService) []corev1.Volume {
+	if m.Spec.Settings == nil {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name: "ords-settings",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: m.Name + dbcommons.OrdsSettingsSecretSuffix},
+		},
+	}}
+}
+
+func settingsMounts(m *dbapi.OracleRestDataService) []corev1.VolumeMount {
+	if m.Spec.Settings == nil {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		MountPath: dbcommons.OrdsSettingsMountDir,
+		Name:      "ords-settings",
+		ReadOnly:  true,
+	}}
+}
+
+// Base URL of a virtual host. The pods only serve HTTPS, which is the scheme of the requests mapped by ORDS
+func getOrdsVirtualHostUrl(m *dbapi.OracleRestDataService, virtualHost dbapi.OracleRestDataServiceVirtualHost) string {
+	return "https://" + virtualHost.Host + getOrdsContextPath(m) + "/"
+}
+
+// Health check of ORDS. Without pods/exec, ORDS is checked through the readiness of its pods, rather than with a Job per check
+func ordsHealthCheck(m *dbapi.OracleRestDataService) string {
+	if !dbcommons.PodExecPermitted() {
+		return dbcommons.OrdsHealthCheckHTTP
+	}
+	return m.Spec.HealthCheck
+}
+
+// Context path of the ORDS server
+func getOrdsContextPath(m *dbapi.OracleRestDataService) string {
+	if m.Spec.ContextPath == "" {
+		return dbcommons.OrdsDefaultContextPath
+	}
+	return m.Spec.ContextPath
+}
+
+// Replace the default context path in an ORDS path or command with the context path of the ORDS
+func withContextPath(m *dbapi.OracleRestDataService, s string) string {
+	return strings.Replace(s, dbcommons.OrdsDefaultContextPath+"/", getOrdsContextPath(m)+"/", 1)
+}
+
+// Hash of the environment of the spec and of the network encryption, recorded on the pods to replace them when it changes,
+// or "" if there is none. The proxy of the operator is left out, it applies to the pods created after it changes
+func ordsEnvHash(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase) string {
+	// ordsEnv starts with the proxy variables
+	hashed := ordsEnv(m, n)[len(dbcommons.ProxyEnv()):]
+	if len(hashed) == 0 {
+		return ""
+	}
+	env, _ := json.Marshal(hashed)
+	hash := fnv.New32a()
+	hash.Write(env)
+	return fmt.Sprintf("%08x", hash.Sum32())
+}
+
+// Wrap an installation command to save its output in a log file of the ORDS volume
+func installLogCMD(m *dbapi.OracleRestDataService, name string, cmd string) string {
+	return withConfigDir(m, fmt.Sprintf(dbcommons.SaveInstallLogCMD, name, cmd, dbcommons.InstallLogRotation+1, dbcommons.InstallLogTailLines))
+}
+
+// Mounts of the configuration of the ORDS container. PerPod containers mount the directory of the pod, and the shared
+// configuration next to it for the commands changing it
+func ordsConfigMounts(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase) []corev1.VolumeMount {
+	if m.Spec.ConfigStrategy != dbcommons.OrdsConfigStrategyPerPod {
+		return []corev1.VolumeMount{{
+			MountPath: getOrdsImageConfigDir(m),
+			Name:      "datamount",
+			SubPath:   getOrdsConfigDir(m, n),
+		}}
+	}
+	return append(podConfigMounts(m, getOrdsImageConfigDir(m)), corev1.VolumeMount{
+		MountPath: withConfigDir(m, dbcommons.OrdsSharedConfigDir),
+		Name:      "datamount",
+		SubPath:   getOrdsConfigDir(m, n),
+	})
+}
+
+// Mount of the configuration directory of a PerPod pod on a path
+func podConfigMounts(m *dbapi.OracleRestDataService, path string) []corev1.VolumeMount {
+	if m.Spec.ConfigStrategy != dbcommons.OrdsConfigStrategyPerPod {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		MountPath: path,
+		Name:      "ords-config",
+	}}
+}
+
+// Distribution of the spec installed instead of the one of the image. The file or directory subPath of the extracted
+// zip is mounted on mountPath
+type ordsArtifact struct {
+	name      string
+	source    *dbapi.OracleRestDataServiceArtifactSource
+	subPath   string
+	mountPath string
+}
+
+// Distributions of spec.apex.source and spec.ords.source. The APEX zip holds an apex directory, the ORDS zip holds ords.war
+func specArtifacts(m *dbapi.OracleRestDataService) []ordsArtifact {
+	var artifacts []ordsArtifact
+	if m.Spec.Apex.Source != nil {
+		artifacts = append(artifacts, ordsArtifact{"apex", m.Spec.Apex.Source, "apex/apex", dbcommons.OrdsApexDir})
+	}
+	if m.Spec.Ords != nil && m.Spec.Ords.Source != nil {
+		artifacts = append(artifacts, ordsArtifact{"ords", m.Spec.Ords.Source, "ords/ords.war", dbcommons.OrdsWarFile})
+	}
+	return artifacts
+}
+
+// Distributions of the spec to install, with their URL redirected to its mirror. In offline mode, the ones downloaded
+// from a URL with no mirror are skipped, and the distributions of the image are used instead
+func ordsArtifacts(m *dbapi.OracleRestDataService) []ordsArtifact {
+	var artifacts []ordsArtifact
+	for _, a := range specArtifacts(m) {
+		if a.source.URL != "" {
+			url, reachable := dbcommons.ResolveExternalLocation(a.source.URL)
+			if !reachable {
+				continue
+			}
+			source := *a.source
+			source.URL = url
+			a.source = &source
 		}
+		artifacts = append(artifacts, a)
+	}
+	return artifacts
+}
 
-		// Drop Admin Users
-		out, err = dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
-			fmt.Sprintf("echo -e  \"%s\"  | %s ", dbcommons.DropAdminUsersSQL, dbcommons.SQLPlusCLI))
-		if err != nil {
-			log.Info(err.Error())
+// Location of the zip of a distribution, such as pvc/<claim>/<path>, followed by its checksum if any
+func artifactLocation(a ordsArtifact) string {
+	location := a.source.URL
+	if a.source.PersistentVolumeClaim != "" {
+		location = "pvc/" + a.source.PersistentVolumeClaim + "/" + a.source.Path
+	} else if a.source.ConfigMap != "" {
+		location = "configmap/" + a.source.ConfigMap + "/" + a.source.Path
+	}
+	if a.source.Sha256 != "" {
+		location += "#sha256=" + a.source.Sha256
+	}
+	return location
+}
+
+// Volume the distributions are extracted to, and volumes of the claims and ConfigMaps holding their zips
+func artifactVolumes(m *dbapi.OracleRestDataService) []corev1.Volume {
+	artifacts := ordsArtifacts(m)
+	if len(artifacts) == 0 {
+		return nil
+	}
+	volumes := []corev1.Volume{{
+		Name:         "artifacts",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+	for _, a := range artifacts {
+		if a.source.PersistentVolumeClaim != "" {
+			volumes = append(volumes, corev1.Volume{
+				Name: a.name + "-source",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: a.source.PersistentVolumeClaim,
+					ReadOnly:  true,
+				}},
+			})
+		} else if a.source.ConfigMap != "" {
+			volumes = append(volumes, corev1.Volume{
+				Name: a.name + "-source",
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: a.source.ConfigMap},
+				}},
+			})
 		}
-		log.Info("Drop admin users: " + out)
+	}
+	return volumes
+}
 
-		//Delete ORDS pod
-		var gracePeriodSeconds int64 = 0
-		policy := metav1.DeletePropagationForeground
-		r.Delete(ctx, &readyPod, &client.DeleteOptions{
-			GracePeriodSeconds: &gracePeriodSeconds, PropagationPolicy: &policy})
+// Mounts of the extracted distributions over the ones of the image
+func artifactMounts(m *dbapi.OracleRestDataService) []corev1.VolumeMount {
+	var mounts []corev1.VolumeMount
+	for _, a := range ordsArtifacts(m) {
+		mounts = append(mounts, corev1.VolumeMount{
+			MountPath: a.mountPath,
+			Name:      "artifacts",
+			SubPath:   a.subPath,
+			ReadOnly:  true,
+		})
+	}
+	return mounts
+}
 
-		//Delete Database Admin Password Secret
-		if !*m.Spec.AdminPassword.KeepSecret {
-			err = r.Delete(ctx, adminPasswordSecret, &client.DeleteOptions{})
-			if err == nil {
-				r.Log.Info("Deleted Admin Password Secret :" + adminPasswordSecret.Name)
-			}
+// Init container fetching the zips of the distributions, checking them and extracting them to the artifacts volume
+func fetchArtifactsContainers(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	runAsUser int64, runAsGroup int64) []corev1.Container {
+	artifacts := ordsArtifacts(m)
+	if len(artifacts) == 0 {
+		return nil
+	}
+	mounts := []corev1.VolumeMount{{MountPath: dbcommons.OrdsArtifactsDir, Name: "artifacts"}}
+	cmds := []string{}
+	for _, a := range artifacts {
+		dir := dbcommons.OrdsArtifactsDir + "/" + a.name
+		zip := dbcommons.OrdsArtifactSourcesDir + "/" + a.name + "/" + a.source.Path
+		if a.source.URL != "" {
+			zip = dir + ".zip"
+			cmds = append(cmds, fmt.Sprintf(dbcommons.DownloadArtifactCMD, a.source.URL, zip))
+		} else {
+			mounts = append(mounts, corev1.VolumeMount{
+				MountPath: dbcommons.OrdsArtifactSourcesDir + "/" + a.name,
+				Name:      a.name + "-source",
+				ReadOnly:  true,
+			})
+		}
+		if a.source.Sha256 != "" {
+			cmds = append(cmds, fmt.Sprintf(dbcommons.CheckArtifactCMD, a.source.Sha256, zip))
+		}
+		cmds = append(cmds, fmt.Sprintf(dbcommons.ExtractArtifactCMD, zip, dir))
+		if a.source.URL != "" {
+			cmds = append(cmds, "rm -f "+zip)
 		}
 	}
+	return []corev1.Container{{
+		Name:    "fetch-artifacts",
+		Image:   ordsPodImage(m).PullFrom,
+		Command: []string{"/bin/sh", "-c", strings.Join(cmds, " && ")},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:  &runAsUser,
+			RunAsGroup: &runAsGroup,
+		},
+		VolumeMounts: mounts,
+		// The proxy of the operator for the downloads
+		Env: ordsEnv(m, n),
+	}}
+}
 
-	// Cleanup steps that the operator needs to do before the CR can be deleted.
-	log.Info("Successfully cleaned up OracleRestDataService ")
-	return nil
+// Have a command changing the ORDS configuration in a pod also change the shared configuration, which the next pods copy
+func withPublishedConfig(m *dbapi.OracleRestDataService, cmd string) string {
+	if m.Spec.ConfigStrategy != dbcommons.OrdsConfigStrategyPerPod {
+		return cmd
+	}
+	return cmd + "\n" + withConfigDir(m, dbcommons.PublishOrdsConfigCMD)
+}
+
+// Directory of the ORDS configuration in the image of the pods
+func getOrdsImageConfigDir(m *dbapi.OracleRestDataService) string {
+	if ordsPodImage(m).ConfigDir == "" {
+		return dbcommons.OrdsConfigDir
+	}
+	return strings.TrimSuffix(ordsPodImage(m).ConfigDir, "/")
+}
+
+// Image of the ORDS pods: the image of the spec, or the previous image while the canary of the image of the spec is rolled back
+func ordsPodImage(m *dbapi.OracleRestDataService) dbapi.OracleRestDataServiceImage {
+	rollout := meta.FindStatusCondition(m.Status.Conditions, dbcommons.RolloutCondition)
+	if m.Status.Image.PullFrom != "" && m.Status.CanaryImage == m.Spec.Image.PullFrom &&
+		rollout != nil && rollout.Reason == dbcommons.RolledBackReason {
+		return m.Status.Image
+	}
+	return m.Spec.Image
+}
+
+// Replace the default directory of the ORDS configuration in a command with the directory of the image
+func withConfigDir(m *dbapi.OracleRestDataService, cmd string) string {
+	configDir := getOrdsImageConfigDir(m)
+	if configDir == dbcommons.OrdsConfigDir {
+		return cmd
+	}
+	return strings.NewReplacer(dbcommons.OrdsConfigDir, configDir, "$ORDS_HOME/config/ords", configDir).Replace(cmd)
 }
 
 // #############################################################################
 //
-//	Configure APEX
+//	Keep the end of an installation log in the install-logs ConfigMap of the ORDS
 //
 // #############################################################################
-func (r *OracleRestDataServiceReconciler) configureApex(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
-	sidbReadyPod corev1.Pod, ordsReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) ctrl.Result {
-	log := r.Log.WithValues("configureApex", req.NamespacedName)
-
-	if m.Spec.ApexPassword.SecretName == "" {
-		m.Status.ApexConfigured = false
-		return requeueN
-	}
-	if m.Status.ApexConfigured {
-		return requeueN
-	}
+func (r *OracleRestDataServiceReconciler) saveInstallLog(m *dbapi.OracleRestDataService, name string, out string,
+	ctx context.Context) {
+	log := r.Log.WithValues("saveInstallLog", m.Name)
 
-	apexPasswordSecret := &corev1.Secret{}
-	err := r.Get(ctx, types.NamespacedName{Name: m.Spec.ApexPassword.SecretName, Namespace: m.Namespace}, apexPasswordSecret)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			m.Status.Status = dbcommons.StatusError
-			eventReason := "Apex Password"
-			eventMsg := "password secret " + m.Spec.ApexPassword.SecretName + " not found, retrying..."
-			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
-			r.Log.Info(eventMsg)
-			return requeueY
-		}
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: m.Name + dbcommons.InstallLogsSuffix, Namespace: m.Namespace}, configMap)
+	if err != nil && !apierrors.IsNotFound(err) {
 		log.Error(err, err.Error())
-		return requeueY
+		return
 	}
-	// APEX_LISTENER , APEX_REST_PUBLIC_USER , APEX_PUBLIC_USER passwords
-	apexPassword := string(apexPasswordSecret.Data[m.Spec.ApexPassword.SecretKey])
-
-	if !n.Status.ApexInstalled {
-		m.Status.Status = dbcommons.StatusUpdating
-		result := r.installApex(m, n, ordsReadyPod, apexPassword, ctx, req)
-		if result.Requeue {
-			log.Info("Reconcile requeued because apex installation failed")
-			return result
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.Name + dbcommons.InstallLogsSuffix,
+				Namespace: m.Namespace,
+			},
+			Data: map[string]string{name + ".log": out},
 		}
+		ctrl.SetControllerReference(m, configMap, r.Scheme)
+		err = r.Create(ctx, configMap)
 	} else {
-		// Alter Apex Users
-		log.Info("Alter APEX Users")
-		_, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "",
-			ctx, req, true, "bash", "-c", fmt.Sprintf("echo -e  \"%s\"  | %s",
-				fmt.Sprintf(dbcommons.AlterApexUsers, apexPassword, n.Spec.Pdbname), dbcommons.SQLPlusCLI))
-		if err != nil {
-			log.Error(err, err.Error())
-			return requeueY
-		}
-	}
-
-	// Set Apex users in apex_rt,apex_al,apex files
-	out, err := dbcommons.ExecCommand(r, r.Config, ordsReadyPod.Name, ordsReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
-		fmt.Sprintf(dbcommons.SetApexUsers, apexPassword))
-	log.Info("SetApexUsers Output: \n" + out)
-	if strings.Contains(strings.ToUpper(out), "ERROR") {
-		return requeueY
-	}
-	if err != nil {
-		log.Info(err.Error())
-		if strings.Contains(strings.ToUpper(err.Error()), "ERROR") {
-			return requeueY
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
 		}
+		configMap.Data[name+".log"] = out
+		err = r.Update(ctx, configMap)
 	}
-
-	// ORDS needs to be restarted to configure APEX
-	r.Log.Info("Restarting ORDS Pod to complete APEX configuration: " + ordsReadyPod.Name)
-	var gracePeriodSeconds int64 = 0
-	policy := metav1.DeletePropagationForeground
-	err = r.Delete(ctx, &ordsReadyPod, &client.DeleteOptions{
-		GracePeriodSeconds: &gracePeriodSeconds, PropagationPolicy: &policy})
 	if err != nil {
-		r.Log.Error(err, err.Error())
+		log.Error(err, err.Error())
 	}
-
-	m.Status.ApexConfigured = true
-	r.Status().Update(ctx, m)
-	eventReason := "Apex Configuration"
-	eventMsg := "configuration of Apex completed!"
-	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
-	log.Info(eventMsg)
-
-	// Cannot return requeue as the secrets will be deleted if keepSecert is false, which cause problem in pod restart
-	return requeueY
 }
 
 // #############################################################################
 //
-//	Install APEX in SIDB
+//	Install APEX languages in SIDB
 //
 // #############################################################################
-func (r *OracleRestDataServiceReconciler) installApex(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
-	ordsReadyPod corev1.Pod, apexPassword string, ctx context.Context, req ctrl.Request) ctrl.Result {
-	log := r.Log.WithValues("installApex", req.NamespacedName)
+func (r *OracleRestDataServiceReconciler) installApexLanguages(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	ordsReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("installApexLanguages", req.NamespacedName)
+
+	if !m.Status.ApexConfigured || !n.Status.ApexInstalled {
+		return requeueN
+	}
+
+	installed := make(map[string]bool)
+	for _, lang := range m.Status.ApexLanguages {
+		installed[lang] = true
+	}
+	var pending []string
+	for _, lang := range m.Spec.Apex.Languages {
+		if !installed[string(lang)] {
+			pending = append(pending, string(lang))
+		}
+	}
+	if len(pending) == 0 {
+		return requeueN
+	}
 
 	// Obtain admin password of the referred database
 	adminPasswordSecret := &corev1.Secret{}
 	err := r.Get(ctx, types.NamespacedName{Name: m.Spec.AdminPassword.SecretName, Namespace: m.Namespace}, adminPasswordSecret)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			m.Status.Status = dbcommons.StatusError
-			eventReason := "Database Password"
-			eventMsg := "password secret " + m.Spec.AdminPassword.SecretName + " not found, retrying..."
+			eventReason := "Apex Languages"
+			eventMsg := "password secret " + m.Spec.AdminPassword.SecretName + " required to install Apex languages " +
+				strings.Join(pending, ",") + " not found"
 			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
 			r.Log.Info(eventMsg)
-			return requeueY
+			return requeueN
 		}
 		log.Error(err, err.Error())
 		return requeueY
 	}
 	sidbPassword := string(adminPasswordSecret.Data[m.Spec.AdminPassword.SecretKey])
 
-	// Status Updation
-	m.Status.Status = dbcommons.StatusUpdating
-	r.Status().Update(ctx, m)
-	eventReason := "Apex Installation"
-	eventMsg := "performing install of Apex in database " + m.Spec.DatabaseRef
-	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
-
-	//Install Apex in SIDB ready pod
-	out, err := dbcommons.ExecCommand(r, r.Config, ordsReadyPod.Name, ordsReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
-		fmt.Sprintf(dbcommons.InstallApexInContainer, apexPassword, sidbPassword, n.Status.Pdbname))
-	if err != nil {
-		log.Info(err.Error())
-	}
-	log.Info("Apex installation output : \n" + out)
+	for _, lang := range pending {
+		eventReason := "Apex Languages"
+		eventMsg := "installing Apex language " + lang + " in database " + m.Spec.DatabaseRef
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		log.Info(eventMsg)
 
-	// Checking if Apex is installed successfully or not
-	out, err = dbcommons.ExecCommand(r, r.Config, ordsReadyPod.Name, ordsReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
-		fmt.Sprintf(dbcommons.IsApexInstalled, sidbPassword, n.Status.Pdbname))
-	if err != nil {
-		log.Error(err, err.Error())
-		return requeueY
-	}
-	log.Info("Is Apex installed: \n" + out)
+		out, err := dbcommons.ExecCommand(r, r.Config, ordsReadyPod.Name, ordsReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
+			installLogCMD(m, "apex-language-"+lang, fmt.Sprintf(dbcommons.InstallApexLanguageInContainer, lang, sidbPassword, n.Status.Pdbname)))
+		r.saveInstallLog(m, "apex-language-"+lang, out, ctx)
+		log.Info("Apex language " + lang + " installation output saved in configmap " + m.Name + dbcommons.InstallLogsSuffix)
+		if err != nil || strings.Contains(out, "ORA-") || strings.Contains(out, "SP2-") {
+			if err != nil {
+				log.Info(err.Error())
+			}
+			eventMsg = "installation of Apex language " + lang + " failed, retrying..."
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			return requeueY
+		}
 
-	apexInstalled := "APEXVERSION:"
-	if !strings.Contains(out, apexInstalled) {
-		eventReason = "Apex Installation"
-		eventMsg = "Unable to determine Apex version, retrying install..."
-		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
-		return requeueY
+		// Record each language as soon as it is loaded so that it is not loaded again
+		m.Status.ApexLanguages = append(m.Status.ApexLanguages, lang)
+		if err := k8s.PatchStatus(ctx, r.Client, m); err != nil {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+		eventMsg = "installation of Apex language " + lang + " completed"
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
 	}
-
-	m.Status.Status = dbcommons.StatusReady
-	eventReason = "Apex Installation"
-	outArr := strings.Split(out, apexInstalled)
-	eventMsg = "installation of Apex " + strings.TrimSpace(outArr[len(outArr)-1]) + " completed"
-	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
-	n.Status.ApexInstalled = true
-	r.Status().Update(ctx, n)
 	return requeueN
 }
 
@@ -1485,7 +4412,7 @@ func (r *OracleRestDataServiceReconciler) restEnableSchemas(m *dbapi.OracleRestD
 			continue
 		}
 
-		getOrdsSchemaStatus := fmt.Sprintf(dbcommons.GetUserORDSSchemaStatusSQL, m.Spec.RestEnableSchemas[i].SchemaName, pdbName)
+		getOrdsSchemaStatus := dbcommons.GetUserORDSSchemaStatus(m.Spec.RestEnableSchemas[i].SchemaName, pdbName)
 
 		// Get ORDS Schema status for PDB
 		out, err := dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
@@ -1523,7 +4450,7 @@ func (r *OracleRestDataServiceReconciler) restEnableSchemas(m *dbapi.OracleRestD
 			}
 			password := string(OrdsPasswordSecret.Data[m.Spec.OrdsPassword.SecretKey])
 			// Create users,schemas and grant enableORDS for PDB
-			createSchemaSQL := fmt.Sprintf(dbcommons.CreateORDSSchemaSQL, m.Spec.RestEnableSchemas[i].SchemaName, password, pdbName)
+			createSchemaSQL := dbcommons.CreateORDSSchema(m.Spec.RestEnableSchemas[i].SchemaName, password, pdbName)
 			log.Info("Creating schema", "schema", m.Spec.RestEnableSchemas[i].SchemaName)
 			_, err = dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
 				fmt.Sprintf("echo -e  \"%s\"  | %s", createSchemaSQL, dbcommons.SQLPlusCLI))
@@ -1535,14 +4462,19 @@ func (r *OracleRestDataServiceReconciler) restEnableSchemas(m *dbapi.OracleRestD
 			log.Info("Noop, ignoring", "schema", m.Spec.RestEnableSchemas[i].SchemaName)
 			continue
 		}
+		// Disabling a schema restarts the ORDS pod, once its critical operations end
+		if !m.Spec.RestEnableSchemas[i].Enable && disruptionBlocked(ctx, r.Client, r.Recorder, m,
+			"disabling of schema "+m.Spec.RestEnableSchemas[i].SchemaName, ordsReadyPod) {
+			return requeueY
+		}
 		urlMappingPattern := ""
 		if m.Spec.RestEnableSchemas[i].UrlMapping == "" {
-			urlMappingPattern = strings.ToLower(m.Spec.RestEnableSchemas[i].SchemaName)
+			urlMappingPattern = dbcommons.ORDSUrlMapping(m.Spec.UrlMappingTemplate, m.Spec.RestEnableSchemas[i].SchemaName, pdbName)
 		} else {
 			urlMappingPattern = strings.ToLower(m.Spec.RestEnableSchemas[i].UrlMapping)
 		}
-		enableORDSSchema := fmt.Sprintf(dbcommons.EnableORDSSchemaSQL, m.Spec.RestEnableSchemas[i].SchemaName,
-			strconv.FormatBool(m.Spec.RestEnableSchemas[i].Enable), urlMappingPattern, pdbName)
+		enableORDSSchema := dbcommons.EnableORDSSchema(m.Spec.RestEnableSchemas[i].SchemaName,
+			m.Spec.RestEnableSchemas[i].Enable, urlMappingPattern, pdbName)
 
 		// EnableORDS for Schema
 		out, err = dbcommons.ExecCommand(r, r.Config, sidbReadyPod.Name, sidbReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
@@ -1574,6 +4506,167 @@ func (r *OracleRestDataServiceReconciler) restEnableSchemas(m *dbapi.OracleRestD
 	return requeueN
 }
 
+// #############################################################################
+//
+//	Create the OAuth clients of the schemas, and the Secrets of their credentials
+//
+// #############################################################################
+func (r *OracleRestDataServiceReconciler) manageOAuthClients(m *dbapi.OracleRestDataService, n *dbapi.SingleInstanceDatabase,
+	sidbReadyPod corev1.Pod, ctx context.Context, req ctrl.Request) ctrl.Result {
+
+	log := r.Log.WithValues("manageOAuthClients", req.NamespacedName)
+
+	if sidbReadyPod.Name == "" || n.Status.Status != dbcommons.StatusReady {
+		eventReason := "Database Check"
+		eventMsg := "status of database " + n.Name + " is not ready, retrying..."
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		return requeueY
+	}
+
+	// Delete the clients removed from the spec, along with their Secret
+	var clients []dbapi.OracleRestDataServiceOAuthClientStatus
+	for i, created := range m.Status.OAuthClients {
+		if getOAuthClient(m, created.Name) != nil {
+			clients = append(clients, created)
+			continue
+		}
+		out, err := dbcommons.ExecSQL(r, r.Config, sidbReadyPod, ctx, req, false,
+			dbcommons.DeleteOAuthClient(created.PdbName, created.Schema, created.Name))
+		if err == nil && strings.Contains(out, "ORA-") {
+			err = errors.New(out)
+		}
+		if err != nil {
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, "OAuth Client", "deleting client %s of schema %s failed: %s",
+				created.Name, created.Schema, err.Error())
+			log.Error(err, err.Error())
+			m.Status.OAuthClients = append(clients, m.Status.OAuthClients[i:]...)
+			return requeueY
+		}
+		err = r.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: created.SecretName, Namespace: m.Namespace}})
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, err.Error())
+		}
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, "OAuth Client", "client %s of schema %s deleted", created.Name, created.Schema)
+	}
+	m.Status.OAuthClients = clients
+
+	for _, oauthClient := range m.Spec.OAuthClients {
+		pdbName := oauthClient.PdbName
+		if pdbName == "" {
+			pdbName = n.Spec.Pdbname
+		}
+		specHash := oauthClientHash(oauthClient, pdbName)
+		secretName := m.Name + "-oauth-" + oauthClient.Name
+
+		// Clients are defined again when their spec changes, or when their Secret is lost
+		index := -1
+		for i := range m.Status.OAuthClients {
+			if m.Status.OAuthClients[i].Name == oauthClient.Name {
+				index = i
+			}
+		}
+		if index >= 0 && m.Status.OAuthClients[index].SpecHash == specHash {
+			err := r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: m.Namespace}, &corev1.Secret{})
+			if err == nil {
+				continue
+			}
+			if !apierrors.IsNotFound(err) {
+				log.Error(err, err.Error())
+				return requeueY
+			}
+		}
+
+		var privileges []dbcommons.OAuthPrivilege
+		for _, privilege := range oauthClient.Privileges {
+			privileges = append(privileges, dbcommons.OAuthPrivilege{Name: privilege.Name, Patterns: privilege.Patterns})
+		}
+		grantType := oauthClient.GrantType
+		if grantType == "" {
+			grantType = "client_credentials"
+		}
+		// The output holds the client secret
+		out, err := dbcommons.ExecSQL(r, r.Config, sidbReadyPod, ctx, req, true,
+			dbcommons.DefineOAuthClient(pdbName, oauthClient.Schema, oauthClient.Name, grantType, oauthClient.AllowedOrigins,
+				oauthClient.RedirectUri, oauthClient.SupportEmail, privileges))
+		if err != nil {
+			log.Error(err, "defining the OAuth client failed", "client", oauthClient.Name)
+			return requeueY
+		}
+		clientId, clientSecret := dbcommons.ParseOAuthClientCredentials(out)
+		if strings.Contains(out, "ORA-") || clientId == "" {
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, "OAuth Client",
+				"defining client %s failed, check that schema %s of PDB %s is REST enabled", oauthClient.Name, oauthClient.Schema, pdbName)
+			log.Info("Defining the OAuth client failed", "client", oauthClient.Name, "schema", oauthClient.Schema)
+			return requeueY
+		}
+
+		// Write the credentials to the Secret of the client
+		secret := &corev1.Secret{}
+		err = r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: m.Namespace}, secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+		if apierrors.IsNotFound(err) {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretName,
+					Namespace: m.Namespace,
+					Labels:    map[string]string{"app": m.Name},
+				},
+			}
+			ctrl.SetControllerReference(m, secret, r.Scheme)
+		}
+		secret.Data = map[string][]byte{
+			"client_id":     []byte(clientId),
+			"client_secret": []byte(clientSecret),
+		}
+		if secret.ResourceVersion == "" {
+			err = r.Create(ctx, secret)
+		} else {
+			err = r.Update(ctx, secret)
+		}
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY
+		}
+
+		created := dbapi.OracleRestDataServiceOAuthClientStatus{
+			Name:       oauthClient.Name,
+			Schema:     oauthClient.Schema,
+			PdbName:    pdbName,
+			SecretName: secretName,
+			SpecHash:   specHash,
+		}
+		if index >= 0 {
+			m.Status.OAuthClients[index] = created
+		} else {
+			m.Status.OAuthClients = append(m.Status.OAuthClients, created)
+		}
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, "OAuth Client", "client %s of schema %s defined, credentials in secret %s",
+			oauthClient.Name, oauthClient.Schema, secretName)
+	}
+	return requeueN
+}
+
+// Returns the OAuth client name of the spec, nil when it was removed
+func getOAuthClient(m *dbapi.OracleRestDataService, name string) *dbapi.OracleRestDataServiceOAuthClient {
+	for i := range m.Spec.OAuthClients {
+		if m.Spec.OAuthClients[i].Name == name {
+			return &m.Spec.OAuthClients[i]
+		}
+	}
+	return nil
+}
+
+func oauthClientHash(oauthClient dbapi.OracleRestDataServiceOAuthClient, pdbName string) string {
+	spec, _ := json.Marshal(oauthClient)
+	hash := fnv.New32a()
+	hash.Write(spec)
+	hash.Write([]byte(pdbName))
+	return fmt.Sprintf("%08x", hash.Sum32())
+}
+
 // #############################################################################
 //
 //	SetupWithManager sets up the controller with the Manager.
@@ -1582,8 +4675,32 @@ func (r *OracleRestDataServiceReconciler) restEnableSchemas(m *dbapi.OracleRestD
 func (r *OracleRestDataServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbapi.OracleRestDataService{}).
-		Owns(&corev1.Pod{}). //Watch for deleted pods of OracleRestDataService Owner
+		WithEventFilter(fleetPredicate()).
+		Owns(&corev1.Pod{}).     //Watch for deleted pods of OracleRestDataService Owner
+		Owns(&corev1.Service{}). //Watch for the address changes of the load balancers
+		Watches(&source.Kind{Type: &dbapi.SingleInstanceDatabase{}},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueDatabaseRefs()),
+			builder.WithPredicates(predicate.Funcs{UpdateFunc: func(e event.UpdateEvent) bool { return false }})).
 		WithEventFilter(dbcommons.ResourceEventHandler()).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 100}). //ReconcileHandler is never invoked concurrently with the same object.
 		Complete(r)
 }
+
+// Requests of the ORDS referring to a database, when the database is created or deleted
+func (r *OracleRestDataServiceReconciler) enqueueDatabaseRefs() handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		ordsList := &dbapi.OracleRestDataServiceList{}
+		if err := r.List(context.TODO(), ordsList, client.InNamespace(o.GetNamespace())); err != nil {
+			return nil
+		}
+		var reqs []reconcile.Request
+		for _, ords := range ordsList.Items {
+			if ords.Spec.DatabaseRef == o.GetName() {
+				reqs = append(reqs, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: ords.Name, Namespace: ords.Namespace},
+				})
+			}
+		}
+		return reqs
+	}
+}
@@ -0,0 +1,646 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dbapi "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+)
+
+var _ = Describe("OracleRestDataService controller", Ordered, func() {
+	const (
+		namespace = "default"
+		sidbName  = "ords-test-sidb"
+		name      = "ords-test"
+		timeout   = time.Second * 30
+		interval  = time.Millisecond * 250
+	)
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+	sidbKey := types.NamespacedName{Name: sidbName, Namespace: namespace}
+	keepSecret := true
+
+	BeforeAll(func() {
+		fakeExecutor.Reset()
+		fakeExecutor.
+			On("show user", "USER is \"SYS\"").
+			On("C##DBAPI_CDB_ADMIN IDENTIFIED BY", "User created.").
+			On(dbcommons.GetORDSStatus, "< HTTP/1.1 200 OK").
+			On("curl -sSk https://localhost:8443/ords/_/db-api/stable/metadata-catalog/", `{"items":[{"name":"EMP"}]}`).
+			On("select s.sid", "no rows selected").
+			On(dbcommons.GetOrdsCommonUsersSQL, "no rows selected")
+
+		adminSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-admin-secret", Namespace: namespace},
+			StringData: map[string]string{"oracle_pwd": "Passw0rd#1"},
+		}
+		Expect(k8sClient.Create(ctx, adminSecret)).To(Succeed())
+
+		By("creating a healthy database to install ORDS on")
+		sidb := &dbapi.SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: sidbName, Namespace: namespace},
+			Spec: dbapi.SingleInstanceDatabaseSpec{
+				Edition:  "enterprise",
+				Sid:      "ORCLCDB",
+				Pdbname:  "ORCLPDB1",
+				Replicas: 1,
+				Image: dbapi.SingleInstanceDatabaseImage{
+					PullFrom: "container-registry.oracle.com/database/enterprise:latest",
+				},
+				AdminPassword: dbapi.SingleInstanceDatabaseAdminPassword{
+					SecretName: adminSecret.Name,
+					SecretKey:  "oracle_pwd",
+					KeepSecret: &keepSecret,
+				},
+				Persistence: dbapi.SingleInstanceDatabasePersistence{
+					Size:         "10Gi",
+					StorageClass: "oci-bv",
+					AccessMode:   "ReadWriteOnce",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sidb)).To(Succeed())
+		sidb.Status.Status = dbcommons.StatusReady
+		sidb.Status.Pdbname = sidb.Spec.Pdbname
+		Expect(k8sClient.Status().Update(ctx, sidb)).To(Succeed())
+
+		sidbPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sidbName + "-pod",
+				Namespace: namespace,
+				Labels:    dbcommons.GetLabelsForController("", sidbName),
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: sidbName, Image: sidb.Spec.Image.PullFrom}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sidbPod)).To(Succeed())
+		markPodReady(ctx, sidbPod)
+
+		ords := &dbapi.OracleRestDataService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: dbapi.OracleRestDataServiceSpec{
+				DatabaseRef: sidbName,
+				Replicas:    1,
+				Image: dbapi.OracleRestDataServiceImage{
+					PullFrom: "container-registry.oracle.com/database/ords:latest",
+				},
+				AdminPassword: dbapi.OracleRestDataServicePassword{
+					SecretName: adminSecret.Name,
+					SecretKey:  "oracle_pwd",
+					KeepSecret: &keepSecret,
+				},
+				OrdsPassword: dbapi.OracleRestDataServicePassword{
+					SecretName: adminSecret.Name,
+					SecretKey:  "oracle_pwd",
+					KeepSecret: &keepSecret,
+				},
+				ApexPassword: dbapi.OracleRestDataServicePassword{
+					KeepSecret: &keepSecret,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ords)).To(Succeed())
+	})
+
+	It("Should create the common users and the ORDS pod", func() {
+		Eventually(func() bool {
+			ordsReconciler.Reconcile(ctx, req)
+			ords := &dbapi.OracleRestDataService{}
+			Expect(k8sClient.Get(ctx, req.NamespacedName, ords)).To(Succeed())
+			return ords.Status.CommonUsersCreated
+		}, timeout, interval).Should(BeTrue())
+		Expect(fakeExecutor.Executed("show user")).To(BeTrue())
+		created := &dbapi.OracleRestDataService{}
+		Expect(k8sClient.Get(ctx, req.NamespacedName, created)).To(Succeed())
+		Expect(created.Status.CreatedUsers).To(ConsistOf(dbcommons.OrdsCdbAdminUser, dbcommons.OrdsPdbAdminUser))
+
+		podList := &corev1.PodList{}
+		Eventually(func() int {
+			ordsReconciler.Reconcile(ctx, req)
+			Expect(k8sClient.List(ctx, podList, client.InNamespace(namespace),
+				client.MatchingLabels(dbcommons.GetLabelsForController("", name)))).To(Succeed())
+			return len(podList.Items)
+		}, timeout, interval).Should(Equal(1))
+
+		ords := &dbapi.OracleRestDataService{}
+		Expect(k8sClient.Get(ctx, req.NamespacedName, ords)).To(Succeed())
+		Expect(controllerutil.ContainsFinalizer(ords, oracleRestDataServiceFinalizer)).To(BeTrue())
+		Expect(k8sClient.Get(ctx, req.NamespacedName, &corev1.Service{})).To(Succeed())
+	})
+
+	It("Should mark ORDS installed once the ORDS pod is healthy", func() {
+		podList := &corev1.PodList{}
+		Expect(k8sClient.List(ctx, podList, client.InNamespace(namespace),
+			client.MatchingLabels(dbcommons.GetLabelsForController("", name)))).To(Succeed())
+		Expect(podList.Items).To(HaveLen(1))
+		markPodReady(ctx, &podList.Items[0])
+
+		Eventually(func() bool {
+			ordsReconciler.Reconcile(ctx, req)
+			ords := &dbapi.OracleRestDataService{}
+			Expect(k8sClient.Get(ctx, req.NamespacedName, ords)).To(Succeed())
+			return ords.Status.OrdsInstalled
+		}, timeout, interval).Should(BeTrue())
+
+		sidb := &dbapi.SingleInstanceDatabase{}
+		Expect(k8sClient.Get(ctx, sidbKey, sidb)).To(Succeed())
+		Expect(sidb.Status.OrdsReference).To(Equal(name))
+
+		ords := &dbapi.OracleRestDataService{}
+		Expect(k8sClient.Get(ctx, req.NamespacedName, ords)).To(Succeed())
+		owner := sidb.GetOwnerConsumer(dbapi.OracleRestDataServiceKind)
+		Expect(owner).NotTo(BeNil())
+		Expect(owner.Matches(dbapi.OracleRestDataServiceKind, ords)).To(BeTrue())
+		Expect(owner.UID).To(Equal(ords.UID))
+		Expect(ords.Annotations[dbcommons.DatabaseUIDAnnotation]).To(Equal(string(sidb.UID)))
+		Expect(meta.IsStatusConditionTrue(ords.Status.Conditions, dbcommons.DatabaseConnectivityCondition)).To(BeTrue())
+		Expect(fakeExecutor.Executed("select 1 from dual")).To(BeTrue())
+	})
+
+	It("Should uninstall ORDS through a job and drop the common users on deletion", func() {
+		ords := &dbapi.OracleRestDataService{}
+		Expect(k8sClient.Get(ctx, req.NamespacedName, ords)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, ords)).To(Succeed())
+
+		job := &batchv1.Job{}
+		jobKey := types.NamespacedName{Name: name + dbcommons.UninstallJobSuffix, Namespace: namespace}
+		Eventually(func() error {
+			ordsReconciler.Reconcile(ctx, req)
+			return k8sClient.Get(ctx, jobKey, job)
+		}, timeout, interval).Should(Succeed())
+		Expect(job.Spec.Template.Spec.Containers[0].Command[2]).To(ContainSubstring("ords.war uninstall"))
+
+		// There is no job controller in envtest
+		now := metav1.Now()
+		job.Status.StartTime = &now
+		job.Status.CompletionTime = &now
+		job.Status.Succeeded = 1
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:               batchv1.JobComplete,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: now,
+		}}
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+
+		Eventually(func() bool {
+			ordsReconciler.Reconcile(ctx, req)
+			err := k8sClient.Get(ctx, req.NamespacedName, &dbapi.OracleRestDataService{})
+			return apierrors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+
+		Expect(fakeExecutor.Executed(dbcommons.DropAdminUsersSQL)).To(BeTrue())
+
+		sidb := &dbapi.SingleInstanceDatabase{}
+		Expect(k8sClient.Get(ctx, sidbKey, sidb)).To(Succeed())
+		Expect(sidb.Status.OrdsReference).To(BeEmpty())
+		Expect(sidb.Status.Consumers).To(BeEmpty())
+	})
+})
+
+var _ = Describe("OracleRestDataService deletion policy", func() {
+	It("Should leave the database untouched and delete the uninstall job with the Abandon policy", func() {
+		ctx := context.Background()
+		m := &dbapi.OracleRestDataService{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords-abandon", Namespace: "default"},
+			Spec: dbapi.OracleRestDataServiceSpec{
+				DatabaseRef:    "sidb-abandon",
+				Image:          dbapi.OracleRestDataServiceImage{PullFrom: "ords:latest"},
+				DeletionPolicy: dbcommons.DeletionPolicyAbandon,
+			},
+			Status: dbapi.OracleRestDataServiceStatus{OrdsInstalled: true},
+		}
+		n := &dbapi.SingleInstanceDatabase{ObjectMeta: metav1.ObjectMeta{Name: "sidb-abandon", Namespace: "default"}}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: m.Name, Namespace: m.Namespace}}
+
+		By("creating the uninstall job started before the policy was changed")
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: m.Name + dbcommons.UninstallJobSuffix, Namespace: m.Namespace},
+			Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				Containers:    []corev1.Container{{Name: "uninstall", Image: m.Spec.Image.PullFrom}},
+			}}},
+		}
+		Expect(k8sClient.Create(ctx, job)).To(Succeed())
+
+		fakeExecutor.Reset()
+		Expect(ordsReconciler.cleanupOracleRestDataService(req, ctx, m, n)).To(Succeed())
+		Expect(fakeExecutor.Commands()).To(BeEmpty())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{})
+			return apierrors.IsNotFound(err)
+		}, time.Second*10, time.Millisecond*250).Should(BeTrue())
+
+		By("cleaning up again once the job is gone")
+		Expect(ordsReconciler.cleanupOracleRestDataService(req, ctx, m, n)).To(Succeed())
+	})
+})
+
+var _ = Describe("OracleRestDataService node failures", func() {
+	ctx := context.Background()
+
+	It("Should find the nodes that stopped reporting their status", func() {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "ords-unreachable-node"}}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+
+		_, unreachable, err := ordsReconciler.nodeUnreachableSince(node.Name, ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unreachable).To(BeFalse())
+
+		lost := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+		node.Status.Conditions = []corev1.NodeCondition{{
+			Type:               corev1.NodeReady,
+			Status:             corev1.ConditionUnknown,
+			LastTransitionTime: lost,
+		}}
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+		since, unreachable, err := ordsReconciler.nodeUnreachableSince(node.Name, ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unreachable).To(BeTrue())
+		Expect(since).To(BeTemporally("==", lost.Time))
+
+		_, unreachable, err = ordsReconciler.nodeUnreachableSince("ords-deleted-node", ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unreachable).To(BeTrue())
+	})
+})
+
+var _ = Describe("OracleRestDataService volume zones", func() {
+	It("Should read the zones of a volume from its node affinity or its labels", func() {
+		pv := &corev1.PersistentVolume{
+			Spec: corev1.PersistentVolumeSpec{
+				NodeAffinity: &corev1.VolumeNodeAffinity{
+					Required: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{MatchExpressions: []corev1.NodeSelectorRequirement{{
+								Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"AD-2", "AD-1"}}}},
+							{MatchExpressions: []corev1.NodeSelectorRequirement{{
+								Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"AD-1"}}}},
+						},
+					},
+				},
+			},
+		}
+		key, zones := volumeZones(pv)
+		Expect(key).To(Equal(corev1.LabelTopologyZone))
+		Expect(zones).To(Equal([]string{"AD-1", "AD-2"}))
+
+		pv = &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{corev1.LabelFailureDomainBetaZone: "AD-3__AD-1"}}}
+		key, zones = volumeZones(pv)
+		Expect(key).To(Equal(corev1.LabelFailureDomainBetaZone))
+		Expect(zones).To(Equal([]string{"AD-1", "AD-3"}))
+
+		_, zones = volumeZones(&corev1.PersistentVolume{})
+		Expect(zones).To(BeEmpty())
+	})
+})
+
+var _ = Describe("OracleRestDataService artifacts", func() {
+	It("Should fetch the distributions of the spec and mount them over the ones of the image", func() {
+		m := &dbapi.OracleRestDataService{Spec: dbapi.OracleRestDataServiceSpec{
+			Image: dbapi.OracleRestDataServiceImage{PullFrom: "ords:latest"},
+			Apex: dbapi.OracleRestDataServiceApex{Source: &dbapi.OracleRestDataServiceArtifactSource{
+				PersistentVolumeClaim: "artifacts", Path: "apex/apex_23.1.zip"}},
+			Ords: &dbapi.OracleRestDataServiceOrds{Source: &dbapi.OracleRestDataServiceArtifactSource{
+				URL: "https://example.com/ords-23.1.0.zip", Sha256: strings.Repeat("a", 64)}},
+		}}
+		containers := fetchArtifactsContainers(m, &dbapi.SingleInstanceDatabase{}, 54321, 54321)
+		Expect(containers).To(HaveLen(1))
+		cmd := containers[0].Command[2]
+		Expect(cmd).To(ContainSubstring("unzip -qo /mnt/artifact-sources/apex/apex/apex_23.1.zip -d /opt/oracle/artifacts/apex"))
+		Expect(cmd).To(ContainSubstring("curl -fsSL --retry 3 -o /opt/oracle/artifacts/ords.zip 'https://example.com/ords-23.1.0.zip'"))
+		Expect(cmd).To(ContainSubstring("sha256sum -c -"))
+		Expect(containers[0].VolumeMounts).To(HaveLen(2))
+
+		Expect(artifactVolumes(m)).To(HaveLen(2))
+		mounts := artifactMounts(m)
+		Expect(mounts).To(HaveLen(2))
+		Expect(mounts[0].SubPath).To(Equal("apex/apex"))
+		Expect(mounts[1].MountPath).To(Equal("/opt/oracle/ords/ords.war"))
+
+		Expect(fetchArtifactsContainers(&dbapi.OracleRestDataService{}, &dbapi.SingleInstanceDatabase{}, 0, 0)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("OracleRestDataService uninstall job", func() {
+	It("Should mount the configuration volume of init-ords after the containers fetching the artifacts", func() {
+		m := &dbapi.OracleRestDataService{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords-uninstall", Namespace: "default"},
+			Spec: dbapi.OracleRestDataServiceSpec{
+				Image: dbapi.OracleRestDataServiceImage{PullFrom: "ords:latest"},
+				Ords: &dbapi.OracleRestDataServiceOrds{Source: &dbapi.OracleRestDataServiceArtifactSource{
+					URL: "https://example.com/ords-23.1.0.zip", Sha256: strings.Repeat("a", 64)}},
+			},
+		}
+		n := &dbapi.SingleInstanceDatabase{ObjectMeta: metav1.ObjectMeta{Name: "sidb-uninstall", Namespace: "default"}}
+		job := ordsReconciler.instantiateUninstallJobSpec(m, n)
+		spec := job.Spec.Template.Spec
+		Expect(spec.Volumes).To(HaveLen(1))
+		Expect(spec.Volumes[0].Name).To(Equal("datamount"))
+		Expect(spec.Containers[0].VolumeMounts).To(HaveLen(1))
+		Expect(spec.Containers[0].VolumeMounts[0].Name).To(Equal("datamount"))
+		Expect(spec.Containers[0].VolumeMounts[0].MountPath).To(Equal(getOrdsImageConfigDir(m)))
+		Expect(spec.Containers[0].Env).To(ContainElement(HaveField("Name", "ORACLE_HOST")))
+	})
+})
+
+var _ = Describe("OracleRestDataService pool service", func() {
+	It("Should replace the pods with the service of the spec set as the service of the pool", func() {
+		m := &dbapi.OracleRestDataService{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords-service", Namespace: "default"},
+			Spec: dbapi.OracleRestDataServiceSpec{
+				Image: dbapi.OracleRestDataServiceImage{PullFrom: "ords:latest"},
+			},
+		}
+		n := &dbapi.SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: "sidb-service", Namespace: "default"},
+			Spec:       dbapi.SingleInstanceDatabaseSpec{Sid: "ORCLCDB", Pdbname: "ORCLPDB1"},
+		}
+		Expect(ordsEnv(m, n)).NotTo(ContainElement(HaveField("Name", "ORDS_POOL_SERVICE")))
+		Expect(getOrdsServiceName(m, n)).To(Equal("ORCLCDB"))
+		defaultHash := ordsEnvHash(m, n)
+
+		m.Spec.OracleService = "SALES"
+		Expect(ordsEnv(m, n)).To(ContainElement(corev1.EnvVar{Name: "ORDS_POOL_SERVICE", Value: "SALES"}))
+		Expect(getOrdsServiceName(m, n)).To(Equal("SALES"))
+		salesHash := ordsEnvHash(m, n)
+		Expect(salesHash).NotTo(Equal(defaultHash))
+
+		m.Spec.OracleService = "HR"
+		Expect(ordsEnvHash(m, n)).NotTo(Equal(salesHash))
+
+		pod, _ := ordsReconciler.instantiatePodSpec(m, n)
+		Expect(pod.Spec.InitContainers).To(ContainElement(SatisfyAll(
+			HaveField("Name", "init-ords"),
+			HaveField("Command", ContainElement(ContainSubstring(`<entry key=\"db.servicename\">${ORACLE_SERVICE}`))),
+			HaveField("Env", ContainElement(corev1.EnvVar{Name: "ORACLE_SERVICE", Value: "HR"})),
+		)))
+	})
+})
+
+var _ = Describe("OracleRestDataService max replicas", func() {
+	It("Should count the pools of the virtual hosts in the replicas that fit in the processes", func() {
+		ctx := context.Background()
+		m := &dbapi.OracleRestDataService{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords-max-replicas", Namespace: "default"},
+			Spec:       dbapi.OracleRestDataServiceSpec{Replicas: 2},
+		}
+		n := &dbapi.SingleInstanceDatabase{ObjectMeta: metav1.ObjectMeta{Name: "sidb-max-replicas", Namespace: "default"}}
+		sidbReadyPod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "sidb-max-replicas-pod", Namespace: "default"}}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: m.Name, Namespace: m.Namespace}}
+
+		fakeExecutor.Reset()
+		fakeExecutor.On(dbcommons.GetProcessLimitsSQL, "\nLIMITS\n--------------------\nprocesses,320\nbackground_processes,60\n")
+		ordsReconciler.updateMaxReplicas(m, n, sidbReadyPod, ctx, req)
+		Expect(m.Status.MaxReplicas).To(Equal(13))
+
+		// Two hosts sharing the pool of a PDB and a host of another PDB add two pools to each pod
+		m.Spec.VirtualHosts = []dbapi.OracleRestDataServiceVirtualHost{
+			{Host: "sales.example.com", PdbName: "SALESPDB"},
+			{Host: "sales.example.org", PdbName: "salespdb"},
+			{Host: "hr.example.com", PdbName: "HRPDB"},
+		}
+		Expect(ordsPoolsPerPod(m)).To(Equal(3))
+		ordsReconciler.updateMaxReplicas(m, n, sidbReadyPod, ctx, req)
+		Expect(m.Status.MaxReplicas).To(Equal(4))
+	})
+})
+
+var _ = Describe("OracleRestDataService settings", Ordered, func() {
+	const (
+		namespace = "default"
+		name      = "ords-settings-test"
+	)
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+	keepSecret := true
+
+	var ords *dbapi.OracleRestDataService
+	sidb := &dbapi.SingleInstanceDatabase{ObjectMeta: metav1.ObjectMeta{Name: "ords-settings-sidb", Namespace: namespace}}
+	secretName := types.NamespacedName{Name: name + dbcommons.OrdsSettingsSecretSuffix, Namespace: namespace}
+
+	BeforeAll(func() {
+		ords = &dbapi.OracleRestDataService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: dbapi.OracleRestDataServiceSpec{
+				DatabaseRef: sidb.Name,
+				Replicas:    1,
+				Image: dbapi.OracleRestDataServiceImage{
+					PullFrom: "container-registry.oracle.com/database/ords:latest",
+				},
+				AdminPassword: dbapi.OracleRestDataServicePassword{SecretName: name, KeepSecret: &keepSecret},
+				OrdsPassword:  dbapi.OracleRestDataServicePassword{SecretName: name, KeepSecret: &keepSecret},
+				Settings: &dbapi.OracleRestDataServiceSettings{Properties: map[string]string{
+					"jdbc.MaxLimit": "40",
+					"mongo.enabled": "true",
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ords)).To(Succeed())
+	})
+
+	It("Should render only the properties of the spec into the Secret", func() {
+		Expect(ordsReconciler.manageSettingsSecret(ords, sidb, ctx, req).Requeue).To(BeFalse())
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, secretName, secret)).To(Succeed())
+		settings := string(secret.Data[dbcommons.OrdsSettingsFile])
+		Expect(settings).To(ContainSubstring(`<entry key="jdbc.MaxLimit">40</entry>`))
+		Expect(settings).To(ContainSubstring(`<entry key="mongo.enabled">true</entry>`))
+		Expect(settings).NotTo(ContainSubstring("security.verifySSL"))
+		Expect(settings).NotTo(ContainSubstring("db.servicename"))
+	})
+
+	It("Should update the Secret when the properties change", func() {
+		ords.Spec.Settings.Properties["jdbc.MaxLimit"] = "60"
+		Expect(ordsReconciler.manageSettingsSecret(ords, sidb, ctx, req).Requeue).To(BeFalse())
+		secret := &corev1.Secret{}
+		Expect(k8sClient.Get(ctx, secretName, secret)).To(Succeed())
+		Expect(string(secret.Data[dbcommons.OrdsSettingsFile])).To(ContainSubstring(`<entry key="jdbc.MaxLimit">60</entry>`))
+	})
+
+	It("Should mount the Secret in the init container merging it into the settings of the volume", func() {
+		mounts := settingsMounts(ords)
+		Expect(mounts).To(HaveLen(1))
+		Expect(mounts[0].MountPath).To(Equal(dbcommons.OrdsSettingsMountDir))
+		Expect(mounts[0].SubPath).To(BeEmpty())
+		Expect(mounts[0].ReadOnly).To(BeTrue())
+	})
+
+	It("Should delete the Secret when the settings are removed", func() {
+		ords.Spec.Settings = nil
+		Expect(ordsReconciler.manageSettingsSecret(ords, sidb, ctx, req).Requeue).To(BeFalse())
+		err := k8sClient.Get(ctx, secretName, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(settingsMounts(ords)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("OracleRestDataService APEX configuration", Ordered, func() {
+	const (
+		namespace = "default"
+		name      = "ords-apex-test"
+	)
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+	keepSecret := true
+
+	var ords *dbapi.OracleRestDataService
+	var sidb *dbapi.SingleInstanceDatabase
+	var sidbPod, ordsPod *corev1.Pod
+
+	BeforeAll(func() {
+		fakeExecutor.Reset()
+		fakeExecutor.
+			On("ALTER USER APEX_PUBLIC_USER", "User altered.").
+			On("set-properties --conf apex_al", "")
+
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-apex-weak", Namespace: namespace},
+			StringData: map[string]string{"apex_pwd": "apex"},
+		})).To(Succeed())
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-apex", Namespace: namespace},
+			StringData: map[string]string{"apex_pwd": "Apex#Passw0rd"},
+		})).To(Succeed())
+
+		ords = &dbapi.OracleRestDataService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: dbapi.OracleRestDataServiceSpec{
+				DatabaseRef: "ords-apex-sidb",
+				Replicas:    1,
+				Image: dbapi.OracleRestDataServiceImage{
+					PullFrom: "container-registry.oracle.com/database/ords:latest",
+				},
+				AdminPassword: dbapi.OracleRestDataServicePassword{SecretName: name + "-apex", KeepSecret: &keepSecret},
+				OrdsPassword:  dbapi.OracleRestDataServicePassword{SecretName: name + "-apex", KeepSecret: &keepSecret},
+				ApexPassword:  dbapi.OracleRestDataServicePassword{SecretKey: "apex_pwd", KeepSecret: &keepSecret},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ords)).To(Succeed())
+
+		// APEX is already installed in the database, so only its users are configured
+		sidb = &dbapi.SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: "ords-apex-sidb", Namespace: namespace},
+			Spec:       dbapi.SingleInstanceDatabaseSpec{Pdbname: "ORCLPDB1"},
+			Status:     dbapi.SingleInstanceDatabaseStatus{Pdbname: "ORCLPDB1", ApexInstalled: true},
+		}
+		sidbPod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ords-apex-sidb-pod", Namespace: namespace}}
+
+		ordsPod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-pod", Namespace: namespace},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: name, Image: ords.Spec.Image.PullFrom}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ordsPod)).To(Succeed())
+	})
+
+	It("Should not configure APEX with a password that does not meet its requirements", func() {
+		ords.Spec.ApexPassword.SecretName = name + "-apex-weak"
+		result := ordsReconciler.configureApex(ords, sidb, *sidbPod, *ordsPod, ctx, req)
+		Expect(result.Requeue).To(BeTrue())
+		Expect(ords.Status.Status).To(Equal(dbcommons.StatusError))
+		Expect(ords.Status.ApexConfigured).To(BeFalse())
+		Expect(fakeExecutor.Executed("ALTER USER APEX_PUBLIC_USER")).To(BeFalse())
+	})
+
+	It("Should set the passwords of the APEX users and restart the ORDS pod", func() {
+		ords.Spec.ApexPassword.SecretName = name + "-apex"
+		ordsReconciler.configureApex(ords, sidb, *sidbPod, *ordsPod, ctx, req)
+		Expect(ords.Status.ApexConfigured).To(BeTrue())
+		Expect(fakeExecutor.Executed("ALTER USER APEX_PUBLIC_USER")).To(BeTrue())
+		Expect(fakeExecutor.Executed("set-properties --conf apex_al")).To(BeTrue())
+
+		pod := &corev1.Pod{}
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: ordsPod.Name, Namespace: namespace}, pod)
+		Expect(apierrors.IsNotFound(err) || pod.DeletionTimestamp != nil).To(BeTrue())
+	})
+
+	It("Should leave APEX alone once configured", func() {
+		commands := len(fakeExecutor.Commands())
+		result := ordsReconciler.configureApex(ords, sidb, *sidbPod, *ordsPod, ctx, req)
+		Expect(result.Requeue).To(BeFalse())
+		Expect(fakeExecutor.Commands()).To(HaveLen(commands))
+	})
+})
+
+var _ = Describe("OracleRestDataService canary rollback", func() {
+	It("Should keep running the pods of the previous image once the canary is rolled back", func() {
+		previous := dbapi.OracleRestDataServiceImage{PullFrom: "container-registry.oracle.com/database/ords:23.1.0"}
+		ords := &dbapi.OracleRestDataService{
+			Spec: dbapi.OracleRestDataServiceSpec{
+				Image:          dbapi.OracleRestDataServiceImage{PullFrom: "container-registry.oracle.com/database/ords:23.2.0"},
+				UpdateStrategy: &dbapi.OracleRestDataServiceUpdateStrategy{Type: "Canary"},
+			},
+			Status: dbapi.OracleRestDataServiceStatus{Image: previous},
+		}
+		// While the canary is deployed, its pod runs the image of the spec
+		ords.Status.CanaryImage = ords.Spec.Image.PullFrom
+		setRolloutCondition(ords, metav1.ConditionFalse, dbcommons.CanaryDeployingReason, "deploying")
+		Expect(ordsPodImage(ords)).To(Equal(ords.Spec.Image))
+
+		setRolloutCondition(ords, metav1.ConditionFalse, dbcommons.RolledBackReason, "rolled back")
+		Expect(ordsPodImage(ords)).To(Equal(previous))
+
+		// A new image gets a canary of its own
+		ords.Spec.Image.PullFrom = "container-registry.oracle.com/database/ords:23.3.0"
+		Expect(ordsPodImage(ords)).To(Equal(ords.Spec.Image))
+	})
+})
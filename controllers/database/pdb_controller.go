@@ -49,12 +49,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	dbapi "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -62,6 +64,7 @@ import (
 	//metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -76,6 +79,7 @@ type PDBReconciler struct {
 	client.Client
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
+	Config   *rest.Config
 	Interval time.Duration
 	Recorder record.EventRecorder
 }
@@ -154,6 +158,7 @@ func (r *PDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		if !pdb.Status.Status {
 			if pdb.Status.Phase == pdbPhaseReady {
 				pdb.Status.Status = true
+				pdb.Status.ObservedGeneration = pdb.GetGeneration()
 			}
 			if err := r.Status().Update(ctx, pdb); err != nil {
 				log.Error(err, "Failed to update status for :"+pdb.Name, "err", err.Error())
@@ -245,6 +250,11 @@ func (r *PDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		}
 	}
 
+	// Apply the resource limits of the open PDB, reverting any drift
+	if pdb.Status.Phase == pdbPhaseReady && pdb.Status.OpenMode == "READ WRITE" && action != "DELETE" && action != "UNPLUG" {
+		r.syncResourceLimits(ctx, req, pdb)
+	}
+
 	log.Info("Reconcile completed")
 	return requeueY, nil
 }
@@ -468,7 +478,8 @@ func (r *PDBReconciler) callAPI(ctx context.Context, req ctrl.Request, pdb *dbap
 
 	tlsConf := &tls.Config{Certificates: []tls.Certificate{certificate}, RootCAs: caCertPool}
 
-	tr := &http.Transport{TLSClientConfig: tlsConf}
+	// The proxy variables of the operator apply to the ORDS of the CDB, unless it is reached through its service
+	tr := &http.Transport{TLSClientConfig: tlsConf, Proxy: dbcommons.ProxyFromEnvironment}
 
 	httpclient := &http.Client{Transport: tr}
 
@@ -1052,6 +1063,111 @@ func (r *PDBReconciler) getPDBState(ctx context.Context, req ctrl.Request, pdb *
 	return nil
 }
 
+/*************************************************
+ * Apply the resource limits of the PDB and revert their drift
+ /************************************************/
+func (r *PDBReconciler) syncResourceLimits(ctx context.Context, req ctrl.Request, pdb *dbapi.PDB) {
+
+	log := r.Log.WithValues("syncResourceLimits", req.NamespacedName)
+
+	limits := pdb.Spec.ResourceLimits
+	if limits == nil {
+		if pdb.Status.ResourceLimits != nil {
+			pdb.Status.ResourceLimits = nil
+			r.Status().Update(ctx, pdb)
+		}
+		return
+	}
+
+	// The limits are set by the CDB administrator user, connected without SYSDBA from the ORDS pod
+	ordsPod, err := r.getORDSPod(ctx, req, pdb)
+	if err != nil {
+		return
+	}
+	execSQL := func(sql string) (string, error) {
+		out, err := dbcommons.ExecCommand(r, r.Config, ordsPod.Name, ordsPod.Namespace, "", ctx, req, true,
+			"bash", "-c", dbcommons.CDBAdminSQLCMD, "bash", sql)
+		if err == nil && (strings.Contains(out, "ORA-") || strings.Contains(out, "SP2-")) {
+			err = errors.New(strings.TrimSpace(out))
+		}
+		return out, err
+	}
+
+	pdbName := pdb.Spec.PDBName
+	out, err := execSQL(fmt.Sprintf(dbcommons.GetPDBResourceLimitsSQL, pdbName))
+	if err != nil {
+		log.Info("Failed to get resource limits of PDB :"+pdbName, "err", err.Error())
+		return
+	}
+	values, err := dbcommons.ParseColumnValues(out)
+	if err != nil {
+		log.Info("Failed to get resource limits of PDB :"+pdbName, "err", err.Error())
+		return
+	}
+	parameters := make(map[string]string)
+	for _, value := range values {
+		if name, value, found := strings.Cut(value, "="); found {
+			parameters[name] = value
+		}
+	}
+
+	drift := pdbResourceLimitsDrift(limits, parameters)
+	if len(drift) > 0 {
+		if pdb.Status.ResourceLimits != nil {
+			r.Recorder.Eventf(pdb, corev1.EventTypeWarning, "ResourceLimitsDrift", "Reverting %s", strings.Join(drift, ", "))
+		}
+		sql := dbcommons.SetPDBResourceLimits(pdbName, limits.CPUCount, limits.SgaTarget, limits.PgaAggregateLimit, limits.MaxSize)
+		if _, err := execSQL(sql); err != nil {
+			log.Info("Failed to set resource limits of PDB :"+pdbName, "err", err.Error())
+			return
+		}
+		r.Recorder.Eventf(pdb, corev1.EventTypeNormal, "ResourceLimits", "Resource limits applied to PDB %s", pdbName)
+		log.Info("Successfully applied resource limits", "PDB Name", pdbName)
+	}
+
+	if !reflect.DeepEqual(pdb.Status.ResourceLimits, limits) {
+		pdb.Status.ResourceLimits = limits.DeepCopy()
+		if err := r.Status().Update(ctx, pdb); err != nil {
+			log.Error(err, "Failed to update status for :"+pdb.Name, "err", err.Error())
+		}
+	}
+}
+
+/*************************************************
+ * Compare the resource limits with the parameters of the PDB
+ /************************************************/
+func pdbResourceLimitsDrift(limits *dbapi.PDBResourceLimits, parameters map[string]string) []string {
+
+	var drift []string
+
+	sameSize := func(desired string, observed string) bool {
+		desiredBytes, err1 := dbcommons.ParseSizeBytes(desired)
+		observedBytes, err2 := dbcommons.ParseSizeBytes(observed)
+		return err1 == nil && err2 == nil && observedBytes >= desiredBytes && observedBytes-desiredBytes < dbcommons.PDBMemoryGranule
+	}
+
+	if limits.CPUCount > 0 && parameters["cpu_count"] != strconv.Itoa(limits.CPUCount) {
+		drift = append(drift, "cpu_count="+parameters["cpu_count"])
+	}
+	if limits.SgaTarget != "" && !sameSize(limits.SgaTarget, parameters["sga_target"]) {
+		drift = append(drift, "sga_target="+parameters["sga_target"])
+	}
+	if limits.PgaAggregateLimit != "" && !sameSize(limits.PgaAggregateLimit, parameters["pga_aggregate_limit"]) {
+		drift = append(drift, "pga_aggregate_limit="+parameters["pga_aggregate_limit"])
+	}
+	if limits.MaxSize != "" {
+		maxSize := parameters["max_pdb_storage"]
+		if strings.EqualFold(limits.MaxSize, "UNLIMITED") || strings.EqualFold(maxSize, "UNLIMITED") {
+			if !strings.EqualFold(limits.MaxSize, maxSize) {
+				drift = append(drift, "max_pdb_storage="+maxSize)
+			}
+		} else if !sameSize(limits.MaxSize, maxSize) {
+			drift = append(drift, "max_pdb_storage="+maxSize)
+		}
+	}
+	return drift
+}
+
 /*************************************************
  * Map Database PDB to Kubernetes PDB CR
  /************************************************/
@@ -1233,6 +1349,7 @@ func (r *PDBReconciler) deletePDBInstance(req ctrl.Request, ctx context.Context,
 func (r *PDBReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbapi.PDB{}).
+		WithEventFilter(fleetPredicate()).
 		WithEventFilter(predicate.Funcs{
 			UpdateFunc: func(e event.UpdateEvent) bool {
 				// Ignore updates to CR status in which case metadata.Generation does not change
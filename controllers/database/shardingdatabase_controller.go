@@ -44,6 +44,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -94,8 +95,7 @@ type ShardingDatabaseReconciler struct {
 // +kubebuilder:rbac:groups=database.oracle.com,resources=shardingdatabases,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=database.oracle.com,resources=shardingdatabases/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=database.oracle.com,resources=shardingdatabases/finalizers,verbs=get;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=pods;pods/log;pods/exec;secrets;services;events;nodes;configmaps;persistentvolumeclaims;namespaces,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups=core,resources=pods;pods/log;secrets;services;events;nodes;configmaps;persistentvolumeclaims;namespaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups='',resources=statefulsets/finalizers,verbs=get;list;watch;create;update;patch;delete
 
@@ -422,6 +422,14 @@ func (r *ShardingDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return result, err
 	}
 
+	// ====================== Coordinated Backup ==============================
+	// The loop is requeued until the backups of the catalog and all the shards are complete
+	if r.manageBackup(instance) {
+		result = resultQ
+		err = nilErr
+		return result, err
+	}
+
 	stateType = string(databasev1alpha1.CrdReconcileCompeleteState)
 	//	r.setCrdLifeCycleState(instance, &result, &err, stateType)
 	// Set error to ni to avoid reconcilation state reconcilation error as we are passing err to setCrdLifeCycleState
@@ -438,6 +446,7 @@ func (r *ShardingDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Req
 func (r *ShardingDatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&databasev1alpha1.ShardingDatabase{}).
+		WithEventFilter(fleetPredicate()).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.Pod{}).
@@ -541,6 +550,112 @@ func (r *ShardingDatabaseReconciler) publishEvents(instance *databasev1alpha1.Sh
 
 }
 
+// ================== Function to run a coordinated backup of the catalog and the shards ==============
+// Returns true while the backup is running
+func (r *ShardingDatabaseReconciler) manageBackup(instance *databasev1alpha1.ShardingDatabase) bool {
+	if instance.Spec.Backup == nil {
+		return false
+	}
+
+	status := instance.Status.Backup
+	if status == nil || status.Tag != instance.Spec.Backup.Tag {
+		return r.startBackup(instance)
+	}
+	if status.State != string(databasev1alpha1.BackupRunningState) {
+		return false
+	}
+
+	for name, state := range status.Databases {
+		if state == string(databasev1alpha1.BackupRunningState) {
+			status.Databases[name] = shardingv1.GetBackupState(name+"-0", status.Tag, instance, r.kubeClient, r.kubeConfig, r.Log)
+		}
+	}
+	r.updateBackupState(instance)
+	return status.State == string(databasev1alpha1.BackupRunningState)
+}
+
+// Create the global restore point through the GSM, and then start the backups of the catalog and the shards
+func (r *ShardingDatabaseReconciler) startBackup(instance *databasev1alpha1.ShardingDatabase) bool {
+	_, gsmPod, err := r.validateGsm(instance)
+	if err != nil {
+		return true
+	}
+
+	tag := instance.Spec.Backup.Tag
+	level := 0
+	if instance.Spec.Backup.Type == "incremental" {
+		level = 1
+	}
+
+	status := &databasev1alpha1.ShardingBackupStatus{
+		Tag:          tag,
+		RestorePoint: strings.ToUpper(tag),
+		State:        string(databasev1alpha1.BackupRunningState),
+		StartTime:    time.Now().Format(time.RFC3339),
+		Databases:    map[string]string{},
+	}
+	instance.Status.Backup = status
+
+	err = shardingv1.CreateGlobalRestorePoint(gsmPod.Name, status.RestorePoint, instance, r.kubeClient, r.kubeConfig, r.Log)
+	if err != nil {
+		shardingv1.LogMessages("INFO", "Unable to create the global restore point "+shardingv1.GetFmtStr(status.RestorePoint)+".", err, instance, r.Log)
+		status.State = string(databasev1alpha1.BackupFailedState)
+		status.CompletionTime = time.Now().Format(time.RFC3339)
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "Backup", "Unable to create the global restore point %s: %s", status.RestorePoint, err.Error())
+		return false
+	}
+
+	var databases []string
+	for _, OraCatalogSpex := range instance.Spec.Catalog {
+		if !OraCatalogSpex.IsDelete {
+			databases = append(databases, OraCatalogSpex.Name)
+		}
+	}
+	for _, OraShardSpex := range instance.Spec.Shard {
+		if !OraShardSpex.IsDelete {
+			databases = append(databases, OraShardSpex.Name)
+		}
+	}
+
+	for _, name := range databases {
+		status.Databases[name] = string(databasev1alpha1.BackupRunningState)
+		err = shardingv1.StartBackup(name+"-0", tag, level, instance, r.kubeClient, r.kubeConfig, r.Log)
+		if err != nil {
+			shardingv1.LogMessages("INFO", "Unable to start the backup of "+shardingv1.GetFmtStr(name)+".", err, instance, r.Log)
+			status.Databases[name] = string(databasev1alpha1.BackupFailedState)
+		}
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Backup", "Started the backup %s of the catalog and the shards at the global restore point %s", tag, status.RestorePoint)
+
+	r.updateBackupState(instance)
+	return status.State == string(databasev1alpha1.BackupRunningState)
+}
+
+// The backup is RUNNING until the backups of all the databases are done, then FAILED if any of them failed
+func (r *ShardingDatabaseReconciler) updateBackupState(instance *databasev1alpha1.ShardingDatabase) {
+	status := instance.Status.Backup
+	state := databasev1alpha1.BackupCompletedState
+	for _, dbState := range status.Databases {
+		if dbState == string(databasev1alpha1.BackupRunningState) {
+			return
+		}
+		if dbState == string(databasev1alpha1.BackupFailedState) {
+			state = databasev1alpha1.BackupFailedState
+		}
+	}
+
+	status.State = string(state)
+	status.CompletionTime = time.Now().Format(time.RFC3339)
+	msg := "The backup " + status.Tag + " of the catalog and the shards is " + string(state) + "."
+	shardingv1.LogMessages("INFO", msg, nil, instance, r.Log)
+	if state == databasev1alpha1.BackupCompletedState {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "Backup", msg)
+	} else {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, "Backup", msg)
+	}
+	r.sendMessage(instance, "Backup "+status.Tag, msg)
+}
+
 // ================== Function to check insytance deletion timestamp and activate the finalizer code ========
 func (r *ShardingDatabaseReconciler) finalizerShardingDatabaseInstance(instance *databasev1alpha1.ShardingDatabase,
 ) (error, bool) {
@@ -886,6 +1001,11 @@ func (r *ShardingDatabaseReconciler) validateSpex(instance *databasev1alpha1.Sha
 			return fmt.Errorf("change of GSM env variables are not")
 		}
 
+		// The storage class and the affinity of an entry are only used when its StatefulSet is created
+		if !r.comparePlacement(instance, lastSuccSpec) {
+			return fmt.Errorf("instance spec has changed and storageClass or affinity change of an entry is not supported")
+		}
+
 	}
 	return nil
 }
@@ -967,6 +1087,61 @@ func (r *ShardingDatabaseReconciler) comapreShardEnvVariables(instance *database
 	return true
 }
 
+// Compare the storage class and the affinity of the shard, catalog and GSM entries
+
+func (r *ShardingDatabaseReconciler) comparePlacement(instance *databasev1alpha1.ShardingDatabase, lastSuccSpec *databasev1alpha1.ShardingDatabaseSpec) bool {
+	for _, OraShardSpex := range instance.Spec.Shard {
+		for _, lastSpex := range lastSuccSpec.Shard {
+			if OraShardSpex.Name == lastSpex.Name &&
+				!r.comparePlacementEntry(instance, lastSuccSpec, OraShardSpex.Name, OraShardSpex.StorageClass, lastSpex.StorageClass, OraShardSpex.Affinity, lastSpex.Affinity) {
+				return false
+			}
+		}
+	}
+	for _, OraCatalogSpex := range instance.Spec.Catalog {
+		for _, lastSpex := range lastSuccSpec.Catalog {
+			if OraCatalogSpex.Name == lastSpex.Name &&
+				!r.comparePlacementEntry(instance, lastSuccSpec, OraCatalogSpex.Name, OraCatalogSpex.StorageClass, lastSpex.StorageClass, OraCatalogSpex.Affinity, lastSpex.Affinity) {
+				return false
+			}
+		}
+	}
+	for _, OraGsmSpex := range instance.Spec.Gsm {
+		for _, lastSpex := range lastSuccSpec.Gsm {
+			if OraGsmSpex.Name == lastSpex.Name &&
+				!r.comparePlacementEntry(instance, lastSuccSpec, OraGsmSpex.Name, OraGsmSpex.StorageClass, lastSpex.StorageClass, OraGsmSpex.Affinity, lastSpex.Affinity) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *ShardingDatabaseReconciler) comparePlacementEntry(instance *databasev1alpha1.ShardingDatabase, lastSuccSpec *databasev1alpha1.ShardingDatabaseSpec, name string,
+	storageClass string, lastStorageClass string, affinity *corev1.Affinity, lastAffinity *corev1.Affinity) bool {
+	var eventMsg string
+	var eventErr string = "Spec Error"
+
+	// An entry without storageClass uses spec.storageClass, which cannot change either
+	if storageClass == "" {
+		storageClass = instance.Spec.StorageClass
+	}
+	if lastStorageClass == "" {
+		lastStorageClass = lastSuccSpec.StorageClass
+	}
+	if storageClass != lastStorageClass {
+		eventMsg = "ShardingDatabase CRD resource " + shardingv1.GetFmtStr(instance.Name) + " StorageClass of " + shardingv1.GetFmtStr(name) + " changed from " + shardingv1.GetFmtStr(lastStorageClass) + " to " + shardingv1.GetFmtStr(storageClass) + ". This change is not allowed."
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, eventErr, eventMsg)
+		return false
+	}
+	if !reflect.DeepEqual(affinity, lastAffinity) {
+		eventMsg = "ShardingDatabase CRD resource " + shardingv1.GetFmtStr(instance.Name) + " Affinity of " + shardingv1.GetFmtStr(name) + " changed. This change is not allowed."
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, eventErr, eventMsg)
+		return false
+	}
+	return true
+}
+
 //===== Set the CRD resource life cycle state ========
 
 func (r *ShardingDatabaseReconciler) setCrdLifeCycleState(instance *databasev1alpha1.ShardingDatabase, result *ctrl.Result, err *error, stateType *string) {
@@ -979,6 +1154,7 @@ func (r *ShardingDatabaseReconciler) setCrdLifeCycleState(instance *databasev1al
 	} else if *stateType == "ReconcileComplete" {
 		metaCondition = shardingv1.GetMetaCondition(instance, result, err, *stateType, string(databasev1alpha1.CrdReconcileCompleteReason))
 		updateFlag = true
+		instance.Status.ObservedGeneration = instance.GetGeneration()
 	} else if result.Requeue {
 		metaCondition = shardingv1.GetMetaCondition(instance, result, err, string(databasev1alpha1.CrdReconcileQueuedState), string(databasev1alpha1.CrdReconcileQueuedReason))
 		updateFlag = true
@@ -39,19 +39,24 @@
 package controllers
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
 	dbapi "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
 	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+	"github.com/oracle/oracle-database-operator/commons/k8s"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -66,6 +71,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // SingleInstanceDatabaseReconciler reconciles a SingleInstanceDatabase object
@@ -92,7 +100,12 @@ var oemExpressUrl string
 //+kubebuilder:rbac:groups=database.oracle.com,resources=singleinstancedatabases,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=database.oracle.com,resources=singleinstancedatabases/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=database.oracle.com,resources=singleinstancedatabases/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=pods;pods/log;pods/exec;persistentvolumeclaims;services;nodes;events,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups="",resources=pods;pods/log;persistentvolumeclaims;services;nodes;events,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=create;delete;get;list;patch;update;watch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;delete;get;list;watch
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=create;get
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -104,6 +117,7 @@ var oemExpressUrl string
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.8.3/pkg/reconcile
 func (r *SingleInstanceDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = k8s.WithStatusTracking(ctx)
 
 	r.Log.Info("Reconcile requested")
 	var result ctrl.Result
@@ -127,6 +141,9 @@ func (r *SingleInstanceDatabaseReconciler) Reconcile(ctx context.Context, req ct
 		r.Log.Error(err, err.Error())
 		return requeueY, err
 	}
+	k8s.TrackStatus(ctx, singleInstanceDatabase)
+	// Log the commands of a resource annotated with database.oracle.com/log-level: debug
+	ctx = dbcommons.WithLogLevel(ctx, singleInstanceDatabase)
 
 	/* Initialize Status */
 	if singleInstanceDatabase.Status.Status == "" {
@@ -142,7 +159,11 @@ func (r *SingleInstanceDatabaseReconciler) Reconcile(ctx context.Context, req ct
 		singleInstanceDatabase.Status.TcpsConnectString = dbcommons.ValueUnavailable
 		singleInstanceDatabase.Status.OemExpressUrl = dbcommons.ValueUnavailable
 		singleInstanceDatabase.Status.ReleaseUpdate = dbcommons.ValueUnavailable
-		r.Status().Update(ctx, singleInstanceDatabase)
+		// Recreated from the state exported on another cluster
+		if _, ok := singleInstanceDatabase.Annotations[dbcommons.ImportedStatusAnnotation]; ok {
+			r.importDatabaseState(singleInstanceDatabase)
+		}
+		k8s.PatchStatus(ctx, r.Client, singleInstanceDatabase)
 	}
 
 	// Manage SingleInstanceDatabase Deletion
@@ -167,6 +188,20 @@ func (r *SingleInstanceDatabaseReconciler) Reconcile(ctx context.Context, req ct
 		return result, nil
 	}
 
+	// Start and stop the database on its schedule
+	result, err = r.manageSchedule(singleInstanceDatabase, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
+	// Delete the pod of a database shut down for a restart
+	result = r.deleteRestartedPod(singleInstanceDatabase, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
 	// PVC Creation
 	result, err = r.createOrReplacePVC(ctx, req, singleInstanceDatabase)
 	if result.Requeue {
@@ -196,8 +231,42 @@ func (r *SingleInstanceDatabaseReconciler) Reconcile(ctx context.Context, req ct
 		return result, nil
 	}
 
+	// Skip the SQL of the post DB ready operations while the database is unreachable
+	result = r.manageDatabaseReachability(singleInstanceDatabase, readyPod)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
 	// Post DB ready operations
 
+	// Serialize administrative SQL against this database
+	lockHolder := dbcommons.DatabaseLockHolder("SingleInstanceDatabase", req.Namespace, req.Name)
+	locked, err := dbcommons.LockDatabase(ctx, r.Client, req.Namespace, singleInstanceDatabase.Name, lockHolder)
+	if err != nil {
+		r.Log.Error(err, err.Error())
+		return requeueY, err
+	}
+	if !locked {
+		r.Log.Info("Database is locked by another operation, Reconcile queued")
+		return requeueY, nil
+	}
+	defer dbcommons.UnlockDatabase(ctx, r.Client, req.Namespace, singleInstanceDatabase.Name, lockHolder)
+
+	// Run the action requested with the action annotation
+	result = r.manageActions(singleInstanceDatabase, readyPod, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
+	// Drain, shut down and restart the database of the restart-database action
+	result = r.manageRestart(singleInstanceDatabase, readyPod, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
 	// Deleting the oracle wallet
 	if singleInstanceDatabase.Status.DatafilesCreated == "true" {
 		result, err = r.deleteWallet(singleInstanceDatabase, ctx, req)
@@ -207,6 +276,18 @@ func (r *SingleInstanceDatabaseReconciler) Reconcile(ctx context.Context, req ct
 		}
 	}
 
+	// Maintain the external password store used for SQL access
+	result, err = r.managePasswordStore(singleInstanceDatabase, readyPod, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
+	// Requeue of a performance report waiting for its end snapshot, which does not hold back the reconcile
+	reportResult := requeueN
+	// Requeue of a database restart for the ORDS tuning waiting for the ORDS installation, which does not hold back the reconcile either
+	tuningResult := requeueN
+
 	if strings.ToUpper(singleInstanceDatabase.Status.Role) == "PRIMARY" {
 
 		// Update DB config
@@ -223,6 +304,14 @@ func (r *SingleInstanceDatabaseReconciler) Reconcile(ctx context.Context, req ct
 			return result, nil
 		}
 
+		// Apply the database settings requested by the ORDS
+		result, err = r.manageOrdsTuning(singleInstanceDatabase, readyPod, ctx, req)
+		if result.Requeue {
+			r.Log.Info("Reconcile queued")
+			return result, nil
+		}
+		tuningResult = result
+
 		// Configure TCPS
 		result, err = r.configTcps(singleInstanceDatabase, readyPod, ctx, req)
 		if result.Requeue {
@@ -230,6 +319,23 @@ func (r *SingleInstanceDatabaseReconciler) Reconcile(ctx context.Context, req ct
 			return result, nil
 		}
 
+		// Configure the DBMS_CLOUD credential for Object Storage
+		result, err = r.configureObjectStorage(singleInstanceDatabase, readyPod, ctx, req)
+		if result.Requeue {
+			r.Log.Info("Reconcile queued")
+			return result, nil
+		}
+
+		// Create and activate the CDB resource plan
+		result, err = r.configureResourceManagerPlan(singleInstanceDatabase, readyPod, ctx, req)
+		if result.Requeue {
+			r.Log.Info("Reconcile queued")
+			return result, nil
+		}
+
+		// Generate the requested AWR or Statspack report
+		reportResult = r.generatePerformanceReport(singleInstanceDatabase, readyPod, ctx, req)
+
 	} else {
 		// Database is in role of standby
 		err = SetupStandbyDatabase(r, singleInstanceDatabase, referredPrimaryDatabase, ctx, req)
@@ -261,8 +367,15 @@ func (r *SingleInstanceDatabaseReconciler) Reconcile(ctx context.Context, req ct
 			referredPrimaryDatabase.Status.StandbyDatabases = make(map[string]string)
 		}
 		referredPrimaryDatabase.Status.StandbyDatabases[strings.ToUpper(singleInstanceDatabase.Spec.Sid)] = singleInstanceDatabase.Name
-		r.Status().Update(ctx, referredPrimaryDatabase)
+		k8s.PatchStatus(ctx, r.Client, referredPrimaryDatabase)
+
+	}
 
+	// Configure the native network encryption, of the standby databases too
+	result, err = r.configureNetworkEncryption(singleInstanceDatabase, readyPod, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
 	}
 
 	// Run Datapatch
@@ -278,6 +391,16 @@ func (r *SingleInstanceDatabaseReconciler) Reconcile(ctx context.Context, req ct
 		}
 	}
 
+	// Verify the components of the registry after the datapatch of an image change, the databases that were not patched are left alone
+	if strings.ToUpper(singleInstanceDatabase.Status.Role) == "PRIMARY" && singleInstanceDatabase.Status.DatafilesPatched == "true" &&
+		datapatchVerificationPending(singleInstanceDatabase) {
+		result, err = r.verifyDatapatch(singleInstanceDatabase, readyPod, ctx, req)
+		if result.Requeue {
+			r.Log.Info("Reconcile queued")
+			return result, nil
+		}
+	}
+
 	// If LoadBalancer = true , ensure Connect String is updated
 	if singleInstanceDatabase.Status.ConnectString == dbcommons.ValueUnavailable {
 		r.Log.Info("Connect string not available for the database " + singleInstanceDatabase.Name)
@@ -288,9 +411,77 @@ func (r *SingleInstanceDatabaseReconciler) Reconcile(ctx context.Context, req ct
 	singleInstanceDatabase.Status.Status = dbcommons.StatusReady
 	r.updateORDSStatus(singleInstanceDatabase, ctx, req)
 
+	// Raise events for the new errors of the alert log
+	r.scanAlertLog(singleInstanceDatabase, readyPod, ctx, req)
+
+	// Report and kill the sessions blocking other sessions
+	if strings.ToUpper(singleInstanceDatabase.Status.Role) == "PRIMARY" {
+		r.manageBlockingSessions(singleInstanceDatabase, readyPod, ctx, req)
+	}
+
+	// Measure the Data Guard lag and the redo rates
+	r.manageReplicationMetrics(singleInstanceDatabase, readyPod, ctx, req)
+
+	// Manage True Cache instances in front of the primary
+	if strings.ToUpper(singleInstanceDatabase.Status.Role) == "PRIMARY" {
+		result, err = r.manageTrueCache(singleInstanceDatabase, ctx, req)
+		if result.Requeue {
+			r.Log.Info("Reconcile queued")
+			return result, nil
+		}
+	}
+
+	// Manage the Oracle Connection Manager instances proxying the connections to the database
+	result, err = r.manageConnectionManager(singleInstanceDatabase, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
+	// Export the manifests of the database and of its ORDS for a disaster recovery cluster
+	result, err = r.manageStateExport(singleInstanceDatabase, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
+	// Launch the Jobs of the hooks on the provisioning and the role transitions of the database
+	result, err = r.manageHooks(singleInstanceDatabase, ctx, req)
+	if result.Requeue {
+		r.Log.Info("Reconcile queued")
+		return result, nil
+	}
+
 	completed = true
 	r.Log.Info("Reconcile completed")
 
+	// Scheduling a reconcile for the next scan of the alert log, of the blocking sessions or of the replication, for the pending
+	// performance report or for the deferred ORDS tuning, whichever comes first, unless the scheduled stop or the cert renewal comes first
+	nextScan := scanRequeue(singleInstanceDatabase)
+	if reportResult.Requeue && (nextScan == requeueN || reportResult.RequeueAfter < nextScan.RequeueAfter) {
+		nextScan = reportResult
+	}
+	if tuningResult.RequeueAfter > 0 && (nextScan == requeueN || tuningResult.RequeueAfter < nextScan.RequeueAfter) {
+		nextScan = tuningResult
+	}
+	if nextScan != requeueN {
+		stopFirst := singleInstanceDatabase.Status.ScheduledState == dbcommons.ScheduledStateRunning &&
+			requeueUntil(singleInstanceDatabase.Status.NextScheduledTransition).RequeueAfter < nextScan.RequeueAfter
+		renewalFirst := futureRequeue != requeueN && futureRequeue.RequeueAfter < nextScan.RequeueAfter
+		if !stopFirst && !renewalFirst {
+			return nextScan, nil
+		}
+	}
+
+	// Scheduling a reconcile for the next scheduled stop, unless the cert renewal comes first
+	if singleInstanceDatabase.Status.ScheduledState == dbcommons.ScheduledStateRunning {
+		scheduleRequeue := requeueUntil(singleInstanceDatabase.Status.NextScheduledTransition)
+		if futureRequeue == requeueN || scheduleRequeue.RequeueAfter < futureRequeue.RequeueAfter {
+			r.Log.Info("Scheduling Reconcile for the scheduled stop", "Transition", singleInstanceDatabase.Status.NextScheduledTransition)
+			return scheduleRequeue, nil
+		}
+	}
+
 	// Scheduling a reconcile for certificate renewal, if TCPS is enabled
 	if futureRequeue != requeueN {
 		r.Log.Info("Scheduling Reconcile for cert renewal", "Duration(Hours)", futureRequeue.RequeueAfter.Hours())
@@ -311,7 +502,7 @@ func (r *SingleInstanceDatabaseReconciler) updateReconcileStatus(m *dbapi.Single
 	result *ctrl.Result, err *error, blocked *bool, completed *bool) {
 
 	// Always refresh status before a reconcile
-	defer r.Status().Update(ctx, m)
+	defer k8s.PatchStatus(ctx, r.Client, m)
 
 	errMsg := func() string {
 		if *err != nil {
@@ -319,6 +510,11 @@ func (r *SingleInstanceDatabaseReconciler) updateReconcileStatus(m *dbapi.Single
 		}
 		return "no reconcile errors"
 	}()
+	if *completed {
+		m.Status.ObservedGeneration = m.GetGeneration()
+	}
+	dbcommons.SetHealthConditions(&m.Status.Conditions, m.GetGeneration(), m.Status.ObservedGeneration, m.Status.Status)
+
 	var condition metav1.Condition
 	if *completed {
 		condition = metav1.Condition{
@@ -359,6 +555,12 @@ func (r *SingleInstanceDatabaseReconciler) updateReconcileStatus(m *dbapi.Single
 	} else {
 		return
 	}
+	// Keep the condition unchanged if only its time would change, so that the status is not patched
+	if current := meta.FindStatusCondition(m.Status.Conditions, condition.Type); current != nil &&
+		current.Status == condition.Status && current.Reason == condition.Reason &&
+		current.Message == condition.Message && current.ObservedGeneration == condition.ObservedGeneration {
+		return
+	}
 	if len(m.Status.Conditions) > 0 {
 		meta.RemoveStatusCondition(&m.Status.Conditions, condition.Type)
 	}
@@ -428,9 +630,26 @@ func (r *SingleInstanceDatabaseReconciler) validate(m *dbapi.SingleInstanceDatab
 	if (m.Spec.Edition == "express" || m.Spec.Edition == "free") && m.Spec.PrimaryDatabaseRef != "" && m.Spec.CreateAsStandby {
 		eventMsgs = append(eventMsgs, "Standby database creation is not supported for "+m.Spec.Edition+" edition")
 	}
-	if m.Status.OrdsReference != "" && m.Status.Persistence.Size != "" && m.Status.Persistence != m.Spec.Persistence {
+	// Without pods/exec, the commands run in Jobs, which connect to the database through the password store
+	if !dbcommons.PodExecPermitted() {
+		if !m.Spec.AdminPassword.ExternalPasswordStore {
+			eventMsgs = append(eventMsgs, "adminPassword.externalPasswordStore is required when the operator cannot exec into pods")
+		}
+		if m.Spec.EnableTCPS != m.Status.IsTcpsEnabled {
+			eventMsgs = append(eventMsgs, "enableTCPS cannot be changed when the operator cannot exec into pods")
+		}
+	}
+	if m.GetOwnerConsumer(dbapi.OracleRestDataServiceKind) != nil && m.Status.Persistence.Size != "" && !reflect.DeepEqual(m.Status.Persistence, m.Spec.Persistence) {
 		eventMsgs = append(eventMsgs, "uninstall ORDS to change Peristence")
 	}
+	// A database cloned from the claim of another database opens the source datafiles, under the source SID
+	if ds := m.Spec.Persistence.DataSource; ds != nil && ds.Kind == "PersistentVolumeClaim" && m.Status.DatafilesCreated != "true" {
+		source := &dbapi.SingleInstanceDatabase{}
+		err = r.Get(ctx, types.NamespacedName{Namespace: m.Namespace, Name: ds.Name}, source)
+		if err == nil && source.Status.Sid != "" && !strings.EqualFold(source.Status.Sid, m.Spec.Sid) {
+			eventMsgs = append(eventMsgs, "sid should be "+source.Status.Sid+", the sid of the database on "+ds.Name)
+		}
+	}
 	if len(eventMsgs) > 0 {
 		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, strings.Join(eventMsgs, ","))
 		r.Log.Info(strings.Join(eventMsgs, "\n"))
@@ -448,7 +667,7 @@ func (r *SingleInstanceDatabaseReconciler) validate(m *dbapi.SingleInstanceDatab
 				r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, err.Error())
 				r.Log.Info(err.Error())
 				m.Status.Status = dbcommons.StatusError
-				r.Status().Update(ctx, m)
+				k8s.PatchStatus(ctx, r.Client, m)
 				return requeueY, err
 			}
 			r.Log.Error(err, "Unable to get the secret. Requeueing..")
@@ -484,6 +703,7 @@ func (r *SingleInstanceDatabaseReconciler) validate(m *dbapi.SingleInstanceDatab
 			}
 			return requeueY, err
 		}
+		k8s.TrackStatus(ctx, n)
 
 		if n.Status.Status != dbcommons.StatusReady {
 			m.Status.Status = dbcommons.StatusPending
@@ -520,6 +740,7 @@ func (r *SingleInstanceDatabaseReconciler) validate(m *dbapi.SingleInstanceDatab
 			}
 			return requeueY, err
 		}
+		k8s.TrackStatus(ctx, rp)
 
 		if m.Spec.Sid == rp.Spec.Sid {
 			r.Log.Info("Standby database SID can not be same as the Primary database SID")
@@ -754,10 +975,16 @@ func (r *SingleInstanceDatabaseReconciler) instantiatePodSpec(m *dbapi.SingleIns
 			Containers: []corev1.Container{{
 				Name:  m.Name,
 				Image: m.Spec.Image.PullFrom,
+				Resources: func() corev1.ResourceRequirements {
+					if m.Spec.Resources == nil {
+						return corev1.ResourceRequirements{}
+					}
+					return *m.Spec.Resources
+				}(),
 				Lifecycle: &corev1.Lifecycle{
 					PreStop: &corev1.LifecycleHandler{
 						Exec: &corev1.ExecAction{
-							Command: []string{"/bin/sh", "-c", "/bin/echo -en 'shutdown abort;\n' | env ORACLE_SID=${ORACLE_SID^^} sqlplus -S / as sysdba"},
+							Command: []string{"/bin/sh", "-c", getShutdownCommand(m)},
 						},
 					},
 				},
@@ -1001,7 +1228,7 @@ func (r *SingleInstanceDatabaseReconciler) instantiatePodSpec(m *dbapi.SingleIns
 				}(),
 			}},
 
-			TerminationGracePeriodSeconds: func() *int64 { i := int64(30); return &i }(),
+			TerminationGracePeriodSeconds: func() *int64 { i := getShutdownGracePeriod(m); return &i }(),
 
 			NodeSelector: func() map[string]string {
 				ns := make(map[string]string)
@@ -1063,12 +1290,139 @@ func (r *SingleInstanceDatabaseReconciler) instantiatePodSpec(m *dbapi.SingleIns
 
 	}
 
+	// Streaming the alert and listener logs from a sidecar sharing the process namespace of the database, so that the
+	// diag directory stays in the file system of the database container
+	if m.Spec.AlertLog != nil && m.Spec.AlertLog.Stream {
+		pod.Spec.ShareProcessNamespace = func() *bool { i := true; return &i }()
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+			Name:    dbcommons.AlertLogContainerName,
+			Image:   m.Spec.Image.PullFrom,
+			Command: []string{"/bin/bash", "-c", dbcommons.AlertLogStreamCMD},
+			Env: []corev1.EnvVar{{
+				Name:  "ORACLE_SID",
+				Value: strings.ToUpper(m.Spec.Sid),
+			}},
+		})
+		// kubectl logs and exec keep defaulting to the database container
+		pod.ObjectMeta.Annotations = map[string]string{"kubectl.kubernetes.io/default-container": m.Name}
+	}
+
 	// Set SingleInstanceDatabase instance as the owner and controller
 	ctrl.SetControllerReference(m, pod, r.Scheme)
 	return pod
 
 }
 
+// Returns the seconds given to the database pods to shut down the database
+func getShutdownGracePeriod(m *dbapi.SingleInstanceDatabase) int64 {
+	if m.Spec.Shutdown.TerminationGracePeriodSeconds != nil {
+		return *m.Spec.Shutdown.TerminationGracePeriodSeconds
+	}
+	return dbcommons.DefaultShutdownGracePeriod
+}
+
+// Returns the preStop command shutting down the database. The shutdown is aborted shortly before the grace period
+// ends, so that the pod is not killed with the database open
+func getShutdownCommand(m *dbapi.SingleInstanceDatabase) string {
+	if m.Spec.Shutdown.Mode == "abort" {
+		return dbcommons.ShutdownAbortCMD
+	}
+	mode := m.Spec.Shutdown.Mode
+	if mode == "" {
+		mode = "immediate"
+	}
+	timeout := getShutdownGracePeriod(m) - 20
+	if timeout < 10 {
+		timeout = 10
+	}
+	return fmt.Sprintf(dbcommons.ShutdownDatabaseCMD, mode, timeout)
+}
+
+var errNoArchitecture = errors.New("no schedulable node of an architecture supported by the image")
+
+// #############################################################################
+//
+//	Select the architecture of the nodes of the pods, and the image for it
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) selectArchitecture(m *dbapi.SingleInstanceDatabase,
+	ctx context.Context) (string, string, error) {
+
+	image := m.Spec.Image
+	if len(image.Architectures) == 0 && len(image.ArchitectureImages) == 0 {
+		return "", image.PullFrom, nil
+	}
+	imageFor := func(arch string) string {
+		if archImage := image.ArchitectureImages[arch]; archImage != "" {
+			return archImage
+		}
+		if len(image.Architectures) == 0 {
+			return image.PullFrom
+		}
+		for _, imageArch := range image.Architectures {
+			if imageArch == arch {
+				return image.PullFrom
+			}
+		}
+		return ""
+	}
+
+	archs := []string{}
+	if arch, ok := m.Spec.NodeSelector[corev1.LabelArchStable]; ok {
+		archs = append(archs, arch)
+	} else {
+		nodeArchs, err := dbcommons.GetNodeArchitectures(r, ctx)
+		if err != nil {
+			return "", "", err
+		}
+		archs = nodeArchs
+	}
+	for _, arch := range archs {
+		if archImage := imageFor(arch); archImage != "" {
+			return arch, archImage, nil
+		}
+	}
+	return "", "", errNoArchitecture
+}
+
+// Schedule a pod on the nodes of an architecture, running the image for it
+func setPodArchitecture(pod *corev1.Pod, pullFrom string, arch string, archImage string) {
+	if arch == "" {
+		return
+	}
+	if archImage != pullFrom {
+		for i := range pod.Spec.InitContainers {
+			if pod.Spec.InitContainers[i].Image == pullFrom {
+				pod.Spec.InitContainers[i].Image = archImage
+			}
+		}
+		for i := range pod.Spec.Containers {
+			if pod.Spec.Containers[i].Image == pullFrom {
+				pod.Spec.Containers[i].Image = archImage
+			}
+		}
+		// The pods are still found by the image of the spec
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[dbcommons.ImageAnnotation] = pullFrom
+	}
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	pod.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      corev1.LabelArchStable,
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{arch},
+				}},
+			}},
+		},
+	}
+}
+
 // #############################################################################
 //
 //	Instantiate Service spec from SingleInstanceDatabase spec
@@ -1149,24 +1503,16 @@ func (r *SingleInstanceDatabaseReconciler) instantiatePVCSpec(m *dbapi.SingleIns
 					"storage": resource.MustParse(m.Spec.Persistence.Size),
 				},
 			},
-			StorageClassName: &m.Spec.Persistence.StorageClass,
-			VolumeName:       m.Spec.Persistence.VolumeName,
-			Selector: func() *metav1.LabelSelector {
-				if m.Spec.Persistence.StorageClass != "oci" {
+			// The default storage class is used if none is specified, except for volumes bound by name. Classes
+			// binding volumes on the first consumer provision them in the zone where the pod is scheduled
+			StorageClassName: func() *string {
+				if m.Spec.Persistence.StorageClass == "" && m.Spec.Persistence.VolumeName == "" {
 					return nil
 				}
-				return &metav1.LabelSelector{
-					MatchLabels: func() map[string]string {
-						ns := make(map[string]string)
-						if len(m.Spec.NodeSelector) != 0 {
-							for key, value := range m.Spec.NodeSelector {
-								ns[key] = value
-							}
-						}
-						return ns
-					}(),
-				}
+				return &m.Spec.Persistence.StorageClass
 			}(),
+			VolumeName: m.Spec.Persistence.VolumeName,
+			DataSource: m.Spec.Persistence.DataSource,
 		},
 	}
 	// Set SingleInstanceDatabase instance as the owner and controller
@@ -1194,7 +1540,8 @@ func (r *SingleInstanceDatabaseReconciler) createOrReplacePVC(ctx context.Contex
 	// Get retrieves an obj ( a struct pointer ) for the given object key from the Kubernetes Cluster.
 	err := r.Get(ctx, types.NamespacedName{Name: m.Name, Namespace: m.Namespace}, pvc)
 	if err == nil {
-		if *pvc.Spec.StorageClassName != m.Spec.Persistence.StorageClass ||
+		if (m.Spec.Persistence.StorageClass != "" && (pvc.Spec.StorageClassName == nil ||
+			*pvc.Spec.StorageClassName != m.Spec.Persistence.StorageClass)) ||
 			pvc.Spec.Resources.Requests["storage"] != resource.MustParse(m.Spec.Persistence.Size) ||
 			(m.Spec.Persistence.VolumeName != "" && pvc.Spec.VolumeName != m.Spec.Persistence.VolumeName) ||
 			pvc.Spec.AccessModes[0] != corev1.PersistentVolumeAccessMode(m.Spec.Persistence.AccessMode) {
@@ -1412,6 +1759,22 @@ func (r *SingleInstanceDatabaseReconciler) createOrReplaceSVC(ctx context.Contex
 		}
 	}
 
+	if isExtSvcFound && dbcommons.SetExternalDNSAnnotation(extSvc, m.Spec.Hostname) {
+		log.Info("Updating the external-dns hostname of the service", "Service.Name", extSvc.Name, "hostname", m.Spec.Hostname)
+		if err := r.Update(ctx, extSvc); err != nil {
+			log.Error(err, "Failed to update Service")
+			return requeueY, err
+		}
+	}
+
+	if isExtSvcFound && dbcommons.SetServiceOptions(extSvc, m.Spec.SessionAffinity, m.Spec.ExternalTrafficPolicy, m.Spec.LoadBalancerSourceRanges) {
+		log.Info("Updating the options of the service", "Service.Name", extSvc.Name)
+		if err := r.Update(ctx, extSvc); err != nil {
+			log.Error(err, "Failed to update Service")
+			return requeueY, err
+		}
+	}
+
 	if !isExtSvcFound {
 		// Reset connect strings whenever extSvc is recreated
 		m.Status.Status = dbcommons.StatusUpdating
@@ -1486,6 +1849,7 @@ func (r *SingleInstanceDatabaseReconciler) createOrReplaceSVC(ctx context.Contex
 
 		// Create the service
 		svc := r.instantiateSVCSpec(m, extSvcName, ports, extSvcType)
+		dbcommons.SetExternalDNSAnnotation(svc, m.Spec.Hostname)
 		log.Info("Creating a new service", "Service.Namespace", svc.Namespace, "Service.Name", svc.Name)
 		err := r.Create(ctx, svc)
 		if err != nil {
@@ -1515,24 +1879,37 @@ func (r *SingleInstanceDatabaseReconciler) createOrReplaceSVC(ctx context.Contex
 	}
 	if m.Spec.LoadBalancer {
 		m.Status.ClusterConnectString = extSvc.Name + "." + extSvc.Namespace + ":" + fmt.Sprint(extSvc.Spec.Ports[1].Port) + "/" + strings.ToUpper(sid)
-		if len(extSvc.Status.LoadBalancer.Ingress) > 0 {
-			// 'lbAddress' will contain the Fully Qualified Hostname of the LB. If the hostname is not available it will contain the IP address of the LB
-			lbAddress := extSvc.Status.LoadBalancer.Ingress[0].Hostname
-			if lbAddress == "" {
-				lbAddress = extSvc.Status.LoadBalancer.Ingress[0].IP
-			}
+		previousConnectString := m.Status.ConnectString
+		// 'lbAddress' will contain the Fully Qualified Hostname of the LB. If the hostname is not available it will contain the IP address of the LB
+		if lbAddress := dbcommons.GetLoadBalancerAddress(extSvc); lbAddress != "" {
+			lbAddress = dbcommons.GetExternalHost(m.Spec.Hostname, lbAddress)
 			m.Status.ConnectString = lbAddress + ":" + fmt.Sprint(extSvc.Spec.Ports[1].Port) + "/" + strings.ToUpper(sid)
+			if previousConnectString != "" && previousConnectString != dbcommons.ValueUnavailable &&
+				previousConnectString != m.Status.ConnectString {
+				eventReason := "Service Address"
+				eventMsg := "connect string changed from " + previousConnectString + " to " + m.Status.ConnectString
+				r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+				log.Info(eventMsg)
+			}
 			m.Status.PdbConnectString = lbAddress + ":" + fmt.Sprint(extSvc.Spec.Ports[1].Port) + "/" + strings.ToUpper(pdbName)
 			oemExpressUrl = "https://" + lbAddress + ":" + fmt.Sprint(extSvc.Spec.Ports[0].Port) + "/em"
 			if m.Spec.EnableTCPS {
 				m.Status.TcpsConnectString = lbAddress + ":" + fmt.Sprint(extSvc.Spec.Ports[len(extSvc.Spec.Ports)-1].Port) + "/" + strings.ToUpper(sid)
 				m.Status.TcpsPdbConnectString = lbAddress + ":" + fmt.Sprint(extSvc.Spec.Ports[len(extSvc.Spec.Ports)-1].Port) + "/" + strings.ToUpper(pdbName)
 			}
+		} else {
+			// The load balancer is being provisioned again, the addresses of the previous one no longer apply
+			m.Status.ConnectString = dbcommons.ValueUnavailable
+			m.Status.PdbConnectString = dbcommons.ValueUnavailable
+			oemExpressUrl = dbcommons.ValueUnavailable
+			m.Status.TcpsConnectString = dbcommons.ValueUnavailable
+			m.Status.TcpsPdbConnectString = dbcommons.ValueUnavailable
 		}
 	} else {
 		m.Status.ClusterConnectString = extSvc.Name + "." + extSvc.Namespace + ":" + fmt.Sprint(extSvc.Spec.Ports[1].Port) + "/" + strings.ToUpper(sid)
 		nodeip := dbcommons.GetNodeIp(r, ctx, req)
 		if nodeip != "" {
+			nodeip = dbcommons.GetExternalHost(m.Spec.Hostname, nodeip)
 			m.Status.ConnectString = nodeip + ":" + fmt.Sprint(extSvc.Spec.Ports[1].NodePort) + "/" + strings.ToUpper(sid)
 			m.Status.PdbConnectString = nodeip + ":" + fmt.Sprint(extSvc.Spec.Ports[1].NodePort) + "/" + strings.ToUpper(pdbName)
 			oemExpressUrl = "https://" + nodeip + ":" + fmt.Sprint(extSvc.Spec.Ports[0].NodePort) + "/em"
@@ -1584,8 +1961,8 @@ func (r *SingleInstanceDatabaseReconciler) createOrReplacePods(m *dbapi.SingleIn
 		if pod.Labels["version"] != m.Spec.Image.Version {
 			oldVersion = pod.Labels["version"]
 		}
-		if pod.Spec.Containers[0].Image != m.Spec.Image.PullFrom {
-			oldImage = pod.Spec.Containers[0].Image
+		if dbcommons.GetPodImage(pod) != m.Spec.Image.PullFrom {
+			oldImage = dbcommons.GetPodImage(pod)
 		}
 
 	}
@@ -1632,6 +2009,17 @@ func (r *SingleInstanceDatabaseReconciler) createOrReplacePods(m *dbapi.SingleIn
 						continue
 					}
 					r.Log.Info("Pod unavailable reason: ", "reason", waitingReason)
+					// An image built for another architecture than the one of the node fails to start
+					for _, status := range append(allAvailable[i].Status.InitContainerStatuses, allAvailable[i].Status.ContainerStatuses...) {
+						if terminated := status.LastTerminationState.Terminated; terminated != nil &&
+							strings.Contains(terminated.Message, "exec format error") {
+							eventReason = "Architecture Error"
+							eventMsg = "image " + status.Image + " does not support the architecture of node " +
+								allAvailable[i].Spec.NodeName + ", set image.architectures or image.architectureImages"
+							r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+							break
+						}
+					}
 					if strings.Contains(waitingReason, "ImagePullBackOff") || strings.Contains(waitingReason, "ErrImagePull") {
 						r.Log.Info("Deleting pod", "name", allAvailable[i].Name)
 						var gracePeriodSeconds int64 = 0
@@ -1656,9 +2044,30 @@ func (r *SingleInstanceDatabaseReconciler) createOrReplacePods(m *dbapi.SingleIn
 	}
 
 	// Version/Image changed
+	// The pods are replaced once their critical operations end
+	if disruptionBlocked(ctx, r.Client, r.Recorder, m, "replacement of the pods with image "+m.Spec.Image.PullFrom, allAvailable...) {
+		return requeueY, nil
+	}
 	// PATCHING START (Only Software Patch)
 	log.Info("Pod image change detected, datapatch to be rerun...")
 	m.Status.DatafilesPatched = "false"
+	meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+		Type:               dbcommons.DatapatchVerifiedCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: m.GetGeneration(),
+		Reason:             dbcommons.DatapatchPendingReason,
+		Message:            "datapatch is pending for image " + m.Spec.Image.PullFrom,
+	})
+	if oldImage != "" {
+		m.Status.PreviousImage = oldImage
+		meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+			Type:               dbcommons.RollbackAvailableCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: m.GetGeneration(),
+			Reason:             dbcommons.PreviousImageReason,
+			Message:            "set image.pullFrom to " + oldImage + " to roll back, datapatch then rolls back the SQL patches missing from the image",
+		})
+	}
 	// call FindPods() to find pods of older version. Delete all the Pods
 	readyPod, oldReplicasFound, oldAvailable, _, err := dbcommons.FindPods(r, oldVersion,
 		oldImage, m.Name, m.Namespace, ctx, req)
@@ -1800,7 +2209,7 @@ func (r *SingleInstanceDatabaseReconciler) createWallet(m *dbapi.SingleInstanceD
 		return requeueY, nil
 	}
 
-	if m.Spec.CloneFrom == "" && m.Spec.Edition != "express" {
+	if m.Spec.CloneFrom == "" && m.Spec.Edition != "express" && m.Spec.Edition != "free" {
 		//Check if Edition of m.Spec.Sid is same as m.Spec.Edition
 		getEditionFile := dbcommons.GetEnterpriseEditionFileCMD
 		eventReason := m.Spec.Sid + " is a enterprise edition"
@@ -1830,7 +2239,7 @@ func (r *SingleInstanceDatabaseReconciler) createWallet(m *dbapi.SingleInstanceD
 		if apierrors.IsNotFound(err) {
 			r.Log.Info("Secret not found")
 			m.Status.Status = dbcommons.StatusError
-			r.Status().Update(ctx, m)
+			k8s.PatchStatus(ctx, r.Client, m)
 			return requeueY, nil
 		}
 		r.Log.Error(err, "Unable to get the secret. Requeueing..")
@@ -1881,10 +2290,21 @@ func (r *SingleInstanceDatabaseReconciler) createPods(m *dbapi.SingleInstanceDat
 	if !replicaPatching {
 		m.Status.Replicas = replicasFound
 	}
+	arch, archImage, err := r.selectArchitecture(m, ctx)
+	if err != nil {
+		if errors.Is(err, errNoArchitecture) {
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, "Architecture Error", err.Error())
+			log.Info(err.Error())
+			return requeueY, nil
+		}
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
 	//  if Found < Required, create new pods, name of pods are generated randomly
 	for i := replicasFound; i < replicasReq; i++ {
 		// mandatory pod affinity if it is replica based patching or not the first pod
 		pod := r.instantiatePodSpec(m, n, rp, replicaPatching || !firstPod)
+		setPodArchitecture(pod, m.Spec.Image.PullFrom, arch, archImage)
 		log.Info("Creating a new "+m.Name+" POD", "POD.Namespace", pod.Namespace, "POD.Name", pod.Name)
 		err := r.Create(ctx, pod)
 		if err != nil {
@@ -1950,6 +2370,11 @@ func (r *SingleInstanceDatabaseReconciler) deletePods(ctx context.Context, req c
 		available = append(available, readyPod)
 	}
 
+	// Only the deletion of the database interrupts the critical operations of the pods
+	if m.DeletionTimestamp == nil && disruptionBlocked(ctx, r.Client, r.Recorder, m, "deletion of the pods", available...) {
+		return requeueY, nil
+	}
+
 	noDeleted := 0
 	for _, availablePod := range available {
 		if readyPod.Name == availablePod.Name && m.Spec.Replicas != 0 {
@@ -2011,14 +2436,18 @@ func (r *SingleInstanceDatabaseReconciler) validateDBReadiness(m *dbapi.SingleIn
 					r.Log.Info(err.Error())
 				}
 			}
-			out, err := dbcommons.ExecCommand(r, r.Config, runningPod.Name, runningPod.Namespace, "",
-				ctx, req, false, "bash", "-c", dbcommons.GetCheckpointFileCMD)
-			if err != nil {
-				r.Log.Info(err.Error())
+			// The datafiles are checked until they are found, which is then cached in the status
+			out := ""
+			if m.Status.DatafilesCreated != "true" {
+				out, err = dbcommons.ExecCommand(r, r.Config, runningPod.Name, runningPod.Namespace, "",
+					ctx, req, false, "bash", "-c", dbcommons.GetCheckpointFileCMD)
+				if err != nil {
+					r.Log.Info(err.Error())
+				}
+				r.Log.Info("GetCheckpointFileCMD Output : \n" + out)
 			}
-			r.Log.Info("GetCheckpointFileCMD Output : \n" + out)
 
-			if out != "" {
+			if out != "" || m.Status.DatafilesCreated == "true" {
 				eventReason := "Database Unhealthy"
 				eventMsg := "datafiles exists"
 				r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
@@ -2154,16 +2583,14 @@ func (r *SingleInstanceDatabaseReconciler) updateClientWallet(m *dbapi.SingleIns
 		var host string
 		var port int32
 		if m.Spec.LoadBalancer {
-			if len(extSvc.Status.LoadBalancer.Ingress) > 0 {
-				host = extSvc.Status.LoadBalancer.Ingress[0].Hostname
-				if host == "" {
-					host = extSvc.Status.LoadBalancer.Ingress[0].IP
-				}
+			if host = dbcommons.GetLoadBalancerAddress(extSvc); host != "" {
+				host = dbcommons.GetExternalHost(m.Spec.Hostname, host)
 				port = extSvc.Spec.Ports[len(extSvc.Spec.Ports)-1].Port
 			}
 		} else {
 			host = dbcommons.GetNodeIp(r, ctx, req)
 			if host != "" {
+				host = dbcommons.GetExternalHost(m.Spec.Hostname, host)
 				port = extSvc.Spec.Ports[len(extSvc.Spec.Ports)-1].NodePort
 			}
 		}
@@ -2194,7 +2621,7 @@ func (r *SingleInstanceDatabaseReconciler) configTcps(m *dbapi.SingleInstanceDat
 	if m.Spec.EnableTCPS && !m.Status.IsTcpsEnabled {
 		// Enable TCPS
 		m.Status.Status = dbcommons.StatusUpdating
-		r.Status().Update(ctx, m)
+		k8s.PatchStatus(ctx, r.Client, m)
 
 		eventMsg := "Enabling TCPS in the database..."
 		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
@@ -2212,7 +2639,7 @@ func (r *SingleInstanceDatabaseReconciler) configTcps(m *dbapi.SingleInstanceDat
 		m.Status.CertCreationTimestamp = time.Now().Format(time.RFC3339)
 		m.Status.IsTcpsEnabled = true
 		m.Status.ClientWalletLoc = fmt.Sprintf(dbcommons.ClientWalletLocation, m.Spec.Sid)
-		r.Status().Update(ctx, m)
+		k8s.PatchStatus(ctx, r.Client, m)
 
 		eventMsg = "TCPS Enabled."
 		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
@@ -2230,7 +2657,7 @@ func (r *SingleInstanceDatabaseReconciler) configTcps(m *dbapi.SingleInstanceDat
 	} else if !m.Spec.EnableTCPS && m.Status.IsTcpsEnabled {
 		// Disable TCPS
 		m.Status.Status = dbcommons.StatusUpdating
-		r.Status().Update(ctx, m)
+		k8s.PatchStatus(ctx, r.Client, m)
 
 		eventMsg := "Disabling TCPS in the database..."
 		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
@@ -2246,7 +2673,7 @@ func (r *SingleInstanceDatabaseReconciler) configTcps(m *dbapi.SingleInstanceDat
 		m.Status.CertCreationTimestamp = ""
 		m.Status.IsTcpsEnabled = false
 		m.Status.ClientWalletLoc = ""
-		r.Status().Update(ctx, m)
+		k8s.PatchStatus(ctx, r.Client, m)
 
 		eventMsg = "TCPS Disabled."
 		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
@@ -2258,7 +2685,7 @@ func (r *SingleInstanceDatabaseReconciler) configTcps(m *dbapi.SingleInstanceDat
 		allowdDuration, _ := time.ParseDuration(m.Spec.TcpsCertRenewInterval)
 		if duration > allowdDuration {
 			m.Status.Status = dbcommons.StatusUpdating
-			r.Status().Update(ctx, m)
+			k8s.PatchStatus(ctx, r.Client, m)
 
 			out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "",
 				ctx, req, false, "bash", "-c", fmt.Sprintf(dbcommons.EnableTcpsCMD))
@@ -2269,7 +2696,7 @@ func (r *SingleInstanceDatabaseReconciler) configTcps(m *dbapi.SingleInstanceDat
 			r.Log.Info("Cert Renewal Output : \n" + out)
 			// Updating the Status and publishing the event
 			m.Status.CertCreationTimestamp = time.Now().Format(time.RFC3339)
-			r.Status().Update(ctx, m)
+			k8s.PatchStatus(ctx, r.Client, m)
 
 			eventMsg := "TCPS Certificates Renewed at time %s,"
 			r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg, time.Now().Format(time.RFC3339))
@@ -2304,69 +2731,1162 @@ func (r *SingleInstanceDatabaseReconciler) configTcps(m *dbapi.SingleInstanceDat
 
 // #############################################################################
 //
-//	Execute Datapatch
+//	Instantiate True Cache POD spec from SingleInstanceDatabase spec
 //
 // #############################################################################
-func (r *SingleInstanceDatabaseReconciler) runDatapatch(m *dbapi.SingleInstanceDatabase,
-	readyPod corev1.Pod, ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-
-	// Datapatch not supported for XE Database
-	if m.Spec.Edition == "express" || m.Spec.Edition == "free" {
-		eventReason := "Datapatch Check"
-		eventMsg := "datapatch not supported for " + m.Spec.Edition + " edition"
-		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
-		r.Log.Info(eventMsg)
-		return requeueN, nil
-	}
-
-	m.Status.Status = dbcommons.StatusPatching
-	eventReason := "Datapatch Executing"
-	eventMsg := "datapatch begins execution"
-	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
-	r.Status().Update(ctx, m)
-
-	//RUN DATAPATCH
-	out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "",
-		ctx, req, false, "bash", "-c", dbcommons.RunDatapatchCMD)
-	if err != nil {
-		r.Log.Error(err, err.Error())
-		return requeueY, err
-	}
-	r.Log.Info("Datapatch output")
-	r.Log.Info(out)
-
-	// Get Sqlpatch Description
-	out, err = dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
-		fmt.Sprintf("echo -e  \"%s\"  | sqlplus -s / as sysdba ", dbcommons.GetSqlpatchDescriptionSQL))
-	releaseUpdate := ""
-	if err == nil {
-		r.Log.Info("GetSqlpatchDescriptionSQL Output")
-		r.Log.Info(out)
-		SqlpatchDescriptions, _ := dbcommons.StringToLines(out)
-		if len(SqlpatchDescriptions) > 0 {
-			releaseUpdate = SqlpatchDescriptions[0]
-		}
-	}
-
-	eventReason = "Datapatch Done"
-	if strings.Contains(out, "Datapatch execution has failed.") {
-		eventMsg = "datapatch execution failed"
-		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
-		return requeueN, errors.New(eventMsg)
-	}
+func (r *SingleInstanceDatabaseReconciler) instantiateTrueCachePodSpec(m *dbapi.SingleInstanceDatabase) *corev1.Pod {
 
-	m.Status.DatafilesPatched = "true"
-	status, versionFrom, versionTo, _ := dbcommons.GetSqlpatchStatus(r, r.Config, readyPod, ctx, req)
-	if versionTo != "" {
-		eventMsg = "data files patched from release update " + versionFrom + " to " + versionTo + ", " + status + ": " + releaseUpdate
-	} else {
-		eventMsg = "datapatch execution completed"
-	}
-	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	tcName := m.Name + dbcommons.TrueCacheSuffix
 
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tcName + "-" + dbcommons.GenerateRandomString(5),
+			Namespace: m.Namespace,
+			Labels:    dbcommons.GetLabelsForController(m.Spec.Image.Version, tcName),
+		},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name:         "datamount",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}, {
+				Name: "oracle-pwd-vol",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: m.Spec.AdminPassword.SecretName,
+						Items: []corev1.KeyToPath{{
+							Key:  m.Spec.AdminPassword.SecretKey,
+							Path: "oracle_pwd",
+						}},
+					},
+				},
+			}},
+			Containers: []corev1.Container{{
+				Name:  tcName,
+				Image: m.Spec.Image.PullFrom,
+				Ports: []corev1.ContainerPort{{ContainerPort: dbcommons.CONTAINER_LISTENER_PORT}},
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						Exec: &corev1.ExecAction{
+							Command: []string{"/bin/sh", "-c", "$ORACLE_BASE/checkDBStatus.sh"},
+						},
+					},
+					InitialDelaySeconds: 20,
+					TimeoutSeconds:      20,
+					PeriodSeconds: func() int32 {
+						if m.Spec.ReadinessCheckPeriod > 0 {
+							return int32(m.Spec.ReadinessCheckPeriod)
+						}
+						return 60
+					}(),
+				},
+				VolumeMounts: []corev1.VolumeMount{{
+					MountPath: "/opt/oracle/oradata",
+					Name:      "datamount",
+				}, {
+					MountPath: "/run/secrets/oracle_pwd",
+					ReadOnly:  true,
+					Name:      "oracle-pwd-vol",
+					SubPath:   "oracle_pwd",
+				}},
+				Env: []corev1.EnvVar{
+					{
+						Name:  "TRUE_CACHE",
+						Value: "true",
+					},
+					{
+						Name:  "PRIMARY_DB_CONN_STR",
+						Value: m.Name + ":" + strconv.Itoa(int(dbcommons.CONTAINER_LISTENER_PORT)) + "/" + strings.ToUpper(m.Spec.Sid),
+					},
+					{
+						// Format: <PDB>;<primary service>;<true cache service>[;<primary service>;<true cache service>...]
+						Name: "PDB_TC_SVCS",
+						Value: func() string {
+							svcs := []string{strings.ToUpper(m.Spec.Pdbname)}
+							for _, svc := range m.Spec.TrueCache.Services {
+								svcs = append(svcs, svc.PrimaryService, svc.CacheService)
+							}
+							return strings.Join(svcs, ";")
+						}(),
+					},
+					{
+						Name:  "ORACLE_EDITION",
+						Value: m.Spec.Edition,
+					},
+				},
+			}},
+
+			TerminationGracePeriodSeconds: func() *int64 { i := int64(30); return &i }(),
+
+			NodeSelector: func() map[string]string {
+				ns := make(map[string]string)
+				for key, value := range m.Spec.TrueCache.NodeSelector {
+					ns[key] = value
+				}
+				return ns
+			}(),
+
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser: func() *int64 {
+					i := int64(dbcommons.ORACLE_UID)
+					return &i
+				}(),
+				RunAsGroup: func() *int64 {
+					i := int64(dbcommons.ORACLE_GUID)
+					return &i
+				}(),
+				FSGroup: func() *int64 {
+					i := int64(dbcommons.ORACLE_GUID)
+					return &i
+				}(),
+			},
+			ImagePullSecrets: []corev1.LocalObjectReference{
+				{
+					Name: m.Spec.Image.PullSecrets,
+				},
+			},
+		},
+	}
+
+	// Set SingleInstanceDatabase instance as the owner and controller
+	ctrl.SetControllerReference(m, pod, r.Scheme)
+	return pod
+}
+
+// #############################################################################
+//
+//	Create, scale or remove the True Cache instances of a primary database
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) manageTrueCache(m *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	log := r.Log.WithValues("manageTrueCache", req.NamespacedName)
+
+	// Nothing deployed and nothing requested
+	if m.Spec.TrueCache == nil && m.Status.TrueCache == nil {
+		return requeueN, nil
+	}
+
+	tcName := m.Name + dbcommons.TrueCacheSuffix
+
+	_, replicasFound, available, _, err := dbcommons.FindPods(r, "", "", tcName, m.Namespace, ctx, req)
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+
+	tcSvc := &corev1.Service{}
+	getSvcErr := r.Get(ctx, types.NamespacedName{Name: tcName, Namespace: m.Namespace}, tcSvc)
+	if getSvcErr != nil && !apierrors.IsNotFound(getSvcErr) {
+		log.Error(getSvcErr, "Error encountered in obtaining the service", tcName)
+		return requeueY, getSvcErr
+	}
+
+	if m.Spec.TrueCache == nil {
+		// True Cache removed from the spec, tear down the instances and their service
+		if replicasFound == 0 && apierrors.IsNotFound(getSvcErr) {
+			m.Status.TrueCache = nil
+			return requeueN, nil
+		}
+		podList := &corev1.PodList{}
+		if err := r.List(ctx, podList, client.InNamespace(m.Namespace), client.MatchingLabels(dbcommons.GetLabelsForController("", tcName))); err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		for i := range podList.Items {
+			log.Info("Deleting True Cache POD", "POD.Name", podList.Items[i].Name)
+			if err := r.Delete(ctx, &podList.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, "Failed to delete True Cache POD", "POD.Name", podList.Items[i].Name)
+				return requeueY, err
+			}
+		}
+		if getSvcErr == nil {
+			log.Info("Deleting True Cache service", "Service.Name", tcName)
+			if err := r.Delete(ctx, tcSvc); err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, "Failed to delete True Cache service", "Service.Name", tcName)
+				return requeueY, err
+			}
+		}
+		m.Status.TrueCache = nil
+		eventReason := "True Cache Removed"
+		eventMsg := "true cache instances deleted"
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		return requeueN, nil
+	}
+
+	// True Cache requires a 23ai primary
+	dbMajorVersion, err := strconv.Atoi(strings.Split(m.Status.ReleaseUpdate, ".")[0])
+	if err != nil || dbMajorVersion < 23 {
+		eventReason := "Spec Error"
+		eventMsg := "true cache is only supported for database version 23 onwards"
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		r.Log.Info(eventMsg)
+		return requeueN, nil
+	}
+
+	if m.Status.TrueCache == nil {
+		m.Status.TrueCache = &dbapi.SingleInstanceDatabaseTrueCacheStatus{Status: dbcommons.StatusPending}
+	}
+
+	if apierrors.IsNotFound(getSvcErr) {
+		ports := []corev1.ServicePort{{Name: "listener", Port: dbcommons.CONTAINER_LISTENER_PORT, Protocol: corev1.ProtocolTCP}}
+		svc := r.instantiateSVCSpec(m, tcName, ports, corev1.ServiceType("ClusterIP"))
+		svc.Labels = dbcommons.GetLabelsForController("", tcName)
+		svc.Spec.Selector = dbcommons.GetLabelsForController("", tcName)
+		log.Info("Creating a new service", "Service.Namespace", svc.Namespace, "Service.Name", svc.Name)
+		if err := r.Create(ctx, svc); err != nil {
+			log.Error(err, "Failed to create new service", "Service.Namespace", svc.Namespace, "Service.Name", svc.Name)
+			return requeueY, err
+		}
+	}
+
+	replicasReq := m.Spec.TrueCache.Replicas
+	if replicasReq == 0 {
+		replicasReq = 1
+	}
+	log.Info("True Cache Replica Info", "Found", replicasFound, "Required", replicasReq)
+
+	for i := replicasFound; i < replicasReq; i++ {
+		pod := r.instantiateTrueCachePodSpec(m)
+		log.Info("Creating a new True Cache POD", "POD.Namespace", pod.Namespace, "POD.Name", pod.Name)
+		if err := r.Create(ctx, pod); err != nil {
+			log.Error(err, "Failed to create new True Cache POD", "POD.Namespace", pod.Namespace, "POD.Name", pod.Name)
+			return requeueY, err
+		}
+		replicasFound += 1
+	}
+	// Scale down, preferring to keep ready instances
+	for i := 0; replicasFound > replicasReq && i < len(available); i++ {
+		log.Info("Deleting True Cache POD", "POD.Name", available[i].Name)
+		if err := r.Delete(ctx, &available[i]); err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to delete True Cache POD", "POD.Name", available[i].Name)
+			return requeueY, err
+		}
+		replicasFound -= 1
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(m.Namespace), client.MatchingLabels(dbcommons.GetLabelsForController("", tcName))); err != nil {
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+	readyReplicas := 0
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp == nil && len(pod.Status.ContainerStatuses) > 0 && pod.Status.ContainerStatuses[0].Ready {
+			readyReplicas += 1
+		}
+	}
+
+	m.Status.TrueCache.Replicas = replicasFound
+	m.Status.TrueCache.ReadyReplicas = readyReplicas
+	m.Status.TrueCache.ConnectStrings = []string{}
+	for _, svc := range m.Spec.TrueCache.Services {
+		m.Status.TrueCache.ConnectStrings = append(m.Status.TrueCache.ConnectStrings,
+			tcName+"."+m.Namespace+":"+strconv.Itoa(int(dbcommons.CONTAINER_LISTENER_PORT))+"/"+svc.CacheService)
+	}
+
+	if readyReplicas < replicasReq {
+		m.Status.TrueCache.Status = dbcommons.StatusPending
+		log.Info("Waiting for True Cache instances to be ready", "Ready", readyReplicas, "Required", replicasReq)
+		return requeueY, nil
+	}
+	if m.Status.TrueCache.Status != dbcommons.StatusReady {
+		eventReason := "True Cache Ready"
+		eventMsg := fmt.Sprintf("%d true cache instance(s) ready", readyReplicas)
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	}
+	m.Status.TrueCache.Status = dbcommons.StatusReady
+	return requeueN, nil
+}
+
+// #############################################################################
+//
+//	Instantiate the Oracle Connection Manager deployment from SingleInstanceDatabase spec
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) instantiateConnectionManagerSpec(m *dbapi.SingleInstanceDatabase) *appsv1.Deployment {
+
+	cmName := m.Name + dbcommons.ConnectionManagerSuffix
+	cm := m.Spec.ConnectionManager
+	port := cm.ListenerPort
+	if port == 0 {
+		port = dbcommons.CONTAINER_LISTENER_PORT
+	}
+	replicas := cm.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmName,
+			Namespace: m.Namespace,
+			Labels:    dbcommons.GetLabelsForController("", cmName),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: dbcommons.GetLabelsForController("", cmName),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: dbcommons.GetLabelsForController("", cmName),
+				},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{
+						Name: "cman-config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: cmName},
+							},
+						},
+					}},
+					Containers: []corev1.Container{{
+						Name:    cmName,
+						Image:   cm.Image,
+						Command: []string{"/bin/bash", "-c", "cmctl startup -c cman && exec sleep infinity"},
+						Ports:   []corev1.ContainerPort{{ContainerPort: port}},
+						Env: []corev1.EnvVar{{
+							Name:  "TNS_ADMIN",
+							Value: dbcommons.ConnectionManagerConfigDir,
+						}},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(int(port))},
+							},
+							InitialDelaySeconds: 10,
+							PeriodSeconds:       10,
+						},
+						LivenessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(int(port))},
+							},
+							InitialDelaySeconds: 30,
+							PeriodSeconds:       30,
+						},
+						VolumeMounts: []corev1.VolumeMount{{
+							MountPath: dbcommons.ConnectionManagerConfigDir,
+							ReadOnly:  true,
+							Name:      "cman-config",
+						}},
+					}},
+
+					NodeSelector: func() map[string]string {
+						if len(cm.NodeSelector) == 0 {
+							return nil
+						}
+						ns := make(map[string]string)
+						for key, value := range cm.NodeSelector {
+							ns[key] = value
+						}
+						return ns
+					}(),
+
+					ImagePullSecrets: func() []corev1.LocalObjectReference {
+						if cm.PullSecrets == "" {
+							return nil
+						}
+						return []corev1.LocalObjectReference{{Name: cm.PullSecrets}}
+					}(),
+				},
+			},
+		},
+	}
+
+	// Set SingleInstanceDatabase instance as the owner and controller
+	ctrl.SetControllerReference(m, deployment, r.Scheme)
+	return deployment
+}
+
+// #############################################################################
+//
+//	Create, update or remove the Oracle Connection Manager instances of a database
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) manageConnectionManager(m *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	log := r.Log.WithValues("manageConnectionManager", req.NamespacedName)
+
+	// Nothing deployed and nothing requested
+	if m.Spec.ConnectionManager == nil && m.Status.ConnectionManager == nil {
+		return requeueN, nil
+	}
+
+	cmName := m.Name + dbcommons.ConnectionManagerSuffix
+	deployment := &appsv1.Deployment{}
+	getDeploymentErr := r.Get(ctx, types.NamespacedName{Name: cmName, Namespace: m.Namespace}, deployment)
+	if getDeploymentErr != nil && !apierrors.IsNotFound(getDeploymentErr) {
+		log.Error(getDeploymentErr, "Error encountered in obtaining the deployment", "Deployment.Name", cmName)
+		return requeueY, getDeploymentErr
+	}
+
+	if m.Spec.ConnectionManager == nil {
+		// Connection Manager removed from the spec, tear down the deployment, its config and its service
+		for _, obj := range []client.Object{
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: cmName, Namespace: m.Namespace}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: cmName, Namespace: m.Namespace}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: cmName, Namespace: m.Namespace}},
+		} {
+			if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, "Failed to delete Connection Manager resource", "Name", cmName)
+				return requeueY, err
+			}
+		}
+		m.Status.ConnectionManager = nil
+		eventReason := "Connection Manager Removed"
+		eventMsg := "connection manager instances deleted"
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		return requeueN, nil
+	}
+
+	cm := m.Spec.ConnectionManager
+	port := cm.ListenerPort
+	if port == 0 {
+		port = dbcommons.CONTAINER_LISTENER_PORT
+	}
+	maxConnections := cm.MaxConnections
+	if maxConnections == 0 {
+		maxConnections = 256
+	}
+	rateLimit := ""
+	if cm.ConnectionRateLimit > 0 {
+		rateLimit = "(rate_limit = " + strconv.Itoa(cm.ConnectionRateLimit) + ")"
+	}
+	dbHost := m.Name + "." + m.Namespace
+	cmanOra := strings.NewReplacer(
+		"##CMAN_PORT##", strconv.Itoa(int(port)),
+		"##CMAN_RATE_LIMIT##", rateLimit,
+		"##MAX_CONNECTIONS##", strconv.Itoa(maxConnections),
+		"##DATABASE_HOST##", dbHost,
+	).Replace(dbcommons.ConnectionManagerConfig)
+
+	if m.Status.ConnectionManager == nil {
+		m.Status.ConnectionManager = &dbapi.SingleInstanceDatabaseConnectionManagerStatus{Status: dbcommons.StatusPending}
+	}
+
+	// cman.ora, the instances are restarted when it changes
+	configChanged := false
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: cmName, Namespace: m.Namespace}, configMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "Error encountered in obtaining the config map", "ConfigMap.Name", cmName)
+		return requeueY, err
+	}
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cmName,
+				Namespace: m.Namespace,
+				Labels:    dbcommons.GetLabelsForController("", cmName),
+			},
+			Data: map[string]string{"cman.ora": cmanOra},
+		}
+		ctrl.SetControllerReference(m, configMap, r.Scheme)
+		log.Info("Creating a new config map", "ConfigMap.Namespace", configMap.Namespace, "ConfigMap.Name", configMap.Name)
+		if err := r.Create(ctx, configMap); err != nil {
+			log.Error(err, "Failed to create new config map", "ConfigMap.Namespace", configMap.Namespace, "ConfigMap.Name", configMap.Name)
+			return requeueY, err
+		}
+	} else if configMap.Data["cman.ora"] != cmanOra {
+		configMap.Data = map[string]string{"cman.ora": cmanOra}
+		log.Info("Updating the config map", "ConfigMap.Namespace", configMap.Namespace, "ConfigMap.Name", configMap.Name)
+		if err := r.Update(ctx, configMap); err != nil {
+			log.Error(err, "Failed to update the config map", "ConfigMap.Namespace", configMap.Namespace, "ConfigMap.Name", configMap.Name)
+			return requeueY, err
+		}
+		configChanged = true
+	}
+
+	newDeployment := r.instantiateConnectionManagerSpec(m)
+	if apierrors.IsNotFound(getDeploymentErr) {
+		log.Info("Creating a new deployment", "Deployment.Namespace", newDeployment.Namespace, "Deployment.Name", newDeployment.Name)
+		if err := r.Create(ctx, newDeployment); err != nil {
+			log.Error(err, "Failed to create new deployment", "Deployment.Namespace", newDeployment.Namespace, "Deployment.Name", newDeployment.Name)
+			return requeueY, err
+		}
+		deployment = newDeployment
+	} else if configChanged || !reflect.DeepEqual(deployment.Spec.Replicas, newDeployment.Spec.Replicas) ||
+		deployment.Spec.Template.Spec.Containers[0].Image != cm.Image ||
+		!reflect.DeepEqual(deployment.Spec.Template.Spec.NodeSelector, newDeployment.Spec.Template.Spec.NodeSelector) ||
+		deployment.Spec.Template.Spec.Containers[0].Ports[0].ContainerPort != port {
+		restartedAt := deployment.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"]
+		if configChanged {
+			restartedAt = time.Now().Format(time.RFC3339)
+		}
+		if restartedAt != "" {
+			newDeployment.Spec.Template.Annotations = map[string]string{"kubectl.kubernetes.io/restartedAt": restartedAt}
+		}
+		deployment.Spec.Replicas = newDeployment.Spec.Replicas
+		deployment.Spec.Template = newDeployment.Spec.Template
+		log.Info("Updating the deployment", "Deployment.Namespace", deployment.Namespace, "Deployment.Name", deployment.Name)
+		if err := r.Update(ctx, deployment); err != nil {
+			log.Error(err, "Failed to update the deployment", "Deployment.Namespace", deployment.Namespace, "Deployment.Name", deployment.Name)
+			return requeueY, err
+		}
+	}
+
+	svcType := corev1.ServiceType("ClusterIP")
+	if cm.LoadBalancer {
+		svcType = corev1.ServiceType("LoadBalancer")
+	}
+	svc := &corev1.Service{}
+	err = r.Get(ctx, types.NamespacedName{Name: cmName, Namespace: m.Namespace}, svc)
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "Error encountered in obtaining the service", "Service.Name", cmName)
+		return requeueY, err
+	}
+	if err == nil && (svc.Spec.Type != svcType || svc.Spec.Ports[0].Port != port) {
+		// The service type and port are replaced by recreating the service
+		log.Info("Deleting the service to change its type or port", "Service.Name", cmName)
+		if err := r.Delete(ctx, svc); err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to delete the service", "Service.Name", cmName)
+			return requeueY, err
+		}
+		return requeueY, nil
+	}
+	if apierrors.IsNotFound(err) {
+		ports := []corev1.ServicePort{{Name: "listener", Port: port, TargetPort: intstr.FromInt(int(port)), Protocol: corev1.ProtocolTCP}}
+		svc = r.instantiateSVCSpec(m, cmName, ports, svcType)
+		svc.Labels = dbcommons.GetLabelsForController("", cmName)
+		svc.Spec.Selector = dbcommons.GetLabelsForController("", cmName)
+		log.Info("Creating a new service", "Service.Namespace", svc.Namespace, "Service.Name", svc.Name)
+		if err := r.Create(ctx, svc); err != nil {
+			log.Error(err, "Failed to create new service", "Service.Namespace", svc.Namespace, "Service.Name", svc.Name)
+			return requeueY, err
+		}
+	}
+
+	cmHost := cmName + "." + m.Namespace
+	if cm.LoadBalancer {
+		cmHost = dbcommons.GetLoadBalancerAddress(svc)
+	}
+	m.Status.ConnectionManager.Replicas = *newDeployment.Spec.Replicas
+	m.Status.ConnectionManager.ReadyReplicas = deployment.Status.ReadyReplicas
+	m.Status.ConnectionManager.ConnectString = dbcommons.ValueUnavailable
+	if cmHost != "" {
+		m.Status.ConnectionManager.ConnectString = strings.NewReplacer(
+			"##CMAN_HOST##", cmHost,
+			"##CMAN_PORT##", strconv.Itoa(int(port)),
+			"##DATABASE_HOST##", dbHost,
+			"##PDB_NAME##", strings.ToUpper(m.Spec.Pdbname),
+		).Replace(dbcommons.ConnectionManagerConnectString)
+	}
+
+	if deployment.Status.ReadyReplicas < *newDeployment.Spec.Replicas || cmHost == "" {
+		m.Status.ConnectionManager.Status = dbcommons.StatusPending
+		log.Info("Waiting for Connection Manager instances to be ready", "Ready", deployment.Status.ReadyReplicas, "Required", *newDeployment.Spec.Replicas)
+		return requeueY, nil
+	}
+	if m.Status.ConnectionManager.Status != dbcommons.StatusReady {
+		eventReason := "Connection Manager Ready"
+		eventMsg := fmt.Sprintf("%d connection manager instance(s) ready", deployment.Status.ReadyReplicas)
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	}
+	m.Status.ConnectionManager.Status = dbcommons.StatusReady
+	return requeueN, nil
+}
+
+// #############################################################################
+//
+//	Create, rotate or remove the external password store of the database
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) managePasswordStore(m *dbapi.SingleInstanceDatabase,
+	readyPod corev1.Pod, ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	log := r.Log.WithValues("managePasswordStore", req.NamespacedName)
+
+	if !m.Spec.AdminPassword.ExternalPasswordStore {
+		if m.Status.PasswordStoreVersion == "" {
+			return requeueN, nil
+		}
+		_, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+			dbcommons.DeletePasswordStoreCMD)
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		m.Status.PasswordStoreVersion = ""
+		log.Info("External password store removed")
+		return requeueN, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: m.Spec.AdminPassword.SecretName, Namespace: m.Namespace}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Keep using the stored credential until the secret is recreated
+			if m.Status.PasswordStoreVersion == "" {
+				eventReason := "Password Store"
+				eventMsg := "admin password secret " + m.Spec.AdminPassword.SecretName + " not found, external password store not created"
+				r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			}
+			return requeueN, nil
+		}
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+	if m.Status.PasswordStoreVersion == secret.ResourceVersion {
+		return requeueN, nil
+	}
+
+	adminPassword := string(secret.Data[m.Spec.AdminPassword.SecretKey])
+	_, err = dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, true, "bash", "-c",
+		fmt.Sprintf("%s && %s", dbcommons.CreatePasswordStoreCMD, fmt.Sprintf(dbcommons.SetPasswordStoreCredentialCMD, adminPassword)))
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+
+	// Verify the stored credential before the operator relies on it
+	out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		dbcommons.ValidateAdminPasswordCommand("", "", true))
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+	if !strings.Contains(out, "USER is \"SYS\"") {
+		eventReason := "Password Store"
+		eventMsg := "login through the external password store failed, check the admin password in secret " + m.Spec.AdminPassword.SecretName
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		r.Log.Info(eventMsg)
+		return requeueY, nil
+	}
+
+	eventReason := "Password Store"
+	eventMsg := "external password store created"
+	if m.Status.PasswordStoreVersion != "" {
+		eventMsg = "external password store rotated"
+	}
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	r.Log.Info(eventMsg)
+	m.Status.PasswordStoreVersion = secret.ResourceVersion
+	return requeueN, nil
+}
+
+// #############################################################################
+//
+//	Create the DBMS_CLOUD credential of the PDB from the OCI secret
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) configureObjectStorage(m *dbapi.SingleInstanceDatabase,
+	readyPod corev1.Pod, ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	log := r.Log.WithValues("configureObjectStorage", req.NamespacedName)
+
+	if m.Spec.ObjectStorage == nil {
+		if m.Status.ObjectStorageCredential == "" {
+			return requeueN, nil
+		}
+		out, err := dbcommons.ExecSQL(r, r.Config, readyPod, ctx, req, false,
+			dbcommons.DropCloudCredential(m.Status.Pdbname, m.Status.ObjectStorageCredential))
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		log.Info("DropCloudCredential Output : \n" + out)
+		m.Status.ObjectStorageCredential = ""
+		m.Status.ObjectStorageSecretVersion = ""
+		return requeueN, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: m.Spec.ObjectStorage.OciSecretName, Namespace: m.Namespace}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			eventReason := "Object Storage"
+			eventMsg := "OCI secret " + m.Spec.ObjectStorage.OciSecretName + " not found"
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			r.Log.Info(eventMsg)
+			return requeueN, nil
+		}
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+
+	credentialName := strings.ToUpper(m.Spec.ObjectStorage.CredentialName)
+	if credentialName == "" {
+		credentialName = "OCI_CRED"
+	}
+	if m.Status.ObjectStorageCredential == credentialName && m.Status.ObjectStorageSecretVersion == secret.ResourceVersion {
+		return requeueN, nil
+	}
+
+	// Replace a credential created under another name
+	if m.Status.ObjectStorageCredential != "" && m.Status.ObjectStorageCredential != credentialName {
+		_, err = dbcommons.ExecSQL(r, r.Config, readyPod, ctx, req, false,
+			dbcommons.DropCloudCredential(m.Status.Pdbname, m.Status.ObjectStorageCredential))
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+	}
+
+	// The private key is not logged
+	out, err := dbcommons.ExecSQL(r, r.Config, readyPod, ctx, req, true,
+		dbcommons.CreateCloudCredential(m.Status.Pdbname, credentialName, string(secret.Data["user"]),
+			string(secret.Data["tenancy"]), string(secret.Data["privatekey"]), string(secret.Data["fingerprint"])))
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+	if strings.Contains(out, "ORA-") || strings.Contains(out, "PLS-") {
+		eventReason := "Object Storage"
+		eventMsg := "failed to create the DBMS_CLOUD credential " + credentialName + ", check that DBMS_CLOUD is installed in the database"
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		r.Log.Info(eventMsg + "\n" + out)
+		return requeueN, nil
+	}
+
+	eventReason := "Object Storage"
+	eventMsg := "DBMS_CLOUD credential " + credentialName + " created in " + m.Status.Pdbname
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	r.Log.Info(eventMsg)
+	m.Status.ObjectStorageCredential = credentialName
+	m.Status.ObjectStorageSecretVersion = secret.ResourceVersion
+	return requeueN, nil
+}
+
+// #############################################################################
+//
+//	Create, replace or drop the CDB resource plan of .spec.resourceManagerPlan
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) configureResourceManagerPlan(m *dbapi.SingleInstanceDatabase,
+	readyPod corev1.Pod, ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	log := r.Log.WithValues("configureResourceManagerPlan", req.NamespacedName)
+
+	plan := m.Spec.ResourceManagerPlan
+	applied := m.Status.ResourceManagerPlan
+
+	// Drop the plan removed from the spec, or renamed
+	if applied != nil && (plan == nil || !strings.EqualFold(plan.Name, applied.Name)) {
+		out, err := dbcommons.ExecSQL(r, r.Config, readyPod, ctx, req, false, dbcommons.DropCdbPlan(applied.Name))
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		log.Info("DropCdbPlan Output : \n" + out)
+		m.Status.ResourceManagerPlan = nil
+		applied = nil
+	}
+
+	if plan != nil && !reflect.DeepEqual(plan, applied) {
+		var directives []dbcommons.CdbPlanDirective
+		for _, directive := range plan.Directives {
+			cdbPlanDirective := dbcommons.CdbPlanDirective{
+				PdbName:          directive.PdbName,
+				Shares:           directive.Shares,
+				UtilizationLimit: directive.UtilizationLimit,
+			}
+			if cdbPlanDirective.Shares == 0 {
+				cdbPlanDirective.Shares = 1
+			}
+			if cdbPlanDirective.UtilizationLimit == 0 {
+				cdbPlanDirective.UtilizationLimit = 100
+			}
+			directives = append(directives, cdbPlanDirective)
+		}
+		out, err := dbcommons.ExecSQL(r, r.Config, readyPod, ctx, req, false,
+			dbcommons.ReplaceCdbPlan(plan.Name, plan.Force, directives))
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		if strings.Contains(out, "ORA-") || strings.Contains(out, "PLS-") {
+			eventReason := "Resource Manager"
+			eventMsg := "failed to create the resource plan " + plan.Name + ", check that the PDBs of its directives exist"
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			r.Log.Info(eventMsg + "\n" + out)
+			return requeueN, nil
+		}
+
+		eventReason := "Resource Manager"
+		eventMsg := "resource plan " + plan.Name + " created and activated"
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		r.Log.Info(eventMsg)
+		m.Status.ResourceManagerPlan = plan.DeepCopy()
+	}
+
+	// Report the plan in use, which may be a maintenance plan of the scheduler
+	out, err := dbcommons.ExecSQL(r, r.Config, readyPod, ctx, req, true, dbcommons.GetActiveResourcePlanSQL)
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueN, nil
+	}
+	if activePlan, err := dbcommons.ParseColumnValue(out); err == nil {
+		m.Status.ActiveResourceManagerPlan = activePlan
+	} else {
+		m.Status.ActiveResourceManagerPlan = ""
+	}
+	return requeueN, nil
+}
+
+// #############################################################################
+//
+//	Generate the AWR or Statspack report of .spec.performanceReport into a ConfigMap
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) generatePerformanceReport(m *dbapi.SingleInstanceDatabase,
+	readyPod corev1.Pod, ctx context.Context, req ctrl.Request) ctrl.Result {
+
+	log := r.Log.WithValues("generatePerformanceReport", req.NamespacedName)
+
+	spec := m.Spec.PerformanceReport
+	if spec == nil {
+		return requeueN
+	}
+	reportType := spec.Type
+	if reportType == "" {
+		reportType = "awr"
+	}
+	if status := m.Status.PerformanceReport; status != nil &&
+		status.Type == reportType && status.Begin == spec.Begin && status.End == spec.End {
+		return requeueN
+	}
+
+	// Validated by the webhook
+	begin, _ := time.Parse(time.RFC3339, spec.Begin)
+	end, _ := time.Parse(time.RFC3339, spec.End)
+
+	status := &dbapi.SingleInstanceDatabasePerformanceReportStatus{
+		Type:  reportType,
+		Begin: spec.Begin,
+		End:   spec.End,
+	}
+	failed := func(msg string) ctrl.Result {
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, "Performance Report", msg)
+		log.Info(msg)
+		status.Error = msg
+		m.Status.PerformanceReport = status
+		return requeueN
+	}
+	retrying := func(err error) ctrl.Result {
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, "Performance Report", "Retrying the %s report: %s", reportType, err.Error())
+		log.Error(err, err.Error())
+		return requeueY
+	}
+
+	out, err := dbcommons.ExecSQL(r, r.Config, readyPod, ctx, req, false,
+		dbcommons.GetPerformanceReportSnapshots(reportType, begin, end))
+	if err != nil {
+		return retrying(err)
+	}
+	beginSnapshot, endSnapshot, waiting, err := dbcommons.ParsePerformanceReportSnapshots(out)
+	if err != nil {
+		return failed("Failed to find the " + reportType + " snapshots: " + err.Error())
+	}
+	if beginSnapshot == 0 {
+		return failed("No " + reportType + " snapshot taken before " + spec.Begin)
+	}
+	if endSnapshot == 0 {
+		// Wait for the next snapshot, taken after the end of the report in database time
+		if waiting {
+			log.Info("Waiting for a snapshot taken after " + spec.End)
+			return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Minute}
+		}
+		return failed("No " + reportType + " snapshot taken after " + spec.End)
+	}
+	status.BeginSnapshot = beginSnapshot
+	status.EndSnapshot = endSnapshot
+
+	// The report scripts list the snapshots of the last num_days days before prompting for them
+	numDays := int(time.Since(begin).Hours()/24) + 1
+	report, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false,
+		"bash", "-c", dbcommons.PerformanceReport(reportType, numDays, beginSnapshot, endSnapshot))
+	if err != nil {
+		return failed("Failed to generate the " + reportType + " report: " + err.Error())
+	}
+	if report == "" {
+		return failed("The " + reportType + " report is empty")
+	}
+
+	key := fmt.Sprintf("%s_%d_%d.html", reportType, beginSnapshot, endSnapshot)
+	if reportType == "statspack" {
+		key = fmt.Sprintf("%s_%d_%d.txt", reportType, beginSnapshot, endSnapshot)
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.Name + "-" + reportType + "-report",
+			Namespace: m.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Data = nil
+		cm.BinaryData = nil
+		if len(report) <= dbcommons.PerformanceReportMaxSize {
+			cm.Data = map[string]string{key: report}
+		} else {
+			data, truncated, err := compressPerformanceReport(report, dbcommons.PerformanceReportMaxSize)
+			if err != nil {
+				return err
+			}
+			status.Truncated = truncated
+			key = key + ".gz"
+			cm.BinaryData = map[string][]byte{key: data}
+		}
+		return ctrl.SetControllerReference(m, cm, r.Scheme)
+	})
+	if err != nil {
+		return retrying(err)
+	}
+
+	status.ConfigMap = cm.Name
+	status.Key = key
+	status.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+	m.Status.PerformanceReport = status
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, "Performance Report", "Generated %s in ConfigMap %s", key, cm.Name)
+	if status.Truncated {
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, "Performance Report", "Truncated %s to fit in ConfigMap %s", key, cm.Name)
+	}
+	return requeueN
+}
+
+// Gzips a performance report, truncating it until it fits in maxSize bytes
+func compressPerformanceReport(report string, maxSize int) ([]byte, bool, error) {
+	truncated := false
+	for {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write([]byte(report)); err != nil {
+			return nil, truncated, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, truncated, err
+		}
+		if buf.Len() <= maxSize {
+			return buf.Bytes(), truncated, nil
+		}
+		// Shrink the report in proportion to its excess, with a margin for the variation of the compression ratio
+		size := int(int64(len(report)) * int64(maxSize) / int64(buf.Len()) * 9 / 10)
+		if truncated {
+			report = report[:len(report)-len(dbcommons.PerformanceReportTruncatedMsg)]
+		}
+		if size > len(report) {
+			size = len(report)
+		}
+		report = report[:size] + dbcommons.PerformanceReportTruncatedMsg
+		truncated = true
+	}
+}
+
+// #############################################################################
+//
+//	Execute Datapatch
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) runDatapatch(m *dbapi.SingleInstanceDatabase,
+	readyPod corev1.Pod, ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	// Datapatch not supported for XE Database
+	if m.Spec.Edition == "express" || m.Spec.Edition == "free" {
+		eventReason := "Datapatch Check"
+		eventMsg := "datapatch not supported for " + m.Spec.Edition + " edition"
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		r.Log.Info(eventMsg)
+		return requeueN, nil
+	}
+
+	// Datapatch connects locally to the instance, from the pod only
+	if !dbcommons.PodExecPermitted() {
+		eventReason := "Datapatch Check"
+		eventMsg := "datapatch needs the operator to exec into pods, run it in pod " + readyPod.Name
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		r.Log.Info(eventMsg)
+		return requeueN, nil
+	}
+
+	// Keep the pod from being deleted or restarted while datapatch runs
+	holder := dbcommons.DatabaseLockHolder("SingleInstanceDatabase", m.Namespace, m.Name)
+	leaseName := dbcommons.CriticalOperationLeaseName(m.Name, dbcommons.CriticalOperationDatapatch)
+	acquired, err := dbcommons.BeginCriticalOperation(ctx, r.Client, m.Namespace, leaseName, holder,
+		m.Spec.BlockEvictionDuringOperations, readyPod)
+	if err != nil || !acquired {
+		return requeueY, err
+	}
+	defer dbcommons.EndCriticalOperation(ctx, r.Client, m.Namespace, leaseName, holder, readyPod)
+
+	m.Status.Status = dbcommons.StatusPatching
+	eventReason := "Datapatch Executing"
+	eventMsg := "datapatch begins execution"
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	k8s.PatchStatus(ctx, r.Client, m)
+
+	//RUN DATAPATCH
+	out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "",
+		ctx, req, false, "bash", "-c", dbcommons.RunDatapatchCMD)
+	if err != nil {
+		r.Log.Error(err, err.Error())
+		return requeueY, err
+	}
+	r.Log.Info("Datapatch output")
+	r.Log.Info(out)
+
+	// Get Sqlpatch Description
+	out, err = dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		fmt.Sprintf("echo -e  \"%s\"  | sqlplus -s / as sysdba ", dbcommons.GetSqlpatchDescriptionSQL))
+	releaseUpdate := ""
+	if err == nil {
+		r.Log.Info("GetSqlpatchDescriptionSQL Output")
+		r.Log.Info(out)
+		SqlpatchDescriptions, _ := dbcommons.StringToLines(out)
+		if len(SqlpatchDescriptions) > 0 {
+			releaseUpdate = SqlpatchDescriptions[0]
+		}
+	}
+
+	eventReason = "Datapatch Done"
+	if strings.Contains(out, "Datapatch execution has failed.") {
+		eventMsg = "datapatch execution failed"
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		return requeueN, errors.New(eventMsg)
+	}
+
+	m.Status.DatafilesPatched = "true"
+	status, versionFrom, versionTo, _ := dbcommons.GetSqlpatchStatus(r, r.Config, readyPod, ctx, req)
+	if versionTo != "" {
+		eventMsg = "data files patched from release update " + versionFrom + " to " + versionTo + ", " + status + ": " + releaseUpdate
+	} else {
+		eventMsg = "datapatch execution completed"
+	}
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+
+	return requeueN, nil
+}
+
+// #############################################################################
+//
+//	Verify that datapatch left all the components of the registry valid
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) verifyDatapatch(m *dbapi.SingleInstanceDatabase,
+	readyPod corev1.Pod, ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("verifyDatapatch", req.NamespacedName)
+
+	out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		fmt.Sprintf("echo -e  \"%s\"  | %s", dbcommons.GetInvalidComponentsSQL, dbcommons.SQLPlusCLI))
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+	log.Info("GetInvalidComponentsSQL Output")
+	log.Info(out)
+	components, err := dbcommons.ParseInvalidComponents(out)
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+
+	eventReason := "Datapatch Verification"
+	if len(components) > 0 {
+		// The database is not marked Ready until the components are recompiled or the patch is rolled back
+		eventMsg := "components not valid after datapatch (container:component:status): " + strings.Join(components, ", ")
+		if m.Status.PreviousImage != "" {
+			eventMsg += ", the previous image is " + m.Status.PreviousImage
+		}
+		if current := meta.FindStatusCondition(m.Status.Conditions, dbcommons.DatapatchVerifiedCondition); current == nil ||
+			current.Message != eventMsg {
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		}
+		meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+			Type:               dbcommons.DatapatchVerifiedCondition,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: m.GetGeneration(),
+			Reason:             dbcommons.ComponentsInvalidReason,
+			Message:            eventMsg,
+		})
+		m.Status.Status = dbcommons.StatusNotReady
+		log.Info(eventMsg)
+		return requeueY, errors.New(eventMsg)
+	}
+
+	eventMsg := "all the components of the registry are valid"
+	meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+		Type:               dbcommons.DatapatchVerifiedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: m.GetGeneration(),
+		Reason:             dbcommons.ComponentsValidReason,
+		Message:            eventMsg,
+	})
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+	return requeueN, nil
+}
+
+// Whether the registry components are to be verified, after the image change that set the DatapatchPending reason and until they are valid
+func datapatchVerificationPending(m *dbapi.SingleInstanceDatabase) bool {
+	condition := meta.FindStatusCondition(m.Status.Conditions, dbcommons.DatapatchVerifiedCondition)
+	return condition != nil && (condition.Reason == dbcommons.DatapatchPendingReason || condition.Reason == dbcommons.ComponentsInvalidReason)
+}
+
+// #############################################################################
+//
+//	Set the native network encryption of the database in its sqlnet.ora
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) configureNetworkEncryption(m *dbapi.SingleInstanceDatabase,
+	readyPod corev1.Pod, ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("configureNetworkEncryption", req.NamespacedName)
+
+	level, algorithms, checksumAlgorithms := networkEncryptionSettings(m)
+	settings := ""
+	if level != "" {
+		settings = level + " " + strings.Join(algorithms, ",") + " " + strings.Join(checksumAlgorithms, ",")
+	}
+	if settings == m.Status.NetworkEncryption {
+		return requeueN, nil
+	}
+
+	// The settings apply to the new connections, without restarting the listener or the database
+	out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false,
+		"bash", "-c", dbcommons.ConfigureNetworkEncryption(level, algorithms, checksumAlgorithms))
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+	log.Info("ConfigureNetworkEncryption Output : \n" + out)
+	m.Status.NetworkEncryption = settings
+
+	eventReason := "Network Encryption"
+	eventMsg := "native network encryption removed from sqlnet.ora"
+	if level != "" {
+		eventMsg = "native network encryption " + level + " with " + strings.Join(algorithms, ", ") +
+			" and integrity " + strings.Join(checksumAlgorithms, ", ") + " for the new connections"
+	}
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
 	return requeueN, nil
 }
 
+// Level and algorithms of the native network encryption of the database, with their defaults. The level is empty
+// without networkEncryption
+func networkEncryptionSettings(m *dbapi.SingleInstanceDatabase) (string, []string, []string) {
+	if m.Spec.NetworkEncryption == nil {
+		return "", nil, nil
+	}
+	level := m.Spec.NetworkEncryption.Level
+	if level == "" {
+		level = "REQUIRED"
+	}
+	algorithms := m.Spec.NetworkEncryption.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = []string{"AES256"}
+	}
+	checksumAlgorithms := m.Spec.NetworkEncryption.ChecksumAlgorithms
+	if len(checksumAlgorithms) == 0 {
+		checksumAlgorithms = []string{"SHA256"}
+	}
+	return level, algorithms, checksumAlgorithms
+}
+
 // #############################################################################
 //
 //	Update Init Parameters
@@ -2381,7 +3901,7 @@ func (r *SingleInstanceDatabaseReconciler) updateInitParameters(m *dbapi.SingleI
 	}
 
 	out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "",
-		ctx, req, false, "bash", "-c", fmt.Sprintf(dbcommons.AlterSgaPgaCpuCMD, m.Spec.InitParams.SgaTarget,
+		ctx, req, false, "bash", "-c", dbcommons.AlterSgaPgaCpu(m.Spec.InitParams.SgaTarget,
 			m.Spec.InitParams.PgaAggregateTarget, m.Spec.InitParams.CpuCount, dbcommons.SQLPlusCLI))
 	if err != nil {
 		log.Error(err, err.Error())
@@ -2393,32 +3913,170 @@ func (r *SingleInstanceDatabaseReconciler) updateInitParameters(m *dbapi.SingleI
 		eventMsg := "Unable to change the init-param as specified. Error log: \n" + out
 		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
 	}
-	log.Info("AlterSgaPgaCpuCMD Output:" + out)
+	log.Info("AlterSgaPgaCpuCMD Output:" + out)
+
+	if m.Status.InitParams.Processes != m.Spec.InitParams.Processes {
+		// Altering 'Processes' needs database to be restarted
+		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "",
+			ctx, req, false, "bash", "-c", dbcommons.AlterProcesses(
+				ordsProcesses(m.Spec.InitParams.Processes, m.Status.OrdsTuning.Processes), dbcommons.SQLPlusCLI))
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		log.Info("AlterProcessesCMD Output:" + out)
+	}
+
+	out, err = dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "",
+		ctx, req, false, "bash", "-c", dbcommons.GetInitParams(dbcommons.SQLPlusCLI))
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+	log.Info("GetInitParamsSQL Output:" + out)
+
+	m.Status.InitParams = m.Spec.InitParams
+	return requeueN, nil
+}
+
+// #############################################################################
+//
+//	Report the circuit breaker of the SQL commands of the ready pod
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) manageDatabaseReachability(m *dbapi.SingleInstanceDatabase,
+	readyPod corev1.Pod) ctrl.Result {
+	eventReason := "Database Unreachable"
+	until := dbcommons.DatabaseUnreachableUntil(m.Namespace, readyPod.Name)
+	if until.IsZero() {
+		if meta.IsStatusConditionTrue(m.Status.Conditions, dbcommons.DatabaseUnreachableCondition) {
+			meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+				Type:               dbcommons.DatabaseUnreachableCondition,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: m.GetGeneration(),
+				Reason:             dbcommons.DatabaseReachableReason,
+				Message:            "SQL commands are executed in pod " + readyPod.Name,
+			})
+			r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, "SQL commands are resumed in pod "+readyPod.Name)
+		}
+		return requeueN
+	}
+
+	eventMsg := "SQL commands in pod " + readyPod.Name + " are suspended until " + until.UTC().Format(time.RFC3339) +
+		" after failing to reach the database"
+	if !meta.IsStatusConditionTrue(m.Status.Conditions, dbcommons.DatabaseUnreachableCondition) {
+		meta.SetStatusCondition(&m.Status.Conditions, metav1.Condition{
+			Type:               dbcommons.DatabaseUnreachableCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: m.GetGeneration(),
+			Reason:             dbcommons.SQLSuspendedReason,
+			Message:            eventMsg,
+		})
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+	}
+	r.Log.Info(eventMsg)
+	return ctrl.Result{Requeue: true, RequeueAfter: time.Until(until)}
+}
+
+// #############################################################################
+//
+//	Apply the database settings requested by the databaseTuning of the ORDS
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) manageOrdsTuning(m *dbapi.SingleInstanceDatabase,
+	readyPod corev1.Pod, ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("manageOrdsTuning", req.NamespacedName)
+
+	// The highest settings of the ORDS, the settings are reverted once no ORDS requests them
+	var tuning dbapi.OracleRestDataServiceDatabaseTuning
+	// Restarting the database would break an ORDS installation in progress
+	installing := false
+	for _, consumer := range m.GetConsumers(dbapi.OracleRestDataServiceKind) {
+		n := &dbapi.OracleRestDataService{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: consumer.Name}, n); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		if !consumer.Matches(dbapi.OracleRestDataServiceKind, n) {
+			continue
+		}
+		if !n.Status.OrdsInstalled {
+			installing = true
+		}
+		if n.Spec.DatabaseTuning == nil {
+			continue
+		}
+		if n.Spec.DatabaseTuning.Processes > tuning.Processes {
+			tuning.Processes = n.Spec.DatabaseTuning.Processes
+		}
+		if n.Spec.DatabaseTuning.SharedServers > tuning.SharedServers {
+			tuning.SharedServers = n.Spec.DatabaseTuning.SharedServers
+		}
+		if n.Spec.DatabaseTuning.Dispatchers > tuning.Dispatchers {
+			tuning.Dispatchers = n.Spec.DatabaseTuning.Dispatchers
+		}
+	}
+	if tuning == m.Status.OrdsTuning {
+		return requeueN, nil
+	}
 
-	if m.Status.InitParams.Processes != m.Spec.InitParams.Processes {
-		// Altering 'Processes' needs database to be restarted
-		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "",
-			ctx, req, false, "bash", "-c", fmt.Sprintf(dbcommons.AlterProcessesCMD, m.Spec.InitParams.Processes, dbcommons.SQLPlusCLI,
-				dbcommons.SQLPlusCLI))
+	eventReason := "ORDS Tuning"
+	if tuning.SharedServers != m.Status.OrdsTuning.SharedServers || tuning.Dispatchers != m.Status.OrdsTuning.Dispatchers {
+		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+			fmt.Sprintf("echo -e  \"%s\"  | %s", dbcommons.SetOrdsSharedServers(tuning.SharedServers, tuning.Dispatchers), dbcommons.SQLPlusCLI))
 		if err != nil {
 			log.Error(err, err.Error())
 			return requeueY, err
 		}
-		log.Info("AlterProcessesCMD Output:" + out)
+		log.Info("SetOrdsSharedServers Output:" + out)
+		if strings.Contains(out, "ORA-") {
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, "unable to set the shared servers for ORDS: "+out)
+		}
 	}
 
-	out, err = dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "",
-		ctx, req, false, "bash", "-c", fmt.Sprintf(dbcommons.GetInitParamsSQL, dbcommons.SQLPlusCLI))
-	if err != nil {
-		log.Error(err, err.Error())
-		return requeueY, err
+	// Altering processes restarts the database, only when the processes of the spec do not already cover the ORDS
+	processes := ordsProcesses(m.Spec.InitParams.Processes, tuning.Processes)
+	if processes != ordsProcesses(m.Spec.InitParams.Processes, m.Status.OrdsTuning.Processes) && installing {
+		// Keeping the previous processes in the status so that the restart is retried once the ORDS are installed
+		tuning.Processes = m.Status.OrdsTuning.Processes
+		eventMsg := fmt.Sprintf("deferring the restart for processes %d until the ORDS are installed", processes)
+		log.Info(eventMsg)
+		if tuning != m.Status.OrdsTuning {
+			m.Status.OrdsTuning = tuning
+			r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		}
+		return ctrl.Result{RequeueAfter: requeueY.RequeueAfter}, nil
+	}
+	if processes != ordsProcesses(m.Spec.InitParams.Processes, m.Status.OrdsTuning.Processes) {
+		cmd := dbcommons.AlterProcesses(processes, dbcommons.SQLPlusCLI)
+		if processes == 0 {
+			cmd = dbcommons.ResetProcesses(dbcommons.SQLPlusCLI)
+		}
+		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c", cmd)
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		log.Info("AlterProcessesCMD Output:" + out)
 	}
-	log.Info("GetInitParamsSQL Output:" + out)
 
-	m.Status.InitParams = m.Spec.InitParams
+	m.Status.OrdsTuning = tuning
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, fmt.Sprintf("applied processes %d, shared servers %d and dispatchers %d for ORDS",
+		processes, tuning.SharedServers, tuning.Dispatchers))
 	return requeueN, nil
 }
 
+// Processes of the database, the processes of the spec unless the ORDS need more
+func ordsProcesses(spec int, ords int) int {
+	if ords > spec {
+		return ords
+	}
+	return spec
+}
+
 // #############################################################################
 //
 //	Update DB config params like FLASHBACK , FORCELOGGING , ARCHIVELOG
@@ -2430,7 +4088,7 @@ func (r *SingleInstanceDatabaseReconciler) updateDBConfig(m *dbapi.SingleInstanc
 	log := r.Log.WithValues("updateDBConfig", req.NamespacedName)
 
 	m.Status.Status = dbcommons.StatusUpdating
-	r.Status().Update(ctx, m)
+	k8s.PatchStatus(ctx, r.Client, m)
 	var forceLoggingStatus bool
 	var flashBackStatus bool
 	var archiveLogStatus bool
@@ -2483,165 +4141,740 @@ func (r *SingleInstanceDatabaseReconciler) updateDBConfig(m *dbapi.SingleInstanc
 			log.Error(err, err.Error())
 			return requeueY, err
 		}
-		log.Info("ArchiveLogTrue Output")
-		log.Info(out)
+		log.Info("ArchiveLogTrue Output")
+		log.Info(out)
+
+	}
+
+	if m.Spec.ForceLogging && !forceLoggingStatus {
+		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+			fmt.Sprintf("echo -e  \"%s\"  | %s", dbcommons.ForceLoggingTrueSQL, dbcommons.SQLPlusCLI))
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		log.Info("ForceLoggingTrue Output")
+		log.Info(out)
+
+	}
+	if m.Spec.FlashBack && !flashBackStatus {
+		_, archiveLogStatus, _, result := dbcommons.CheckDBConfig(readyPod, r, r.Config, ctx, req, m.Spec.Edition)
+		if result.Requeue {
+			m.Status.Status = dbcommons.StatusNotReady
+			return result, nil
+		}
+		if archiveLogStatus {
+			out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+				fmt.Sprintf("echo -e  \"%s\"  | %s", dbcommons.FlashBackTrueSQL, dbcommons.SQLPlusCLI))
+			if err != nil {
+				log.Error(err, err.Error())
+				m.Status.Status = dbcommons.StatusNotReady
+				return requeueY, err
+			}
+			log.Info("FlashBackTrue Output")
+			log.Info(out)
+
+		} else {
+			// Occurs when flashback is attempted to be turned on without turning on archiving first
+			eventReason := "Database Check"
+			eventMsg := "enable ArchiveLog to turn on Flashback"
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			log.Info(eventMsg)
+
+			changeArchiveLog = true
+		}
+	}
+
+	//#################################################################################################
+	//                  TURNING FLASHBACK , ARCHIVELOG , FORCELOGGING TO FALSE
+	//#################################################################################################
+
+	if !m.Spec.FlashBack && flashBackStatus {
+		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+			fmt.Sprintf("echo -e  \"%s\"  | %s", dbcommons.FlashBackFalseSQL, dbcommons.SQLPlusCLI))
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		log.Info("FlashBackFalse Output")
+		log.Info(out)
+	}
+	if !m.Spec.ArchiveLog && archiveLogStatus {
+		flashBackStatus, _, _, result := dbcommons.CheckDBConfig(readyPod, r, r.Config, ctx, req, m.Spec.Edition)
+		if result.Requeue {
+			m.Status.Status = dbcommons.StatusNotReady
+			return result, nil
+		}
+		if !flashBackStatus {
+
+			out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+				fmt.Sprintf(dbcommons.ArchiveLogFalseCMD, dbcommons.SQLPlusCLI))
+			if err != nil {
+				log.Error(err, err.Error())
+				m.Status.Status = dbcommons.StatusNotReady
+				return requeueY, err
+			}
+			log.Info("ArchiveLogFalse Output")
+			log.Info(out)
+
+		} else {
+			// Occurs when archiving is attempted to be turned off without turning off flashback first
+			eventReason := "Database Check"
+			eventMsg := "turn off Flashback to disable ArchiveLog"
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			log.Info(eventMsg)
+
+			changeArchiveLog = true
+		}
+	}
+	if !m.Spec.ForceLogging && forceLoggingStatus {
+		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+			fmt.Sprintf("echo -e  \"%s\"  | %s", dbcommons.ForceLoggingFalseSQL, dbcommons.SQLPlusCLI))
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		log.Info("ForceLoggingFalse Output")
+		log.Info(out)
+	}
+
+	//#################################################################################################
+	//                  CHECK FLASHBACK , ARCHIVELOG , FORCELOGGING
+	//#################################################################################################
+
+	flashBackStatus, archiveLogStatus, forceLoggingStatus, result = dbcommons.CheckDBConfig(readyPod, r, r.Config, ctx, req, m.Spec.Edition)
+	if result.Requeue {
+		m.Status.Status = dbcommons.StatusNotReady
+		return result, nil
+	}
+
+	log.Info("Flashback", "Status :", flashBackStatus)
+	log.Info("ArchiveLog", "Status :", archiveLogStatus)
+	log.Info("ForceLog", "Status :", forceLoggingStatus)
+
+	m.Status.ArchiveLog = strconv.FormatBool(archiveLogStatus)
+	m.Status.ForceLogging = strconv.FormatBool(forceLoggingStatus)
+
+	// If Flashback has turned from OFF to ON in this reconcile ,
+	// Needs to restart the Non Ready Pods ( Delete old ones and create new ones )
+	if m.Status.FlashBack == strconv.FormatBool(false) && flashBackStatus {
+
+		// call FindPods() to fetch pods all version/images of the same SIDB kind
+		readyPod, replicasFound, available, _, err := dbcommons.FindPods(r, "", "", m.Name, m.Namespace, ctx, req)
+		if err != nil {
+			log.Error(err, err.Error())
+			return requeueY, err
+		}
+		// delete non ready Pods as flashback needs restart of pods
+		_, err = r.deletePods(ctx, req, m, available, readyPod, replicasFound, 1)
+		return requeueY, err
+	}
+
+	m.Status.FlashBack = strconv.FormatBool(flashBackStatus)
+
+	if !changeArchiveLog && (flashBackStatus != m.Spec.FlashBack ||
+		archiveLogStatus != m.Spec.ArchiveLog || forceLoggingStatus != m.Spec.ForceLogging) {
+		return requeueY, nil
+	}
+	return requeueN, nil
+}
+
+// #############################################################################
+//
+//	Update ORDS Status
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) updateORDSStatus(m *dbapi.SingleInstanceDatabase, ctx context.Context, req ctrl.Request) {
+
+	for _, consumer := range m.GetConsumers(dbapi.OracleRestDataServiceKind) {
+		n := &dbapi.OracleRestDataService{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: consumer.Name}, n)
+		if err != nil || !consumer.Matches(dbapi.OracleRestDataServiceKind, n) {
+			continue
+		}
+		k8s.TrackStatus(ctx, n)
+
+		if n.Status.OrdsInstalled {
+			// Update Status to Healthy/Unhealthy when SIDB turns Healthy/Unhealthy after ORDS is Installed
+			n.Status.Status = m.Status.Status
+			k8s.PatchStatus(ctx, r.Client, n)
+		}
+	}
+}
+
+// #############################################################################
+//
+//	Start and stop the database on its schedule
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) manageSchedule(m *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("manageSchedule", req.NamespacedName)
+
+	if m.Spec.Schedule == nil {
+		if m.Status.ScheduledState == dbcommons.ScheduledStateStopped {
+			m.Status.Status = dbcommons.StatusPending
+		}
+		m.Status.ScheduledState = ""
+		m.Status.NextScheduledTransition = ""
+		return requeueN, nil
+	}
+	// Never stop a database while it is being created
+	if m.Status.DatafilesCreated != "true" {
+		return requeueN, nil
+	}
+
+	running, next, err := dbcommons.GetScheduledState(m.Spec.Schedule.Start, m.Spec.Schedule.Stop,
+		m.Spec.Schedule.TimeZone, time.Now())
+	if err != nil {
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, "Schedule", err.Error())
+		log.Error(err, err.Error())
+		return requeueN, err
+	}
+	m.Status.NextScheduledTransition = next.Format(time.RFC3339)
+
+	if running {
+		if m.Status.ScheduledState == dbcommons.ScheduledStateStopped {
+			eventReason := "Scheduled Start"
+			eventMsg := "starting the database, next scheduled stop at " + m.Status.NextScheduledTransition
+			r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+			log.Info(eventMsg)
+			m.Status.Status = dbcommons.StatusPending
+		}
+		m.Status.ScheduledState = dbcommons.ScheduledStateRunning
+		return requeueN, nil
+	}
+
+	readyPod, _, available, _, err := dbcommons.FindPods(r, "", "", m.Name, m.Namespace, ctx, req)
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+	if readyPod.Name != "" {
+		available = append(available, readyPod)
+	}
+	if disruptionBlocked(ctx, r.Client, r.Recorder, m, "scheduled stop", available...) {
+		return requeueY, nil
+	}
+
+	if m.Status.ScheduledState != dbcommons.ScheduledStateStopped {
+		eventReason := "Scheduled Stop"
+		eventMsg := "stopping the database, next scheduled start at " + m.Status.NextScheduledTransition
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		log.Info(eventMsg)
+	}
+	m.Status.ScheduledState = dbcommons.ScheduledStateStopped
+	m.Status.Status = dbcommons.StatusStopped
+
+	// Delete the pods with their grace period, so that the preStop hook shuts down the database
+	for i := range available {
+		log.Info("Deleting Pod : ", "POD.NAME", available[i].Name)
+		if err := r.Delete(ctx, &available[i]); err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to delete existing POD", "POD.Name", available[i].Name)
+			return requeueY, err
+		}
+	}
+	m.Status.Replicas = 0
+	r.updateORDSStatus(m, ctx, req)
+
+	return requeueUntil(m.Status.NextScheduledTransition), nil
+}
+
+// #############################################################################
+//
+//	Raise events for the ORA- errors of the alert log
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) scanAlertLog(m *dbapi.SingleInstanceDatabase, readyPod corev1.Pod,
+	ctx context.Context, req ctrl.Request) {
+	log := r.Log.WithValues("scanAlertLog", req.NamespacedName)
+
+	if m.Spec.AlertLog == nil || !m.Spec.AlertLog.Events {
+		return
+	}
+
+	out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, true, "bash", "-c",
+		fmt.Sprintf(dbcommons.AlertLogErrorsCMD, m.Status.AlertLogOffset))
+	if err != nil {
+		log.Error(err, "Failed to read the alert log")
+		return
+	}
+	errs, count, err := dbcommons.ParseAlertLogErrors(out)
+	if err != nil {
+		log.Error(err, "Failed to read the alert log")
+		return
+	}
+	if count < 0 {
+		// The database has not created its alert log yet
+		return
+	}
+
+	// The first scan, and the first scan of a new alert log, only record the number of lines
+	if m.Status.AlertLogOffset > 0 && count >= m.Status.AlertLogOffset {
+		if len(errs) > dbcommons.AlertLogMaxEvents {
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, "DatabaseError", "%d errors in the alert log, showing the last %d",
+				len(errs), dbcommons.AlertLogMaxEvents)
+			errs = errs[len(errs)-dbcommons.AlertLogMaxEvents:]
+		}
+		for _, e := range errs {
+			r.Recorder.Event(m, corev1.EventTypeWarning, "DatabaseError", e)
+		}
+	}
+	m.Status.AlertLogOffset = count
+}
+
+// #############################################################################
+//
+//	Report and kill the sessions blocking other sessions
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) manageBlockingSessions(m *dbapi.SingleInstanceDatabase, readyPod corev1.Pod,
+	ctx context.Context, req ctrl.Request) {
+	log := r.Log.WithValues("manageBlockingSessions", req.NamespacedName)
+
+	if m.Spec.SessionManagement == nil {
+		m.Status.BlockingSessions = nil
+		return
+	}
+
+	out, err := dbcommons.ExecSQL(r, r.Config, readyPod, ctx, req, true, dbcommons.GetBlockingSessionsSQL)
+	if err != nil {
+		log.Error(err, "Failed to list the blocking sessions")
+		return
+	}
+	sessions, err := dbcommons.ParseBlockingSessions(out)
+	if err != nil {
+		log.Error(err, "Failed to list the blocking sessions")
+		return
+	}
+
+	protected := append(append([]string{}, dbcommons.SessionManagementProtectedUsers...), m.Spec.SessionManagement.ProtectedUsers...)
+	killAfter := m.Spec.SessionManagement.KillBlockersAfterSeconds
+	kills := 0
+	blockingSessions := []dbapi.SingleInstanceDatabaseBlockingSession{}
+	for _, session := range sessions {
+		blockingSession := dbapi.SingleInstanceDatabaseBlockingSession{
+			Session:         session.Session,
+			Username:        session.Username,
+			BlockedSessions: session.BlockedSessions,
+			BlockedSeconds:  session.BlockedSeconds,
+		}
 
+		// Sessions are listed by decreasing wait, so the longest blockers are killed first
+		if killAfter > 0 && session.BlockedSeconds >= killAfter && kills < dbcommons.SessionManagementMaxKills &&
+			!containsUser(protected, session.Username) {
+			_, err = dbcommons.ExecSQL(r, r.Config, readyPod, ctx, req, false, dbcommons.KillSession(session.Session))
+			if err != nil {
+				log.Error(err, "Failed to kill the blocking session", "Session", session.Session)
+			} else {
+				kills++
+				blockingSession.Killed = true
+				r.Recorder.Eventf(m, corev1.EventTypeWarning, "Session Killed",
+					"Killed session %s of %s, which blocked %d sessions for %d seconds",
+					session.Session, session.Username, session.BlockedSessions, session.BlockedSeconds)
+			}
+		}
+		blockingSessions = append(blockingSessions, blockingSession)
 	}
+	m.Status.BlockingSessions = blockingSessions
+}
 
-	if m.Spec.ForceLogging && !forceLoggingStatus {
-		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
-			fmt.Sprintf("echo -e  \"%s\"  | %s", dbcommons.ForceLoggingTrueSQL, dbcommons.SQLPlusCLI))
-		if err != nil {
-			log.Error(err, err.Error())
-			return requeueY, err
+// #############################################################################
+//
+//	Publish the Data Guard lag and the redo rates as metrics and in the status
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) manageReplicationMetrics(m *dbapi.SingleInstanceDatabase, readyPod corev1.Pod,
+	ctx context.Context, req ctrl.Request) {
+	log := r.Log.WithValues("manageReplicationMetrics", req.NamespacedName)
+
+	if m.Spec.ReplicationMetrics == nil {
+		if m.Status.Replication != nil {
+			dbcommons.ForgetReplicationStats(m.Namespace, m.Name)
+			m.Status.Replication = nil
 		}
-		log.Info("ForceLoggingTrue Output")
-		log.Info(out)
+		return
+	}
 
+	// A failed measure removes the previous one, rather than leaving a stale lag in the metrics
+	out, err := dbcommons.ExecSQL(r, r.Config, readyPod, ctx, req, true, dbcommons.GetReplicationStatsSQL)
+	if err != nil {
+		log.Error(err, "Failed to measure the replication")
+		dbcommons.ForgetReplicationStats(m.Namespace, m.Name)
+		m.Status.Replication = nil
+		return
 	}
-	if m.Spec.FlashBack && !flashBackStatus {
-		_, archiveLogStatus, _, result := dbcommons.CheckDBConfig(readyPod, r, r.Config, ctx, req, m.Spec.Edition)
-		if result.Requeue {
-			m.Status.Status = dbcommons.StatusNotReady
-			return result, nil
+	stats, err := dbcommons.ParseReplicationStats(out)
+	if err != nil {
+		log.Error(err, "Failed to measure the replication")
+		dbcommons.ForgetReplicationStats(m.Namespace, m.Name)
+		m.Status.Replication = nil
+		return
+	}
+	dbcommons.RecordReplicationStats(m.Namespace, m.Name, stats)
+	m.Status.Replication = &dbapi.SingleInstanceDatabaseReplication{
+		TransportLagSeconds:          stats.TransportLagSeconds,
+		ApplyLagSeconds:              stats.ApplyLagSeconds,
+		ApplyRateBytesPerSecond:      stats.ApplyRateBytesPerSecond,
+		RedoGenerationBytesPerSecond: stats.RedoGenerationBytesPerSecond,
+		UpdatedAt:                    time.Now().Format(time.RFC3339),
+	}
+}
+
+// Returns true if users contains username, ignoring the case
+func containsUser(users []string, username string) bool {
+	for _, user := range users {
+		if strings.EqualFold(user, username) {
+			return true
 		}
-		if archiveLogStatus {
-			out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
-				fmt.Sprintf("echo -e  \"%s\"  | %s", dbcommons.FlashBackTrueSQL, dbcommons.SQLPlusCLI))
-			if err != nil {
-				log.Error(err, err.Error())
-				m.Status.Status = dbcommons.StatusNotReady
-				return requeueY, err
-			}
-			log.Info("FlashBackTrue Output")
-			log.Info(out)
+	}
+	return false
+}
 
-		} else {
-			// Occurs when flashback is attempted to be turned on without turning on archiving first
-			eventReason := "Database Check"
-			eventMsg := "enable ArchiveLog to turn on Flashback"
-			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
-			log.Info(eventMsg)
+// Returns a requeue at the shortest poll interval of the alert log, of the blocking sessions and of the replication
+// metrics, or requeueN if none is polled
+func scanRequeue(m *dbapi.SingleInstanceDatabase) ctrl.Result {
+	var intervals []int
+	if alertLog := m.Spec.AlertLog; alertLog != nil && alertLog.Events {
+		intervals = append(intervals, alertLog.PollInterval)
+	}
+	if sessionManagement := m.Spec.SessionManagement; sessionManagement != nil &&
+		strings.ToUpper(m.Status.Role) == "PRIMARY" {
+		intervals = append(intervals, sessionManagement.PollInterval)
+	}
+	if replicationMetrics := m.Spec.ReplicationMetrics; replicationMetrics != nil {
+		intervals = append(intervals, replicationMetrics.PollInterval)
+	}
 
-			changeArchiveLog = true
+	// A zero interval, from a resource created before the defaults of the CRD, is not polled
+	interval := 0
+	for _, i := range intervals {
+		if i > 0 && (interval == 0 || i < interval) {
+			interval = i
 		}
 	}
+	if interval == 0 {
+		return requeueN
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: time.Duration(interval) * time.Second}
+}
 
-	//#################################################################################################
-	//                  TURNING FLASHBACK , ARCHIVELOG , FORCELOGGING TO FALSE
-	//#################################################################################################
+// Returns a requeue at the given RFC 3339 time
+func requeueUntil(transition string) ctrl.Result {
+	next, err := time.Parse(time.RFC3339, transition)
+	if err != nil {
+		return requeueY
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: time.Until(next) + time.Second}
+}
 
-	if !m.Spec.FlashBack && flashBackStatus {
-		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
-			fmt.Sprintf("echo -e  \"%s\"  | %s", dbcommons.FlashBackFalseSQL, dbcommons.SQLPlusCLI))
-		if err != nil {
-			log.Error(err, err.Error())
-			return requeueY, err
-		}
-		log.Info("FlashBackFalse Output")
-		log.Info(out)
+// #############################################################################
+//
+//	Run the action of the action annotation
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) manageActions(m *dbapi.SingleInstanceDatabase, readyPod corev1.Pod,
+	ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("manageActions", req.NamespacedName)
+
+	action, ok := m.Annotations[dbcommons.ActionAnnotation]
+	if !ok {
+		return requeueN
 	}
-	if !m.Spec.ArchiveLog && archiveLogStatus {
-		flashBackStatus, _, _, result := dbcommons.CheckDBConfig(readyPod, r, r.Config, ctx, req, m.Spec.Edition)
-		if result.Requeue {
-			m.Status.Status = dbcommons.StatusNotReady
-			return result, nil
-		}
-		if !flashBackStatus {
 
-			out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
-				fmt.Sprintf(dbcommons.ArchiveLogFalseCMD, dbcommons.SQLPlusCLI))
-			if err != nil {
-				log.Error(err, err.Error())
-				m.Status.Status = dbcommons.StatusNotReady
-				return requeueY, err
-			}
-			log.Info("ArchiveLogFalse Output")
-			log.Info(out)
+	// Remove the annotation first, so that the action runs once even if the reconcile fails afterwards
+	annotated := m.DeepCopy()
+	delete(annotated.Annotations, dbcommons.ActionAnnotation)
+	if err := r.Patch(ctx, annotated, client.MergeFromWithOptions(m, client.MergeFromWithOptimisticLock{})); err != nil {
+		log.Error(err, "Failed to remove the action annotation")
+		return requeueY
+	}
+	delete(m.Annotations, dbcommons.ActionAnnotation)
+	m.ResourceVersion = annotated.ResourceVersion
 
-		} else {
-			// Occurs when archiving is attempted to be turned off without turning off flashback first
-			eventReason := "Database Check"
-			eventMsg := "turn off Flashback to disable ArchiveLog"
-			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
-			log.Info(eventMsg)
+	// The annotation removed, the outcome of the action is kept in the ActionCompleted condition
+	condition := metav1.Condition{
+		Type:               dbcommons.ActionCompletedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: m.GetGeneration(),
+		Reason:             dbcommons.ActionSucceededReason,
+	}
+	message, err := r.runAction(m, readyPod, action, ctx, req)
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = dbcommons.ActionFailedReason
+		condition.Message = action + " failed: " + err.Error()
+	} else {
+		condition.Message = action + " " + message
+	}
+	meta.SetStatusCondition(&m.Status.Conditions, condition)
+	return requeueN
+}
 
-			changeArchiveLog = true
+// Run an action of the action annotation, returning what was done
+func (r *SingleInstanceDatabaseReconciler) runAction(m *dbapi.SingleInstanceDatabase, readyPod corev1.Pod, action string,
+	ctx context.Context, req ctrl.Request) (string, error) {
+	log := r.Log.WithValues("runAction", req.NamespacedName)
+
+	eventReason := "Action"
+	switch action {
+	case dbcommons.ActionConvertToSnapshotStandby, dbcommons.ActionConvertToPhysicalStandby:
+		from, to := "PHYSICAL_STANDBY", "SNAPSHOT"
+		if action == dbcommons.ActionConvertToPhysicalStandby {
+			from, to = "SNAPSHOT_STANDBY", "PHYSICAL"
 		}
-	}
-	if !m.Spec.ForceLogging && forceLoggingStatus {
-		out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
-			fmt.Sprintf("echo -e  \"%s\"  | %s", dbcommons.ForceLoggingFalseSQL, dbcommons.SQLPlusCLI))
+		if !m.Status.DgBrokerConfigured || strings.ToUpper(m.Status.Role) != from {
+			eventMsg := "the database is not a " + strings.ToLower(strings.Replace(from, "_", " ", 1)) + " of a Data Guard configuration"
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, action+" ignored, "+eventMsg)
+			return "", errors.New(eventMsg)
+		}
+		if err := r.convertStandby(m, readyPod, to, ctx, req); err != nil {
+			return "", err
+		}
+		return "converted the database to a " + strings.ToLower(to) + " standby", nil
+	case dbcommons.ActionRestartDatabase:
+		if m.Status.Restart != nil {
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, action+" ignored, the database is already restarting")
+			return "", errors.New("the database is already restarting")
+		}
+		openMode, err := dbcommons.GetDatabaseOpenMode(readyPod, r, r.Config, ctx, req, m.Spec.Edition)
 		if err != nil {
 			log.Error(err, err.Error())
-			return requeueY, err
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, action+" failed to read the open mode of the database")
+			return "", fmt.Errorf("unable to read the open mode of the database: %w", err)
 		}
-		log.Info("ForceLoggingFalse Output")
-		log.Info(out)
+		m.Status.Restart = &dbapi.SingleInstanceDatabaseRestart{
+			Phase:     dbcommons.RestartPhaseDraining,
+			Pod:       readyPod.Name,
+			StartTime: time.Now().Format(time.RFC3339),
+			OpenMode:  openMode,
+		}
+		eventMsg := fmt.Sprintf("restarting the database once its user sessions end, within %d seconds", getRestartDrainTimeout(m))
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, "Database Restart", eventMsg)
+		log.Info(eventMsg)
+		return "started the restart of the database", nil
+	default:
+		eventMsg := "unknown action, expected one of " + strings.Join([]string{dbcommons.ActionConvertToSnapshotStandby,
+			dbcommons.ActionConvertToPhysicalStandby, dbcommons.ActionRestartDatabase}, ", ")
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, action+" is an "+eventMsg)
+		return "", errors.New(eventMsg)
 	}
+}
 
-	//#################################################################################################
-	//                  CHECK FLASHBACK , ARCHIVELOG , FORCELOGGING
-	//#################################################################################################
-
-	flashBackStatus, archiveLogStatus, forceLoggingStatus, result = dbcommons.CheckDBConfig(readyPod, r, r.Config, ctx, req, m.Spec.Edition)
-	if result.Requeue {
-		m.Status.Status = dbcommons.StatusNotReady
-		return result, nil
+// Returns the seconds a restart waits for the user sessions to end
+func getRestartDrainTimeout(m *dbapi.SingleInstanceDatabase) int64 {
+	if m.Spec.Shutdown.DrainTimeoutSeconds != nil {
+		return *m.Spec.Shutdown.DrainTimeoutSeconds
 	}
+	return dbcommons.DefaultRestartDrainTimeout
+}
 
-	log.Info("Flashback", "Status :", flashBackStatus)
-	log.Info("ArchiveLog", "Status :", archiveLogStatus)
-	log.Info("ForceLog", "Status :", forceLoggingStatus)
+// Returns the shutdown mode of a restart. A restart never aborts the database, unless the shutdown times out
+func getRestartShutdownMode(m *dbapi.SingleInstanceDatabase) string {
+	if m.Spec.Shutdown.Mode == "transactional" {
+		return "transactional"
+	}
+	return "immediate"
+}
 
-	m.Status.ArchiveLog = strconv.FormatBool(archiveLogStatus)
-	m.Status.ForceLogging = strconv.FormatBool(forceLoggingStatus)
+// #############################################################################
+//
+//	Restart the database requested by the restart-database action: wait for the user sessions to end, shut down
+//	the database, replace its pod and check that the database opens like before
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) manageRestart(m *dbapi.SingleInstanceDatabase, readyPod corev1.Pod,
+	ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("manageRestart", req.NamespacedName)
 
-	// If Flashback has turned from OFF to ON in this reconcile ,
-	// Needs to restart the Non Ready Pods ( Delete old ones and create new ones )
-	if m.Status.FlashBack == strconv.FormatBool(false) && flashBackStatus {
+	restart := m.Status.Restart
+	if restart == nil {
+		return requeueN
+	}
+	eventReason := "Database Restart"
 
-		// call FindPods() to fetch pods all version/images of the same SIDB kind
-		readyPod, replicasFound, available, _, err := dbcommons.FindPods(r, "", "", m.Name, m.Namespace, ctx, req)
+	if restart.Phase == dbcommons.RestartPhaseRestarting {
+		if readyPod.Name == restart.Pod {
+			return requeueY
+		}
+		openMode, err := dbcommons.GetDatabaseOpenMode(readyPod, r, r.Config, ctx, req, m.Spec.Edition)
 		if err != nil {
 			log.Error(err, err.Error())
-			return requeueY, err
+			return requeueY
 		}
-		// delete non ready Pods as flashback needs restart of pods
-		_, err = r.deletePods(ctx, req, m, available, readyPod, replicasFound, 1)
-		return requeueY, err
+		m.Status.Restart = nil
+		if openMode != restart.OpenMode {
+			eventMsg := "database restarted in pod " + readyPod.Name + " with open mode " + openMode + " instead of " + restart.OpenMode
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			log.Info(eventMsg)
+			return requeueN
+		}
+		eventMsg := "database restarted in pod " + readyPod.Name + " with open mode " + openMode
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+		log.Info(eventMsg)
+		r.restartOrds(m, eventReason, ctx, req)
+		return requeueN
 	}
 
-	m.Status.FlashBack = strconv.FormatBool(flashBackStatus)
+	// Wait for the user sessions to end, up to the drain timeout
+	out, err := dbcommons.ExecSQL(r, r.Config, readyPod, ctx, req, false, dbcommons.CountUserSessionsSQL)
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	value, err := dbcommons.ParseColumnValue(out)
+	if err != nil {
+		log.Info("Failed to count the user sessions: " + out)
+		return requeueY
+	}
+	// SQL*Plus right-aligns the count, its padding replaced by "_"
+	sessions, err := strconv.Atoi(strings.Trim(value, "_"))
+	if err != nil {
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	start, _ := time.Parse(time.RFC3339, restart.StartTime)
+	if sessions > 0 {
+		if time.Since(start) < time.Duration(getRestartDrainTimeout(m))*time.Second {
+			log.Info("Waiting for the user sessions to end", "sessions", sessions)
+			return requeueY
+		}
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason,
+			"%d user sessions still open after the drain timeout, shutting down the database", sessions)
+	}
+	if disruptionBlocked(ctx, r.Client, r.Recorder, m, "restart", readyPod) {
+		return requeueY
+	}
 
-	if !changeArchiveLog && (flashBackStatus != m.Spec.FlashBack ||
-		archiveLogStatus != m.Spec.ArchiveLog || forceLoggingStatus != m.Spec.ForceLogging) {
-		return requeueY, nil
+	mode := getRestartShutdownMode(m)
+	eventMsg := "shutting down the database " + mode
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+	// The preStop hook of the pod shuts down the database anyway if the shutdown fails
+	out, err = dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		fmt.Sprintf(dbcommons.ShutdownDatabaseCMD, mode, getShutdownGracePeriod(m)))
+	if err != nil {
+		log.Error(err, err.Error())
 	}
-	return requeueN, nil
+	log.Info("Shutdown Output : \n" + out)
+
+	// Record the phase before the pod is deleted, the database not being ready again until its new pod is
+	restart.Phase = dbcommons.RestartPhaseRestarting
+	m.Status.Status = dbcommons.StatusUpdating
+	k8s.PatchStatus(ctx, r.Client, m)
+	r.deleteRestartedPod(m, ctx, req)
+	return requeueY
 }
 
-// #############################################################################
-//
-//	Update ORDS Status
-//
-// #############################################################################
-func (r *SingleInstanceDatabaseReconciler) updateORDSStatus(m *dbapi.SingleInstanceDatabase, ctx context.Context, req ctrl.Request) {
+// Delete the pod of a restart once its database is shut down, for createOrReplacePods to replace it
+func (r *SingleInstanceDatabaseReconciler) deleteRestartedPod(m *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) ctrl.Result {
+	log := r.Log.WithValues("deleteRestartedPod", req.NamespacedName)
 
-	if m.Status.OrdsReference == "" {
-		return
+	if m.Status.Restart == nil || m.Status.Restart.Phase != dbcommons.RestartPhaseRestarting {
+		return requeueN
+	}
+	pod := &corev1.Pod{}
+	err := r.Get(ctx, types.NamespacedName{Name: m.Status.Restart.Pod, Namespace: m.Namespace}, pod)
+	if apierrors.IsNotFound(err) || (err == nil && pod.DeletionTimestamp != nil) {
+		return requeueN
 	}
-	n := &dbapi.OracleRestDataService{}
-	err := r.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: m.Status.OrdsReference}, n)
 	if err != nil {
-		return
+		log.Error(err, err.Error())
+		return requeueY
+	}
+	log.Info("Deleting Pod : ", "POD.NAME", pod.Name)
+	if err := r.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "Failed to delete existing POD", "POD.Name", pod.Name)
+		return requeueY
 	}
+	return requeueN
+}
 
-	if n.Status.OrdsInstalled {
-		// Update Status to Healthy/Unhealthy when SIDB turns Healthy/Unhealthy after ORDS is Installed
-		n.Status.Status = m.Status.Status
-		r.Status().Update(ctx, n)
-		return
+// Convert a standby of the Data Guard configuration to a snapshot or a physical standby, and restart the ORDS serving it
+// to reconnect them once the database is restarted by the broker
+func (r *SingleInstanceDatabaseReconciler) convertStandby(m *dbapi.SingleInstanceDatabase, readyPod corev1.Pod, to string,
+	ctx context.Context, req ctrl.Request) error {
+	log := r.Log.WithValues("convertStandby", req.NamespacedName)
+
+	eventReason := "Standby Conversion"
+	adminPasswordSecret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: m.Spec.AdminPassword.SecretName, Namespace: m.Namespace}, adminPasswordSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			eventMsg := "password secret " + m.Spec.AdminPassword.SecretName + " required to convert the standby not found"
+			r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+			log.Info(eventMsg)
+			return errors.New(eventMsg)
+		}
+		log.Error(err, err.Error())
+		return err
+	}
+
+	eventMsg := "converting the database to a " + strings.ToLower(to) + " standby"
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+	m.Status.Status = dbcommons.StatusUpdating
+	k8s.PatchStatus(ctx, r.Client, m)
+
+	_, err = dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, true, "bash", "-c",
+		fmt.Sprintf(dbcommons.CreateAdminPasswordFile, string(adminPasswordSecret.Data[m.Spec.AdminPassword.SecretKey])))
+	if err != nil {
+		log.Error(err, err.Error())
+		return err
+	}
+	out, err := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		fmt.Sprintf(dbcommons.ConvertStandbyCMD, to))
+	if _, rmErr := dbcommons.ExecCommand(r, r.Config, readyPod.Name, readyPod.Namespace, "", ctx, req, false, "bash", "-c",
+		dbcommons.RemoveAdminPasswordFile); rmErr != nil {
+		log.Error(rmErr, rmErr.Error())
+	}
+	if err != nil {
+		out += err.Error()
+	}
+	log.Info("ConvertStandby Output : \n" + out)
+	if !strings.Contains(out, "converted successfully") {
+		eventMsg = "conversion to a " + strings.ToLower(to) + " standby failed, check the operator logs"
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, eventReason, eventMsg)
+		return errors.New(eventMsg)
+	}
+
+	if role, err := dbcommons.GetDatabaseRole(readyPod, r, r.Config, ctx, req, m.Spec.Edition); err == nil {
+		m.Status.Role = strings.ToUpper(role)
+	}
+	eventMsg = "database converted to a " + strings.ToLower(to) + " standby"
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
+	log.Info(eventMsg)
+
+	// The ORDS connections were closed with the database
+	r.restartOrds(m, eventReason, ctx, req)
+	return nil
+}
+
+// Request the restart of the ORDS serving the database with their action annotation, so that they reconnect to it
+func (r *SingleInstanceDatabaseReconciler) restartOrds(m *dbapi.SingleInstanceDatabase, eventReason string,
+	ctx context.Context, req ctrl.Request) {
+	log := r.Log.WithValues("restartOrds", req.NamespacedName)
+
+	for _, consumer := range m.GetConsumers(dbapi.OracleRestDataServiceKind) {
+		ords := &dbapi.OracleRestDataService{}
+		if err := r.Get(ctx, types.NamespacedName{Name: consumer.Name, Namespace: m.Namespace}, ords); err != nil {
+			log.Error(err, err.Error())
+			continue
+		}
+		if !consumer.Matches(dbapi.OracleRestDataServiceKind, ords) {
+			continue
+		}
+		annotated := ords.DeepCopy()
+		if annotated.Annotations == nil {
+			annotated.Annotations = make(map[string]string)
+		}
+		annotated.Annotations[dbcommons.ActionAnnotation] = dbcommons.ActionRestartOrds
+		if err := r.Patch(ctx, annotated, client.MergeFrom(ords)); err != nil {
+			log.Error(err, err.Error())
+			continue
+		}
+		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, "restarting ORDS "+consumer.Name+" to reconnect to the database")
 	}
 }
 
@@ -2702,7 +4935,13 @@ func (r *SingleInstanceDatabaseReconciler) cleanupSingleInstanceDatabase(req ctr
 	log := r.Log.WithValues("cleanupSingleInstanceDatabase", req.NamespacedName)
 	// Cleanup steps that the operator needs to do before the CR can be deleted.
 
-	if m.Status.OrdsReference != "" {
+	// The ORDS deleted without deregistering, or registered with a previous database of the same name, do not
+	// block the deletion
+	if err := r.pruneConsumers(m, ctx, req); err != nil {
+		log.Error(err, err.Error())
+		return requeueY, err
+	}
+	if m.GetOwnerConsumer(dbapi.OracleRestDataServiceKind) != nil {
 		eventReason := "Cannot cleanup"
 		eventMsg := "uninstall ORDS to clean this SIDB"
 		r.Recorder.Eventf(m, corev1.EventTypeNormal, eventReason, eventMsg)
@@ -2717,6 +4956,11 @@ func (r *SingleInstanceDatabaseReconciler) cleanupSingleInstanceDatabase(req ctr
 		return requeueY, errors.New(eventMsg)
 	}
 
+	// Run the preDelete hook while the database is still up
+	if result := r.runPreDeleteHook(m, ctx, req); result.Requeue {
+		return result, nil
+	}
+
 	// call deletePods() with zero pods in avaiable and nil readyPod to delete all pods
 	result, err := r.deletePods(ctx, req, m, []corev1.Pod{}, corev1.Pod{}, 0, 0)
 	if result.Requeue {
@@ -2739,11 +4983,40 @@ func (r *SingleInstanceDatabaseReconciler) cleanupSingleInstanceDatabase(req ctr
 			podNames += pod.Name + " "
 		}
 	}
+	dbcommons.ForgetReplicationStats(m.Namespace, m.Name)
 
 	log.Info("Successfully cleaned up SingleInstanceDatabase")
 	return requeueN, nil
 }
 
+// Removes the consumers of the database which no longer exist, or whose UID or database UID annotation differ
+func (r *SingleInstanceDatabaseReconciler) pruneConsumers(m *dbapi.SingleInstanceDatabase, ctx context.Context,
+	req ctrl.Request) error {
+	log := r.Log.WithValues("pruneConsumers", req.NamespacedName)
+
+	consumers := m.GetConsumers(dbapi.OracleRestDataServiceKind)
+	var live []dbapi.SingleInstanceDatabaseConsumerRef
+	for _, consumer := range consumers {
+		ords := &dbapi.OracleRestDataService{}
+		err := r.Get(ctx, types.NamespacedName{Name: consumer.Name, Namespace: consumer.Namespace}, ords)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil && consumer.Matches(dbapi.OracleRestDataServiceKind, ords) {
+			if uid, ok := ords.Annotations[dbcommons.DatabaseUIDAnnotation]; !ok || uid == string(m.UID) {
+				live = append(live, consumer)
+				continue
+			}
+		}
+		log.Info("Removing a stale consumer", "Kind", consumer.Kind, "Name", consumer.Name, "UID", consumer.UID)
+	}
+	if len(live) == len(consumers) {
+		return nil
+	}
+	m.SetConsumers(dbapi.OracleRestDataServiceKind, live)
+	return nil
+}
+
 // #############################################################################
 //
 //	SetupWithManager sets up the controller with the Manager
@@ -2752,7 +5025,16 @@ func (r *SingleInstanceDatabaseReconciler) cleanupSingleInstanceDatabase(req ctr
 func (r *SingleInstanceDatabaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dbapi.SingleInstanceDatabase{}).
-		Owns(&corev1.Pod{}). //Watch for deleted pods of SingleInstanceDatabase Owner
+		WithEventFilter(fleetPredicate()).
+		Owns(&corev1.Pod{}).     //Watch for deleted pods of SingleInstanceDatabase Owner
+		Owns(&corev1.Service{}). //Watch for the address changes of the load balancers
+		Owns(&appsv1.Deployment{}).
+		Watches(&source.Kind{Type: &dbapi.OracleRestDataService{}},
+			handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+				// The database applies the databaseTuning of its ORDS
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{
+					Namespace: o.GetNamespace(), Name: o.(*dbapi.OracleRestDataService).Spec.DatabaseRef}}}
+			})).
 		WithEventFilter(dbcommons.ResourceEventHandler()).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 100}). //ReconcileHandler is never invoked concurrently with the same object.
 		Complete(r)
@@ -2784,6 +5066,13 @@ func GetDatabaseReadyPod(r client.Reader, d *dbapi.SingleInstanceDatabase, ctx c
 	return dbReadyPod, err
 }
 
+// Returns true if the external password store of d holds the password of its current admin password secret
+func IsPasswordStoreCurrent(d *dbapi.SingleInstanceDatabase, adminPasswordSecret *corev1.Secret) bool {
+	return d.Spec.AdminPassword.ExternalPasswordStore && d.Status.PasswordStoreVersion != "" &&
+		adminPasswordSecret.Name == d.Spec.AdminPassword.SecretName &&
+		d.Status.PasswordStoreVersion == adminPasswordSecret.ResourceVersion
+}
+
 func GetDatabaseAdminPassword(r client.Reader, d *dbapi.SingleInstanceDatabase, ctx context.Context) (string, error) {
 
 	adminPasswordSecret := &corev1.Secret{}
@@ -2804,8 +5093,14 @@ func ValidatePrimaryDatabaseAdminPassword(r *SingleInstanceDatabaseReconciler, p
 		return err
 	}
 
+	adminPasswordSecret := &corev1.Secret{}
+	err = r.Get(ctx, types.NamespacedName{Name: p.Spec.AdminPassword.SecretName, Namespace: p.Namespace}, adminPasswordSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
 	out, err := dbcommons.ExecCommand(r, r.Config, dbReadyPod.Name, dbReadyPod.Namespace, "", ctx, req, true, "bash", "-c",
-		fmt.Sprintf("echo -e  \"%s\"  | %s", fmt.Sprintf(dbcommons.ValidateAdminPassword, adminPassword), dbcommons.GetSqlClient(p.Spec.Edition)))
+		dbcommons.ValidateAdminPasswordCommand(adminPassword, dbcommons.GetSqlClient(p.Spec.Edition), IsPasswordStoreCurrent(p, adminPasswordSecret)))
 	if err != nil {
 		return err
 	}
@@ -3133,6 +5428,8 @@ func SetupStandbyDatabase(r *SingleInstanceDatabaseReconciler, stdby *dbapi.Sing
 	if result.Requeue {
 		return fmt.Errorf("error in obtaining the Database Config status")
 	}
+	// Reported with the role of the standby, as the primary reports it in updateDBConfig
+	stdby.Status.FlashBack = strconv.FormatBool(flashBackStatus)
 	if !flashBackStatus {
 		r.Log.Info("Setting up flashback mode in the standby database")
 		err = EnableFlashbackInDatabase(r, stdbyReadyPod, ctx, req)
@@ -0,0 +1,411 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dbapi "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+)
+
+var _ = Describe("SingleInstanceDatabase controller", Ordered, func() {
+	const (
+		namespace = "default"
+		name      = "sidb-test"
+		timeout   = time.Second * 30
+		interval  = time.Millisecond * 250
+	)
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+	keepSecret := true
+
+	BeforeAll(func() {
+		fakeExecutor.Reset()
+
+		adminSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-admin-secret", Namespace: namespace},
+			StringData: map[string]string{"oracle_pwd": "Passw0rd#1"},
+		}
+		Expect(k8sClient.Create(ctx, adminSecret)).To(Succeed())
+
+		sidb := &dbapi.SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: dbapi.SingleInstanceDatabaseSpec{
+				Edition:  "enterprise",
+				Sid:      "ORCLCDB",
+				Pdbname:  "ORCLPDB1",
+				Replicas: 1,
+				Image: dbapi.SingleInstanceDatabaseImage{
+					PullFrom: "container-registry.oracle.com/database/enterprise:latest",
+				},
+				AdminPassword: dbapi.SingleInstanceDatabaseAdminPassword{
+					SecretName: adminSecret.Name,
+					SecretKey:  "oracle_pwd",
+					KeepSecret: &keepSecret,
+				},
+				Persistence: dbapi.SingleInstanceDatabasePersistence{
+					Size:         "10Gi",
+					StorageClass: "oci-bv",
+					AccessMode:   "ReadWriteOnce",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sidb)).To(Succeed())
+	})
+
+	It("Should add the finalizer and create the PVC and the database pod", func() {
+		podList := &corev1.PodList{}
+		Eventually(func() int {
+			sidbReconciler.Reconcile(ctx, req)
+			Expect(k8sClient.List(ctx, podList, client.InNamespace(namespace),
+				client.MatchingLabels(dbcommons.GetLabelsForController("", name)))).To(Succeed())
+			return len(podList.Items)
+		}, timeout, interval).Should(Equal(1))
+
+		sidb := &dbapi.SingleInstanceDatabase{}
+		Expect(k8sClient.Get(ctx, req.NamespacedName, sidb)).To(Succeed())
+		Expect(controllerutil.ContainsFinalizer(sidb, singleInstanceDatabaseFinalizer)).To(BeTrue())
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(k8sClient.Get(ctx, req.NamespacedName, pvc)).To(Succeed())
+		Expect(pvc.Spec.AccessModes).To(ConsistOf(corev1.ReadWriteOnce))
+	})
+
+	It("Should remove the finalizer once the database is deleted", func() {
+		// No kubelet in envtest, terminate the pods as the cluster would
+		forceDeletePods(ctx, namespace, dbcommons.GetLabelsForController("", name))
+
+		sidb := &dbapi.SingleInstanceDatabase{}
+		Expect(k8sClient.Get(ctx, req.NamespacedName, sidb)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, sidb)).To(Succeed())
+
+		Eventually(func() bool {
+			sidbReconciler.Reconcile(ctx, req)
+			err := k8sClient.Get(ctx, req.NamespacedName, &dbapi.SingleInstanceDatabase{})
+			return apierrors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+	})
+})
+
+var _ = Describe("SingleInstanceDatabase hooks", func() {
+	It("Should build the job of a hook from the manifest of its config map", func() {
+		ctx := context.Background()
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "hook-test", Namespace: "default"},
+			Data: map[string]string{"job.yaml": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: dns-update
+spec:
+  template:
+    spec:
+      restartPolicy: Never
+      serviceAccountName: admin
+      hostNetwork: true
+      containers:
+      - name: dns
+        image: busybox
+        securityContext:
+          privileged: true
+        env:
+        - name: ZONE
+          value: example.com
+`,
+				"host.yaml": `apiVersion: batch/v1
+kind: Job
+spec:
+  template:
+    spec:
+      containers:
+      - name: host
+        image: busybox
+      volumes:
+      - name: root
+        hostPath:
+          path: /
+`},
+		}
+		Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+
+		sidb := &dbapi.SingleInstanceDatabase{ObjectMeta: metav1.ObjectMeta{Name: "sidb-hooks", Namespace: "default"}}
+		sidb.Status.Role = "PRIMARY"
+		job, err := hookJob(ctx, k8sClient, sidb, dbcommons.HookPostFailover,
+			&dbapi.SingleInstanceDatabaseHook{ConfigMap: configMap.Name})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(job.Name).To(BeEmpty())
+		Expect(job.GenerateName).To(Equal("sidb-hooks-postfailover-"))
+		Expect(job.Labels).To(HaveKeyWithValue(dbcommons.HookLabel, dbcommons.HookPostFailover))
+		env := job.Spec.Template.Spec.Containers[0].Env
+		Expect(env[0].Name).To(Equal("ZONE"))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: "DATABASE_ROLE", Value: "PRIMARY"}))
+
+		// Only the fields granting no privilege are kept from the manifest
+		spec := job.Spec.Template.Spec
+		Expect(spec.ServiceAccountName).To(Equal(dbcommons.HookServiceAccount))
+		Expect(spec.HostNetwork).To(BeFalse())
+		Expect(*spec.SecurityContext.RunAsNonRoot).To(BeTrue())
+		Expect(spec.Containers[0].SecurityContext.Privileged).To(BeNil())
+		Expect(*spec.Containers[0].SecurityContext.AllowPrivilegeEscalation).To(BeFalse())
+
+		_, err = hookJob(ctx, k8sClient, sidb, dbcommons.HookPostFailover,
+			&dbapi.SingleInstanceDatabaseHook{ConfigMap: configMap.Name, Key: "host.yaml"})
+		Expect(err).To(MatchError(ContainSubstring("volume root")))
+
+		_, err = hookJob(ctx, k8sClient, sidb, dbcommons.HookPostFailover,
+			&dbapi.SingleInstanceDatabaseHook{ConfigMap: configMap.Name, Key: "missing.yaml"})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("SingleInstanceDatabase scans", func() {
+	It("Should requeue at the shortest poll interval configured", func() {
+		sidb := &dbapi.SingleInstanceDatabase{}
+		Expect(scanRequeue(sidb)).To(Equal(requeueN))
+
+		sidb.Spec.AlertLog = &dbapi.SingleInstanceDatabaseAlertLog{Stream: true, PollInterval: 30}
+		Expect(scanRequeue(sidb)).To(Equal(requeueN))
+
+		sidb.Spec.AlertLog.Events = true
+		sidb.Spec.ReplicationMetrics = &dbapi.SingleInstanceDatabaseReplicationMetrics{PollInterval: 300}
+		Expect(scanRequeue(sidb).RequeueAfter).To(Equal(30 * time.Second))
+
+		sidb.Spec.AlertLog.PollInterval = 0
+		Expect(scanRequeue(sidb).RequeueAfter).To(Equal(300 * time.Second))
+
+		sidb.Spec.ReplicationMetrics.PollInterval = 0
+		Expect(scanRequeue(sidb)).To(Equal(requeueN))
+	})
+
+	It("Should stream the alert log from a sidecar sharing the process namespace", func() {
+		sidb := &dbapi.SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: "sidb-alert-log", Namespace: "default"},
+			Spec: dbapi.SingleInstanceDatabaseSpec{
+				Sid:      "ORCLCDB",
+				Image:    dbapi.SingleInstanceDatabaseImage{PullFrom: "container-registry.oracle.com/database/enterprise:latest"},
+				AlertLog: &dbapi.SingleInstanceDatabaseAlertLog{Stream: true},
+			},
+		}
+		pod := sidbReconciler.instantiatePodSpec(sidb, &dbapi.SingleInstanceDatabase{}, &dbapi.SingleInstanceDatabase{}, false)
+		Expect(*pod.Spec.ShareProcessNamespace).To(BeTrue())
+		Expect(pod.Spec.Containers).To(HaveLen(2))
+		Expect(pod.Spec.Containers[1].Name).To(Equal(dbcommons.AlertLogContainerName))
+		for _, container := range pod.Spec.Containers {
+			for _, mount := range container.VolumeMounts {
+				Expect(mount.MountPath).NotTo(Equal("/opt/oracle/diag"))
+			}
+		}
+	})
+})
+
+var _ = Describe("SingleInstanceDatabase actions", func() {
+	ctx := context.Background()
+
+	runAction := func(name string, action string, role string) *metav1.Condition {
+		sidb := &dbapi.SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default",
+				Annotations: map[string]string{dbcommons.ActionAnnotation: action}},
+			Spec: dbapi.SingleInstanceDatabaseSpec{
+				Image: dbapi.SingleInstanceDatabaseImage{PullFrom: "container-registry.oracle.com/database/enterprise:latest"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sidb)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, sidb)
+		sidb.Status.Role = role
+
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}}
+		Expect(sidbReconciler.manageActions(sidb, corev1.Pod{}, ctx, req)).To(Equal(requeueN))
+
+		// The annotation is removed whatever the outcome, so that the action is not repeated
+		updated := &dbapi.SingleInstanceDatabase{}
+		Expect(k8sClient.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		Expect(updated.Annotations).ToNot(HaveKey(dbcommons.ActionAnnotation))
+		return meta.FindStatusCondition(sidb.Status.Conditions, dbcommons.ActionCompletedCondition)
+	}
+
+	BeforeEach(func() {
+		fakeExecutor.Reset()
+	})
+
+	It("Should record the failure of an action in the ActionCompleted condition", func() {
+		condition := runAction("sidb-action-unknown", "restart-everything", "PRIMARY")
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(dbcommons.ActionFailedReason))
+		Expect(condition.Message).To(ContainSubstring("unknown action"))
+
+		condition = runAction("sidb-action-primary", dbcommons.ActionConvertToSnapshotStandby, "PRIMARY")
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("not a physical standby"))
+	})
+
+	It("Should record the success of an action in the ActionCompleted condition", func() {
+		fakeExecutor.On("open_mode", "\nOPEN_MODE\n----------\nREAD WRITE\n")
+		condition := runAction("sidb-action-restart", dbcommons.ActionRestartDatabase, "PRIMARY")
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(dbcommons.ActionSucceededReason))
+	})
+})
+
+var _ = Describe("SingleInstanceDatabase restart", Ordered, func() {
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "sidb-restart", Namespace: "default"}}
+	readyPod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "sidb-restart-a1b2c", Namespace: "default"}}
+	sidb := &dbapi.SingleInstanceDatabase{}
+
+	BeforeAll(func() {
+		sidb = &dbapi.SingleInstanceDatabase{
+			ObjectMeta: metav1.ObjectMeta{Name: req.Name, Namespace: req.Namespace},
+			Spec: dbapi.SingleInstanceDatabaseSpec{
+				Image: dbapi.SingleInstanceDatabaseImage{PullFrom: "container-registry.oracle.com/database/enterprise:latest"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, sidb)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, sidb)
+		sidb.Status.Restart = &dbapi.SingleInstanceDatabaseRestart{
+			Phase:     dbcommons.RestartPhaseDraining,
+			Pod:       readyPod.Name,
+			StartTime: time.Now().Format(time.RFC3339),
+			OpenMode:  "READ_WRITE",
+		}
+	})
+
+	BeforeEach(func() {
+		fakeExecutor.Reset()
+	})
+
+	It("Should not wait for the sessions of ORDS, APEX and of the schemas ORDS connects to as a proxy", func() {
+		Expect(dbcommons.CountUserSessionsSQL).To(ContainSubstring("'APEX_PUBLIC_USER'"))
+		Expect(dbcommons.CountUserSessionsSQL).To(ContainSubstring("not like 'Oracle REST Data Services%'"))
+		Expect(dbcommons.CountUserSessionsSQL).To(ContainSubstring("authentication_type = 'PROXY'"))
+	})
+
+	It("Should wait for the user sessions to end within the drain timeout", func() {
+		fakeExecutor.On("count(*) as sessions", "\nSESSIONS\n----------\n         2\n")
+		Expect(sidbReconciler.manageRestart(sidb, readyPod, ctx, req)).To(Equal(requeueY))
+		Expect(sidb.Status.Restart.Phase).To(Equal(dbcommons.RestartPhaseDraining))
+		Expect(fakeExecutor.Executed("shutdown")).To(BeFalse())
+	})
+
+	It("Should shut down the database once the drain timeout is over", func() {
+		fakeExecutor.On("count(*) as sessions", "\nSESSIONS\n----------\n         2\n")
+		sidb.Status.Restart.StartTime = time.Now().Add(-time.Hour).Format(time.RFC3339)
+		Expect(sidbReconciler.manageRestart(sidb, readyPod, ctx, req)).To(Equal(requeueY))
+		Expect(fakeExecutor.Executed("shutdown immediate")).To(BeTrue())
+		Expect(sidb.Status.Restart.Phase).To(Equal(dbcommons.RestartPhaseRestarting))
+	})
+
+	It("Should end the restart once the new pod opens the database like before", func() {
+		// Still the pod that was shut down
+		Expect(sidbReconciler.manageRestart(sidb, readyPod, ctx, req)).To(Equal(requeueY))
+
+		fakeExecutor.On("open_mode", "\nOPEN_MODE\n----------\nREAD WRITE\n")
+		newPod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "sidb-restart-d3e4f", Namespace: "default"}}
+		Expect(sidbReconciler.manageRestart(sidb, newPod, ctx, req)).To(Equal(requeueN))
+		Expect(sidb.Status.Restart).To(BeNil())
+	})
+})
+
+var _ = Describe("SingleInstanceDatabase datapatch verification", func() {
+	It("Should verify the registry components only after the datapatch of an image change", func() {
+		sidb := &dbapi.SingleInstanceDatabase{}
+		Expect(datapatchVerificationPending(sidb)).To(BeFalse())
+
+		for reason, pending := range map[string]bool{
+			dbcommons.DatapatchPendingReason:  true,
+			dbcommons.ComponentsInvalidReason: true,
+			dbcommons.ComponentsValidReason:   false,
+		} {
+			meta.SetStatusCondition(&sidb.Status.Conditions, metav1.Condition{
+				Type:   dbcommons.DatapatchVerifiedCondition,
+				Status: metav1.ConditionFalse,
+				Reason: reason,
+			})
+			Expect(datapatchVerificationPending(sidb)).To(Equal(pending), reason)
+		}
+	})
+})
+
+var _ = Describe("SingleInstanceDatabase performance reports", func() {
+	gunzip := func(data []byte) string {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		report, err := io.ReadAll(zr)
+		Expect(err).ToNot(HaveOccurred())
+		return string(report)
+	}
+
+	It("Should gzip a report fitting once compressed", func() {
+		report := strings.Repeat("<tr><td>db file sequential read</td></tr>\n", 50000)
+		data, truncated, err := compressPerformanceReport(report, 1024*1024)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(truncated).To(BeFalse())
+		Expect(gunzip(data)).To(Equal(report))
+	})
+
+	It("Should truncate a report still too large once compressed", func() {
+		random := make([]byte, 300*1024)
+		rand.New(rand.NewSource(1)).Read(random)
+		report := string(random)
+		data, truncated, err := compressPerformanceReport(report, 100*1024)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(truncated).To(BeTrue())
+		Expect(len(data)).To(BeNumerically("<=", 100*1024))
+		out := gunzip(data)
+		Expect(strings.HasSuffix(out, dbcommons.PerformanceReportTruncatedMsg)).To(BeTrue())
+		Expect(strings.HasPrefix(report, strings.TrimSuffix(out, dbcommons.PerformanceReportTruncatedMsg))).To(BeTrue())
+	})
+})
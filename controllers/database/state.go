@@ -0,0 +1,333 @@
+/*
+** Copyright (c) 2023 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	dbapi "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// #############################################################################
+//
+//	Export the manifests of the database and of its ORDS into the state ConfigMap
+//
+// #############################################################################
+func (r *SingleInstanceDatabaseReconciler) manageStateExport(m *dbapi.SingleInstanceDatabase,
+	ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	log := r.Log.WithValues("manageStateExport", req.NamespacedName)
+
+	cmName := m.Name + dbcommons.StateConfigMapSuffix
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: cmName, Namespace: m.Namespace}, configMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "Error encountered in obtaining the config map", "ConfigMap.Name", cmName)
+		return requeueY, err
+	}
+	exists := err == nil
+
+	if !m.Spec.StateExport {
+		if exists {
+			if err := r.Delete(ctx, configMap); err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, "Failed to delete the state config map", "ConfigMap.Name", cmName)
+				return requeueY, err
+			}
+		}
+		return requeueN, nil
+	}
+
+	data, err := r.exportState(m, ctx)
+	if err != nil {
+		log.Error(err, "Failed to export the state", "ConfigMap.Name", cmName)
+		return requeueY, err
+	}
+
+	if !exists {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cmName,
+				Namespace: m.Namespace,
+				Labels:    dbcommons.GetLabelsForController("", cmName),
+			},
+			Data: data,
+		}
+		ctrl.SetControllerReference(m, configMap, r.Scheme)
+		if err := r.Create(ctx, configMap); err != nil {
+			log.Error(err, "Failed to create the state config map", "ConfigMap.Name", cmName)
+			return requeueY, err
+		}
+		log.Info("State config map created", "ConfigMap.Name", cmName)
+		return requeueN, nil
+	}
+
+	if !reflect.DeepEqual(configMap.Data, data) {
+		configMap.Data = data
+		if err := r.Update(ctx, configMap); err != nil {
+			log.Error(err, "Failed to update the state config map", "ConfigMap.Name", cmName)
+			return requeueY, err
+		}
+		log.Info("State config map updated", "ConfigMap.Name", cmName)
+	}
+	return requeueN, nil
+}
+
+// exportState returns the manifests of the database and of its ORDS, re-attached to the volumes bound to their
+// claims and carrying the status to import in the ImportedStatusAnnotation, with the names of the secrets they refer to
+func (r *SingleInstanceDatabaseReconciler) exportState(m *dbapi.SingleInstanceDatabase,
+	ctx context.Context) (map[string]string, error) {
+
+	secrets := map[string]bool{m.Spec.AdminPassword.SecretName: true, m.Spec.Image.PullSecrets: true}
+	if m.Spec.ObjectStorage != nil {
+		secrets[m.Spec.ObjectStorage.OciSecretName] = true
+	}
+
+	// The datafiles are already on the replicated volume, the database is neither cloned nor restored again
+	db := &dbapi.SingleInstanceDatabase{
+		TypeMeta:   metav1.TypeMeta{APIVersion: dbapi.GroupVersion.String(), Kind: "SingleInstanceDatabase"},
+		ObjectMeta: metav1.ObjectMeta{Name: m.Name, Namespace: m.Namespace, Labels: m.Labels},
+		Spec:       *m.Spec.DeepCopy(),
+	}
+	db.Spec.CloneFrom = ""
+	db.Spec.Persistence.DataSource = nil
+	if db.Spec.Persistence.Size != "" && db.Spec.Persistence.VolumeName == "" {
+		volumeName, err := r.boundVolumeName(ctx, m.Namespace, m.Name)
+		if err != nil {
+			return nil, err
+		}
+		db.Spec.Persistence.VolumeName = volumeName
+	}
+	status := dbapi.SingleInstanceDatabaseStatus{
+		Sid:              m.Status.Sid,
+		Edition:          m.Status.Edition,
+		Charset:          m.Status.Charset,
+		Pdbname:          m.Status.Pdbname,
+		DatafilesCreated: m.Status.DatafilesCreated,
+		DatafilesPatched: m.Status.DatafilesPatched,
+		ReleaseUpdate:    m.Status.ReleaseUpdate,
+		ApexInstalled:    m.Status.ApexInstalled,
+		PrebuiltDB:       m.Status.PrebuiltDB,
+		Persistence:      db.Spec.Persistence,
+	}
+	// The ORDS get other UIDs in the disaster recovery cluster, where they register again
+	exported := &dbapi.SingleInstanceDatabase{Status: status}
+	var consumers []dbapi.SingleInstanceDatabaseConsumerRef
+	for _, consumer := range m.GetConsumers(dbapi.OracleRestDataServiceKind) {
+		consumer.UID = ""
+		consumers = append(consumers, consumer)
+	}
+	exported.SetConsumers(dbapi.OracleRestDataServiceKind, consumers)
+	status = exported.Status
+	if m.Status.CloneFrom != "" {
+		status.CloneFrom = dbcommons.NoCloneRef
+	}
+	dbManifest, err := stateManifest(db, status)
+	if err != nil {
+		return nil, err
+	}
+
+	var ordsManifests []string
+	for _, name := range m.GetConsumerNames(dbapi.OracleRestDataServiceKind) {
+		n := &dbapi.OracleRestDataService{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: m.Namespace}, n); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		secrets[n.Spec.AdminPassword.SecretName] = true
+		secrets[n.Spec.OrdsPassword.SecretName] = true
+		secrets[n.Spec.ApexPassword.SecretName] = true
+		secrets[n.Spec.Image.PullSecrets] = true
+		if n.Spec.Ingress != nil {
+			secrets[n.Spec.Ingress.TlsSecret] = true
+		}
+
+		// The configuration is kept in the directory it was installed in
+		ords := &dbapi.OracleRestDataService{
+			TypeMeta:   metav1.TypeMeta{APIVersion: dbapi.GroupVersion.String(), Kind: "OracleRestDataService"},
+			ObjectMeta: metav1.ObjectMeta{Name: n.Name, Namespace: n.Namespace, Labels: n.Labels},
+			Spec:       *n.Spec.DeepCopy(),
+		}
+		if n.Status.ConfigDir != "" {
+			ords.Spec.ConfigSubPath = n.Status.ConfigDir
+		}
+		ords.Spec.Persistence.DataSource = nil
+		if ords.Spec.Persistence.Size != "" && ords.Spec.Persistence.VolumeName == "" {
+			volumeName, err := r.boundVolumeName(ctx, n.Namespace, n.Name)
+			if err != nil {
+				return nil, err
+			}
+			ords.Spec.Persistence.VolumeName = volumeName
+		}
+		ordsStatus := dbapi.OracleRestDataServiceStatus{
+			DatabaseRef:            n.Status.DatabaseRef,
+			OrdsInstalled:          n.Status.OrdsInstalled,
+			ApexConfigured:         n.Status.ApexConfigured,
+			CommonUsersCreated:     n.Status.CommonUsersCreated,
+			Image:                  n.Status.Image,
+			ConfigDir:              n.Status.ConfigDir,
+			ApexLanguages:          n.Status.ApexLanguages,
+			CreatedUsers:           n.Status.CreatedUsers,
+			MetadataBackup:         n.Status.MetadataBackup,
+			MetadataBackupLocation: n.Status.MetadataBackupLocation,
+			MetadataRestored:       n.Status.MetadataRestored,
+			ApexExport:             n.Status.ApexExport,
+			ApexExportLocation:     n.Status.ApexExportLocation,
+			ApexExportVersion:      n.Status.ApexExportVersion,
+		}
+		manifest, err := stateManifest(ords, ordsStatus)
+		if err != nil {
+			return nil, err
+		}
+		ordsManifests = append(ordsManifests, manifest)
+	}
+
+	var secretNames []string
+	for name := range secrets {
+		if name != "" {
+			secretNames = append(secretNames, name)
+		}
+	}
+	sort.Strings(secretNames)
+
+	data := map[string]string{
+		dbcommons.StateDatabaseKey: dbManifest,
+		dbcommons.StateSecretsKey:  strings.Join(secretNames, "\n"),
+	}
+	if len(ordsManifests) > 0 {
+		data[dbcommons.StateOrdsKey] = strings.Join(ordsManifests, "---\n")
+	}
+	return data, nil
+}
+
+// boundVolumeName returns the persistent volume bound to a claim
+func (r *SingleInstanceDatabaseReconciler) boundVolumeName(ctx context.Context, namespace, name string) (string, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, pvc); err != nil {
+		return "", err
+	}
+	return pvc.Spec.VolumeName, nil
+}
+
+// stateManifest returns the YAML manifest of obj without its status, which is carried by the ImportedStatusAnnotation
+func stateManifest(obj runtime.Object, status interface{}) (string, error) {
+	imported, err := json.Marshal(status)
+	if err != nil {
+		return "", err
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+	delete(u, "status")
+	metadata := u["metadata"].(map[string]interface{})
+	delete(metadata, "creationTimestamp")
+	metadata["annotations"] = map[string]interface{}{dbcommons.ImportedStatusAnnotation: string(imported)}
+	manifest, err := yaml.Marshal(u)
+	if err != nil {
+		return "", err
+	}
+	return string(manifest), nil
+}
+
+// importDatabaseState restores the status exported with the manifest of the database
+func (r *SingleInstanceDatabaseReconciler) importDatabaseState(m *dbapi.SingleInstanceDatabase) {
+	var status dbapi.SingleInstanceDatabaseStatus
+	if err := json.Unmarshal([]byte(m.Annotations[dbcommons.ImportedStatusAnnotation]), &status); err != nil {
+		r.Recorder.Eventf(m, corev1.EventTypeWarning, "Spec Error", "invalid "+dbcommons.ImportedStatusAnnotation+
+			" annotation: "+err.Error())
+		return
+	}
+	m.Status.Sid = status.Sid
+	m.Status.Edition = status.Edition
+	m.Status.Charset = status.Charset
+	m.Status.Pdbname = status.Pdbname
+	m.Status.DatafilesCreated = status.DatafilesCreated
+	m.Status.DatafilesPatched = status.DatafilesPatched
+	m.Status.ReleaseUpdate = status.ReleaseUpdate
+	m.Status.CloneFrom = status.CloneFrom
+	m.Status.ApexInstalled = status.ApexInstalled
+	m.Status.PrebuiltDB = status.PrebuiltDB
+	m.Status.OrdsReference = status.OrdsReference
+	m.Status.OrdsReferences = status.OrdsReferences
+	m.Status.Consumers = status.Consumers
+	m.Status.Persistence = status.Persistence
+	r.Recorder.Eventf(m, corev1.EventTypeNormal, dbcommons.StateImportedReason,
+		"status of database %s imported, its datafiles are reused", status.Sid)
+}
+
+// importOrdsState restores the status exported with the manifest of the ORDS
+func (r *OracleRestDataServiceReconciler) importOrdsState(n *dbapi.OracleRestDataService) {
+	var status dbapi.OracleRestDataServiceStatus
+	if err := json.Unmarshal([]byte(n.Annotations[dbcommons.ImportedStatusAnnotation]), &status); err != nil {
+		r.Recorder.Eventf(n, corev1.EventTypeWarning, "Spec Error", "invalid "+dbcommons.ImportedStatusAnnotation+
+			" annotation: "+err.Error())
+		return
+	}
+	n.Status.DatabaseRef = status.DatabaseRef
+	n.Status.OrdsInstalled = status.OrdsInstalled
+	n.Status.ApexConfigured = status.ApexConfigured
+	n.Status.CommonUsersCreated = status.CommonUsersCreated
+	n.Status.Image = status.Image
+	n.Status.ConfigDir = status.ConfigDir
+	n.Status.ApexLanguages = status.ApexLanguages
+	n.Status.CreatedUsers = status.CreatedUsers
+	n.Status.MetadataBackup = status.MetadataBackup
+	n.Status.MetadataBackupLocation = status.MetadataBackupLocation
+	n.Status.MetadataRestored = status.MetadataRestored
+	n.Status.ApexExport = status.ApexExport
+	n.Status.ApexExportLocation = status.ApexExportLocation
+	n.Status.ApexExportVersion = status.ApexExportVersion
+	r.Recorder.Eventf(n, corev1.EventTypeNormal, dbcommons.StateImportedReason,
+		"status imported, the configuration in %s is reused", status.ConfigDir)
+}
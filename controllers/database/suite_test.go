@@ -39,19 +39,25 @@
 package controllers
 
 import (
+	"context"
+	"os"
 	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	databasev1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -62,6 +68,13 @@ var cfg *rest.Config
 var k8sClient client.Client
 var testEnv *envtest.Environment
 
+// Reconcilers under test. They are invoked directly by the specs,
+// with all the pod exec/SQL calls answered by fakeExecutor
+var fakeExecutor *dbcommons.FakeCommandExecutor
+var sidbReconciler *SingleInstanceDatabaseReconciler
+var ordsReconciler *OracleRestDataServiceReconciler
+var dataguardBrokerReconciler *DataguardBrokerReconciler
+
 func TestAPIs(t *testing.T) {
 	RegisterFailHandler(Fail)
 
@@ -71,6 +84,10 @@ func TestAPIs(t *testing.T) {
 var _ = BeforeSuite(func() {
 	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
 
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		Skip("KUBEBUILDER_ASSETS is not set, run the suite with \"make test\"")
+	}
+
 	By("bootstrapping test environment")
 	testEnv = &envtest.Environment{
 		CRDDirectoryPaths: []string{filepath.Join("../..", "config", "crd", "bases")},
@@ -92,10 +109,59 @@ var _ = BeforeSuite(func() {
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	Expect(err).ToNot(HaveOccurred())
 	Expect(k8sClient).ToNot(BeNil())
+
+	By("replacing the pod exec layer with a fake executor")
+	fakeExecutor = dbcommons.NewFakeCommandExecutor()
+	dbcommons.SetCommandExecutor(fakeExecutor)
+
+	// A FakeRecorder without an Events channel drops the recorded events
+	sidbReconciler = &SingleInstanceDatabaseReconciler{
+		Client:   k8sClient,
+		Log:      ctrl.Log.WithName("controllers").WithName("SingleInstanceDatabase"),
+		Scheme:   scheme.Scheme,
+		Config:   cfg,
+		Recorder: &record.FakeRecorder{},
+	}
+	ordsReconciler = &OracleRestDataServiceReconciler{
+		Client:   k8sClient,
+		Log:      ctrl.Log.WithName("controllers").WithName("OracleRestDataService"),
+		Scheme:   scheme.Scheme,
+		Config:   cfg,
+		Recorder: &record.FakeRecorder{},
+	}
+	dataguardBrokerReconciler = &DataguardBrokerReconciler{
+		Client:   k8sClient,
+		Log:      ctrl.Log.WithName("controllers").WithName("DataguardBroker"),
+		Scheme:   scheme.Scheme,
+		Config:   cfg,
+		Recorder: &record.FakeRecorder{},
+	}
 })
 
 var _ = AfterSuite(func() {
+	if testEnv == nil {
+		return
+	}
 	By("tearing down the test environment")
 	err := testEnv.Stop()
 	Expect(err).ToNot(HaveOccurred())
 })
+
+// Marks pod as running with a ready container, as the kubelet would do
+func markPodReady(ctx context.Context, pod *corev1.Pod) {
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  pod.Spec.Containers[0].Name,
+		Image: pod.Spec.Containers[0].Image,
+		Ready: true,
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}}
+	Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+}
+
+// Deletes the pods matching labels in namespace right away, as there is no kubelet nor garbage collector in envtest
+func forceDeletePods(ctx context.Context, namespace string, labels map[string]string) {
+	var gracePeriodSeconds int64 = 0
+	Expect(k8sClient.DeleteAllOf(ctx, &corev1.Pod{}, client.InNamespace(namespace), client.MatchingLabels(labels),
+		client.GracePeriodSeconds(gracePeriodSeconds), client.PropagationPolicy("Background"))).To(Succeed())
+}
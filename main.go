@@ -41,20 +41,26 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"hash/fnv"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	databasev1alpha1 "github.com/oracle/oracle-database-operator/apis/database/v1alpha1"
+	dbcommons "github.com/oracle/oracle-database-operator/commons/database"
 	databasecontroller "github.com/oracle/oracle-database-operator/controllers/database"
 	// +kubebuilder:scaffold:imports
 )
@@ -74,32 +80,140 @@ func init() {
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var testMode bool
+	var watchSelector string
+	var fleetShards int
+	var fleetShard int
+	var rwxStorageClasses string
+	var tracingEndpoint string
+	var commandMode string
+	var inventoryAddr string
+	var inventoryCertDir string
+	var offlineMode string
+	var namespaceQuota string
+	var mirrors string
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
-	flag.Parse()
-
+	flag.BoolVar(&testMode, "test-mode", false,
+		"Enable test mode: short requeue intervals, reduced retries and Oracle Free image defaults. "+
+			"Meant for e2e tests and CI pipelines, not to be used in production.")
+	flag.StringVar(&watchSelector, "watch-selector", "",
+		"Only reconcile the database resources matching this label selector, "+
+			"so that several operator deployments can divide the resources of a large fleet.")
+	flag.IntVar(&fleetShards, "fleet-shards", 1,
+		"Number of operator deployments dividing the namespaces of the database resources by their hash.")
+	flag.IntVar(&fleetShard, "fleet-shard", 0,
+		"Shard of the namespaces reconciled by this operator deployment, from 0 to fleet-shards - 1.")
+	flag.StringVar(&rwxStorageClasses, "rwx-storage-classes", "",
+		"Comma separated storage classes supporting the ReadWriteMany access mode. "+
+			"When set, the webhooks reject a ReadWriteMany persistence on other storage classes.")
+	flag.StringVar(&tracingEndpoint, "tracing-endpoint", "",
+		"OTLP/HTTP endpoint receiving the traces of the reconciles, for example http://otel-collector:4318. "+
+			"The OTEL_EXPORTER_OTLP_ENDPOINT variable is used when not set, and traces are not exported without both.")
+	flag.StringVar(&commandMode, "command-mode", "auto",
+		"How the operator runs commands in the database and ORDS pods: exec into the pods, run the commands in Jobs, "+
+			"or auto to run them in Jobs when the operator is not permitted to exec into pods.")
+	flag.StringVar(&inventoryAddr, "inventory-bind-address", "",
+		"The address the HTTPS endpoint serving the inventory of the databases and ORDS binds to, such as :8444. "+
+			"The inventory is not served when not set.")
+	flag.StringVar(&inventoryCertDir, "inventory-cert-dir", "",
+		"The directory of the tls.crt and tls.key of the inventory endpoint. Defaults to the certificate of the webhooks.")
+	flag.StringVar(&offlineMode, "offline-mode", "false",
+		"Run the operator for an air-gapped cluster: true, false, or auto to probe the Oracle container registry at startup. "+
+			"In offline mode, the steps reaching out to the internet with no mirror are skipped.")
+	flag.StringVar(&mirrors, "mirrors", "",
+		"Comma separated prefix=replacement redirecting the URLs and images the operator fetches to internal mirrors, "+
+			"for example container-registry.oracle.com=registry.internal/oracle.")
+	flag.StringVar(&namespaceQuota, "namespace-quota", "",
+		"Quota of each namespace, such as singleinstancedatabases=5,oraclerestdataservices=5,storage=1Ti,ordsreplicas=4, enforced by the webhooks. "+
+			"The database.oracle.com/quota annotation of a namespace overrides it. No quota when not set.")
 	// Initialize new logger Opts
 	options := &zap.Options{
 		Development: true,
 		TimeEncoder: zapcore.RFC3339TimeEncoder,
 	}
+	options.BindFlags(flag.CommandLine)
+	flag.Parse()
 
 	ctrl.SetLogger(zap.New(func(o *zap.Options) { *o = *options }))
+	// The resources annotated with database.oracle.com/log-level: debug are logged at the debug level
+	dbcommons.SetDebugLogger(zap.New(func(o *zap.Options) { *o = *options; o.Level = zapcore.Level(-dbcommons.CommandLogVerbosity) }).WithName("debug"))
+
+	if testMode {
+		setupLog.Info("Running the operator in test mode")
+		dbcommons.EnableTestMode()
+		databasecontroller.EnableTestMode()
+	}
+
+	if err := dbcommons.SetMirrors(mirrors); err != nil {
+		setupLog.Error(err, "invalid mirrors")
+		os.Exit(1)
+	}
+	switch offlineMode {
+	case "true":
+		dbcommons.SetOfflineMode(true)
+	case "false":
+	case "auto":
+		dbcommons.SetOfflineMode(dbcommons.DetectOffline(context.TODO()))
+	default:
+		setupLog.Error(fmt.Errorf("unknown offline mode %s", offlineMode), "invalid offline-mode, use auto, true or false")
+		os.Exit(1)
+	}
+	if dbcommons.IsOfflineMode() {
+		setupLog.Info("Running the operator in offline mode", "mirrors", mirrors)
+	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	shutdownTracing, err := dbcommons.SetupTracing(context.TODO(), tracingEndpoint)
+	if err != nil {
+		setupLog.Error(err, "unable to export the traces", "endpoint", tracingEndpoint)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.TODO())
+
+	mgrOptions := ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
 		Port:               9443,
 		LeaderElection:     enableLeaderElection,
 		LeaderElectionID:   "a9d608ea.oracle.com",
-	})
+	}
+	if watchSelector != "" || fleetShards > 1 {
+		if err := configureFleet(&mgrOptions, watchSelector, fleetShards, fleetShard); err != nil {
+			setupLog.Error(err, "invalid fleet options")
+			os.Exit(1)
+		}
+		setupLog.Info("Reconciling a part of the fleet", "leaderElectionID", mgrOptions.LeaderElectionID,
+			"selector", watchSelector, "shards", fleetShards, "shard", fleetShard)
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), mgrOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	switch commandMode {
+	case "exec":
+		dbcommons.SetPodExecPermitted(true)
+	case "job":
+		dbcommons.SetPodExecPermitted(false)
+	case "auto":
+		permitted, err := dbcommons.DetectPodExec(context.TODO(), mgr.GetConfig())
+		if err != nil {
+			setupLog.Error(err, "unable to check the permission to exec into pods")
+			os.Exit(1)
+		}
+		dbcommons.SetPodExecPermitted(permitted)
+	default:
+		setupLog.Error(fmt.Errorf("unknown command mode %s", commandMode), "invalid command-mode, use auto, exec or job")
+		os.Exit(1)
+	}
+	if !dbcommons.PodExecPermitted() {
+		setupLog.Info("Running the commands of the pods in Jobs")
+	}
+
 	// Get Cache
 	cache := mgr.GetCache()
 
@@ -185,9 +299,20 @@ func main() {
 	i, err := strconv.ParseInt(interval, 10, 64)
 	if err != nil {
 		i = 15
+		if testMode {
+			i = int64(dbcommons.TestModeRequeueInterval.Seconds())
+		}
 		setupLog.Info("Setting default reconcile period for database-controller", "Secs", i)
 	}
 
+	if err := databasev1alpha1.SetNamespaceQuota(namespaceQuota); err != nil {
+		setupLog.Error(err, "invalid namespace-quota")
+		os.Exit(1)
+	}
+	if rwxStorageClasses != "" {
+		databasev1alpha1.SetReadWriteManyStorageClasses(strings.Split(rwxStorageClasses, ","))
+	}
+
 	// Set ENABLE_WEBHOOKS=false when we run locally to skip webhook part when testing just the controller. Not to be used in production.
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		if err = (&databasev1alpha1.SingleInstanceDatabase{}).SetupWebhookWithManager(mgr); err != nil {
@@ -232,6 +357,7 @@ func main() {
 	if err = (&databasecontroller.PDBReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
+		Config:   mgr.GetConfig(),
 		Log:      ctrl.Log.WithName("controllers").WithName("PDB"),
 		Interval: time.Duration(i),
 		Recorder: mgr.GetEventRecorderFor("PDB"),
@@ -263,8 +389,31 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&databasecontroller.OracleDatabaseClassReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("database").WithName("OracleDatabaseClass"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("OracleDatabaseClass"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OracleDatabaseClass")
+		os.Exit(1)
+	}
+
 	// +kubebuilder:scaffold:builder
 
+	if inventoryAddr != "" {
+		if err = mgr.Add(&databasecontroller.InventoryServer{
+			Addr:    inventoryAddr,
+			CertDir: inventoryCertDir,
+			Reader:  mgr.GetClient(),
+			Config:  mgr.GetConfig(),
+			Log:     ctrl.Log.WithName("inventory"),
+		}); err != nil {
+			setupLog.Error(err, "unable to serve the inventory")
+			os.Exit(1)
+		}
+	}
+
 	// Add index for PDB CR to enable mgr to cache PDBs
 	indexFunc := func(obj client.Object) []string {
 		return []string{obj.(*databasev1alpha1.PDB).Spec.PDBName}
@@ -274,9 +423,50 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Add index for the pods of the controllers to look them up by their "app" label
+	if err = dbcommons.IndexPodsByApp(context.TODO(), cache); err != nil {
+		setupLog.Error(err, "unable to create index function for ", "controller", "SingleInstanceDatabase")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// configureFleet restricts the cache of the manager to the database resources matching the selector,
+// and the controllers to the namespaces of the shard. Each part of the fleet elects its own leader.
+func configureFleet(options *ctrl.Options, watchSelector string, shards int, shard int) error {
+	if err := databasecontroller.EnableFleetSharding(shards, shard); err != nil {
+		return err
+	}
+
+	if watchSelector != "" {
+		selector, err := labels.Parse(watchSelector)
+		if err != nil {
+			return err
+		}
+		selectors := ctrlcache.SelectorsByObject{}
+		for gvk := range scheme.AllKnownTypes() {
+			if gvk.GroupVersion() != databasev1alpha1.GroupVersion {
+				continue
+			}
+			obj, err := scheme.New(gvk)
+			if err != nil {
+				return err
+			}
+			// The lists are selected by the selector of their items
+			if object, ok := obj.(client.Object); ok {
+				selectors[object] = ctrlcache.ObjectSelector{Label: selector}
+			}
+		}
+		options.NewCache = ctrlcache.BuilderWithOptions(ctrlcache.Options{SelectorsByObject: selectors})
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(fmt.Sprintf("%s/%d/%d", watchSelector, shards, shard)))
+	options.LeaderElectionID = fmt.Sprintf("a9d608ea-%08x.oracle.com", hash.Sum32())
+	return nil
+}